@@ -0,0 +1,218 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/57blocks/auto-action/cli/internal/command"
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// apply represents the apply command
+var apply = &cobra.Command{
+	Use:   "apply [flags]",
+	Short: "Make the actions match the manifest",
+	Long: `
+Description:
+  The apply command executes the plan of the manifest, autoaction.yaml by default,
+  which registers, updates and removes the actions step by step.
+  See the plan command for the format of the manifest.
+
+Examples:
+  autoaction apply
+  autoaction apply -f ./deploy/staging.yaml
+  autoaction apply --prune
+
+Notes:
+  - The plan is printed before being executed, along with the progress of each step.
+  - The apply stops on the first error, the steps done are kept, run it again to continue.
+  - The actions registered but not declared are removed only with the --prune flag.
+`,
+	Args: cobra.NoArgs,
+	RunE: applyFunc,
+}
+
+func init() {
+	command.Root.AddCommand(apply)
+
+	addManifestFlags(apply)
+}
+
+func applyFunc(cmd *cobra.Command, _ []string) error {
+	steps, cleanup, err := buildPlan(cmd)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	printPlan(steps)
+
+	executable := make([]*planStep, 0, len(steps))
+	for _, step := range steps {
+		if step.Kind != stepKeep {
+			executable = append(executable, step)
+		}
+	}
+
+	for i, step := range executable {
+		fmt.Printf("\n[%d/%d] %s %s\n", i+1, len(executable), step.Kind, step.Name)
+
+		if err := applyStep(step); err != nil {
+			logx.Logger.Error("apply stopped", "step", fmt.Sprintf("%s %s", step.Kind, step.Name))
+			return err
+		}
+	}
+
+	if len(executable) > 0 {
+		logx.Logger.Info("applied successfully", "steps", len(executable))
+	}
+
+	return nil
+}
+
+func applyStep(step *planStep) error {
+	switch step.Kind {
+	case stepCreate:
+		return manifestRegister(step.Action)
+	case stepDelete:
+		return manifestRemove(step.Name)
+	}
+
+	if step.redeploy {
+		if err := manifestUpdate(step.Action); err != nil {
+			return err
+		}
+	}
+	// the variables are carried by the redeploying
+	if len(step.envSet) > 0 && !step.redeploy {
+		if err := supplierEnv(http.MethodPut, step.Name, nil, map[string]interface{}{
+			"variables": step.envSet,
+		}); err != nil {
+			return err
+		}
+	}
+	if len(step.envUnset) > 0 {
+		if err := supplierEnv(http.MethodDelete, step.Name, url.Values{"key": step.envUnset}, nil); err != nil {
+			return err
+		}
+	}
+	if step.scheduleSet {
+		body := map[string]string{"expression": step.Action.Schedule}
+		if step.Action.payload != "" {
+			body["payload"] = step.Action.payload
+		}
+		if err := supplierSchedule(http.MethodPut, step.Name, "", body); err != nil {
+			return err
+		}
+	}
+	if step.scheduleRemove {
+		if err := supplierSchedule(http.MethodDelete, step.Name, "", nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// manifestForm builds the form of the register and update requests by the declared action.
+func manifestForm(action *manifestAction) (map[string]string, error) {
+	form := map[string]string{"runtime": action.Runtime}
+
+	if len(action.Env) > 0 {
+		envJSON, err := json.Marshal(action.Env)
+		if err != nil {
+			return nil, errorx.Internal(err.Error())
+		}
+		form["env"] = string(envJSON)
+	}
+	if action.Timeout > 0 {
+		form[resourceFields[constant.FlagTimeout]] = strconv.Itoa(action.Timeout)
+	}
+
+	return form, nil
+}
+
+func manifestRegister(action *manifestAction) error {
+	form, err := manifestForm(action)
+	if err != nil {
+		return err
+	}
+	if action.Schedule != "" {
+		form["expression"] = action.Schedule
+	}
+	if action.payload != "" {
+		form["payload"] = action.payload
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda", config.Vp.GetString("bound_with.endpoint")))
+
+	return supplierManifest(http.MethodPost, URL, action.bundle, form, "registered successfully")
+}
+
+func manifestUpdate(action *manifestAction) error {
+	form, err := manifestForm(action)
+	if err != nil {
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(action.Name)))
+
+	return supplierManifest(http.MethodPut, URL, action.bundle, form, "updated successfully")
+}
+
+func manifestRemove(name string) error {
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(name)))
+
+	return supplierManifest(http.MethodDelete, URL, "", nil, "removed successfully")
+}
+
+// supplierManifest sends the request of the step, with the bundle and the form in multipart if any,
+// and prints the result in response.
+func supplierManifest(method, URL, bundle string, form map[string]string, msg string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	request := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		})
+	if bundle != "" {
+		request = request.
+			SetHeader("Content-Type", "multipart/form-data").
+			SetFile(bundle, bundle).
+			SetFormData(form)
+	}
+
+	response, err := request.Execute(method, URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info(msg, "result", respData)
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// chain represents the chain command
+var chain = &cobra.Command{
+	Use:   "chain <name/arn> [flags]",
+	Short: "Trigger downstream actions once an action succeeded or failed",
+	Long: `
+Description:
+  The chain command sets the downstream actions of a specific action, identified by
+  its name or ARN (Amazon Resource Name). Once the action succeeded or failed, the
+  downstream one is invoked asynchronously with the output of it as the payload.
+
+This command:
+  - Sets or unsets the action invoked on success and the one invoked on failure
+  - Rejects the chains making a cycle
+  - Prints the links of the whole chain the action belongs to
+
+Arguments:
+  <name/arn>    The name or ARN of the upstream action
+
+Examples:
+  autoaction action chain fetch-price --on-success compute
+  autoaction action chain compute --on-success submit-tx --on-failure alert
+  autoaction action chain compute --on-failure ''
+
+Notes:
+  - The flag not set is kept, and the empty one unsets the downstream action.
+  - The output not being a JSON object is set to the payload field of the event,
+    along with the chain_id shared by all the executions in the chain.
+  - The invocations of all kinds are chained, including the scheduled ones.
+  - Use the info command to view the chain graph, and the history command to track the executions.
+  - Chaining requires the asynchronous invocation enabled on the server.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: chainFunc,
+}
+
+func init() {
+	actionGroup.AddCommand(chain)
+
+	chain.Flags().String(
+		constant.FlagOnSuccess.ValStr(),
+		"",
+		`Name or ARN of the action invoked once this one succeeded, empty to unset.
+`)
+	chain.Flags().String(
+		constant.FlagOnFailure.ValStr(),
+		"",
+		`Name or ARN of the action invoked once this one failed, empty to unset.
+`)
+}
+
+func chainFunc(cmd *cobra.Command, args []string) error {
+	body := make(map[string]string, 2)
+	for flag, field := range map[constant.FlagName]string{
+		constant.FlagOnSuccess: "on_success",
+		constant.FlagOnFailure: "on_failure",
+	} {
+		if !cmd.Flags().Changed(flag.ValStr()) {
+			continue
+		}
+
+		value, _ := cmd.Flags().GetString(flag.ValStr())
+		body[field] = strings.TrimSpace(value)
+	}
+	if len(body) == 0 {
+		return errorx.BadRequest("either the on-success flag or the on-failure flag should be set")
+	}
+
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/chain", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		SetBody(body).
+		Put(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData map[string]interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("action chain", "result", respData)
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// envGroup represents the action env command
+var envGroup = &cobra.Command{
+	Use:   "env",
+	Short: "Manage the environment variables of an action",
+	Long: `
+Description:
+  The env command group manages the non-secret environment variables of an action.
+  Each change publishes a new version of the action, which becomes live right away.
+
+This command group allows you to:
+  - List the variables set by you and the ones injected by the platform
+  - Add or change variables
+  - Remove variables
+
+Notes:
+  - Variables prefixed with AA_ are reserved for the platform, which injects the region,
+    organization, account, network and server endpoint into every action.
+  - Never put secrets into the environment variables, they are stored in plain text.
+
+For detailed information on a specific subcommand, use:
+  autoaction action env <subcommand> --help
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+func init() {
+	actionGroup.AddCommand(envGroup)
+}
+
+// addEnvFlags adds the flags to set environment variables to the command.
+func addEnvFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayP(
+		constant.FlagEnv.ValStr(),
+		"e",
+		nil,
+		`Environment variable for the action, repeatable.
+Example: -e LOG_LEVEL=debug -e HORIZON_URL=https://horizon-testnet.stellar.org
+`)
+
+	cmd.Flags().String(
+		constant.FlagEnvFile.ValStr(),
+		"",
+		`File of environment variables in the dotenv format, overridden by the --env flags.
+Example: ./action.env
+`)
+}
+
+// envFlags collects the environment variables set by the env flags of the command,
+// along with the extra KEY=VALUE pairs, which override the flags.
+func envFlags(cmd *cobra.Command, extra ...string) (map[string]string, error) {
+	pairs, err := cmd.Flags().GetStringArray(constant.FlagEnv.ValStr())
+	if err != nil {
+		return nil, errorx.BadRequest(err.Error())
+	}
+	pairs = append(pairs, extra...)
+
+	envFile, err := cmd.Flags().GetString(constant.FlagEnvFile.ValStr())
+	if err != nil {
+		return nil, errorx.BadRequest(err.Error())
+	}
+
+	return util.ParseEnv(pairs, envFile)
+}
+
+// supplierEnv sends the env request of the action, and prints the variables in response.
+func supplierEnv(method, action string, query url.Values, body interface{}) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf(
+		"%s/lambda/%s/env",
+		config.Vp.GetString("bound_with.endpoint"),
+		url.PathEscape(action),
+	))
+
+	request := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		SetQueryParamsFromValues(query)
+	if body != nil {
+		request = request.SetBody(body)
+	}
+
+	response, err := request.Execute(method, URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData map[string]interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("action env", "result", respData)
+
+	return nil
+}
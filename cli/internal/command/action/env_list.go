@@ -0,0 +1,34 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// envList represents the action env list command
+var envList = &cobra.Command{
+	Use:   "list <name/arn>",
+	Short: "List the environment variables of an action",
+	Long: `
+Description:
+  The list command shows the environment variables of the action identified by its name
+  or ARN (Amazon Resource Name), including the ones injected by the platform.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction action env list my-action
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: envListFunc,
+}
+
+func init() {
+	envGroup.AddCommand(envList)
+}
+
+func envListFunc(_ *cobra.Command, args []string) error {
+	return supplierEnv(http.MethodGet, args[0], nil, nil)
+}
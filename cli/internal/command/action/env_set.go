@@ -0,0 +1,55 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+
+	"github.com/spf13/cobra"
+)
+
+// envSet represents the action env set command
+var envSet = &cobra.Command{
+	Use:   "set <name/arn> [KEY=VALUE...] [flags]",
+	Short: "Add or change the environment variables of an action",
+	Long: `
+Description:
+  The set command adds or changes the environment variables of the action identified
+  by its name or ARN (Amazon Resource Name), the other variables are kept.
+  A new version of the action is published with the variables, and becomes live right away.
+
+Arguments:
+  <name/arn>       The name or ARN of the action
+  [KEY=VALUE...]   The variables to set, the same as the --env flags
+
+Examples:
+  autoaction action env set my-action LOG_LEVEL=debug
+  autoaction action env set my-action -e LOG_LEVEL=debug -e RETRIES=3
+  autoaction action env set my-action --env-file ./action.env
+
+Notes:
+  - Variables prefixed with AA_ are reserved for the platform.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: envSetFunc,
+}
+
+func init() {
+	envGroup.AddCommand(envSet)
+
+	addEnvFlags(envSet)
+}
+
+func envSetFunc(cmd *cobra.Command, args []string) error {
+	env, err := envFlags(cmd, args[1:]...)
+	if err != nil {
+		return err
+	}
+	if len(env) == 0 {
+		return errorx.BadRequest("at least one environment variable should be set")
+	}
+
+	return supplierEnv(http.MethodPut, args[0], nil, map[string]interface{}{
+		"variables": env,
+	})
+}
@@ -0,0 +1,41 @@
+package action
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+)
+
+// envUnset represents the action env unset command
+var envUnset = &cobra.Command{
+	Use:   "unset <name/arn> <KEY...>",
+	Short: "Remove environment variables from an action",
+	Long: `
+Description:
+  The unset command removes the environment variables from the action identified
+  by its name or ARN (Amazon Resource Name), the other variables are kept.
+  A new version of the action is published without the variables, and becomes live right away.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+  <KEY...>      The keys of the variables to remove
+
+Examples:
+  autoaction action env unset my-action LOG_LEVEL
+  autoaction action env unset my-action LOG_LEVEL RETRIES
+
+Notes:
+  - The variables injected by the platform can't be removed.
+`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: envUnsetFunc,
+}
+
+func init() {
+	envGroup.AddCommand(envUnset)
+}
+
+func envUnsetFunc(_ *cobra.Command, args []string) error {
+	return supplierEnv(http.MethodDelete, args[0], url.Values{"key": args[1:]}, nil)
+}
@@ -0,0 +1,211 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// failures represents the failures command
+var failures = &cobra.Command{
+	Use:   "failures <name/arn> [flags]",
+	Short: "List or redrive the failed scheduled executions of an action",
+	Long: `
+Description:
+  The failures command lists the scheduled executions of a specific action, identified by
+  its name or ARN (Amazon Resource Name), which failed after all the retries of the schedule,
+  from the latest to the oldest.
+  With the --redrive flag, the action is invoked again asynchronously with the events of them.
+
+This command provides details including:
+  - The failure ID, the error and the retry attempts of each failure
+  - When it failed, and when it was redriven along with the execution ID
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction action failures my-action
+  autoaction action failures my-action --page 2 --size 50 -o json
+  autoaction action failures my-action --redrive
+  autoaction action failures my-action --redrive --id 7 --id 8
+
+Notes:
+  - The retry policy of the schedule is set by the register and schedule set commands.
+  - The errors of the handler in the scheduled executions are listed as well, when the
+    asynchronous invocation is enabled on the server.
+  - All the failures not redriven yet are redriven when no --id is given.
+  - The redriven failures are kept, with the execution ID to track by the result command.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: failuresFunc,
+}
+
+func init() {
+	actionGroup.AddCommand(failures)
+
+	failures.Flags().Int(
+		constant.FlagPage.ValStr(),
+		1,
+		`The page of the failures, starting from 1.
+`)
+	failures.Flags().Int(
+		constant.FlagSize.ValStr(),
+		20,
+		`The number of failures per page, at most 100.
+`)
+	failures.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+	failures.Flags().Bool(
+		constant.FlagRedrive.ValStr(),
+		false,
+		`Invoke the action again with the events of the failures.
+`)
+	failures.Flags().UintSlice(
+		constant.FlagID.ValStr(),
+		nil,
+		`ID of the failure to redrive, repeatable, all the ones not redriven yet by default.
+Example: --id 7 --id 8
+`)
+}
+
+type failure struct {
+	ID                 uint64     `json:"id"`
+	Payload            string     `json:"payload"`
+	ErrorCode          string     `json:"error_code"`
+	ErrorMessage       string     `json:"error_message"`
+	RetryAttempts      int32      `json:"retry_attempts"`
+	FailedAt           time.Time  `json:"failed_at"`
+	RedriveExecutionID string     `json:"redrive_execution_id"`
+	RedrivenAt         *time.Time `json:"redriven_at"`
+}
+
+type failuresResp struct {
+	Total    int64      `json:"total"`
+	Page     int        `json:"page"`
+	Size     int        `json:"size"`
+	Failures []*failure `json:"failures"`
+}
+
+type redriveResp struct {
+	Redriven []*failure `json:"redriven"`
+}
+
+func failuresFunc(cmd *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	redrive, _ := cmd.Flags().GetBool(constant.FlagRedrive.ValStr())
+	if !redrive && cmd.Flags().Changed(constant.FlagID.ValStr()) {
+		return errorx.BadRequest("the id flag should be set along with the redrive flag")
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/failures", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	request := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		})
+
+	method := http.MethodGet
+	if redrive {
+		ids, _ := cmd.Flags().GetUintSlice(constant.FlagID.ValStr())
+		request = request.SetBody(map[string]interface{}{"ids": ids})
+		method, URL = http.MethodPost, URL+"/redrive"
+	} else {
+		params := make(map[string]string, 2)
+		for _, flag := range []constant.FlagName{constant.FlagPage, constant.FlagSize} {
+			value, _ := cmd.Flags().GetInt(flag.ValStr())
+			params[flag.ValStr()] = strconv.Itoa(value)
+		}
+		request = request.SetQueryParams(params)
+	}
+
+	response, err := request.Execute(method, URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	if output == "json" {
+		fmt.Println(string(response.Body()))
+		return nil
+	}
+
+	if redrive {
+		respData := new(redriveResp)
+		if err := json.Unmarshal(response.Body(), respData); err != nil {
+			logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+			return errorx.Internal(err.Error())
+		}
+
+		return printFailures(respData.Redriven, fmt.Sprintf("%d failures redriven", len(respData.Redriven)))
+	}
+
+	respData := new(failuresResp)
+	if err := json.Unmarshal(response.Body(), respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	return printFailures(respData.Failures, fmt.Sprintf("page %d of %d failures in total", respData.Page, respData.Total))
+}
+
+func printFailures(list []*failure, footer string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tFAILED AT\tRETRIES\tERROR CODE\tREDRIVEN AT\tEXECUTION ID\tERROR")
+	for _, f := range list {
+		redrivenAt, executionID := "-", "-"
+		if f.RedrivenAt != nil {
+			redrivenAt = f.RedrivenAt.Local().Format(time.DateTime)
+		}
+		if f.RedriveExecutionID != "" {
+			executionID = f.RedriveExecutionID
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			f.ID,
+			f.FailedAt.Local().Format(time.DateTime),
+			f.RetryAttempts,
+			f.ErrorCode,
+			redrivenAt,
+			executionID,
+			firstLine(f.ErrorMessage),
+		)
+	}
+
+	fmt.Fprintf(w, "\n%s\n", footer)
+
+	return w.Flush()
+}
@@ -0,0 +1,226 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// history represents the history command
+var history = &cobra.Command{
+	Use:   "history <name/arn> [flags]",
+	Short: "List the invocation history of an action",
+	Long: `
+Description:
+  The history command lists the executions of a specific action, identified by
+  its name or ARN (Amazon Resource Name), from the latest to the oldest.
+  Both the synchronous and the asynchronous invocations are recorded.
+
+This command provides details including:
+  - The execution ID, the mode and the status of each execution
+  - The status code and the duration reported by the action
+  - Who invoked the action and when
+
+Arguments:
+  <name/arn>    The name or ARN of the action to query
+
+Examples:
+  autoaction action history my-action
+  autoaction action history my-action --status failed --since 24h
+  autoaction action history my-action --since 2024-09-01T00:00:00Z --until 2024-09-02T00:00:00Z
+  autoaction action history my-action --page 2 --size 50 -o json
+
+Notes:
+  - The since and until flags accept RFC3339 or a duration before now, like 30m or 24h.
+  - The table output omits the payload, the result and the log tail,
+    use the JSON output or the result command to view them.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: historyFunc,
+}
+
+func init() {
+	actionGroup.AddCommand(history)
+
+	history.Flags().String(
+		constant.FlagStatus.ValStr(),
+		"",
+		`Filter by the status: pending, succeeded or failed.
+`)
+	history.Flags().String(
+		constant.FlagMode.ValStr(),
+		"",
+		`Filter by the mode of invocation: sync or async.
+`)
+	history.Flags().String(
+		constant.FlagSince.ValStr(),
+		"",
+		`List the executions since the time, RFC3339 or a duration like 24h.
+`)
+	history.Flags().String(
+		constant.FlagUntil.ValStr(),
+		"",
+		`List the executions until the time, RFC3339 or a duration like 1h.
+`)
+	history.Flags().Int(
+		constant.FlagPage.ValStr(),
+		1,
+		`The page of the history, starting from 1.
+`)
+	history.Flags().Int(
+		constant.FlagSize.ValStr(),
+		20,
+		`The number of executions per page, at most 100.
+`)
+	history.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+}
+
+func historyFunc(cmd *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	params, err := historyParams(cmd)
+	if err != nil {
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/executions", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		SetQueryParams(params).
+		Get(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	if output == "json" {
+		fmt.Println(string(response.Body()))
+		return nil
+	}
+
+	respData := new(historyResp)
+	if err := json.Unmarshal(response.Body(), respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	return printHistory(respData)
+}
+
+type historyResp struct {
+	Total      int64 `json:"total"`
+	Page       int   `json:"page"`
+	Size       int   `json:"size"`
+	Executions []struct {
+		ExecutionID string    `json:"execution_id"`
+		Invoker     string    `json:"invoker"`
+		Mode        string    `json:"mode"`
+		Status      string    `json:"status"`
+		StatusCode  int32     `json:"status_code"`
+		Duration    float64   `json:"duration"`
+		Error       string    `json:"error"`
+		CreatedAt   time.Time `json:"created_at"`
+	} `json:"executions"`
+}
+
+// historyParams builds the query of the history by the flags, the unchanged ones are left to the server.
+func historyParams(cmd *cobra.Command) (map[string]string, error) {
+	params := make(map[string]string)
+	now := time.Now()
+
+	for _, flag := range []constant.FlagName{constant.FlagStatus, constant.FlagMode} {
+		if value, _ := cmd.Flags().GetString(flag.ValStr()); value != "" {
+			params[flag.ValStr()] = value
+		}
+	}
+
+	for _, flag := range []constant.FlagName{constant.FlagSince, constant.FlagUntil} {
+		value, _ := cmd.Flags().GetString(flag.ValStr())
+		t, err := util.ParseTime(value, now)
+		if err != nil {
+			return nil, err
+		}
+		if !t.IsZero() {
+			params[flag.ValStr()] = t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	for _, flag := range []constant.FlagName{constant.FlagPage, constant.FlagSize} {
+		value, _ := cmd.Flags().GetInt(flag.ValStr())
+		params[flag.ValStr()] = strconv.Itoa(value)
+	}
+
+	return params, nil
+}
+
+func printHistory(resp *historyResp) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "EXECUTION ID\tMODE\tSTATUS\tCODE\tDURATION\tINVOKER\tCREATED AT\tERROR")
+	for _, exe := range resp.Executions {
+		code, duration := "-", "-"
+		if exe.StatusCode != 0 {
+			code = strconv.Itoa(int(exe.StatusCode))
+		}
+		if exe.Duration != 0 {
+			duration = fmt.Sprintf("%.2f ms", exe.Duration)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			exe.ExecutionID,
+			exe.Mode,
+			exe.Status,
+			code,
+			duration,
+			exe.Invoker,
+			exe.CreatedAt.Local().Format(time.DateTime),
+			firstLine(exe.Error),
+		)
+	}
+
+	fmt.Fprintf(w, "\npage %d of %d executions in total\n", resp.Page, resp.Total)
+
+	return w.Flush()
+}
+
+// firstLine keeps the first line of the message to fit in the table.
+func firstLine(msg string) string {
+	line, _, _ := strings.Cut(msg, "\n")
+
+	return line
+}
@@ -25,6 +25,7 @@ Description:
 This command provides details including:
   - Basic action configuration
   - Bound Event Bridge Schedulers
+  - The graph of the chain the action belongs to, set by the chain command
 
 Arguments:
   <name/arn>    The name or ARN of the action to query
@@ -76,5 +77,21 @@ func infoFunc(_ *cobra.Command, args []string) error {
 
 	logx.Logger.Info("action info", "result", respData)
 
+	chainResp := new(struct {
+		Chain *struct {
+			Links []struct {
+				From string `json:"from"`
+				To   string `json:"to"`
+				On   string `json:"on"`
+			} `json:"links"`
+		} `json:"chain"`
+	})
+	if err := json.Unmarshal(response.Body(), chainResp); err == nil && chainResp.Chain != nil {
+		fmt.Println("chain:")
+		for _, link := range chainResp.Chain.Links {
+			fmt.Printf("  %s --%s--> %s\n", link.From, link.On, link.To)
+		}
+	}
+
 	return nil
 }
@@ -31,12 +31,15 @@ Examples:
   autoaction action invoke my-action
   autoaction action invoke arn:aws:lambda:us-west-2:123456789012:function:my-action
   autoaction action invoke my-action -p '{"key": "value"}'
+  autoaction action invoke my-action --async
 
 Notes:
   - If the action does not require input data, the payload flag can be omitted.
   - When provided, the payload must be a valid JSON string that matches the
     expected input format of your action's handler.
   - Ensure you have the necessary permissions to invoke the action.
+  - With the async flag, the command returns an execution ID immediately instead of
+    waiting for the action to finish. Use the result command to fetch the result.
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: invokeFunc,
@@ -53,16 +56,28 @@ func init() {
 		`A well-formed JSON string representing the payload for the action. 
 This payload should be compatible with your action's handler. 
 Example: '{"key": "value"}'
+`)
+
+	invoke.Flags().Bool(
+		constant.FlagAsync.ValStr(),
+		false,
+		`Invoke the action asynchronously and return the execution ID immediately.
+Fetch the result later by: autoaction action result <execution-id>
 `)
 }
 
-func invokeFunc(_ *cobra.Command, args []string) error {
+func invokeFunc(cmd *cobra.Command, args []string) error {
 	token, err := config.Token()
 	if err != nil {
 		logx.Logger.Error("PS: Should login first.")
 		return err
 	}
 
+	async, err := cmd.Flags().GetBool(constant.FlagAsync.ValStr())
+	if err != nil {
+		return errorx.BadRequest(err.Error())
+	}
+
 	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s", config.Vp.GetString("bound_with.endpoint"), args[0]))
 
 	response, err := restyx.Client.R().
@@ -71,8 +86,9 @@ func invokeFunc(_ *cobra.Command, args []string) error {
 			"Content-Type":  "application/json",
 			"Authorization": token,
 		}).
-		SetBody(map[string]string{
+		SetBody(map[string]interface{}{
 			"payload": config.Vp.GetString(constant.FlagPayload.ValStr()),
+			"async":   async,
 		}).
 		Post(URL)
 	if err != nil {
@@ -88,6 +104,11 @@ func invokeFunc(_ *cobra.Command, args []string) error {
 		return errorx.Internal(err.Error())
 	}
 
+	if async {
+		logx.Logger.Info("invoke action accepted", "execution_id", respData["ExecutionID"])
+		return nil
+	}
+
 	logx.Logger.Info("invoke action success", "result", respData)
 
 	return nil
@@ -28,7 +28,7 @@ Examples:
   autoaction action list -f
 
 Output:
-  By default, the command output includes the function name, ARN, and creation date.
+  By default, the command output includes the function name, ARN, runtime, and creation date.
 
 When using the --full flag, additional details are displayed:
   - Action configuration (e.g., handler, runtime, role)
@@ -1,6 +1,7 @@
 package action
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
 	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
 	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
 	"github.com/57blocks/auto-action/cli/internal/pkg/util"
@@ -18,28 +20,40 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// logPongWait the time to wait for the ping of the server, which pings every 54 seconds
+const logPongWait = 60 * time.Second
+
 // logs represents the `log` command
 var logs = &cobra.Command{
-	Use:   "log <name/arn>",
-	Short: "Track execution logs of an action",
+	Use:   "log <name/arn> [flags]",
+	Short: "Track or query execution logs of an action",
 	Long: `
 Description:
-  The log command allows you to track execution logs of a specific action in real-time.
+  The log command allows you to track execution logs of a specific action in real-time,
+  or to query the historical logs of it within a time range.
   You can identify the action by its name or ARN (Amazon Resource Name).
 
 Arguments:
   <name/arn>    The name or ARN of the action to track
 
 Features:
-  - Fetches the latest log events every 5 seconds
-  - Displays logs in real-time as they are generated
+  - Displays logs in real-time as they are generated, following the new runs
+  - Shows the timestamp and the log stream of each log event
+  - Queries the historical logs across all the runs, filtered by a pattern
 
 Examples:
   autoaction action log my-action
   autoaction action log arn:aws:lambda:us-west-2:123456789012:function:my-action
+  autoaction action log my-action --since 2h --filter ERROR --no-follow
+  autoaction action log my-action --since 2024-09-01T00:00:00Z --until 2024-09-02T00:00:00Z
 
 Note:
-  Use Ctrl+C to stop the log tracking.
+  - Use Ctrl+C to stop the log tracking.
+  - The logs are polled by the server, the interval backs off while the action is idle.
+  - Any of the since, until, filter or no-follow flags queries the historical logs
+    instead of tracking, the logs of the last hour are queried if since is absent.
+  - The since and until flags accept RFC3339 or a duration before now, like 30m or 24h.
+  - The filter flag takes the filter pattern syntax of CloudWatch Logs, e.g. '?ERROR ?WARN'.
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: logFunc,
@@ -47,15 +61,48 @@ Note:
 
 func init() {
 	actionGroup.AddCommand(logs)
+
+	logs.Flags().String(
+		constant.FlagSince.ValStr(),
+		"",
+		`Query the logs since the time, RFC3339 or a duration like 2h.
+`)
+	logs.Flags().String(
+		constant.FlagUntil.ValStr(),
+		"",
+		`Query the logs until the time, RFC3339 or a duration like 1h.
+`)
+	logs.Flags().String(
+		constant.FlagFilter.ValStr(),
+		"",
+		`The filter pattern of CloudWatch Logs to match the log events.
+Example: ERROR
+`)
+	logs.Flags().Int(
+		constant.FlagLimit.ValStr(),
+		1000,
+		`The number of log events to query at most.
+`)
+	logs.Flags().Bool(
+		constant.FlagNoFollow.ValStr(),
+		false,
+		`Query the historical logs and exit, rather than tracking in real-time.
+`)
 }
 
-func logFunc(_ *cobra.Command, args []string) error {
+func logFunc(cmd *cobra.Command, args []string) error {
 	token, err := config.Token()
 	if err != nil {
 		logx.Logger.Error("PS: Should login first.")
 		return err
 	}
 
+	for _, flag := range []constant.FlagName{constant.FlagSince, constant.FlagUntil, constant.FlagFilter, constant.FlagNoFollow} {
+		if cmd.Flags().Changed(flag.ValStr()) {
+			return queryLogs(cmd, token, args[0])
+		}
+	}
+
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
 
@@ -79,47 +126,68 @@ func logFunc(_ *cobra.Command, args []string) error {
 	}
 	defer c.Close()
 
+	// the server pings periodically, each ping extends the deadline of reading and gets a pong back
+	_ = c.SetReadDeadline(time.Now().Add(logPongWait))
+	c.SetPingHandler(func(data string) error {
+		_ = c.SetReadDeadline(time.Now().Add(logPongWait))
+		err := c.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		if errors.Is(err, websocket.ErrCloseSent) {
+			return nil
+		}
+		return err
+	})
+
 	done := make(chan struct{})
+	var readErr error
 
 	go func() {
 		defer close(done)
 
 		for {
-			_, message, err := c.ReadMessage()
-			if err != nil {
-				logx.Logger.Error("ws", "read cloudwatch events error", err.Error())
-
+			frame := new(logFrame)
+			if err := c.ReadJSON(frame); err != nil {
+				if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					readErr = err
+				}
 				return
 			}
 
-			logx.Logger.Info("CloudWatch Event", "detail", string(message))
+			var timestamp time.Time
+			if frame.Timestamp != nil {
+				timestamp = *frame.Timestamp
+			}
+			logx.PrettyLogEvent(timestamp, frame.Stream, frame.Message, frame.Type == "error")
 		}
 	}()
 
-	ticker := time.NewTicker(time.Second * 10)
-	defer ticker.Stop()
-
-	for {
+	select {
+	case <-done:
+		if readErr != nil {
+			logx.Logger.Error("ws", "read log frames error", readErr.Error())
+			return errorx.Internal(readErr.Error())
+		}
+		logx.Logger.Info("log tracking closed by the server")
+		return nil
+	case <-interrupt:
+		// Cleanly close the connection by sending a close message and then
+		// waiting (with timeout) for the server to close the connection.
+		err := c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		if err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to write close message to websocket: %s", err.Error()))
+		}
 		select {
 		case <-done:
-			return nil
-		case t := <-ticker.C:
-			err := c.WriteMessage(websocket.TextMessage, []byte(t.String()))
-			if err != nil {
-				return errorx.Internal(fmt.Sprintf("failed to write message to websocket: %s", err.Error()))
-			}
-		case <-interrupt:
-			// Cleanly close the connection by sending a close message and then
-			// waiting (with timeout) for the server to close the connection.
-			err := c.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-			if err != nil {
-				return errorx.Internal(fmt.Sprintf("failed to write close message to websocket: %s", err.Error()))
-			}
-			select {
-			case <-done:
-			case <-time.After(time.Second):
-			}
-			return nil
+		case <-time.After(time.Second):
 		}
+		return nil
 	}
 }
+
+// logFrame the frame of the live logs sent by the server
+type logFrame struct {
+	Type          string     `json:"type"`
+	Timestamp     *time.Time `json:"timestamp"`
+	IngestionTime *time.Time `json:"ingestion_time"`
+	Stream        string     `json:"stream"`
+	Message       string     `json:"message"`
+}
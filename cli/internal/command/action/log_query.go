@@ -0,0 +1,107 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// logQueryPageMax the number of log events queried in each request at most
+const logQueryPageMax = 1000
+
+type logQueryResp struct {
+	Events []struct {
+		Timestamp time.Time `json:"timestamp"`
+		Stream    string    `json:"stream"`
+		Message   string    `json:"message"`
+	} `json:"events"`
+	NextToken string `json:"next_token"`
+}
+
+// queryLogs queries the historical logs of the action page by page,
+// until the limit is reached or none log left.
+func queryLogs(cmd *cobra.Command, token, action string) error {
+	now := time.Now()
+
+	sinceVal, _ := cmd.Flags().GetString(constant.FlagSince.ValStr())
+	since, err := util.ParseTime(sinceVal, now)
+	if err != nil {
+		return err
+	}
+	if since.IsZero() {
+		since = now.Add(-time.Hour)
+	}
+
+	untilVal, _ := cmd.Flags().GetString(constant.FlagUntil.ValStr())
+	until, err := util.ParseTime(untilVal, now)
+	if err != nil {
+		return err
+	}
+
+	filter, _ := cmd.Flags().GetString(constant.FlagFilter.ValStr())
+
+	limit, _ := cmd.Flags().GetInt(constant.FlagLimit.ValStr())
+	if limit <= 0 {
+		return errorx.BadRequest(fmt.Sprintf("invalid limit: %d", limit))
+	}
+
+	params := map[string]string{
+		"start": since.UTC().Format(time.RFC3339),
+	}
+	if !until.IsZero() {
+		params["end"] = until.UTC().Format(time.RFC3339)
+	}
+	if filter != "" {
+		params["filter"] = filter
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/logs/query", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(action)))
+
+	for printed := 0; printed < limit; {
+		params["limit"] = strconv.Itoa(min(limit-printed, logQueryPageMax))
+
+		response, err := restyx.Client.R().
+			EnableTrace().
+			SetHeaders(map[string]string{
+				"Content-Type":  "application/json",
+				"Authorization": token,
+			}).
+			SetQueryParams(params).
+			Get(URL)
+		if err != nil {
+			return errorx.RestyError(err.Error())
+		}
+		if response.IsError() {
+			return errorx.WithRestyResp(response)
+		}
+
+		respData := new(logQueryResp)
+		if err := json.Unmarshal(response.Body(), respData); err != nil {
+			logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+			return errorx.Internal(err.Error())
+		}
+
+		for _, event := range respData.Events {
+			fmt.Printf("%s %s\n", event.Timestamp.Local().Format(time.RFC3339), event.Message)
+		}
+		printed += len(respData.Events)
+
+		if respData.NextToken == "" {
+			break
+		}
+		params["next_token"] = respData.NextToken
+	}
+
+	return nil
+}
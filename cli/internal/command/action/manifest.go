@@ -0,0 +1,495 @@
+package action
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// manifestFile the default manifest file in the current directory
+const manifestFile = "autoaction.yaml"
+
+// the kinds of the steps in the plan
+const (
+	stepCreate = "create"
+	stepUpdate = "update"
+	stepDelete = "delete"
+	// the action registered but not declared, kept without pruning
+	stepKeep = "keep"
+)
+
+type (
+	// manifest declares the actions of the account, the sources are relative to the manifest file.
+	manifest struct {
+		Actions []*manifestAction `yaml:"actions"`
+	}
+
+	manifestAction struct {
+		Name     string            `yaml:"name"`
+		Source   string            `yaml:"source"`
+		Runtime  string            `yaml:"runtime"`
+		Schedule string            `yaml:"schedule"`
+		Payload  interface{}       `yaml:"payload"`
+		Env      map[string]string `yaml:"env"`
+		Timeout  int               `yaml:"timeout"`
+
+		// the bundle packed from the source, and its code_sha256 in base64 as Lambda does
+		bundle     string
+		codeSHA256 string
+		// the payload in JSON
+		payload string
+	}
+
+	// remoteAction the action registered, in the full list of actions
+	remoteAction struct {
+		FunctionName string            `json:"function_name"`
+		Runtime      string            `json:"runtime"`
+		Timeout      int               `json:"timeout"`
+		CodeSHA256   string            `json:"code_sha256"`
+		Environment  map[string]string `json:"environment"`
+		Scheduler    struct {
+			ScheduleArn string `json:"schedule_arn"`
+			Expression  string `json:"expression"`
+			Payload     string `json:"payload"`
+		} `json:"scheduler"`
+	}
+
+	// planStep the change of one action, the changes list the fields differed of the update.
+	planStep struct {
+		Kind    string
+		Name    string
+		Action  *manifestAction
+		Changes []string
+
+		// the update replaces the code, along with the runtime, the timeout and the variables declared
+		redeploy bool
+		// the variables changed without redeploying, and the ones removed
+		envSet   map[string]string
+		envUnset []string
+		// the schedule is set by the expression and the payload, or removed when none declared
+		scheduleSet    bool
+		scheduleRemove bool
+	}
+)
+
+// addManifestFlags adds the flags shared by the plan and apply commands.
+func addManifestFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(
+		constant.FlagFile.ValStr(),
+		"f",
+		manifestFile,
+		`Path of the manifest.
+`)
+	cmd.Flags().Bool(
+		constant.FlagPrune.ValStr(),
+		false,
+		`Delete the actions registered but no longer declared in the manifest.
+`)
+}
+
+// buildPlan loads the manifest by the flags, packs the sources, and diffs them against the registered actions.
+// The cleanup removes the bundles packed, which should be called once the plan is done.
+func buildPlan(cmd *cobra.Command) ([]*planStep, func(), error) {
+	cleanup := func() {}
+
+	path, err := cmd.Flags().GetString(constant.FlagFile.ValStr())
+	if err != nil {
+		return nil, cleanup, errorx.BadRequest(err.Error())
+	}
+	prune, err := cmd.Flags().GetBool(constant.FlagPrune.ValStr())
+	if err != nil {
+		return nil, cleanup, errorx.BadRequest(err.Error())
+	}
+
+	mf, err := loadManifest(path)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	cleanup, err = packManifest(mf, filepath.Dir(path))
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	prefix, err := functionPrefix()
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	remotes, err := remoteActions(prefix)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	return diffManifest(mf, remotes, prune), cleanup, nil
+}
+
+// loadManifest reads and validates the manifest.
+func loadManifest(path string) (*manifest, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorx.BadRequest(err.Error())
+	}
+
+	mf := new(manifest)
+	if err := yaml.Unmarshal(content, mf); err != nil {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid manifest: %s, err: %s", path, err.Error()))
+	}
+
+	names := make(map[string]bool, len(mf.Actions))
+	for i, action := range mf.Actions {
+		if action == nil || strings.TrimSpace(action.Name) == "" {
+			return nil, errorx.BadRequest(fmt.Sprintf("the name of action #%d is required", i+1))
+		}
+		if names[action.Name] {
+			return nil, errorx.BadRequest(fmt.Sprintf("duplicated action: %s", action.Name))
+		}
+		names[action.Name] = true
+
+		if err := validateManifestAction(action); err != nil {
+			return nil, err
+		}
+	}
+
+	return mf, nil
+}
+
+func validateManifestAction(action *manifestAction) error {
+	if strings.TrimSpace(action.Source) == "" {
+		return errorx.BadRequest(fmt.Sprintf("%s: source is required", action.Name))
+	}
+
+	if action.Runtime == "" {
+		action.Runtime = runtimes[0]
+	}
+	if !slices.Contains(runtimes, action.Runtime) {
+		return errorx.BadRequest(fmt.Sprintf("%s: unsupported runtime: %s, supported: %s",
+			action.Name, action.Runtime, strings.Join(runtimes, ", ")))
+	}
+
+	if action.Timeout < 0 {
+		return errorx.BadRequest(fmt.Sprintf("%s: timeout should be a positive integer", action.Name))
+	}
+
+	action.Schedule = strings.TrimSpace(action.Schedule)
+	if action.Payload != nil {
+		if action.Schedule == "" {
+			return errorx.BadRequest(fmt.Sprintf("%s: payload is sent by the schedule, which is absent", action.Name))
+		}
+
+		payload, err := manifestPayload(action.Payload)
+		if err != nil {
+			return errorx.BadRequest(fmt.Sprintf("%s: %s", action.Name, err.Error()))
+		}
+		action.payload = payload
+	}
+
+	return nil
+}
+
+// manifestPayload converts the payload into JSON, which is either a mapping or a JSON object in string.
+func manifestPayload(payload interface{}) (string, error) {
+	if raw, ok := payload.(string); ok {
+		object := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(raw), &object); err != nil {
+			return "", fmt.Errorf("invalid payload: %s", raw)
+		}
+		payload = object
+	}
+
+	if _, ok := payload.(map[string]interface{}); !ok {
+		return "", fmt.Errorf("payload should be an object")
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid payload: %s", err.Error())
+	}
+
+	return string(payloadJSON), nil
+}
+
+// packManifest packs the sources of the actions into a temporary directory, and computes the code_sha256 of them.
+// The zip files are taken as they are, which should be named after the action.
+func packManifest(mf *manifest, base string) (func(), error) {
+	tempDir, err := os.MkdirTemp("", "autoaction-manifest-")
+	if err != nil {
+		return func() {}, errorx.Internal(err.Error())
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(tempDir)
+	}
+
+	for _, action := range mf.Actions {
+		source := action.Source
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(base, source)
+		}
+
+		if strings.HasSuffix(source, ".zip") {
+			if name := strings.TrimSuffix(filepath.Base(source), ".zip"); name != action.Name {
+				return cleanup, errorx.BadRequest(fmt.Sprintf("%s: the zip file should be named after the action, got: %s.zip", action.Name, name))
+			}
+			if err := util.ValidateZipFiles(action.Runtime, []string{source}); err != nil {
+				return cleanup, err
+			}
+
+			sum, err := fileSHA256(source)
+			if err != nil {
+				return cleanup, err
+			}
+
+			action.bundle, action.codeSHA256 = source, sum
+			continue
+		}
+
+		dir, name, err := util.PackSource(source)
+		if err != nil {
+			return cleanup, err
+		}
+		if util.IsBundleEntry(source) && name != action.Name {
+			return cleanup, errorx.BadRequest(fmt.Sprintf("%s: the entry should be named after the action, got: %s", action.Name, filepath.Base(source)))
+		}
+
+		bundle := filepath.Join(tempDir, action.Name+".zip")
+		sum, err := util.PackDir(dir, bundle, action.Runtime)
+		if err != nil {
+			return cleanup, err
+		}
+
+		action.bundle, action.codeSHA256 = bundle, hexToBase64(sum)
+	}
+
+	return cleanup, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errorx.BadRequest(err.Error())
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", errorx.Internal(err.Error())
+	}
+
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+func hexToBase64(sum string) string {
+	raw, _ := hex.DecodeString(sum)
+
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+// functionPrefix returns the prefix of the function names of the account logged in, <organization>-<account>-.
+func functionPrefix() (string, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return "", err
+	}
+
+	credential, err := config.ReadCredential(cfg.Credential)
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%s-", credential.Organization, credential.Account), nil
+}
+
+// remoteActions fetches the registered actions, keyed by the names without the prefix.
+func remoteActions(prefix string) (map[string]*remoteAction, error) {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return nil, err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda?full=true", config.Vp.GetString("bound_with.endpoint")))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		Get(URL)
+	if err != nil {
+		return nil, errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return nil, errorx.WithRestyResp(response)
+	}
+
+	var respData []*remoteAction
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return nil, errorx.Internal(err.Error())
+	}
+
+	remotes := make(map[string]*remoteAction, len(respData))
+	for _, remote := range respData {
+		if name, ok := strings.CutPrefix(remote.FunctionName, prefix); ok {
+			remotes[name] = remote
+		}
+	}
+
+	return remotes, nil
+}
+
+// diffManifest diffs the declared actions against the registered ones, in the order of the manifest,
+// followed by the undeclared ones in the order of names, which are deleted when pruning.
+func diffManifest(mf *manifest, remotes map[string]*remoteAction, prune bool) []*planStep {
+	steps := make([]*planStep, 0, len(mf.Actions))
+
+	for _, action := range mf.Actions {
+		remote, ok := remotes[action.Name]
+		if !ok {
+			steps = append(steps, &planStep{Kind: stepCreate, Name: action.Name, Action: action})
+			continue
+		}
+
+		if step := diffAction(action, remote); len(step.Changes) > 0 {
+			steps = append(steps, step)
+		}
+	}
+
+	undeclared := make([]string, 0)
+	for name := range remotes {
+		if !slices.ContainsFunc(mf.Actions, func(action *manifestAction) bool { return action.Name == name }) {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+
+	kind := stepKeep
+	if prune {
+		kind = stepDelete
+	}
+	for _, name := range undeclared {
+		steps = append(steps, &planStep{Kind: kind, Name: name})
+	}
+
+	return steps
+}
+
+func diffAction(action *manifestAction, remote *remoteAction) *planStep {
+	step := &planStep{Kind: stepUpdate, Name: action.Name, Action: action}
+
+	if action.codeSHA256 != remote.CodeSHA256 {
+		step.Changes = append(step.Changes, "code")
+		step.redeploy = true
+	}
+	if action.Runtime != remote.Runtime {
+		step.Changes = append(step.Changes, "runtime")
+		step.redeploy = true
+	}
+	// the timeout absent is left as it is
+	if action.Timeout != 0 && action.Timeout != remote.Timeout {
+		step.Changes = append(step.Changes, "timeout")
+		step.redeploy = true
+	}
+
+	for key, value := range action.Env {
+		if current, ok := remote.Environment[key]; !ok || current != value {
+			if step.envSet == nil {
+				step.envSet = make(map[string]string)
+			}
+			step.envSet[key] = value
+		}
+	}
+	for key := range remote.Environment {
+		if _, ok := action.Env[key]; !ok {
+			step.envUnset = append(step.envUnset, key)
+		}
+	}
+	sort.Strings(step.envUnset)
+	if !reflect.DeepEqual(nonNilEnv(action.Env), nonNilEnv(remote.Environment)) {
+		step.Changes = append(step.Changes, "env")
+	}
+
+	switch {
+	case action.Schedule == "" && remote.Scheduler.ScheduleArn != "":
+		step.Changes = append(step.Changes, "schedule")
+		step.scheduleRemove = true
+	case action.Schedule != "" && action.Schedule != remote.Scheduler.Expression:
+		step.Changes = append(step.Changes, "schedule")
+		step.scheduleSet = true
+	case action.Schedule != "" && !samePayload(action.payload, remote.Scheduler.Payload):
+		step.Changes = append(step.Changes, "payload")
+		step.scheduleSet = true
+	}
+
+	return step
+}
+
+func nonNilEnv(env map[string]string) map[string]string {
+	if env == nil {
+		return map[string]string{}
+	}
+
+	return env
+}
+
+// samePayload compares the payloads in JSON semantically, the empty ones are the same.
+func samePayload(declared, registered string) bool {
+	if declared == "" || registered == "" {
+		return declared == registered
+	}
+
+	var d, r interface{}
+	if json.Unmarshal([]byte(declared), &d) != nil || json.Unmarshal([]byte(registered), &r) != nil {
+		return declared == registered
+	}
+
+	return reflect.DeepEqual(d, r)
+}
+
+// printPlan prints the steps of the plan, one line per action.
+func printPlan(steps []*planStep) {
+	counts := make(map[string]int, 4)
+	for _, step := range steps {
+		counts[step.Kind]++
+
+		switch step.Kind {
+		case stepCreate:
+			fmt.Printf("  + %-8s %s\n", step.Kind, step.Name)
+		case stepUpdate:
+			fmt.Printf("  ~ %-8s %s (%s)\n", step.Kind, step.Name, strings.Join(step.Changes, ", "))
+		case stepDelete:
+			fmt.Printf("  - %-8s %s\n", step.Kind, step.Name)
+		case stepKeep:
+			fmt.Printf("    %-8s %s (not declared, use --prune to delete)\n", step.Kind, step.Name)
+		}
+	}
+
+	if counts[stepCreate]+counts[stepUpdate]+counts[stepDelete] == 0 {
+		fmt.Println("No changes. The actions are up to date with the manifest.")
+		return
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete.\n",
+		counts[stepCreate], counts[stepUpdate], counts[stepDelete])
+}
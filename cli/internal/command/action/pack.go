@@ -0,0 +1,158 @@
+package action
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// pack represents the pack command
+var pack = &cobra.Command{
+	Use:   "pack <dir/entry> [flags]",
+	Short: "Pack a source directory into a deployable bundle",
+	Long: `
+Description:
+  The pack command packs the source directory of an action into a zip bundle,
+  which is accepted by the register and update commands.
+  The same sources are always packed into the same bundle, byte for byte.
+
+This command:
+  - Checks the entry file <name>.js or <name>.py exports the handler
+  - Bundles the TypeScript or ES module entry <name>.ts, <name>.mts or <name>.mjs
+    and its local imports into one CommonJS <name>.js, along with the source map
+  - Skips the files matched by the patterns in the .autoactionignore file
+  - Includes the production dependencies in node_modules, the dev ones are skipped
+  - Prints the sha256 of the bundle
+
+Arguments:
+  <dir/entry>    The source directory of the action, or the entry file to bundle in it
+
+Examples:
+  autoaction action pack ./my-action
+  autoaction action pack ./src -o ./dist/my-action.zip
+  autoaction action pack ./my-action --runtime python3.12
+  autoaction action pack ./src/my-action.ts --runtime nodejs22.x
+
+Notes:
+  - The name of the bundle is the name of the action, the one of the directory by default,
+    and the entry file should be named after it at the top level of the directory.
+  - The .autoactionignore file takes one pattern per line, like the .gitignore but the negations:
+    node_modules/.cache/, *.test.js, /scripts/*
+  - The production dependencies are the ones declared in the dependencies of package.json,
+    along with the ones they depend on.
+  - The entry to bundle targets the Node.js version of the runtime, nodejs20.x by default.
+    The packages imported are not bundled, but packed along with the node_modules.
+  - The compile errors are reported by file:line, and nothing is packed then.
+  - Set NODE_OPTIONS=--enable-source-maps by the --env flag of register or update,
+    to trace the errors back to the sources in the logs.
+  - The register and update commands pack the directories and the entries given on the fly.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: packFunc,
+}
+
+func init() {
+	actionGroup.AddCommand(pack)
+
+	pack.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"",
+		`Path of the bundle, <dir-name>.zip or <entry-name>.zip in the current directory by default.
+`)
+	addRuntimeFlag(pack, "", `Runtime of the action, decides the entry file to check.
+Both the js and python entry files are accepted by default.
+`)
+}
+
+func packFunc(cmd *cobra.Command, args []string) error {
+	runtime, err := runtimeFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	dest, err := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if err != nil {
+		return errorx.BadRequest(err.Error())
+	}
+
+	dir, name, err := util.PackSource(args[0])
+	if err != nil {
+		return err
+	}
+
+	if dest == "" {
+		dest = name + ".zip"
+	}
+	if !strings.HasSuffix(dest, ".zip") {
+		return errorx.BadRequest("the bundle should be a .zip file")
+	}
+	if util.IsBundleEntry(args[0]) && filepath.Base(dest) != name+".zip" {
+		return errorx.BadRequest(fmt.Sprintf("the bundle should be named after the entry: %s.zip", name))
+	}
+
+	sum, err := util.PackDir(dir, dest, runtime)
+	if err != nil {
+		return err
+	}
+
+	logx.Logger.Info("packed successfully", "bundle", dest, "sha256", sum)
+
+	return nil
+}
+
+// packDirs packs the directories and the entries to bundle among the paths into a temporary directory on the fly,
+// the paths of the bundles are returned in place of them, the other paths are kept.
+// The cleanup removes the temporary directory, which should be called once the bundles are shipped.
+func packDirs(runtime string, paths []string) ([]string, func(), error) {
+	bundles := make([]string, 0, len(paths))
+	tempDir := ""
+	cleanup := func() {
+		if tempDir != "" {
+			_ = os.RemoveAll(tempDir)
+		}
+	}
+
+	for _, path := range paths {
+		stat, err := os.Stat(path)
+		if err != nil || !stat.IsDir() && !util.IsBundleEntry(path) {
+			bundles = append(bundles, path)
+			continue
+		}
+
+		if tempDir == "" {
+			tempDir, err = os.MkdirTemp("", "autoaction-pack-")
+			if err != nil {
+				return nil, cleanup, errorx.Internal(err.Error())
+			}
+		}
+
+		dir, name, err := util.PackSource(path)
+		if err != nil {
+			return nil, cleanup, err
+		}
+
+		dest := filepath.Join(tempDir, name+".zip")
+		if util.IsExists(dest) {
+			return nil, cleanup, errorx.BadRequest("duplicated action name: " + name)
+		}
+
+		sum, err := util.PackDir(dir, dest, runtime)
+		if err != nil {
+			return nil, cleanup, err
+		}
+		logx.Logger.Info("packed on the fly", "source", path, "sha256", sum)
+
+		bundles = append(bundles, dest)
+	}
+
+	return bundles, cleanup, nil
+}
@@ -0,0 +1,72 @@
+package action
+
+import (
+	"github.com/57blocks/auto-action/cli/internal/command"
+
+	"github.com/spf13/cobra"
+)
+
+// plan represents the plan command
+var plan = &cobra.Command{
+	Use:   "plan [flags]",
+	Short: "Show the changes to make the actions match the manifest",
+	Long: `
+Description:
+  The plan command diffs the actions declared in the manifest, autoaction.yaml by default,
+  against the ones registered, and prints the actions to create, update and delete.
+  Nothing is changed by the plan, use the apply command to execute it.
+
+The manifest declares each action by:
+  - name        The name of the action, required
+  - source      The zip package, the directory or the entry to pack, relative to the manifest, required
+  - runtime     The runtime, nodejs20.x by default
+  - schedule    The schedule expression, cron, rate or at
+  - payload     The payload sent by the schedule, an object
+  - env         The environment variables
+  - timeout     The timeout in seconds, left as it is when absent
+
+Example of the manifest:
+  actions:
+    - name: price-watcher
+      source: ./actions/price-watcher
+      schedule: rate(5 minutes)
+      payload:
+        asset: XLM
+      env:
+        LOG_LEVEL: info
+      timeout: 60
+    - name: settle
+      source: ./actions/settle/settle.ts
+      runtime: nodejs22.x
+
+Examples:
+  autoaction plan
+  autoaction plan -f ./deploy/staging.yaml
+  autoaction plan --prune
+
+Notes:
+  - The code is compared by the code_sha256 of the bundles, which are packed the same as the pack command.
+  - The environment variables not declared are removed from the action.
+  - The actions registered but not declared are kept, unless with the --prune flag.
+`,
+	Args: cobra.NoArgs,
+	RunE: planFunc,
+}
+
+func init() {
+	command.Root.AddCommand(plan)
+
+	addManifestFlags(plan)
+}
+
+func planFunc(cmd *cobra.Command, _ []string) error {
+	steps, cleanup, err := buildPlan(cmd)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	printPlan(steps)
+
+	return nil
+}
@@ -3,6 +3,7 @@ package action
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/57blocks/auto-action/cli/internal/config"
@@ -18,7 +19,7 @@ import (
 
 // register represents the register command
 var register = &cobra.Command{
-	Use:   "register [zips/packages]",
+	Use:   "register [zips/packages/dirs/entries]",
 	Short: "Register local handlers as actions",
 	Long: `
 Description:
@@ -26,35 +27,45 @@ Description:
 
 Notes:
   - Action name is derived from the file name; ensure it's unique.
+  - A directory is packed on the fly, the same as the pack command, named after the directory.
+  - A TypeScript or ES module entry (.ts/.mts/.mjs) is bundled and packed on the fly,
+    named after the entry file.
   - The handler function must be named "handler".
+  - The runtime is nodejs20.x by default, the entry file is <name>.js for Node.js,
+    and <name>.py for Python, where <name> is the name of the zip file.
   - Without flags, the action will be triggered manually via the invoke command.
   - Payload must be a valid JSON string, usable by the handler(s).
   - Only one scheduling expression (cron/rate/at) can be set per action.
+  - Environment variables are set by the --env and --env-file flags, the ones prefixed
+    with AA_ are reserved for the platform.
+  - The scheduled executions failed after the retries are captured as failures,
+    see the failures command.
+  - The --on-payment, --on-operation and --on-effect flags watch the Stellar accounts,
+    and invoke the action with each of their activities streamed from Horizon, the decoded
+    record is set to the operation or effect field of the payload. Manage them by the
+    trigger command.
 
 Scheduling Options:
   - Cron: Standard cron expression
-  - Rate: Supports minutes, hours, days. E.g., rate(1 minutes)
+  - Rate: Supports minutes, hours, days, singular for the value of 1. E.g., rate(1 minute), rate(5 minutes)
   - At: One-time execution. Format: at(yyyy-mm-ddThh:mm:ss)
 
 Examples:
   autoaction action register ./handler.zip
+  autoaction action register ./handler
+  autoaction action register ./src/handler.ts
   autoaction action register ./handler.zip -a 'at(2022-12-31T23:59:59)' -p '{"key": "value"}'
-  autoaction action register ./handler.zip -r 'rate(1 minutes)' -p '{"key": "value"}'
+  autoaction action register ./handler.zip -r 'rate(1 minute)' -p '{"key": "value"}'
   autoaction action register ./handler.zip -c 'cron(0 12 * * ? *)' -p '{"key": "value"}'
+  autoaction action register ./handler.zip -r 'rate(1 hour)' --max-retry-attempts 3 --max-event-age 3600
+  autoaction action register ./handler.zip -e LOG_LEVEL=debug --env-file ./action.env
+  autoaction action register ./handler.zip --timeout 120 --memory 512
+  autoaction action register ./handler.zip --runtime python3.12
+  autoaction action register ./handler.zip --on-payment GABC...XYZ
 `,
-	PreRunE: func(cmd *cobra.Command, args []string) error {
-		a := cmd.Flags().Changed(constant.FlagAt.ValStr())
-		c := cmd.Flags().Changed(constant.FlagCron.ValStr())
-		r := cmd.Flags().Changed(constant.FlagRate.ValStr())
-
-		if a && r || a && c || r && c {
-			return errorx.BadRequest("at most one expression flag should be set")
-		}
-
-		return nil
-	},
-	Args: cobra.MinimumNArgs(1),
-	RunE: registerFunc,
+	PreRunE: exclusiveExpression,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    registerFunc,
 }
 
 func init() {
@@ -102,15 +113,81 @@ More info: https://docs.aws.amazon.com/scheduler/latest/UserGuide/schedule-types
 		`JSON payload for the action execution.
 Must be a valid JSON string.
 Example: '{"key": "value"}'
+`)
+
+	addEnvFlags(register)
+	addResourceFlags(register)
+	addRetryFlags(register)
+	addTriggerFlags(register)
+	addRuntimeFlag(register, runtimes[0], `Runtime of the action.
 `)
 }
 
-func registerFunc(_ *cobra.Command, args []string) error {
-	if err := util.ValidateZipFiles(args); err != nil {
+// exclusiveExpression ensures at most one of the expression flags is set.
+func exclusiveExpression(cmd *cobra.Command, _ []string) error {
+	a := cmd.Flags().Changed(constant.FlagAt.ValStr())
+	c := cmd.Flags().Changed(constant.FlagCron.ValStr())
+	r := cmd.Flags().Changed(constant.FlagRate.ValStr())
+
+	if a && r || a && c || r && c {
+		return errorx.BadRequest("at most one expression flag should be set")
+	}
+
+	return nil
+}
+
+// expressionFlag returns the value of the expression flag which is set.
+func expressionFlag() string {
+	fKeys := []string{constant.FlagAt.ValStr(), constant.FlagCron.ValStr(), constant.FlagRate.ValStr()}
+	for _, key := range fKeys {
+		if expVal := strings.TrimSpace(config.Vp.GetString(key)); expVal != "" {
+			return expVal
+		}
+	}
+
+	return ""
+}
+
+func registerFunc(cmd *cobra.Command, args []string) error {
+	runtime, err := runtimeFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	bundles, cleanup, err := packDirs(runtime, args)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	if err := util.ValidateZipFiles(runtime, bundles); err != nil {
+		return err
+	}
+
+	env, err := envFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	resources, err := resourceFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	retry, err := retryFlags(cmd)
+	if err != nil {
+		return err
+	}
+	for field, value := range retry {
+		resources[field] = strconv.Itoa(value)
+	}
+
+	triggers, err := triggerFlags(cmd)
+	if err != nil {
 		return err
 	}
 
-	response, err := supplierRegister(args)
+	response, err := supplierRegister(bundles, runtime, env, resources, triggers)
 	if err != nil {
 		return err
 	}
@@ -126,7 +203,12 @@ func registerFunc(_ *cobra.Command, args []string) error {
 	return nil
 }
 
-func supplierRegister(args []string) (*resty.Response, error) {
+func supplierRegister(
+	args []string,
+	runtime string,
+	env, resources map[string]string,
+	triggers map[string][]string,
+) (*resty.Response, error) {
 	token, err := config.Token()
 	if err != nil {
 		logx.Logger.Error("PS: Should login first.")
@@ -150,13 +232,9 @@ func supplierRegister(args []string) (*resty.Response, error) {
 
 	// flags handling
 	fMap := make(map[string]string, 1)
-	fKeys := []string{constant.FlagAt.ValStr(), constant.FlagCron.ValStr(), constant.FlagRate.ValStr()}
-	for _, key := range fKeys {
-		if expVal := strings.TrimSpace(config.Vp.GetString(key)); expVal != "" {
-			fMap["expression"] = expVal
-			logx.Logger.Info("register action", "invoke expression", expVal)
-			break
-		}
+	if expVal := expressionFlag(); expVal != "" {
+		fMap["expression"] = expVal
+		logx.Logger.Info("register action", "invoke expression", expVal)
 	}
 
 	if len(fMap) == 0 {
@@ -173,8 +251,30 @@ func supplierRegister(args []string) (*resty.Response, error) {
 	}
 	logx.Logger.Info("register action", "invoke payload", "none")
 
+	if len(env) > 0 {
+		envJSON, err := json.Marshal(env)
+		if err != nil {
+			return nil, errorx.Internal(err.Error())
+		}
+
+		fMap["env"] = string(envJSON)
+	}
+
+	for field, value := range resources {
+		fMap[field] = value
+	}
+
+	if runtime != "" {
+		fMap["runtime"] = runtime
+	}
+
 	request = request.SetFormData(fMap)
 
+	if len(triggers) > 0 {
+		request = request.SetFormDataFromValues(triggers)
+		logx.Logger.Info("register action", "triggers", triggers)
+	}
+
 	response, err := request.Post(URL)
 	if err != nil {
 		return nil, errorx.RestyError(err.Error())
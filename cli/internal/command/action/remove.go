@@ -33,6 +33,7 @@ Examples:
 Notes:
   - This command removes both the action and its associated EventBridge Scheduler (if any).
   - Execution logs for the action will remain in CloudWatch Logs and are not deleted by this command.
+  - The execution history and the failed events of the action are deleted along with it.
   - The removal response will include information about both the action and its associated scheduler.
 
 Caution:
@@ -0,0 +1,65 @@
+package action
+
+import (
+	"strconv"
+
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+
+	"github.com/spf13/cobra"
+)
+
+// resourceFields maps the resource flags to the form fields of the request.
+var resourceFields = map[constant.FlagName]string{
+	constant.FlagTimeout:          "timeout",
+	constant.FlagMemory:           "memory",
+	constant.FlagEphemeralStorage: "ephemeral_storage",
+}
+
+// addResourceFlags adds the flags to configure the resources of the action to the command.
+func addResourceFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(
+		constant.FlagTimeout.ValStr(),
+		0,
+		`Timeout of the action in seconds, 30 by default when registering.
+The ceiling is configured by the server, 300 seconds by default.
+Example: --timeout 120
+`)
+
+	cmd.Flags().Int(
+		constant.FlagMemory.ValStr(),
+		0,
+		`Memory of the action in MB, 128 by default when registering.
+Example: --memory 512
+`)
+
+	cmd.Flags().Int(
+		constant.FlagEphemeralStorage.ValStr(),
+		0,
+		`Ephemeral storage (/tmp) of the action in MB, 512 by default when registering.
+Example: --ephemeral-storage 1024
+`)
+}
+
+// resourceFlags collects the resource flags set, into the form fields of the request.
+func resourceFlags(cmd *cobra.Command) (map[string]string, error) {
+	fields := make(map[string]string, len(resourceFields))
+
+	for flag, field := range resourceFields {
+		if !cmd.Flags().Changed(flag.ValStr()) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetInt(flag.ValStr())
+		if err != nil {
+			return nil, errorx.BadRequest(err.Error())
+		}
+		if value <= 0 {
+			return nil, errorx.BadRequest(flag.ValStr() + " should be a positive integer")
+		}
+
+		fields[field] = strconv.Itoa(value)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,105 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// resultPollInterval the interval of polling the execution while waiting
+const resultPollInterval = 2 * time.Second
+
+// result represents the result command
+var result = &cobra.Command{
+	Use:   "result <execution-id> [flags]",
+	Short: "Fetch the result of an asynchronous invocation",
+	Long: `
+Description:
+  The result command fetches the execution of an action invoked asynchronously,
+  identified by the execution ID returned by: autoaction action invoke --async
+
+This command provides details including:
+  - The status of the execution: pending, succeeded or failed
+  - The payload returned by the action, or the error it failed with
+  - The tail of the logs printed during the execution
+
+Arguments:
+  <execution-id>    The execution ID returned by the asynchronous invocation
+
+Examples:
+  autoaction action result 2b6b4d8a-1c3e-4f5a-9d7e-0a1b2c3d4e5f
+  autoaction action result 2b6b4d8a-1c3e-4f5a-9d7e-0a1b2c3d4e5f --wait
+
+Notes:
+  - Without the wait flag, a pending execution is returned as it is.
+  - With the wait flag, the command polls until the execution is finished,
+    press Ctrl+C to stop waiting.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: resultFunc,
+}
+
+func init() {
+	actionGroup.AddCommand(result)
+
+	result.Flags().BoolP(
+		constant.FlagWait.ValStr(),
+		"w",
+		false,
+		`Wait until the execution is finished.
+`)
+}
+
+func resultFunc(cmd *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	wait, err := cmd.Flags().GetBool(constant.FlagWait.ValStr())
+	if err != nil {
+		return errorx.BadRequest(err.Error())
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/executions/%s", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	for {
+		response, err := restyx.Client.R().
+			EnableTrace().
+			SetHeaders(map[string]string{
+				"Content-Type":  "application/json",
+				"Authorization": token,
+			}).
+			Get(URL)
+		if err != nil {
+			return errorx.RestyError(err.Error())
+		}
+		if response.IsError() {
+			return errorx.WithRestyResp(response)
+		}
+
+		var respData map[string]interface{}
+		if err := json.Unmarshal(response.Body(), &respData); err != nil {
+			logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+			return errorx.Internal(err.Error())
+		}
+
+		if !wait || respData["status"] != "pending" {
+			logx.Logger.Info("action execution", "result", respData)
+			return nil
+		}
+
+		time.Sleep(resultPollInterval)
+	}
+}
@@ -0,0 +1,54 @@
+package action
+
+import (
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+
+	"github.com/spf13/cobra"
+)
+
+// retryFields maps the retry flags to the fields of the request.
+var retryFields = map[constant.FlagName]string{
+	constant.FlagMaxRetryAttempts: "max_retry_attempts",
+	constant.FlagMaxEventAge:      "max_event_age",
+}
+
+// addRetryFlags adds the flags to configure the retry policy of the schedule to the command.
+func addRetryFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(
+		constant.FlagMaxRetryAttempts.ValStr(),
+		0,
+		`Maximum times to retry the failed scheduled execution, from 0 to 185, 185 by default.
+Example: --max-retry-attempts 3
+`)
+
+	cmd.Flags().Int(
+		constant.FlagMaxEventAge.ValStr(),
+		0,
+		`Maximum age of the scheduled event to retry in seconds, from 60 to 86400, 86400 by default.
+Example: --max-event-age 3600
+`)
+}
+
+// retryFlags collects the retry flags set, into the fields of the request.
+func retryFlags(cmd *cobra.Command) (map[string]int, error) {
+	fields := make(map[string]int, len(retryFields))
+
+	for flag, field := range retryFields {
+		if !cmd.Flags().Changed(flag.ValStr()) {
+			continue
+		}
+
+		value, err := cmd.Flags().GetInt(flag.ValStr())
+		if err != nil {
+			return nil, errorx.BadRequest(err.Error())
+		}
+		if value < 0 {
+			return nil, errorx.BadRequest(flag.ValStr() + " should not be negative")
+		}
+
+		fields[field] = value
+	}
+
+	return fields, nil
+}
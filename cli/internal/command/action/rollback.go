@@ -0,0 +1,93 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// rollback represents the rollback command
+var rollback = &cobra.Command{
+	Use:   "rollback <name/arn> --to <version>",
+	Short: "Make a previously published version of an action live again",
+	Long: `
+Description:
+  The rollback command repoints a specific action, identified by its name or ARN
+  (Amazon Resource Name), to one of its previously published versions.
+  The code is not uploaded again, the version is switched in place.
+
+Arguments:
+  <name/arn>    The name or ARN of the action to roll back
+
+Examples:
+  autoaction action rollback my-action --to 3
+  autoaction action rollback arn:aws:lambda:us-west-2:123456789012:function:my-action --to 3
+
+Notes:
+  - Use the versions command to find the version to roll back to.
+  - The bound scheduler keeps running, and runs the rolled back version from now on.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: rollbackFunc,
+}
+
+func init() {
+	actionGroup.AddCommand(rollback)
+
+	fTo := constant.FlagTo.ValStr()
+	rollback.Flags().String(
+		fTo,
+		config.Vp.GetString(fTo),
+		`The published version to roll back to.
+Example: 3
+`)
+	if err := rollback.MarkFlagRequired(fTo); err != nil {
+		return
+	}
+}
+
+func rollbackFunc(_ *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/rollback", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		SetBody(map[string]string{
+			"version": config.Vp.GetString(constant.FlagTo.ValStr()),
+		}).
+		Post(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData map[string]interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("rolled back successfully", "result", respData)
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package action
+
+import (
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+
+	"github.com/spf13/cobra"
+)
+
+// runtimes the runtimes supported by the server, the first one is the default.
+var runtimes = []string{"nodejs20.x", "nodejs22.x", "python3.12"}
+
+// addRuntimeFlag adds the flag to specify the runtime of the action to the command.
+func addRuntimeFlag(cmd *cobra.Command, defVal string, usage string) {
+	cmd.Flags().String(
+		constant.FlagRuntime.ValStr(),
+		defVal,
+		usage+`Supported: `+strings.Join(runtimes, ", ")+`
+`)
+}
+
+// runtimeFlag returns the runtime flag, the empty one means not specified.
+func runtimeFlag(cmd *cobra.Command) (string, error) {
+	runtime, err := cmd.Flags().GetString(constant.FlagRuntime.ValStr())
+	if err != nil {
+		return "", errorx.BadRequest(err.Error())
+	}
+
+	return strings.TrimSpace(runtime), nil
+}
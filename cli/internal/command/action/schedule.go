@@ -0,0 +1,86 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// scheduleGroup represents the action schedule command
+var scheduleGroup = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage the schedule bound with an action",
+	Long: `
+Description:
+  The schedule command group manages the EventBridge Scheduler bound with an action,
+  without removing and registering the action again.
+
+This command group allows you to:
+  - Bind a schedule with an action, or change its expression or payload
+  - Pause and resume the schedule
+  - Detach the schedule from the action
+  - Preview the next fire times of an expression
+
+For detailed information on a specific subcommand, use:
+  autoaction action schedule <subcommand> --help
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+func init() {
+	actionGroup.AddCommand(scheduleGroup)
+}
+
+// supplierSchedule sends the schedule request of the action, and prints the schedule in response.
+func supplierSchedule(method, action, subPath string, body interface{}) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf(
+		"%s/lambda/%s/schedule%s",
+		config.Vp.GetString("bound_with.endpoint"),
+		url.PathEscape(action),
+		subPath,
+	))
+
+	request := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		})
+	if body != nil {
+		request = request.SetBody(body)
+	}
+
+	response, err := request.Execute(method, URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData map[string]interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("action schedule", "result", respData)
+
+	return nil
+}
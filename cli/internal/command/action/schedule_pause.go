@@ -0,0 +1,38 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// schedulePause represents the action schedule pause command
+var schedulePause = &cobra.Command{
+	Use:   "pause <name/arn>",
+	Short: "Pause the schedule bound with an action",
+	Long: `
+Description:
+  The pause command disables the schedule bound with the action identified by its name
+  or ARN (Amazon Resource Name). The schedule stops invoking the action until it is resumed.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction action schedule pause my-action
+
+Notes:
+  - The expression and payload of the schedule are kept.
+  - The action can still be invoked manually while the schedule is paused.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: schedulePauseFunc,
+}
+
+func init() {
+	scheduleGroup.AddCommand(schedulePause)
+}
+
+func schedulePauseFunc(_ *cobra.Command, args []string) error {
+	return supplierSchedule(http.MethodPost, args[0], "/pause", nil)
+}
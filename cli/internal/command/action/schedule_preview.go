@@ -0,0 +1,114 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// schedulePreview represents the action schedule preview command
+var schedulePreview = &cobra.Command{
+	Use:   "preview <expression> [flags]",
+	Short: "Preview the next fire times of a schedule expression",
+	Long: `
+Description:
+  The preview command validates a schedule expression (cron/rate/at) on the server,
+  and prints the next fire times of it in the given timezone, before binding it
+  with any action.
+
+Arguments:
+  <expression>    The schedule expression to preview
+
+Examples:
+  autoaction action schedule preview 'cron(0 12 ? * MON-FRI *)'
+  autoaction action schedule preview 'rate(5 minutes)' -n 10
+  autoaction action schedule preview 'cron(0 9 L * ? *)' -z America/New_York
+
+Notes:
+  - The expression follows the syntax of EventBridge Scheduler.
+  - The expression is evaluated in UTC, the same as the bound schedules.
+  - The timezone is an IANA timezone name to display the fire times in, UTC by default.
+  - The fire times of rate expressions are counted from now.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: schedulePreviewFunc,
+}
+
+func init() {
+	scheduleGroup.AddCommand(schedulePreview)
+
+	fCount := constant.FlagCount.ValStr()
+	schedulePreview.Flags().IntP(
+		fCount,
+		"n",
+		5,
+		`The number of fire times to preview, at most 50.
+Example: 10
+`)
+
+	fTimezone := constant.FlagTimezone.ValStr()
+	schedulePreview.Flags().StringP(
+		fTimezone,
+		"z",
+		"UTC",
+		`The timezone to display the fire times in.
+Example: Asia/Shanghai
+`)
+}
+
+func schedulePreviewFunc(cmd *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	count, err := cmd.Flags().GetInt(constant.FlagCount.ValStr())
+	if err != nil {
+		return errorx.BadRequest(err.Error())
+	}
+	timezone, err := cmd.Flags().GetString(constant.FlagTimezone.ValStr())
+	if err != nil {
+		return errorx.BadRequest(err.Error())
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/schedule/preview", config.Vp.GetString("bound_with.endpoint")))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		SetQueryParams(map[string]string{
+			"expression": args[0],
+			"count":      strconv.Itoa(count),
+			"timezone":   timezone,
+		}).
+		Get(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData map[string]interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("schedule preview", "result", respData)
+
+	return nil
+}
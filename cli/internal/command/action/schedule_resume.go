@@ -0,0 +1,37 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// scheduleResume represents the action schedule resume command
+var scheduleResume = &cobra.Command{
+	Use:   "resume <name/arn>",
+	Short: "Resume the paused schedule bound with an action",
+	Long: `
+Description:
+  The resume command enables the paused schedule bound with the action identified by its name
+  or ARN (Amazon Resource Name). The schedule invokes the action again by its expression.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction action schedule resume my-action
+
+Notes:
+  - Executions missed while the schedule was paused are not made up.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: scheduleResumeFunc,
+}
+
+func init() {
+	scheduleGroup.AddCommand(scheduleResume)
+}
+
+func scheduleResumeFunc(_ *cobra.Command, args []string) error {
+	return supplierSchedule(http.MethodPost, args[0], "/resume", nil)
+}
@@ -0,0 +1,38 @@
+package action
+
+import (
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+// scheduleRm represents the action schedule rm command
+var scheduleRm = &cobra.Command{
+	Use:   "rm <name/arn>",
+	Short: "Detach the schedule from an action",
+	Long: `
+Description:
+  The rm command deletes the schedule bound with the action identified by its name
+  or ARN (Amazon Resource Name). The action itself is kept, and can be invoked manually
+  or bind with a new schedule by the set command.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction action schedule rm my-action
+
+Caution:
+  This operation is irreversible, the expression and payload of the schedule are dropped.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: scheduleRmFunc,
+}
+
+func init() {
+	scheduleGroup.AddCommand(scheduleRm)
+}
+
+func scheduleRmFunc(_ *cobra.Command, args []string) error {
+	return supplierSchedule(http.MethodDelete, args[0], "", nil)
+}
@@ -0,0 +1,117 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+
+	"github.com/spf13/cobra"
+)
+
+// scheduleSet represents the action schedule set command
+var scheduleSet = &cobra.Command{
+	Use:   "set <name/arn> [flags]",
+	Short: "Bind a schedule with an action, or change the bound one",
+	Long: `
+Description:
+  The set command binds a schedule with the action identified by its name or ARN
+  (Amazon Resource Name). When the action already has a schedule, its expression,
+  payload and/or retry policy are changed in place, the state of the schedule is kept.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction action schedule set my-action -r 'rate(5 minutes)'
+  autoaction action schedule set my-action -c 'cron(0 12 * * ? *)' -p '{"key": "value"}'
+  autoaction action schedule set my-action -p '{"key": "another value"}'
+  autoaction action schedule set my-action --max-retry-attempts 0
+
+Notes:
+  - An expression is required when the action has no schedule yet.
+  - Only one scheduling expression (cron/rate/at) can be set.
+  - Payload must be a valid JSON string, usable by the handler.
+  - The retry policy not set is kept, or 185 attempts within 86400 seconds by default.
+`,
+	PreRunE: exclusiveExpression,
+	Args:    cobra.ExactArgs(1),
+	RunE:    scheduleSetFunc,
+}
+
+func init() {
+	scheduleGroup.AddCommand(scheduleSet)
+
+	fCron := constant.FlagCron.ValStr()
+	scheduleSet.Flags().StringP(
+		fCron,
+		"c",
+		config.Vp.GetString(fCron),
+		`Cron expression for scheduled execution.
+Example: cron(0 12 * * ? *) for daily at 12:00 PM UTC
+`)
+
+	fRate := constant.FlagRate.ValStr()
+	scheduleSet.Flags().StringP(
+		fRate,
+		"r",
+		config.Vp.GetString(fRate),
+		`Rate expression for recurring execution.
+Example: rate(5 minutes) for every 5 minutes
+`)
+
+	fAt := constant.FlagAt.ValStr()
+	scheduleSet.Flags().StringP(
+		fAt,
+		"a",
+		config.Vp.GetString(fAt),
+		`One-time execution at a specific future time.
+Example: at(2023-12-31T23:59:59) for Dec 31, 2023 at 23:59:59 UTC
+`)
+
+	fPayload := constant.FlagPayload.ValStr()
+	scheduleSet.Flags().StringP(
+		fPayload,
+		"p",
+		config.Vp.GetString(fPayload),
+		`JSON payload for the action execution.
+Example: '{"key": "value"}'
+`)
+
+	addRetryFlags(scheduleSet)
+}
+
+func scheduleSetFunc(cmd *cobra.Command, args []string) error {
+	expression := expressionFlag()
+	payload := strings.TrimSpace(config.Vp.GetString(constant.FlagPayload.ValStr()))
+
+	retry, err := retryFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if expression == "" && payload == "" && len(retry) == 0 {
+		return errorx.BadRequest("either an expression flag, the payload flag or a retry flag should be set")
+	}
+
+	if payload != "" {
+		temp := make(map[string]interface{}, 1)
+		if err := json.Unmarshal([]byte(payload), &temp); err != nil {
+			return errorx.BadRequest(fmt.Sprintf("invalid payload: %s", payload))
+		}
+	}
+
+	body := map[string]interface{}{
+		"expression": expression,
+		"payload":    payload,
+	}
+	for field, value := range retry {
+		body[field] = value
+	}
+
+	return supplierSchedule(http.MethodPut, args[0], "", body)
+}
@@ -0,0 +1,92 @@
+package action
+
+import (
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/command"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+
+	"github.com/spf13/cobra"
+)
+
+// triggerGroup represents the trigger command
+var triggerGroup = &cobra.Command{
+	Use:   "trigger",
+	Short: "Manage the on-chain triggers of actions",
+	Long: `
+Description:
+  The trigger command group manages the triggers of actions, which watch the activities
+  of Stellar accounts by streaming from Horizon, or the events emitted by Soroban contracts
+  by polling the Soroban RPC, and invoke the action with each of them.
+  The account triggers are added by the register command with the --on-payment,
+  --on-operation and --on-effect flags as well.
+
+This command group allows you to:
+  - Add a trigger to an action
+  - List the triggers of an action, or all the ones of yours
+  - Remove a trigger by its ID
+
+For detailed information on a specific subcommand, use:
+  autoaction trigger <subcommand> --help
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+func init() {
+	command.Root.AddCommand(triggerGroup)
+}
+
+// triggerFields maps the trigger flags to the fields of the request.
+var triggerFields = map[constant.FlagName]string{
+	constant.FlagOnPayment:   "on_payment",
+	constant.FlagOnOperation: "on_operation",
+	constant.FlagOnEffect:    "on_effect",
+}
+
+// addTriggerFlags adds the flags to watch the activities of Stellar accounts to the command.
+func addTriggerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray(
+		constant.FlagOnPayment.ValStr(),
+		nil,
+		`Stellar account whose payments trigger the action, repeatable.
+Example: --on-payment GABC...XYZ
+`)
+
+	cmd.Flags().StringArray(
+		constant.FlagOnOperation.ValStr(),
+		nil,
+		`Stellar account whose operations trigger the action, repeatable.
+`)
+
+	cmd.Flags().StringArray(
+		constant.FlagOnEffect.ValStr(),
+		nil,
+		`Stellar account whose effects trigger the action, repeatable.
+`)
+}
+
+// triggerFlags collects the accounts of the trigger flags set, into the fields of the request.
+func triggerFlags(cmd *cobra.Command) (map[string][]string, error) {
+	fields := make(map[string][]string, len(triggerFields))
+
+	for flag, field := range triggerFields {
+		addresses, err := cmd.Flags().GetStringArray(flag.ValStr())
+		if err != nil {
+			return nil, errorx.BadRequest(err.Error())
+		}
+
+		for _, address := range addresses {
+			address = strings.TrimSpace(address)
+			if address == "" {
+				return nil, errorx.BadRequest(flag.ValStr() + " should not be empty")
+			}
+
+			fields[field] = append(fields[field], address)
+		}
+	}
+
+	return fields, nil
+}
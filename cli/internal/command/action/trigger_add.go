@@ -0,0 +1,148 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// triggerAdd represents the trigger add command
+var triggerAdd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a trigger to an action",
+	Long: `
+Description:
+  The add command group adds a trigger of the type to an existing action.
+
+For detailed information on a specific type, use:
+  autoaction trigger add <type> --help
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+// triggerAddContractEvent represents the trigger add contract-event command
+var triggerAddContractEvent = &cobra.Command{
+	Use:   "contract-event <name/arn> [flags]",
+	Short: "Invoke an action with the events emitted by a Soroban contract",
+	Long: `
+Description:
+  The contract-event command adds the trigger to the action identified by its name or ARN
+  (Amazon Resource Name), which polls the events emitted by the Soroban contract from the
+  Soroban RPC, and invokes the action once with each of the events matching any of the topics.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction trigger add contract-event my-action --contract CA3D...GAXE
+  autoaction trigger add contract-event my-action --contract CA3D...GAXE --topic transfer,*,GABC...XYZ
+  autoaction trigger add contract-event my-action --contract CA3D...GAXE --topic mint,** --topic burn,**
+
+Notes:
+  - Each segment of a topic is a symbol, a Stellar account or contract address, the base64 XDR
+    of any other value prefixed by xdr:, * matching any one segment, or ** matching the rest.
+  - All the events of the contract trigger the action when none topics given.
+  - The events are polled from the time the trigger added, the decoded topics and value are set
+    to the event field of the payload.
+  - An event may be handed more than once on failures, the dedup_key field of the payload is
+    unique per trigger and event, by which the duplicated ones can be skipped.
+  - Contract event triggers require the Soroban RPC configured on the server.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: triggerAddContractEventFunc,
+}
+
+func init() {
+	triggerGroup.AddCommand(triggerAdd)
+	triggerAdd.AddCommand(triggerAddContractEvent)
+
+	triggerAddContractEvent.Flags().String(
+		constant.FlagContract.ValStr(),
+		"",
+		`The contract ID emitting the events, C...
+`)
+	triggerAddContractEvent.Flags().StringArray(
+		constant.FlagTopic.ValStr(),
+		nil,
+		`The topic filtering the events, segments separated by comma, repeatable.
+Example: --topic transfer,*,GABC...XYZ
+`)
+
+	if err := triggerAddContractEvent.MarkFlagRequired(constant.FlagContract.ValStr()); err != nil {
+		return
+	}
+}
+
+func triggerAddContractEventFunc(cmd *cobra.Command, args []string) error {
+	contract, _ := cmd.Flags().GetString(constant.FlagContract.ValStr())
+	contract = strings.TrimSpace(contract)
+	if contract == "" {
+		return errorx.BadRequest("contract should not be empty")
+	}
+
+	flagTopics, err := cmd.Flags().GetStringArray(constant.FlagTopic.ValStr())
+	if err != nil {
+		return errorx.BadRequest(err.Error())
+	}
+
+	topics := make([][]string, 0, len(flagTopics))
+	for _, topic := range flagTopics {
+		segments := strings.Split(topic, ",")
+		for idx, segment := range segments {
+			segments[idx] = strings.TrimSpace(segment)
+			if segments[idx] == "" {
+				return errorx.BadRequest(fmt.Sprintf("invalid topic: %s, segments should not be empty", topic))
+			}
+		}
+		topics = append(topics, segments)
+	}
+
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/triggers", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		SetBody(map[string]interface{}{
+			"type":    "contract_event",
+			"address": contract,
+			"topics":  topics,
+		}).
+		Post(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData map[string]interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("trigger added", "result", respData)
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// triggerList represents the trigger list command
+var triggerList = &cobra.Command{
+	Use:   "list [name/arn]",
+	Short: "List the triggers of an action, or all of yours",
+	Long: `
+Description:
+  The list command lists the triggers of the action identified by its name or ARN
+  (Amazon Resource Name), or all the triggers of your actions when none given.
+
+This command provides details including:
+  - The trigger ID, which is used to remove it
+  - The action, the type of the activities and the Stellar account or the contract watched
+  - The topics filtering the events of the contract
+  - The cursor, the paging token of the last activity handled on Horizon,
+    or the ID of the last contract event handled
+
+Arguments:
+  [name/arn]    The name or ARN of the action, optional
+
+Examples:
+  autoaction trigger list
+  autoaction trigger list my-action -o json
+
+Notes:
+  - The cursor "now" means none activities handled yet since the trigger added.
+  - The topics are shown in base64 XDR, the same as filtered on the Soroban RPC.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: triggerListFunc,
+}
+
+func init() {
+	triggerGroup.AddCommand(triggerList)
+
+	triggerList.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+}
+
+type trigger struct {
+	ID      uint64     `json:"id"`
+	Lambda  string     `json:"lambda"`
+	Type    string     `json:"type"`
+	Address string     `json:"address"`
+	Topics  [][]string `json:"topics"`
+	Cursor  string     `json:"cursor"`
+}
+
+func triggerListFunc(cmd *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/triggers", config.Vp.GetString("bound_with.endpoint")))
+
+	request := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		})
+	if len(args) > 0 {
+		request = request.SetQueryParam("lambda", args[0])
+	}
+
+	response, err := request.Get(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	if output == "json" {
+		fmt.Println(string(response.Body()))
+		return nil
+	}
+
+	triggers := make([]*trigger, 0)
+	if err := json.Unmarshal(response.Body(), &triggers); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tACTION\tTYPE\tADDRESS\tTOPICS\tCURSOR")
+	for _, t := range triggers {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", t.ID, t.Lambda, t.Type, t.Address, topicsStr(t.Topics), t.Cursor)
+	}
+	fmt.Fprintf(w, "\n%d triggers in total\n", len(triggers))
+
+	return w.Flush()
+}
+
+// topicsStr joins the segments of each topic by comma, and the topics by semicolon, "-" for none.
+func topicsStr(topics [][]string) string {
+	if len(topics) == 0 {
+		return "-"
+	}
+
+	joined := make([]string, 0, len(topics))
+	for _, topic := range topics {
+		joined = append(joined, strings.Join(topic, ","))
+	}
+
+	return strings.Join(joined, ";")
+}
@@ -0,0 +1,74 @@
+package action
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// triggerRm represents the trigger rm command
+var triggerRm = &cobra.Command{
+	Use:   "rm <id>",
+	Short: "Remove a trigger of an action",
+	Long: `
+Description:
+  The rm command removes the trigger by its ID, shown by the list command.
+  The action itself is kept, and no longer invoked by the activities of the account.
+
+Arguments:
+  <id>    The ID of the trigger
+
+Examples:
+  autoaction trigger rm 7
+
+Caution:
+  This operation is irreversible, a trigger added again starts from the time it's added,
+  the activities in between are not handled.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: triggerRmFunc,
+}
+
+func init() {
+	triggerGroup.AddCommand(triggerRm)
+}
+
+func triggerRmFunc(_ *cobra.Command, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return errorx.BadRequest(fmt.Sprintf("invalid trigger id: %s", args[0]))
+	}
+
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/triggers/%d", config.Vp.GetString("bound_with.endpoint"), id))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		Delete(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	logx.Logger.Info("trigger", "removed", id)
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// updateCmd represents the action update command
+var updateCmd = &cobra.Command{
+	Use:   "update <name/arn> <zip/package/dir>",
+	Short: "Update the code of a registered action in place",
+	Long: `
+Description:
+  The update command replaces the code of an existing action, identified by its name or ARN
+  (Amazon Resource Name), with the given zip package. The action keeps its ARN and its
+  associated trigger (scheduler), so there is no need to remove and register it again.
+
+Arguments:
+  <name/arn>           The name or ARN of the action to update
+  <zip/package/dir>    The zip package contains the new handler, or the directory or the entry to pack
+
+Examples:
+  autoaction action update my-action ./my-action.zip
+  autoaction action update my-action ./my-action
+  autoaction action update my-action ./src/my-action.ts
+  autoaction action update arn:aws:lambda:us-west-2:123456789012:function:my-action ./my-action.zip
+  autoaction action update my-action ./my-action.zip -e LOG_LEVEL=info
+  autoaction action update my-action ./my-action.zip --timeout 120 --memory 512
+  autoaction action update my-action ./my-action.zip --runtime nodejs22.x
+
+Notes:
+  - The handler function must be named "handler", the same as register.
+  - The package is checked by the validator of the runtime before being shipped, the same as register.
+  - A directory, or a TypeScript or ES module entry (.ts/.mts/.mjs), is packed on the fly,
+    the same as the pack command.
+  - The runtime is kept unless the --runtime flag is given.
+  - The bound scheduler, including its expression and payload, stays untouched.
+  - The environment variables given by the --env and --env-file flags are merged into
+    the current ones, use the env command to remove variables.
+  - Only the timeout, memory and ephemeral storage given are changed, the others are kept.
+`,
+	Args: cobra.ExactArgs(2),
+	RunE: updateFunc,
+}
+
+func init() {
+	actionGroup.AddCommand(updateCmd)
+
+	addEnvFlags(updateCmd)
+	addResourceFlags(updateCmd)
+	addRuntimeFlag(updateCmd, "", `Runtime to switch the action to, the current one is kept by default.
+`)
+}
+
+func updateFunc(cmd *cobra.Command, args []string) error {
+	runtime, err := runtimeFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	bundles, cleanup, err := packDirs(runtime, args[1:])
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	if err := util.ValidateZipFiles(runtime, bundles); err != nil {
+		return err
+	}
+
+	env, err := envFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	fMap := make(map[string]string, 1)
+	if len(env) > 0 {
+		envJSON, err := json.Marshal(env)
+		if err != nil {
+			return errorx.Internal(err.Error())
+		}
+
+		fMap["env"] = string(envJSON)
+	}
+
+	resources, err := resourceFlags(cmd)
+	if err != nil {
+		return err
+	}
+	for field, value := range resources {
+		fMap[field] = value
+	}
+
+	if runtime != "" {
+		fMap["runtime"] = runtime
+	}
+
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "multipart/form-data",
+			"Authorization": token,
+		}).
+		SetFile(bundles[0], bundles[0]).
+		SetFormData(fMap).
+		Put(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData map[string]interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("updated successfully", "result", respData)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// versions represents the versions command
+var versions = &cobra.Command{
+	Use:   "versions <name/arn>",
+	Short: "List the published versions of an action",
+	Long: `
+Description:
+  The versions command lists every version published for a specific action,
+  identified by its name or ARN (Amazon Resource Name), from the newest to the oldest.
+
+This command provides details including:
+  - The version number and the SHA256 of its code
+  - Who uploaded the version and when
+  - Which version is live, schedulers and invocations go to the live version
+
+Arguments:
+  <name/arn>    The name or ARN of the action to query
+
+Examples:
+  autoaction action versions my-action
+  autoaction action versions arn:aws:lambda:us-west-2:123456789012:function:my-action
+
+Note:
+  - Each register and update publishes a new version.
+  - Use the rollback command to make a previous version live again.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: versionsFunc,
+}
+
+func init() {
+	actionGroup.AddCommand(versions)
+}
+
+func versionsFunc(_ *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/versions", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		Get(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	var respData []map[string]interface{}
+	if err := json.Unmarshal(response.Body(), &respData); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("action versions", "result", respData)
+
+	return nil
+}
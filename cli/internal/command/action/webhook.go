@@ -0,0 +1,131 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/command"
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// webhookGroup represents the webhook command
+var webhookGroup = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manage the inbound webhooks of actions",
+	Long: `
+Description:
+  The webhook command group manages the inbound webhooks of actions, by which the external
+  systems, such as price feeds, GitHub or alerting tools, invoke the action without the
+  user credentials. Each action has at most one webhook, verified by the secret of it.
+
+This command group allows you to:
+  - Create the webhook of an action
+  - List the webhooks of an action, or all the ones of yours
+  - Rotate the secret of a webhook
+  - Remove the webhook of an action
+
+Calling a webhook:
+  POST the body to the URL of the webhook, along with either:
+  - The X-AutoAction-Timestamp header, the unix seconds of now, and the X-AutoAction-Signature
+    header, sha256= followed by the HMAC-SHA256 in hex of "<timestamp>.<body>" keyed by the secret.
+  - Or the secret as the bearer token in the Authorization header.
+  The signed requests out of the tolerance of the timestamp are rejected, which limits the
+  replays of them to the tolerance. The bearer ones are not protected against replays,
+  prefer the signed ones where the caller is able to sign.
+
+For detailed information on a specific subcommand, use:
+  autoaction webhook <subcommand> --help
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+func init() {
+	command.Root.AddCommand(webhookGroup)
+}
+
+type webhook struct {
+	ID      uint64   `json:"id"`
+	Lambda  string   `json:"lambda"`
+	HookID  string   `json:"hook_id"`
+	Path    string   `json:"path"`
+	Secret  string   `json:"secret"`
+	Headers []string `json:"headers"`
+}
+
+// addHeaderFlag adds the flag of the request headers picked into the event to the command.
+func addHeaderFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray(
+		constant.FlagHeader.ValStr(),
+		nil,
+		`Request header picked into the event along with the body, repeatable.
+Example: --header X-GitHub-Event
+`)
+}
+
+// webhookSecretFunc creates the webhook of the action, or rotates the secret of it, by the path,
+// and prints the URL and the secret of it.
+func webhookSecretFunc(cmd *cobra.Command, lambda, path, result string) error {
+	body := make(map[string]interface{}, 1)
+	if cmd.Flags().Changed(constant.FlagHeader.ValStr()) {
+		headers, err := cmd.Flags().GetStringArray(constant.FlagHeader.ValStr())
+		if err != nil {
+			return errorx.BadRequest(err.Error())
+		}
+
+		for idx, header := range headers {
+			headers[idx] = strings.TrimSpace(header)
+		}
+		body["headers"] = headers
+	}
+
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	endpoint := config.Vp.GetString("bound_with.endpoint")
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/%s", endpoint, url.PathEscape(lambda), path))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		SetBody(body).
+		Post(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	wh := new(webhook)
+	if err := json.Unmarshal(response.Body(), wh); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info(result,
+		"action", wh.Lambda,
+		"url", util.ParseReqPath(endpoint+wh.Path),
+		"secret", wh.Secret,
+		"headers", wh.Headers,
+	)
+	logx.Logger.Warn("PS: The secret is only shown this time, keep it safe.")
+
+	return nil
+}
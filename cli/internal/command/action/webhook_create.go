@@ -0,0 +1,38 @@
+package action
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// webhookCreate represents the webhook create command
+var webhookCreate = &cobra.Command{
+	Use:   "create <name/arn> [flags]",
+	Short: "Create the webhook of an action",
+	Long: `
+Description:
+  The create command creates the inbound webhook of the action identified by its name
+  or ARN (Amazon Resource Name), and prints the URL and the secret of it.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction webhook create my-action
+  autoaction webhook create my-action --header X-GitHub-Event --header X-GitHub-Delivery
+
+Notes:
+  - The body of the request is set to the body field of the event, as is when in JSON,
+    otherwise as the string. The headers picked are set to the headers field.
+  - The secret is only shown once, rotate it when lost.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return webhookSecretFunc(cmd, args[0], "webhook", "webhook created")
+	},
+}
+
+func init() {
+	webhookGroup.AddCommand(webhookCreate)
+
+	addHeaderFlag(webhookCreate)
+}
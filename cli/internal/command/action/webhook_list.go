@@ -0,0 +1,114 @@
+package action
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// webhookList represents the webhook list command
+var webhookList = &cobra.Command{
+	Use:   "list [name/arn]",
+	Short: "List the webhooks of an action, or all of yours",
+	Long: `
+Description:
+  The list command lists the webhook of the action identified by its name or ARN
+  (Amazon Resource Name), or all the webhooks of your actions when none given.
+
+This command provides details including:
+  - The action and the URL of the webhook
+  - The request headers picked into the event
+
+Arguments:
+  [name/arn]    The name or ARN of the action, optional
+
+Examples:
+  autoaction webhook list
+  autoaction webhook list my-action -o json
+
+Notes:
+  - The secrets are not listed, rotate the one lost.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: webhookListFunc,
+}
+
+func init() {
+	webhookGroup.AddCommand(webhookList)
+
+	webhookList.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+}
+
+func webhookListFunc(cmd *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	endpoint := config.Vp.GetString("bound_with.endpoint")
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/webhooks", endpoint))
+
+	request := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		})
+	if len(args) > 0 {
+		request = request.SetQueryParam("lambda", args[0])
+	}
+
+	response, err := request.Get(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	if output == "json" {
+		fmt.Println(string(response.Body()))
+		return nil
+	}
+
+	webhooks := make([]*webhook, 0)
+	if err := json.Unmarshal(response.Body(), &webhooks); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tURL\tHEADERS")
+	for _, wh := range webhooks {
+		headers := "-"
+		if len(wh.Headers) > 0 {
+			headers = strings.Join(wh.Headers, ",")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", wh.Lambda, util.ParseReqPath(endpoint+wh.Path), headers)
+	}
+	fmt.Fprintf(w, "\n%d webhooks in total\n", len(webhooks))
+
+	return w.Flush()
+}
@@ -0,0 +1,69 @@
+package action
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+)
+
+// webhookRm represents the webhook rm command
+var webhookRm = &cobra.Command{
+	Use:   "rm <name/arn>",
+	Short: "Remove the webhook of an action",
+	Long: `
+Description:
+  The rm command removes the webhook of the action identified by its name or ARN
+  (Amazon Resource Name). The action itself is kept.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction webhook rm my-action
+
+Caution:
+  This operation is irreversible, the requests to the webhook are rejected since,
+  a webhook created again has a new URL.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: webhookRmFunc,
+}
+
+func init() {
+	webhookGroup.AddCommand(webhookRm)
+}
+
+func webhookRmFunc(_ *cobra.Command, args []string) error {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/lambda/%s/webhook", config.Vp.GetString("bound_with.endpoint"), url.PathEscape(args[0])))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		Delete(URL)
+	if err != nil {
+		return errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return errorx.WithRestyResp(response)
+	}
+
+	logx.Logger.Info("webhook removed", "action", args[0])
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package action
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// webhookRotate represents the webhook rotate command
+var webhookRotate = &cobra.Command{
+	Use:   "rotate <name/arn> [flags]",
+	Short: "Rotate the secret of the webhook of an action",
+	Long: `
+Description:
+  The rotate command replaces the secret of the webhook of the action identified by its name
+  or ARN (Amazon Resource Name), and prints the new one. The URL of the webhook is kept.
+
+Arguments:
+  <name/arn>    The name or ARN of the action
+
+Examples:
+  autoaction webhook rotate my-action
+  autoaction webhook rotate my-action --header X-Request-Id
+
+Notes:
+  - The headers picked are replaced when the --header flag set, otherwise kept.
+
+Caution:
+  The old secret is invalid at once, update the external systems calling the webhook.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return webhookSecretFunc(cmd, args[0], "webhook/rotate", "webhook rotated")
+	},
+}
+
+func init() {
+	webhookGroup.AddCommand(webhookRotate)
+
+	addHeaderFlag(webhookRotate)
+}
@@ -0,0 +1,88 @@
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+
+	"github.com/spf13/cobra"
+)
+
+// get represents the kv get command
+var get = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Get the value of a key",
+	Long: `
+Description:
+  The get command prints the value of the key, along with the version of it,
+  which the --version flag of the put and rm commands compares with.
+
+Arguments:
+  <key>    The key of the entry
+
+Examples:
+  autoaction kv get last-ledger
+  autoaction kv get last-ledger --action my-action -o json
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: getFunc,
+}
+
+func init() {
+	kv.AddCommand(get)
+
+	addActionFlag(get)
+	get.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+}
+
+func getFunc(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	request, err := kvRequest()
+	if err != nil {
+		return err
+	}
+
+	action, _ := cmd.Flags().GetString(constant.FlagAction.ValStr())
+	request = request.SetQueryParams(map[string]string{
+		"key":    args[0],
+		"action": action,
+	})
+
+	response, err := doKV(request, http.MethodGet, "/entry")
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		fmt.Println(string(response.Body()))
+		return nil
+	}
+
+	e := new(entry)
+	if err := json.Unmarshal(response.Body(), e); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("kv entry",
+		"key", e.Key,
+		"version", e.Version,
+		"expires_at", expiresStr(e.ExpiresAt),
+	)
+	fmt.Println(e.Value)
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package kv
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/command"
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+// kv represents the kv command
+var kv = &cobra.Command{
+	Use:   "kv",
+	Short: "Manage the key-value store persisting data across the runs of actions",
+	Long: `
+Description:
+  The kv command group manages the key-value store of your account, by which the actions
+  persist the data across their runs, such as the last processed ledger, counters or
+  idempotency keys. The entries are shared by all of your actions, or owned by one of them
+  with the --action flag.
+
+This command group allows you to:
+  - Get the value of a key
+  - Put the value of a key, optionally with a TTL, or only when it's still at a version
+  - Remove a key
+  - List the keys by a prefix
+
+Reaching the store from actions:
+  Each action has the AA_ENDPOINT and AA_RUNTIME_TOKEN environment variables, call the same
+  endpoints under <AA_ENDPOINT>/runtime/kv with the token as the bearer token in the
+  Authorization header.
+
+Notes:
+  - Each put increases the version of the entry, compare it by the --version flag to avoid
+    overriding the changes of the others, 0 for the entry not existing yet.
+  - The expired entries are treated as absent.
+  - The number of the keys and the size of the values are limited by the server.
+
+For detailed information on a specific subcommand, use:
+  autoaction kv <subcommand> --help
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+func init() {
+	command.Root.AddCommand(kv)
+}
+
+type entry struct {
+	Key       string     `json:"key"`
+	Value     string     `json:"value"`
+	Version   int64      `json:"version"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// addActionFlag adds the flag of the action owning the entries to the command.
+func addActionFlag(cmd *cobra.Command) {
+	cmd.Flags().String(
+		constant.FlagAction.ValStr(),
+		"",
+		`The name or ARN of the action owning the entries,
+the ones shared by all of your actions when omitted.
+`)
+}
+
+// kvRequest builds the authenticated request to the key-value store.
+func kvRequest() (*resty.Request, error) {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return nil, err
+	}
+
+	return restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}), nil
+}
+
+// doKV sends the request, and returns the response when it succeeds.
+func doKV(request *resty.Request, method, path string) (*resty.Response, error) {
+	URL := util.ParseReqPath(fmt.Sprintf("%s/kv%s", config.Vp.GetString("bound_with.endpoint"), path))
+
+	response, err := request.Execute(method, URL)
+	if err != nil {
+		return nil, errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return nil, errorx.WithRestyResp(response)
+	}
+
+	return response, nil
+}
+
+func expiresStr(expiresAt *time.Time) string {
+	if expiresAt == nil {
+		return "-"
+	}
+
+	return expiresAt.Local().Format(time.RFC3339)
+}
@@ -0,0 +1,115 @@
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+
+	"github.com/spf13/cobra"
+)
+
+// ls represents the kv ls command
+var ls = &cobra.Command{
+	Use:   "ls [prefix]",
+	Short: "List the keys by a prefix",
+	Long: `
+Description:
+  The ls command lists the keys starting with the prefix, or all the keys when none given,
+  in the order of the keys, along with the versions and the expiry of them.
+
+Arguments:
+  [prefix]    The prefix of the keys, optional
+
+Examples:
+  autoaction kv ls
+  autoaction kv ls user/ --action my-action --limit 500 -o json
+
+Notes:
+  - The values are not listed, get them one by one.
+`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: lsFunc,
+}
+
+func init() {
+	kv.AddCommand(ls)
+
+	addActionFlag(ls)
+	ls.Flags().Int(
+		constant.FlagLimit.ValStr(),
+		100,
+		`The number of the keys listed, at most 1000.
+`)
+	ls.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+}
+
+type entries struct {
+	Entries   []*entry `json:"entries"`
+	Truncated bool     `json:"truncated"`
+}
+
+func lsFunc(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	request, err := kvRequest()
+	if err != nil {
+		return err
+	}
+
+	action, _ := cmd.Flags().GetString(constant.FlagAction.ValStr())
+	limit, _ := cmd.Flags().GetInt(constant.FlagLimit.ValStr())
+	params := map[string]string{
+		"action": action,
+		"limit":  strconv.Itoa(limit),
+	}
+	if len(args) > 0 {
+		params["prefix"] = args[0]
+	}
+
+	response, err := doKV(request.SetQueryParams(params), http.MethodGet, "")
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		fmt.Println(string(response.Body()))
+		return nil
+	}
+
+	listed := new(entries)
+	if err := json.Unmarshal(response.Body(), listed); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVERSION\tEXPIRES AT")
+	for _, e := range listed.Entries {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", e.Key, e.Version, expiresStr(e.ExpiresAt))
+	}
+	fmt.Fprintf(w, "\n%d keys listed\n", len(listed.Entries))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if listed.Truncated {
+		logx.Logger.Warn("PS: There are more keys, narrow down the prefix or raise the --limit.")
+	}
+
+	return nil
+}
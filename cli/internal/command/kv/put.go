@@ -0,0 +1,144 @@
+package kv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+
+	"github.com/spf13/cobra"
+)
+
+// put represents the kv put command
+var put = &cobra.Command{
+	Use:   "put <key> [value]",
+	Short: "Put the value of a key",
+	Long: `
+Description:
+  The put command puts the value of the key, the version of the entry is increased
+  each time, starting from 1.
+
+Arguments:
+  <key>      The key of the entry
+  [value]    The value, read from the file given by the --file flag when omitted,
+             or from the standard input with --file -
+
+Examples:
+  autoaction kv put last-ledger 51234
+  autoaction kv put session abc --ttl 1h --action my-action
+  autoaction kv put state --file state.json --version 3
+  autoaction kv put lock owner-1 --version 0 --ttl 30s
+
+Notes:
+  - With --version, the value is put only when the entry is still at the version,
+    0 for the entry not existing yet, otherwise it's rejected as a conflict.
+  - Without --ttl, the entry never expires, and the TTL of the existing one is cleared.
+`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: putFunc,
+}
+
+func init() {
+	kv.AddCommand(put)
+
+	addActionFlag(put)
+	put.Flags().Duration(
+		constant.FlagTTL.ValStr(),
+		0,
+		`The time to live of the entry, in seconds at least.
+Example: --ttl 30s, --ttl 24h
+`)
+	put.Flags().Int64(
+		constant.FlagVersion.ValStr(),
+		0,
+		`Put only when the entry is still at the version, 0 for the entry not existing yet.
+`)
+	put.Flags().StringP(
+		constant.FlagFile.ValStr(),
+		"f",
+		"",
+		`The file of the value, - for the standard input.
+`)
+}
+
+func putFunc(cmd *cobra.Command, args []string) error {
+	value, err := putValue(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ttl, _ := cmd.Flags().GetDuration(constant.FlagTTL.ValStr())
+	if ttl < 0 || (ttl > 0 && ttl.Seconds() < 1) {
+		return errorx.BadRequest(fmt.Sprintf("invalid ttl: %s, should be at least 1s", ttl))
+	}
+
+	action, _ := cmd.Flags().GetString(constant.FlagAction.ValStr())
+	body := map[string]interface{}{
+		"key":    args[0],
+		"value":  value,
+		"action": action,
+		"ttl":    int64(ttl.Seconds()),
+	}
+	if cmd.Flags().Changed(constant.FlagVersion.ValStr()) {
+		version, _ := cmd.Flags().GetInt64(constant.FlagVersion.ValStr())
+		body["version"] = version
+	}
+
+	request, err := kvRequest()
+	if err != nil {
+		return err
+	}
+
+	response, err := doKV(request.SetBody(body), http.MethodPut, "/entry")
+	if err != nil {
+		return err
+	}
+
+	e := new(entry)
+	if err := json.Unmarshal(response.Body(), e); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("kv entry put",
+		"key", e.Key,
+		"version", e.Version,
+		"expires_at", expiresStr(e.ExpiresAt),
+	)
+
+	return nil
+}
+
+// putValue returns the value in the arguments, or the one in the file given.
+func putValue(cmd *cobra.Command, args []string) (string, error) {
+	file, _ := cmd.Flags().GetString(constant.FlagFile.ValStr())
+
+	switch {
+	case len(args) == 2 && file != "":
+		return "", errorx.BadRequest("the value and the --file flag are exclusive")
+	case len(args) == 2:
+		return args[1], nil
+	case file == "":
+		return "", errorx.BadRequest("the value is required, either in the arguments or by the --file flag")
+	}
+
+	var (
+		content []byte
+		err     error
+	)
+	if file == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return "", errorx.BadRequest(fmt.Sprintf("failed to read the value from: %s, err: %s", file, err.Error()))
+	}
+
+	return string(content), nil
+}
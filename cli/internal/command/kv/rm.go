@@ -0,0 +1,70 @@
+package kv
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+
+	"github.com/spf13/cobra"
+)
+
+// rm represents the kv rm command
+var rm = &cobra.Command{
+	Use:   "rm <key>",
+	Short: "Remove a key",
+	Long: `
+Description:
+  The rm command removes the entry of the key.
+
+Arguments:
+  <key>    The key of the entry
+
+Examples:
+  autoaction kv rm last-ledger
+  autoaction kv rm lock --version 4 --action my-action
+
+Notes:
+  - With --version, the entry is removed only when it's still at the version,
+    otherwise it's rejected as a conflict.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: rmFunc,
+}
+
+func init() {
+	kv.AddCommand(rm)
+
+	addActionFlag(rm)
+	rm.Flags().Int64(
+		constant.FlagVersion.ValStr(),
+		0,
+		`Remove only when the entry is still at the version.
+`)
+}
+
+func rmFunc(cmd *cobra.Command, args []string) error {
+	request, err := kvRequest()
+	if err != nil {
+		return err
+	}
+
+	action, _ := cmd.Flags().GetString(constant.FlagAction.ValStr())
+	request = request.SetQueryParams(map[string]string{
+		"key":    args[0],
+		"action": action,
+	})
+	if cmd.Flags().Changed(constant.FlagVersion.ValStr()) {
+		version, _ := cmd.Flags().GetInt64(constant.FlagVersion.ValStr())
+		request = request.SetQueryParam("version", strconv.FormatInt(version, 10))
+	}
+
+	if _, err := doKV(request, http.MethodDelete, "/entry"); err != nil {
+		return err
+	}
+
+	logx.Logger.Info("kv entry removed", "key", args[0])
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+
+	"github.com/spf13/cobra"
+)
+
+// list represents the secret list command
+var list = &cobra.Command{
+	Use:   "list",
+	Short: "List the secrets",
+	Long: `
+Description:
+  The list command lists the names of the secrets in your account, along with the actions
+  granted with them.
+
+Examples:
+  autoaction secret list
+  autoaction secret list -o json
+
+Notes:
+  - The values are never listed.
+`,
+	Args: cobra.NoArgs,
+	RunE: listFunc,
+}
+
+func init() {
+	secret.AddCommand(list)
+
+	list.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+}
+
+func listFunc(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	request, err := secretRequest()
+	if err != nil {
+		return err
+	}
+
+	response, err := doSecret(request, http.MethodGet, "")
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		fmt.Println(string(response.Body()))
+		return nil
+	}
+
+	secrets := make([]*secretInfo, 0)
+	if err := json.Unmarshal(response.Body(), &secrets); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tACTIONS\tUPDATED AT")
+	for _, s := range secrets {
+		actions := "-"
+		if len(s.Actions) > 0 {
+			actions = strings.Join(s.Actions, ",")
+		}
+
+		updatedAt := "-"
+		if s.UpdatedAt != nil {
+			updatedAt = s.UpdatedAt.Local().Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, actions, updatedAt)
+	}
+	fmt.Fprintf(w, "\n%d secrets listed\n", len(secrets))
+
+	return w.Flush()
+}
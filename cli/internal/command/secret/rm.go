@@ -0,0 +1,48 @@
+package secret
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+
+	"github.com/spf13/cobra"
+)
+
+// rm represents the secret rm command
+var rm = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Remove a secret",
+	Long: `
+Description:
+  The rm command removes the secret, the actions granted with it lose it from their
+  next runs.
+
+Arguments:
+  <name>    The name of the secret
+
+Examples:
+  autoaction secret rm API_KEY
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: rmFunc,
+}
+
+func init() {
+	secret.AddCommand(rm)
+}
+
+func rmFunc(cmd *cobra.Command, args []string) error {
+	request, err := secretRequest()
+	if err != nil {
+		return err
+	}
+
+	if _, err := doSecret(request, http.MethodDelete, "/"+url.PathEscape(args[0])); err != nil {
+		return err
+	}
+
+	logx.Logger.Info("secret removed", "name", args[0])
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package secret
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/command"
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+// secret represents the secret command
+var secret = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage the secrets provided to actions at runtime",
+	Long: `
+Description:
+  The secret command group manages the secrets of your account, such as API keys or
+  passwords used by your actions. The values are encrypted by the public key of the server
+  before leaving the CLI, and never shown back once set.
+
+This command group allows you to:
+  - Set a secret, and grant it to the actions using it
+  - List the names of the secrets, along with the actions granted
+  - Remove a secret
+
+Reaching the secrets from actions:
+  Each action has the AA_ENDPOINT and AA_RUNTIME_TOKEN environment variables, call
+  <AA_ENDPOINT>/runtime/secrets with the token as the bearer token in the Authorization
+  header, the secrets granted to the action are returned by the names.
+
+Notes:
+  - The secrets are never put into the environment variables of the actions.
+  - The number of the secrets and the size of the values are limited by the server.
+
+For detailed information on a specific subcommand, use:
+  autoaction secret <subcommand> --help
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+func init() {
+	command.Root.AddCommand(secret)
+}
+
+type secretInfo struct {
+	Name      string     `json:"name"`
+	Actions   []string   `json:"actions"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// secretRequest builds the authenticated request to the secrets.
+func secretRequest() (*resty.Request, error) {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return nil, err
+	}
+
+	return restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}), nil
+}
+
+// doSecret sends the request, and returns the response when it succeeds.
+func doSecret(request *resty.Request, method, path string) (*resty.Response, error) {
+	URL := util.ParseReqPath(fmt.Sprintf("%s/secret%s", config.Vp.GetString("bound_with.endpoint"), path))
+
+	response, err := request.Execute(method, URL)
+	if err != nil {
+		return nil, errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return nil, errorx.WithRestyResp(response)
+	}
+
+	return response, nil
+}
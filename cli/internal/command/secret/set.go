@@ -0,0 +1,175 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// set represents the secret set command
+var set = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Set a secret, and grant it to the actions",
+	Long: `
+Description:
+  The set command sets the value of the secret, prompted without echoing, or read from
+  the file given by the --file flag. The value of the existing one is replaced.
+
+Arguments:
+  <name>    The name of the secret, starting with a letter or underscore, and containing
+            only letters, numbers and underscores, 128 characters at most
+
+Examples:
+  autoaction secret set API_KEY --action my-action --action other-action
+  autoaction secret set DB_PASSWORD --file password.txt
+  cat key.txt | autoaction secret set PRIVATE_KEY --file -
+  autoaction secret set API_KEY --no-actions
+
+Notes:
+  - With --action, the actions granted with the secret are replaced by the ones given,
+    without it, they are kept as they are.
+  - With --no-actions, the secret is revoked from all the actions granted.
+  - The value is encrypted by the public key of the server in the configuration.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: setFunc,
+}
+
+func init() {
+	secret.AddCommand(set)
+
+	set.Flags().StringArray(
+		constant.FlagAction.ValStr(),
+		nil,
+		`The name or ARN of the action granted with the secret, repeatable.
+`)
+	set.Flags().Bool(
+		constant.FlagNoActions.ValStr(),
+		false,
+		`Revoke the secret from all the actions granted.
+`)
+	set.Flags().StringP(
+		constant.FlagFile.ValStr(),
+		"f",
+		"",
+		`The file of the value, - for the standard input.
+`)
+	set.MarkFlagsMutuallyExclusive(constant.FlagAction.ValStr(), constant.FlagNoActions.ValStr())
+}
+
+// chunkSize is the most bytes encrypted at once, by RSA-OAEP with SHA-256 and a 2048 bits key.
+const chunkSize = 190
+
+func setFunc(cmd *cobra.Command, args []string) error {
+	value, err := setValue(cmd)
+	if err != nil {
+		return err
+	}
+	if len(value) == 0 {
+		return errorx.BadRequest("empty value error")
+	}
+
+	encrypted, err := encryptValue(value)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"value": encrypted,
+	}
+	if noActions, _ := cmd.Flags().GetBool(constant.FlagNoActions.ValStr()); noActions {
+		body["actions"] = []string{}
+	}
+	if cmd.Flags().Changed(constant.FlagAction.ValStr()) {
+		actions, _ := cmd.Flags().GetStringArray(constant.FlagAction.ValStr())
+		body["actions"] = actions
+	}
+
+	request, err := secretRequest()
+	if err != nil {
+		return err
+	}
+
+	response, err := doSecret(request.SetBody(body), http.MethodPut, "/"+url.PathEscape(args[0]))
+	if err != nil {
+		return err
+	}
+
+	s := new(secretInfo)
+	if err := json.Unmarshal(response.Body(), s); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	logx.Logger.Info("secret set",
+		"name", s.Name,
+		"actions", strings.Join(s.Actions, ","),
+	)
+
+	return nil
+}
+
+// setValue returns the value prompted, or the one in the file given.
+func setValue(cmd *cobra.Command) (string, error) {
+	file, _ := cmd.Flags().GetString(constant.FlagFile.ValStr())
+
+	var (
+		content []byte
+		err     error
+	)
+	switch file {
+	case "":
+		fmt.Println("Value: ")
+
+		content, err = terminal.ReadPassword(int(os.Stdin.Fd()))
+		if err != nil {
+			return "", errorx.Internal(fmt.Sprintf("reading value error: %s", err.Error()))
+		}
+
+		return string(content), nil
+	case "-":
+		content, err = io.ReadAll(os.Stdin)
+	default:
+		content, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return "", errorx.BadRequest(fmt.Sprintf("failed to read the value from: %s, err: %s", file, err.Error()))
+	}
+
+	return string(content), nil
+}
+
+// encryptValue encrypts the value chunk by chunk, as RSA limits the size of the plaintext.
+func encryptValue(value string) (string, error) {
+	key, err := util.LoadPublicKey(config.Vp.GetString("general.public_key"))
+	if err != nil {
+		return "", err
+	}
+
+	chunks := make([]string, 0, len(value)/chunkSize+1)
+	for start := 0; start < len(value); start += chunkSize {
+		end := min(start+chunkSize, len(value))
+
+		encrypted, err := util.EncryptPassword(value[start:end], key)
+		if err != nil {
+			return "", errorx.Internal(fmt.Sprintf("failed to encrypt the value: %s", err.Error()))
+		}
+
+		chunks = append(chunks, encrypted)
+	}
+
+	return strings.Join(chunks, "."), nil
+}
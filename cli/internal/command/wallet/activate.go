@@ -0,0 +1,121 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+var activate = &cobra.Command{
+	Use:   "activate [wallet-address]",
+	Short: "Activate a wallet by the funding wallet of the organization",
+	Long: `
+Description:
+  The activate command creates the account of the wallet on the Stellar network from the
+  funding wallet designated by the organization. The funding wallet sends the starting balance
+  to the new account, and sponsors the base reserves of it when the server is configured so.
+  The transaction is signed by CubeSigner and submitted right away.
+
+Arguments:
+  [wallet-address]    The Stellar public key of the wallet to activate
+
+Example:
+  autoaction wallet activate GXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX
+
+Important Notes:
+  - The organization must designate a funding wallet first, by: autoaction wallet funding set.
+  - The number of activations of each organization is limited per day.
+  - The wallet activated already is rejected.
+  - You can only activate wallet addresses associated with your own user account.
+
+Related Commands:
+  autoaction wallet create --activate - Create a new wallet and activate it right away
+  autoaction wallet funding - View the funding wallet and the activations of today
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: activateFunc,
+}
+
+func init() {
+	wallet.AddCommand(activate)
+}
+
+type activated struct {
+	submitted
+	Funder          string `json:"funder"`
+	StartingBalance string `json:"starting_balance"`
+	Sponsored       bool   `json:"sponsored"`
+}
+
+func activateFunc(_ *cobra.Command, args []string) error {
+	return activateWallet(args[0])
+}
+
+// activateWallet activates the wallet by the funding wallet of the organization, and reports the transaction.
+func activateWallet(walletAddress string) error {
+	logx.Logger.Info(fmt.Sprintf("Activating wallet with address: %s", walletAddress))
+
+	resp, err := supplierActivate(walletAddress)
+	if err != nil {
+		return err
+	}
+
+	result := new(activated)
+	if err := json.Unmarshal(resp.Body(), result); err != nil {
+		return errorx.Internal(fmt.Sprintf("unmarshaling json response error: %s", err.Error()))
+	}
+
+	if !result.Successful {
+		logx.Logger.Warn("Activation rejected",
+			"hash", result.Hash,
+			"transaction_code", result.Transaction,
+			"operation_codes", strings.Join(result.Operations, ","),
+		)
+		return errorx.BadRequest(fmt.Sprintf("activation rejected: %s", result.Transaction))
+	}
+
+	logx.Logger.Info("Wallet activated",
+		"funder", result.Funder,
+		"starting_balance", result.StartingBalance,
+		"sponsored", result.Sponsored,
+		"hash", result.Hash,
+		"ledger", result.Ledger,
+	)
+
+	return nil
+}
+
+func supplierActivate(walletAddress string) (*resty.Response, error) {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return nil, err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/wallet/%s/activate", config.Vp.GetString("bound_with.endpoint"), walletAddress))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		Post(URL)
+	if err != nil {
+		return nil, errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return nil, errorx.WithRestyResp(response)
+	}
+
+	return response, nil
+}
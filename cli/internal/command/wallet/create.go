@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
 	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
 	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
 	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
@@ -35,8 +36,19 @@ Important Notes:
 Output:
   Upon successful creation, the command will display the new wallet address.
 
+Testnet Funding:
+  With --fund, the wallet created is funded by the Friendbot right away, which activates it
+  with test XLM. It's only available on the networks other than the mainnet.
+
+Organization Activation:
+  With --activate, the wallet created is activated by the funding wallet designated by the
+  organization, which sends the starting balance to it, and sponsors the base reserves of it
+  when the server is configured so. The activations of each organization are limited per day.
+
 Example:
   autoaction wallet create
+  autoaction wallet create --fund
+  autoaction wallet create --activate
 
 Next Steps:
   1. Securely store the generated wallet address.
@@ -48,9 +60,21 @@ Next Steps:
 
 func init() {
 	wallet.AddCommand(create)
+
+	create.Flags().Bool(
+		constant.FlagFund.ValStr(),
+		false,
+		`Fund the wallet created by the Friendbot, not available on the mainnet.
+`)
+	create.Flags().Bool(
+		constant.FlagActivate.ValStr(),
+		false,
+		`Activate the wallet created by the funding wallet of the organization.
+`)
+	create.MarkFlagsMutuallyExclusive(constant.FlagFund.ValStr(), constant.FlagActivate.ValStr())
 }
 
-func createFunc(_ *cobra.Command, _ []string) error {
+func createFunc(cmd *cobra.Command, _ []string) error {
 	resp, err := supplierCreate()
 	if err != nil {
 		return err
@@ -62,6 +86,13 @@ func createFunc(_ *cobra.Command, _ []string) error {
 	}
 
 	logx.Logger.Info(fmt.Sprintf("create wallet success, address is %s", wallet["address"]))
+
+	if fund, _ := cmd.Flags().GetBool(constant.FlagFund.ValStr()); fund {
+		return fundWallet(wallet["address"].(string))
+	}
+	if activate, _ := cmd.Flags().GetBool(constant.FlagActivate.ValStr()); activate {
+		return activateWallet(wallet["address"].(string))
+	}
 	logx.Logger.Info("PS: Should deposit 1 XLM to the new address to activate it.")
 
 	return nil
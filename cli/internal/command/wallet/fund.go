@@ -0,0 +1,96 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+var fund = &cobra.Command{
+	Use:   "fund [wallet-address]",
+	Short: "Fund a wallet by the Friendbot on testnet",
+	Long: `
+Description:
+  The fund command asks the Friendbot to fund the wallet with test XLM, which activates it
+  on the networks other than the mainnet. The command waits until the account shows up on
+  the network, and reports the balance of it.
+
+Arguments:
+  [wallet-address]    The Stellar public key of the wallet to fund
+
+Example:
+  autoaction wallet fund GXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX
+
+Important Notes:
+  - Funding is refused when the server is bound with the mainnet.
+  - The Friendbot funds each account only once, the one funded already is rejected.
+  - You can only fund wallet addresses associated with your own user account.
+
+Related Commands:
+  autoaction wallet create --fund - Create a new wallet and fund it right away
+  autoaction wallet info - View the balances and state of a wallet
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: fundFunc,
+}
+
+func init() {
+	wallet.AddCommand(fund)
+}
+
+func fundFunc(_ *cobra.Command, args []string) error {
+	return fundWallet(args[0])
+}
+
+// fundWallet funds the wallet by the Friendbot, and reports the balance of it.
+func fundWallet(walletAddress string) error {
+	logx.Logger.Info(fmt.Sprintf("Funding wallet with address: %s", walletAddress))
+
+	resp, err := supplierFund(walletAddress)
+	if err != nil {
+		return err
+	}
+
+	funded := make(map[string]interface{})
+	if err := json.Unmarshal(resp.Body(), &funded); err != nil {
+		return errorx.Internal(fmt.Sprintf("unmarshaling json response error: %s", err.Error()))
+	}
+
+	logx.Logger.Info(fmt.Sprintf("fund wallet success, the balance of %s is %s XLM", walletAddress, funded["balance"]))
+
+	return nil
+}
+
+func supplierFund(walletAddress string) (*resty.Response, error) {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return nil, err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/wallet/%s/fund", config.Vp.GetString("bound_with.endpoint"), walletAddress))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		Post(URL)
+	if err != nil {
+		return nil, errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return nil, errorx.WithRestyResp(response)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,198 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+var funding = &cobra.Command{
+	Use:   "funding",
+	Short: "Display the funding wallet of the organization",
+	Long: `
+Description:
+  The funding command displays the funding wallet designated by the organization, which
+  activates the new wallets of the organization by: autoaction wallet activate. Along with
+  the starting balance, whether the base reserves are sponsored, and the activations of today.
+
+Subcommands:
+  set [wallet-address]    Designate one of your wallets as the funding wallet
+  clear                   Clear the funding wallet of the organization
+
+Examples:
+  autoaction wallet funding
+  autoaction wallet funding -o json
+  autoaction wallet funding set GXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX
+  autoaction wallet funding clear
+
+Important Notes:
+  - The funding wallet must be activated, and hold enough XLM for the starting balances.
+  - The starting balance, the sponsoring and the daily cap are configured by the server.
+  - The activations of today are counted since 00:00 UTC.
+`,
+	Args: cobra.NoArgs,
+	RunE: fundingFunc,
+}
+
+var fundingSet = &cobra.Command{
+	Use:   "set [wallet-address]",
+	Short: "Designate a wallet as the funding wallet of the organization",
+	Long: `
+Description:
+  The set command designates one of your wallets as the funding wallet of the organization,
+  replacing the one designated before by you.
+
+Arguments:
+  [wallet-address]    The Stellar public key of the wallet
+
+Example:
+  autoaction wallet funding set GXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX
+
+Important Notes:
+  - You can only designate wallet addresses associated with your own user account.
+  - The funding wallet designated by another account can only be replaced by that account.
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return setFunding(args[0])
+	},
+}
+
+var fundingClear = &cobra.Command{
+	Use:   "clear",
+	Short: "Clear the funding wallet of the organization",
+	Long: `
+Description:
+  The clear command clears the funding wallet of the organization, the wallets can't be
+  activated by: autoaction wallet activate until another one is designated.
+
+Example:
+  autoaction wallet funding clear
+
+Important Notes:
+  - Only the account designated the funding wallet can clear it.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return setFunding("")
+	},
+}
+
+func init() {
+	wallet.AddCommand(funding)
+	funding.AddCommand(fundingSet)
+	funding.AddCommand(fundingClear)
+
+	funding.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+}
+
+type fundingWallet struct {
+	Address         string `json:"address"`
+	StartingBalance string `json:"starting_balance"`
+	Sponsored       bool   `json:"sponsored"`
+	DailyCap        int    `json:"daily_cap"`
+	ActivatedToday  int64  `json:"activated_today"`
+}
+
+func fundingFunc(cmd *cobra.Command, _ []string) error {
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	resp, err := supplierFunding("GET", nil)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		fmt.Println(string(resp.Body()))
+		return nil
+	}
+
+	return printFunding(resp)
+}
+
+// setFunding designates the funding wallet of the organization, the empty address clears it.
+func setFunding(walletAddress string) error {
+	resp, err := supplierFunding("PUT", map[string]string{
+		"address": walletAddress,
+	})
+	if err != nil {
+		return err
+	}
+
+	if walletAddress == "" {
+		logx.Logger.Info("clear funding wallet success")
+	} else {
+		logx.Logger.Info(fmt.Sprintf("set funding wallet success, address is %s", walletAddress))
+	}
+
+	return printFunding(resp)
+}
+
+func printFunding(resp *resty.Response) error {
+	fw := new(fundingWallet)
+	if err := json.Unmarshal(resp.Body(), fw); err != nil {
+		return errorx.Internal(fmt.Sprintf("unmarshaling json response error: %s", err.Error()))
+	}
+
+	if fw.Address == "" {
+		logx.Logger.Warn("None funding wallet designated, set one by: autoaction wallet funding set.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Address:\t%s\n", fw.Address)
+	fmt.Fprintf(w, "Starting Balance:\t%s XLM\n", fw.StartingBalance)
+	fmt.Fprintf(w, "Sponsored:\t%t\n", fw.Sponsored)
+	fmt.Fprintf(w, "Activated Today:\t%d/%d\n", fw.ActivatedToday, fw.DailyCap)
+
+	return w.Flush()
+}
+
+func supplierFunding(method string, body interface{}) (*resty.Response, error) {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return nil, err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/wallet/funding", config.Vp.GetString("bound_with.endpoint")))
+
+	request := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		})
+	if body != nil {
+		request.SetBody(body)
+	}
+
+	response, err := request.Execute(method, URL)
+	if err != nil {
+		return nil, errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return nil, errorx.WithRestyResp(response)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,221 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+var info = &cobra.Command{
+	Use:   "info [wallet-address]",
+	Short: "Display the balances and state of a wallet",
+	Long: `
+Description:
+  The info command displays the state of a wallet on the Stellar network, including the
+  balances of the native and issued assets, the trustlines, the signers and the thresholds.
+
+Arguments:
+  [wallet-address]    The Stellar public key of the wallet
+
+Output:
+  - Sequence number, subentry count and the minimum reserve of the account
+  - Balances, with the limits, liabilities and authorization flags of the trustlines
+  - Signers and their weights, the thresholds and the flags of the account
+
+Examples:
+  autoaction wallet info GXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX
+  autoaction wallet info GXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX -o json
+
+Important Notes:
+  - A wallet not activated yet has no state, transfer at least 1 XLM to it first.
+  - The state is reused by the server for a few seconds, and might lag behind the network.
+  - You can only view wallet addresses associated with your own user account.
+
+Related Commands:
+  autoaction wallet list --balances - View the balances of all wallets in your account
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: infoFunc,
+}
+
+func init() {
+	wallet.AddCommand(info)
+
+	info.Flags().StringP(
+		constant.FlagOutput.ValStr(),
+		"o",
+		"table",
+		`The output format: table or json.
+`)
+}
+
+type (
+	walletInfo struct {
+		Address        string     `json:"address"`
+		Activated      bool       `json:"activated"`
+		Sequence       string     `json:"sequence"`
+		SubentryCount  int32      `json:"subentry_count"`
+		NumSponsoring  uint32     `json:"num_sponsoring"`
+		NumSponsored   uint32     `json:"num_sponsored"`
+		MinimumReserve string     `json:"minimum_reserve"`
+		Balances       []*balance `json:"balances"`
+		Signers        []*signer  `json:"signers"`
+		Thresholds     struct {
+			Low    byte `json:"low"`
+			Medium byte `json:"medium"`
+			High   byte `json:"high"`
+		} `json:"thresholds"`
+		Flags struct {
+			AuthRequired        bool `json:"auth_required"`
+			AuthRevocable       bool `json:"auth_revocable"`
+			AuthImmutable       bool `json:"auth_immutable"`
+			AuthClawbackEnabled bool `json:"auth_clawback_enabled"`
+		} `json:"flags"`
+	}
+
+	balance struct {
+		AssetType                         string `json:"asset_type"`
+		AssetCode                         string `json:"asset_code"`
+		AssetIssuer                       string `json:"asset_issuer"`
+		LiquidityPoolID                   string `json:"liquidity_pool_id"`
+		Balance                           string `json:"balance"`
+		Limit                             string `json:"limit"`
+		IsAuthorized                      *bool  `json:"is_authorized"`
+		IsAuthorizedToMaintainLiabilities *bool  `json:"is_authorized_to_maintain_liabilities"`
+	}
+
+	signer struct {
+		Key    string `json:"key"`
+		Type   string `json:"type"`
+		Weight int32  `json:"weight"`
+	}
+)
+
+func infoFunc(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString(constant.FlagOutput.ValStr())
+	if output != "table" && output != "json" {
+		return errorx.BadRequest(fmt.Sprintf("invalid output: %s, should be table or json", output))
+	}
+
+	resp, err := supplierInfo(args[0])
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		fmt.Println(string(resp.Body()))
+		return nil
+	}
+
+	wi := new(walletInfo)
+	if err := json.Unmarshal(resp.Body(), wi); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	if !wi.Activated {
+		logx.Logger.Warn(fmt.Sprintf("The wallet address %s is not activated yet, transfer at least 1 XLM to it first.", wi.Address))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Address:\t%s\n", wi.Address)
+	fmt.Fprintf(w, "Sequence:\t%s\n", wi.Sequence)
+	fmt.Fprintf(w, "Subentries:\t%d\n", wi.SubentryCount)
+	fmt.Fprintf(w, "Sponsoring/Sponsored:\t%d/%d\n", wi.NumSponsoring, wi.NumSponsored)
+	fmt.Fprintf(w, "Minimum Reserve:\t%s XLM\n", wi.MinimumReserve)
+	fmt.Fprintf(w, "Thresholds:\tlow %d, medium %d, high %d\n", wi.Thresholds.Low, wi.Thresholds.Medium, wi.Thresholds.High)
+	fmt.Fprintf(w, "Flags:\tauth_required %t, auth_revocable %t, auth_immutable %t, auth_clawback_enabled %t\n",
+		wi.Flags.AuthRequired, wi.Flags.AuthRevocable, wi.Flags.AuthImmutable, wi.Flags.AuthClawbackEnabled)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ASSET\tBALANCE\tLIMIT\tAUTHORIZED")
+	for _, b := range wi.Balances {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", b.asset(), b.Balance, orDash(b.Limit), b.authorized())
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SIGNER\tTYPE\tWEIGHT")
+	for _, s := range wi.Signers {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", s.Key, s.Type, s.Weight)
+	}
+
+	return w.Flush()
+}
+
+func (b *balance) asset() string {
+	switch b.AssetType {
+	case "native":
+		return "XLM"
+	case "liquidity_pool_shares":
+		return fmt.Sprintf("pool:%s", b.LiquidityPoolID)
+	default:
+		return fmt.Sprintf("%s:%s", b.AssetCode, b.AssetIssuer)
+	}
+}
+
+func (b *balance) authorized() string {
+	switch {
+	case b.IsAuthorized == nil:
+		return "-"
+	case *b.IsAuthorized:
+		return "yes"
+	case b.IsAuthorizedToMaintainLiabilities != nil && *b.IsAuthorizedToMaintainLiabilities:
+		return "maintain liabilities"
+	default:
+		return "no"
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+func supplierInfo(walletAddress string) (*resty.Response, error) {
+	token, err := config.Token()
+	if err != nil {
+		logx.Logger.Error("PS: Should login first.")
+		return nil, err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/wallet/%s", config.Vp.GetString("bound_with.endpoint"), walletAddress))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		Get(URL)
+	if err != nil {
+		return nil, errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return nil, errorx.WithRestyResp(response)
+	}
+
+	return response, nil
+}
@@ -3,8 +3,12 @@ package wallet
 import (
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
 
 	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
 	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
 	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
 	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
@@ -27,26 +31,53 @@ Output:
   This may include details such as:
     - Wallet address (public key)
 
+  With --balances, a table of the wallets is displayed instead, along with whether each one
+  is activated, the XLM balance and the other assets held.
+
+Examples:
+  autoaction wallet list
+  autoaction wallet list --balances
+
 Note:
   - The list includes all wallets, regardless of their balance or activity status.
   - Ensure you are authenticated before running this command.
+  - The balances are reused by the server for a few seconds, and might lag behind the network.
 
 Related Commands:
   autoaction wallet create - Create a new wallet address
+  autoaction wallet info - View the balances and state of a wallet
 `,
 	RunE: listFunc,
 }
 
 func init() {
 	wallet.AddCommand(list)
+
+	list.Flags().Bool(
+		constant.FlagBalances.ValStr(),
+		false,
+		`List the balances of each wallet along with the addresses.
+`)
+}
+
+type listedWallet struct {
+	Address   string     `json:"address"`
+	Activated *bool      `json:"activated"`
+	Balances  []*balance `json:"balances"`
 }
 
-func listFunc(_ *cobra.Command, _ []string) error {
-	resp, err := supplierList()
+func listFunc(cmd *cobra.Command, _ []string) error {
+	balances, _ := cmd.Flags().GetBool(constant.FlagBalances.ValStr())
+
+	resp, err := supplierList(balances)
 	if err != nil {
 		return err
 	}
 
+	if balances {
+		return printListBalances(resp)
+	}
+
 	var respData map[string]interface{}
 	if err := json.Unmarshal(resp.Body(), &respData); err != nil {
 		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
@@ -58,7 +89,38 @@ func listFunc(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
-func supplierList() (*resty.Response, error) {
+func printListBalances(resp *resty.Response) error {
+	listed := new(struct {
+		Data []*listedWallet `json:"data"`
+	})
+	if err := json.Unmarshal(resp.Body(), listed); err != nil {
+		logx.Logger.Error("Error unmarshalling JSON", "error", err.Error())
+		return errorx.Internal(err.Error())
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ADDRESS\tACTIVATED\tXLM\tOTHER ASSETS")
+	for _, lw := range listed.Data {
+		activated, native, others := "unknown", "-", 0
+		if lw.Activated != nil {
+			activated = strconv.FormatBool(*lw.Activated)
+		}
+		for _, b := range lw.Balances {
+			if b.AssetType == "native" {
+				native = b.Balance
+				continue
+			}
+			others++
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", lw.Address, activated, native, others)
+	}
+	fmt.Fprintf(w, "\n%d wallets listed\n", len(listed.Data))
+
+	return w.Flush()
+}
+
+func supplierList(balances bool) (*resty.Response, error) {
 	token, err := config.Token()
 	if err != nil {
 		logx.Logger.Error("PS: Should login first.")
@@ -73,6 +135,7 @@ func supplierList() (*resty.Response, error) {
 			"Content-Type":  "application/json",
 			"Authorization": token,
 		}).
+		SetQueryParam("balances", strconv.FormatBool(balances)).
 		Get(URL)
 	if err != nil {
 		return nil, errorx.RestyError(err.Error())
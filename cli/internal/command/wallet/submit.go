@@ -0,0 +1,145 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/config"
+	"github.com/57blocks/auto-action/cli/internal/constant"
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/logx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/restyx"
+	"github.com/57blocks/auto-action/cli/internal/pkg/util"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/spf13/cobra"
+)
+
+var submit = &cobra.Command{
+	Use:   "submit [wallet-address]",
+	Short: "Sign a Stellar transaction by a wallet and submit it",
+	Long: `
+Description:
+  The submit command sends an unsigned transaction envelope to the server, which signs it
+  by the wallet and submits it to the Stellar network. The private key of the wallet never
+  leaves the signer.
+
+Arguments:
+  [wallet-address]    The Stellar public key of the wallet signing the transaction,
+                      which must be the source account of it, or the fee account of a fee bump one
+
+Examples:
+  autoaction wallet submit GXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX --xdr AAAAAgAAAAA...
+  cat tx.xdr | autoaction wallet submit GXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX --xdr -
+
+Output:
+  The hash of the transaction, along with the ledger it's included in, or the result codes
+  when it's rejected by the network.
+
+Important Notes:
+  - The envelope is hashed with the passphrase of the network the server is bound with.
+  - You can only sign with wallet addresses associated with your own user account.
+  - The envelope must be built with a sequence number and fee valid at the time of submitting.
+
+Related Commands:
+  autoaction wallet list - View all wallets in your account
+  autoaction wallet verify - Check whether a wallet is activated
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: submitFunc,
+}
+
+func init() {
+	wallet.AddCommand(submit)
+
+	submit.Flags().String(
+		constant.FlagXDR.ValStr(),
+		"",
+		`The unsigned transaction envelope in base64 encoded XDR, - for the standard input.
+`)
+	if err := submit.MarkFlagRequired(constant.FlagXDR.ValStr()); err != nil {
+		return
+	}
+}
+
+type submitted struct {
+	Hash        string   `json:"hash"`
+	Successful  bool     `json:"successful"`
+	Ledger      int32    `json:"ledger"`
+	Transaction string   `json:"transaction_code"`
+	Operations  []string `json:"operation_codes"`
+}
+
+func submitFunc(cmd *cobra.Command, args []string) error {
+	walletAddress := args[0]
+
+	envelope, _ := cmd.Flags().GetString(constant.FlagXDR.ValStr())
+	if envelope == "-" {
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return errorx.BadRequest(fmt.Sprintf("failed to read the envelope from the standard input: %s", err.Error()))
+		}
+		envelope = string(content)
+	}
+	envelope = strings.TrimSpace(envelope)
+	if envelope == "" {
+		return errorx.BadRequest("empty transaction envelope error")
+	}
+
+	resp, err := supplierSubmit(walletAddress, envelope)
+	if err != nil {
+		return err
+	}
+
+	result := new(submitted)
+	if err := json.Unmarshal(resp.Body(), result); err != nil {
+		return errorx.Internal(fmt.Sprintf("unmarshaling json response error: %s", err.Error()))
+	}
+
+	if !result.Successful {
+		logx.Logger.Warn("Transaction rejected",
+			"hash", result.Hash,
+			"transaction_code", result.Transaction,
+			"operation_codes", strings.Join(result.Operations, ","),
+		)
+		return errorx.BadRequest(fmt.Sprintf("transaction rejected: %s", result.Transaction))
+	}
+
+	logx.Logger.Info("Transaction submitted",
+		"hash", result.Hash,
+		"ledger", result.Ledger,
+	)
+
+	return nil
+}
+
+func supplierSubmit(walletAddress, envelope string) (*resty.Response, error) {
+	token, err := config.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	URL := util.ParseReqPath(fmt.Sprintf("%s/wallet/%s/transactions", config.Vp.GetString("bound_with.endpoint"), walletAddress))
+
+	response, err := restyx.Client.R().
+		EnableTrace().
+		SetHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": token,
+		}).
+		SetBody(map[string]string{
+			"xdr": envelope,
+		}).
+		Post(URL)
+	if err != nil {
+		return nil, errorx.RestyError(err.Error())
+	}
+	if response.IsError() {
+		return nil, errorx.WithRestyResp(response)
+	}
+
+	return response, nil
+}
@@ -28,15 +28,142 @@ const (
 	FlagRate FlagName = "rate"
 )
 
-// FlagPayload Flags for Action invoke command
+// Flags for the retry policy of the schedule, used by register and schedule set commands
+const (
+	FlagMaxRetryAttempts FlagName = "max-retry-attempts"
+	FlagMaxEventAge      FlagName = "max-event-age"
+)
+
+// Flags for Action environment variables, used by register, update and env set commands
+const (
+	FlagEnv     FlagName = "env"
+	FlagEnvFile FlagName = "env-file"
+)
+
+// Flags for Action resources, used by register and update commands
+const (
+	FlagTimeout          FlagName = "timeout"
+	FlagMemory           FlagName = "memory"
+	FlagEphemeralStorage FlagName = "ephemeral-storage"
+)
+
+// FlagRuntime Flags for Action runtime, used by register and update commands
+const (
+	FlagRuntime FlagName = "runtime"
+)
+
+// Flags for Action invoke command
 const (
 	FlagPayload FlagName = "payload"
+	FlagAsync   FlagName = "async"
+)
+
+// FlagWait Flags for Action result command
+const (
+	FlagWait FlagName = "wait"
+)
+
+// Flags for Action log command, the since and until flags are shared with the history command
+const (
+	FlagFilter   FlagName = "filter"
+	FlagLimit    FlagName = "limit"
+	FlagNoFollow FlagName = "no-follow"
+)
+
+// Flags for Action history command, the output flag is shared with the pack command
+const (
+	FlagStatus FlagName = "status"
+	FlagMode   FlagName = "mode"
+	FlagSince  FlagName = "since"
+	FlagUntil  FlagName = "until"
+	FlagPage   FlagName = "page"
+	FlagSize   FlagName = "size"
+	FlagOutput FlagName = "output"
 )
 
 const (
 	FlagFull FlagName = "full"
 )
 
+// FlagRedrive Flags for Action failures command, the id flag picks the failures to redrive
+const (
+	FlagRedrive FlagName = "redrive"
+	FlagID      FlagName = "id"
+)
+
+// FlagTo Flags for Action rollback command
+const (
+	FlagTo FlagName = "to"
+)
+
+// Flags for Action chain command
+const (
+	FlagOnSuccess FlagName = "on-success"
+	FlagOnFailure FlagName = "on-failure"
+)
+
+// Flags for Action register command, the account activities on Stellar which trigger the action
+const (
+	FlagOnPayment   FlagName = "on-payment"
+	FlagOnOperation FlagName = "on-operation"
+	FlagOnEffect    FlagName = "on-effect"
+)
+
+// Flags for the trigger add contract-event command, the contract emitting the events and the topics filtering them
+const (
+	FlagContract FlagName = "contract"
+	FlagTopic    FlagName = "topic"
+)
+
+// FlagHeader Flags for the webhook create and rotate commands, the request headers picked into the event
+const (
+	FlagHeader FlagName = "header"
+)
+
+// Flags for the kv commands, the action owning the entries, the TTL and the version compared before putting or removing
+const (
+	FlagAction  FlagName = "action"
+	FlagTTL     FlagName = "ttl"
+	FlagVersion FlagName = "version"
+)
+
+// FlagNoActions Flags for the secret set command, revoking the secret from all the actions granted
+const (
+	FlagNoActions FlagName = "no-actions"
+)
+
+// FlagFund Flags for the wallet create command, funding the wallet created by the Friendbot
+const (
+	FlagFund FlagName = "fund"
+)
+
+// FlagActivate Flags for the wallet create command, activating the wallet created by the funding wallet of the organization
+const (
+	FlagActivate FlagName = "activate"
+)
+
+// FlagBalances Flags for the wallet list command, listing the balances of each wallet along with the addresses
+const (
+	FlagBalances FlagName = "balances"
+)
+
+// FlagXDR Flags for the wallet submit command, the transaction envelope signed and submitted by the wallet
+const (
+	FlagXDR FlagName = "xdr"
+)
+
+// Flags for Action schedule preview command
+const (
+	FlagCount    FlagName = "count"
+	FlagTimezone FlagName = "timezone"
+)
+
+// Flags for the plan and apply commands
+const (
+	FlagFile  FlagName = "file"
+	FlagPrune FlagName = "prune"
+)
+
 func (f FlagName) ValStr() string {
 	return string(f)
 }
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"strconv"
 	"sync"
+	"time"
 )
 
 const (
@@ -147,3 +148,21 @@ func (h *PrettyHandler) computeAttrs(
 	}
 	return attrs, nil
 }
+
+// PrettyLogEvent prints a log event of the action in colors,
+// the failures of tracking the logs are printed in red.
+func PrettyLogEvent(timestamp time.Time, stream, message string, failed bool) {
+	if failed {
+		fmt.Println(
+			colorize(lightGray, time.Now().Format(timeFormat)),
+			colorize(lightRed, message),
+		)
+		return
+	}
+
+	fmt.Println(
+		colorize(lightGray, timestamp.Local().Format(timeFormat)),
+		colorize(darkGray, stream),
+		colorize(white, message),
+	)
+}
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+
+	"github.com/evanw/esbuild/pkg/api"
+)
+
+// BundleExts the extensions of the entry files bundled into one CommonJS file before being packed
+var BundleExts = []string{".ts", ".mts", ".mjs"}
+
+// IsBundleEntry checks the file is an entry file to bundle, by its extension.
+func IsBundleEntry(path string) bool {
+	return slices.Contains(BundleExts, filepath.Ext(path))
+}
+
+// BundleEntry bundles the entry file and its local imports into one CommonJS file <name>.js in the outDir,
+// along with the source map <name>.js.map, targeting the Node.js version of the runtime.
+// The packages imported are left to the node_modules, and the compile errors are reported with file:line.
+func BundleEntry(entry, outDir, runtime string) ([]string, error) {
+	dir, err := filepath.Abs(filepath.Dir(entry))
+	if err != nil {
+		return nil, errorx.Internal(err.Error())
+	}
+	name := strings.TrimSuffix(filepath.Base(entry), filepath.Ext(entry))
+
+	result := api.Build(api.BuildOptions{
+		AbsWorkingDir: dir,
+		EntryPoints:   []string{filepath.Base(entry)},
+		// the output is placed by the entry virtually, so the sources in the map are relative to the directory
+		Outfile:        filepath.Join(dir, name+".js"),
+		Bundle:         true,
+		Packages:       api.PackagesExternal,
+		Format:         api.FormatCommonJS,
+		Platform:       api.PlatformNode,
+		Engines:        []api.Engine{{Name: api.EngineNode, Version: nodeVersion(runtime)}},
+		Sourcemap:      api.SourceMapLinked,
+		SourcesContent: api.SourcesContentExclude,
+		LogLevel:       api.LogLevelSilent,
+		Write:          false,
+	})
+	if len(result.Errors) > 0 {
+		return nil, errorx.BadRequest(fmt.Sprintf("failed to bundle: %s\n%s", entry, buildMessages(result.Errors)))
+	}
+
+	files := make([]string, 0, len(result.OutputFiles))
+	for _, output := range result.OutputFiles {
+		path := filepath.Join(outDir, filepath.Base(output.Path))
+		if err := os.WriteFile(path, output.Contents, 0o644); err != nil {
+			return nil, errorx.Internal(err.Error())
+		}
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+// nodeVersion returns the major version of Node.js of the runtime, the default runtime is nodejs20.x.
+func nodeVersion(runtime string) string {
+	version := strings.TrimSuffix(strings.TrimPrefix(runtime, "nodejs"), ".x")
+	if version == "" || version == runtime {
+		return "20"
+	}
+
+	return version
+}
+
+// buildMessages formats the messages of esbuild one per line, by file:line:column: text.
+func buildMessages(messages []api.Message) string {
+	lines := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Location == nil {
+			lines = append(lines, msg.Text)
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%s:%d:%d: %s",
+			msg.Location.File, msg.Location.Line, msg.Location.Column+1, msg.Text))
+	}
+
+	return strings.Join(lines, "\n")
+}
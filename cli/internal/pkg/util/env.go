@@ -0,0 +1,85 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+)
+
+// ParseEnv collects the environment variables from the env file and the KEY=VALUE pairs,
+// the pairs override the variables in the file with the same keys.
+func ParseEnv(pairs []string, envFile string) (map[string]string, error) {
+	env := make(map[string]string)
+
+	if envFile != "" {
+		fileEnv, err := ReadEnvFile(envFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, value := range fileEnv {
+			env[key] = value
+		}
+	}
+
+	for _, pair := range pairs {
+		key, value, err := splitEnvPair(pair)
+		if err != nil {
+			return nil, err
+		}
+
+		env[key] = value
+	}
+
+	return env, nil
+}
+
+// ReadEnvFile reads the variables in the dotenv format, one KEY=VALUE per line.
+// Blank lines and lines starting with # are skipped, the "export " prefix and the quotes around values are trimmed.
+func ReadEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errorx.BadRequest(err.Error())
+	}
+	defer file.Close()
+
+	env := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, err := splitEnvPair(strings.TrimPrefix(line, "export "))
+		if err != nil {
+			return nil, errorx.BadRequest(fmt.Sprintf("%s:%d: %s", path, lineNo, err.Error()))
+		}
+
+		env[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorx.Internal(err.Error())
+	}
+
+	return env, nil
+}
+
+func splitEnvPair(pair string) (string, string, error) {
+	key, value, found := strings.Cut(pair, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", "", errorx.BadRequest(fmt.Sprintf("invalid environment variable: %s, should be KEY=VALUE", pair))
+	}
+
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+
+	return key, value, nil
+}
@@ -0,0 +1,451 @@
+package util
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+)
+
+// PackIgnoreFile the file in the source directory listing the patterns of the files not packed
+const PackIgnoreFile = ".autoactionignore"
+
+// packModTime the modified time of all the entries, which keeps the bundle deterministic
+var packModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// packIgnored the patterns always ignored, besides the ones in the ignore file
+var packIgnored = []string{".git/", ".DS_Store", PackIgnoreFile}
+
+var (
+	jsHandlerRegex = regexp.MustCompile(`(?m)(exports\.handler\s*=|export\s+(async\s+)?(function\s*\*?\s*handler\b|(const|let|var)\s+handler\b)|export\s*\{[^}]*\bhandler\b[^}]*}|module\.exports\s*=\s*\{[^}]*\bhandler\b)`)
+	pyHandlerRegex = regexp.MustCompile(`(?m)^(async\s+)?def\s+handler\s*\(`)
+)
+
+// PackDir packs the source directory into the zip file at dest, returns the sha256 of the zip file.
+// The name of the zip file is the name of the action, so the entry file <name>.js or <name>.py
+// should be at the top level of the directory and export the handler.
+// For the Node.js runtimes, the entry <name>.ts, <name>.mts or <name>.mjs is bundled into <name>.js instead,
+// and the sources bundled are left out of the zip file.
+func PackDir(dir, dest, runtime string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errorx.Internal(err.Error())
+	}
+	dest, err = filepath.Abs(dest)
+	if err != nil {
+		return "", errorx.Internal(err.Error())
+	}
+
+	stat, err := os.Stat(dir)
+	if err != nil {
+		return "", errorx.BadRequest(err.Error())
+	}
+	if !stat.IsDir() {
+		return "", errorx.BadRequest(fmt.Sprintf("not a directory: %s", dir))
+	}
+
+	name := strings.TrimSuffix(filepath.Base(dest), filepath.Ext(dest))
+	entry, err := checkEntry(dir, name, runtime)
+	if err != nil {
+		return "", err
+	}
+
+	files, err := packFiles(dir, dest)
+	if err != nil {
+		return "", err
+	}
+
+	// the name in the zip file to the path of the file packed
+	entries := make(map[string]string, len(files))
+	for _, rel := range files {
+		entries[rel] = filepath.Join(dir, filepath.FromSlash(rel))
+	}
+
+	if IsBundleEntry(entry) {
+		tempDir, err := os.MkdirTemp("", "autoaction-bundle-")
+		if err != nil {
+			return "", errorx.Internal(err.Error())
+		}
+		defer os.RemoveAll(tempDir)
+
+		bundled, err := BundleEntry(filepath.Join(dir, entry), tempDir, runtime)
+		if err != nil {
+			return "", err
+		}
+
+		for _, rel := range files {
+			if IsBundleEntry(rel) && !strings.HasPrefix(rel, "node_modules/") {
+				delete(entries, rel)
+			}
+		}
+		for _, path := range bundled {
+			entries[filepath.Base(path)] = path
+		}
+	}
+
+	return writeZip(dest, entries)
+}
+
+// PackSource resolves the source directory and the name of the action by the path,
+// which is either a directory or an entry file to bundle in the directory.
+func PackSource(path string) (string, string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", errorx.Internal(err.Error())
+	}
+
+	if IsBundleEntry(abs) {
+		return filepath.Dir(abs), strings.TrimSuffix(filepath.Base(abs), filepath.Ext(abs)), nil
+	}
+
+	return abs, filepath.Base(abs), nil
+}
+
+// checkEntry checks the entry file of the runtime exists and exports the handler, returns the name of it.
+// Either of the js and python entry files is accepted when the runtime is not specified,
+// and the ones to bundle are accepted unless the runtime is python.
+func checkEntry(dir, name, runtime string) (string, error) {
+	exts := runtimeExts(runtime)
+	if !strings.HasPrefix(runtime, "python") {
+		exts = append(exts, BundleExts...)
+	}
+
+	for _, ext := range exts {
+		content, err := os.ReadFile(filepath.Join(dir, name+ext))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", errorx.Internal(err.Error())
+		}
+
+		handlerRegex := jsHandlerRegex
+		if ext == ".py" {
+			handlerRegex = pyHandlerRegex
+		}
+		if !handlerRegex.Match(content) {
+			return "", errorx.BadRequest(fmt.Sprintf("%s%s: handler is not exported", name, ext))
+		}
+
+		return name + ext, nil
+	}
+
+	return "", errorx.BadRequest(fmt.Sprintf("entry file %s not found in: %s",
+		strings.Join(prefixExts(name, exts), "/"), dir))
+}
+
+func prefixExts(name string, exts []string) []string {
+	files := make([]string, 0, len(exts))
+	for _, ext := range exts {
+		files = append(files, name+ext)
+	}
+
+	return files
+}
+
+// packFiles lists the files to pack, by the slash separated paths relative to the directory, in order.
+// The node_modules are packed only for the production dependencies declared in the package.json,
+// and the zip file packed into is excluded when it's in the directory.
+func packFiles(dir, dest string) ([]string, error) {
+	patterns, err := readIgnoreFile(filepath.Join(dir, PackIgnoreFile))
+	if err != nil {
+		return nil, err
+	}
+	patterns = append(patterns, packIgnored...)
+
+	modules, err := productionModules(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	walker := &packWalker{
+		dest:     dest,
+		patterns: patterns,
+		modules:  modules,
+		walking:  make(map[string]bool),
+		files:    make([]string, 0),
+	}
+	if err := walker.walk(dir, ""); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(walker.files)
+
+	return walker.files, nil
+}
+
+// packWalker walks the directory for the files to pack, the symlinks are followed,
+// like the node_modules linked by pnpm or npm link.
+type packWalker struct {
+	dest     string
+	patterns []string
+	modules  map[string]bool
+	// walking the real paths of the directories being walked, to detect the symlink loops
+	walking map[string]bool
+	files   []string
+}
+
+func (w *packWalker) walk(dir, rel string) error {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return errorx.Internal(err.Error())
+	}
+	if w.walking[real] {
+		return errorx.BadRequest(fmt.Sprintf("symlink loop found at: %s", rel))
+	}
+	w.walking[real] = true
+	defer delete(w.walking, real)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to read the directory: %s, err: %s", dir, err.Error()))
+	}
+
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		if p == w.dest {
+			continue
+		}
+		entryRel := path.Join(rel, entry.Name())
+
+		// the symlinks are resolved to the files or directories linked to
+		stat, err := os.Stat(p)
+		if err != nil {
+			if entry.Type()&fs.ModeSymlink != 0 {
+				return errorx.BadRequest(fmt.Sprintf("broken symlink: %s", entryRel))
+			}
+			return errorx.Internal(err.Error())
+		}
+
+		if ignored(entryRel, stat.IsDir(), w.patterns) {
+			continue
+		}
+		if entryRel == "node_modules" || strings.HasPrefix(entryRel, "node_modules/") {
+			if !inModules(entryRel, stat.IsDir(), w.modules) {
+				continue
+			}
+		}
+
+		switch {
+		case stat.IsDir():
+			if err := w.walk(p, entryRel); err != nil {
+				return err
+			}
+		case stat.Mode().IsRegular():
+			w.files = append(w.files, entryRel)
+		default:
+			return errorx.BadRequest(fmt.Sprintf("neither a regular file nor a directory: %s", entryRel))
+		}
+	}
+
+	return nil
+}
+
+// readIgnoreFile reads the patterns of the ignore file, the blank lines and the comments are skipped.
+func readIgnoreFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorx.Internal(err.Error())
+	}
+	defer file.Close()
+
+	patterns := make([]string, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorx.Internal(err.Error())
+	}
+
+	return patterns, nil
+}
+
+// ignored matches the path against the patterns, in the way of the .gitignore but the negations:
+// the pattern ends with a slash only matches directories, the one contains a slash is matched against
+// the path from the top, and the others are matched against the name at any level.
+func ignored(rel string, isDir bool, patterns []string) bool {
+	for _, pattern := range patterns {
+		dirOnly := strings.HasSuffix(pattern, "/")
+		pattern = strings.TrimSuffix(pattern, "/")
+		if dirOnly && !isDir {
+			continue
+		}
+
+		target := path.Base(rel)
+		if strings.Contains(pattern, "/") {
+			pattern = strings.TrimPrefix(pattern, "/")
+			target = rel
+		}
+
+		if matched, _ := path.Match(pattern, target); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// productionModules resolves the production dependencies declared in the package.json,
+// including the ones depended by them, which are installed in the node_modules.
+func productionModules(dir string) (map[string]bool, error) {
+	modules := make(map[string]bool)
+
+	deps, err := readDependencies(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	for len(deps) > 0 {
+		dep := deps[0]
+		deps = deps[1:]
+		if modules[dep] {
+			continue
+		}
+
+		manifest := filepath.Join(dir, "node_modules", filepath.FromSlash(dep), "package.json")
+		if !IsExists(manifest) {
+			// not installed, the runtime may provide it, like the aws-sdk
+			continue
+		}
+		modules[dep] = true
+
+		transitive, err := readDependencies(manifest)
+		if err != nil {
+			return nil, err
+		}
+		deps = append(deps, transitive...)
+	}
+
+	return modules, nil
+}
+
+// readDependencies reads the names of the dependencies in the package.json, the absent one has none.
+func readDependencies(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorx.Internal(err.Error())
+	}
+
+	manifest := new(struct {
+		Dependencies         map[string]string `json:"dependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	})
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid package.json: %s, err: %s", path, err.Error()))
+	}
+
+	deps := make([]string, 0, len(manifest.Dependencies)+len(manifest.OptionalDependencies))
+	for dep := range manifest.Dependencies {
+		deps = append(deps, dep)
+	}
+	for dep := range manifest.OptionalDependencies {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
+
+	return deps, nil
+}
+
+// inModules checks the path under the node_modules belongs to the modules,
+// the directories of the scopes, like node_modules/@stellar, are walked into.
+func inModules(rel string, isDir bool, modules map[string]bool) bool {
+	if rel == "node_modules" {
+		return len(modules) > 0
+	}
+
+	parts := strings.Split(strings.TrimPrefix(rel, "node_modules/"), "/")
+	module := parts[0]
+	if strings.HasPrefix(module, "@") {
+		if len(parts) == 1 {
+			return isDir
+		}
+		module += "/" + parts[1]
+	}
+
+	return modules[module]
+}
+
+// writeZip writes the entries into the zip file in order, with the fixed modified time and modes,
+// so the same sources are always packed into the same bytes.
+func writeZip(dest string, entries map[string]string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", errorx.Internal(err.Error())
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return "", errorx.Internal(err.Error())
+	}
+	defer out.Close()
+
+	hash := sha256.New()
+	archive := zip.NewWriter(io.MultiWriter(out, hash))
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, rel := range names {
+		header := &zip.FileHeader{
+			Name:     rel,
+			Method:   zip.Deflate,
+			Modified: packModTime,
+		}
+		header.SetMode(0o644)
+
+		writer, err := archive.CreateHeader(header)
+		if err != nil {
+			return "", errorx.Internal(err.Error())
+		}
+
+		if err := copyFile(entries[rel], writer); err != nil {
+			return "", err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return "", errorx.Internal(err.Error())
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func copyFile(path string, writer io.Writer) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errorx.Internal(err.Error())
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(writer, file); err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to pack: %s, err: %s", path, err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writeFiles writes the files by the slash separated paths relative to the directory.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for rel, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestIgnored(t *testing.T) {
+	patterns := []string{"*.log", "dist/", "/src/secret.js", ".git/"}
+
+	for _, c := range []struct {
+		rel     string
+		isDir   bool
+		ignored bool
+	}{
+		{"debug.log", false, true},
+		{"nested/debug.log", false, true},
+		{"dist", true, true},
+		{"nested/dist", true, true},
+		{"dist", false, false},
+		{"src/secret.js", false, true},
+		{"nested/src/secret.js", false, false},
+		{".git", true, true},
+		{"index.js", false, false},
+	} {
+		if got := ignored(c.rel, c.isDir, patterns); got != c.ignored {
+			t.Errorf("ignored(%q, %t) = %t, want %t", c.rel, c.isDir, got, c.ignored)
+		}
+	}
+}
+
+func TestInModules(t *testing.T) {
+	modules := map[string]bool{"axios": true, "@stellar/stellar-sdk": true}
+
+	for _, c := range []struct {
+		rel   string
+		isDir bool
+		in    bool
+	}{
+		{"node_modules", true, true},
+		{"node_modules/axios", true, true},
+		{"node_modules/axios/index.js", false, true},
+		{"node_modules/jest", true, false},
+		{"node_modules/@stellar", true, true},
+		{"node_modules/@stellar/stellar-sdk/package.json", false, true},
+		{"node_modules/@stellar/freighter", true, false},
+		{"node_modules/.package-lock.json", false, false},
+	} {
+		if got := inModules(c.rel, c.isDir, modules); got != c.in {
+			t.Errorf("inModules(%q, %t) = %t, want %t", c.rel, c.isDir, got, c.in)
+		}
+	}
+
+	if inModules("node_modules", true, map[string]bool{}) {
+		t.Error("node_modules is walked into without any production dependency")
+	}
+}
+
+func TestProductionModules(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"package.json": `{"dependencies": {"axios": "^1.0.0", "aws-sdk": "^2.0.0"},
+			"optionalDependencies": {"@stellar/stellar-sdk": "^12.0.0"},
+			"devDependencies": {"jest": "^29.0.0"}}`,
+		"node_modules/axios/package.json":                `{"dependencies": {"follow-redirects": "^1.0.0"}}`,
+		"node_modules/follow-redirects/package.json":     `{}`,
+		"node_modules/@stellar/stellar-sdk/package.json": `{"dependencies": {"axios": "^1.0.0"}}`,
+		"node_modules/jest/package.json":                 `{}`,
+	})
+
+	modules, err := productionModules(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the aws-sdk isn't installed and left to the runtime, the jest is a dev dependency
+	want := map[string]bool{"axios": true, "follow-redirects": true, "@stellar/stellar-sdk": true}
+	if !reflect.DeepEqual(modules, want) {
+		t.Errorf("productionModules = %v, want %v", modules, want)
+	}
+}
+
+func TestProductionModulesInvalidManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"package.json": `{`})
+
+	if _, err := productionModules(dir); err == nil {
+		t.Error("invalid package.json is accepted")
+	}
+}
+
+func TestCheckEntry(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"js.js":        "exports.handler = async (event) => event;",
+		"esm.ts":       "export const handler = async (event: unknown) => event;",
+		"py.py":        "def handler(event, context):\n    return event\n",
+		"noexport.js":  "const handler = async () => {};",
+		"noexport.py":  "def main(event, context):\n    pass\n",
+		"both.js":      "exports.handler = async () => {};",
+		"both.py":      "def handler(event, context):\n    pass\n",
+		"unrelated.md": "# readme",
+	})
+
+	for _, c := range []struct {
+		name    string
+		runtime string
+		entry   string
+		fails   bool
+	}{
+		{"js", "", "js.js", false},
+		{"js", "nodejs20.x", "js.js", false},
+		{"esm", "nodejs20.x", "esm.ts", false},
+		{"esm", "python3.12", "", true},
+		{"py", "python3.12", "py.py", false},
+		{"py", "nodejs20.x", "", true},
+		{"both", "python3.12", "both.py", false},
+		{"noexport", "nodejs20.x", "", true},
+		{"noexport", "python3.12", "", true},
+		{"missing", "", "", true},
+	} {
+		entry, err := checkEntry(dir, c.name, c.runtime)
+		if c.fails {
+			if err == nil {
+				t.Errorf("checkEntry(%q, %q) = %q, want error", c.name, c.runtime, entry)
+			}
+			continue
+		}
+		if err != nil || entry != c.entry {
+			t.Errorf("checkEntry(%q, %q) = %q, %v, want %q", c.name, c.runtime, entry, err, c.entry)
+		}
+	}
+}
+
+func TestPackFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		PackIgnoreFile:                         "# comment\n\n*.log\ntest/\n",
+		"index.js":                             "exports.handler = async () => {};",
+		"lib/util.js":                          "module.exports = {};",
+		"debug.log":                            "log",
+		"test/index.test.js":                   "test",
+		".git/HEAD":                            "ref",
+		"package.json":                         `{"dependencies": {"axios": "^1.0.0"}}`,
+		"node_modules/axios/package.json":      `{}`,
+		"node_modules/axios/index.js":          "module.exports = {};",
+		"node_modules/jest/package.json":       `{}`,
+		"node_modules/.package-lock.json":      `{}`,
+		"node_modules/axios/lib/debug.log":     "log",
+		"node_modules/axios/lib/adapters/x.js": "x",
+	})
+	dest := filepath.Join(dir, "index.zip")
+	writeFiles(t, dir, map[string]string{"index.zip": "stale"})
+
+	files, err := packFiles(dir, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"index.js",
+		"lib/util.js",
+		"node_modules/axios/index.js",
+		"node_modules/axios/lib/adapters/x.js",
+		"node_modules/axios/package.json",
+		"package.json",
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("packFiles = %v, want %v", files, want)
+	}
+}
+
+func TestPackFilesFollowSymlinks(t *testing.T) {
+	dir := t.TempDir()
+	store := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"index.js":     "exports.handler = async () => {};",
+		"package.json": `{"dependencies": {"linked": "^1.0.0"}}`,
+	})
+	writeFiles(t, store, map[string]string{
+		"linked/package.json": `{}`,
+		"linked/index.js":     "module.exports = {};",
+		"shared.js":           "module.exports = {};",
+	})
+	if err := os.MkdirAll(filepath.Join(dir, "node_modules"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(store, "linked"), filepath.Join(dir, "node_modules", "linked")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(store, "shared.js"), filepath.Join(dir, "shared.js")); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := packFiles(dir, filepath.Join(dir, "index.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"index.js",
+		"node_modules/linked/index.js",
+		"node_modules/linked/package.json",
+		"package.json",
+		"shared.js",
+	}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("packFiles = %v, want %v", files, want)
+	}
+}
+
+func TestPackFilesBrokenSymlink(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"index.js": "exports.handler = async () => {};"})
+	if err := os.Symlink(filepath.Join(dir, "missing.js"), filepath.Join(dir, "broken.js")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := packFiles(dir, filepath.Join(dir, "index.zip")); err == nil {
+		t.Error("broken symlink is packed silently")
+	}
+}
+
+func TestPackFilesSymlinkLoop(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"lib/index.js": "module.exports = {};"})
+	if err := os.Symlink(filepath.Join(dir, "lib"), filepath.Join(dir, "lib", "self")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := packFiles(dir, filepath.Join(dir, "index.zip")); err == nil {
+		t.Error("symlink loop is walked without error")
+	}
+}
+
+func TestWriteZipDeterministic(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.js":     "a",
+		"lib/b.js": "b",
+	})
+	entries := map[string]string{
+		"a.js":     filepath.Join(dir, "a.js"),
+		"lib/b.js": filepath.Join(dir, "lib", "b.js"),
+	}
+
+	first, err := writeZip(filepath.Join(dir, "out", "first.zip"), entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the modified time and the mode of the sources are not packed
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(entries["a.js"], later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(entries["lib/b.js"], 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := writeZip(filepath.Join(dir, "out", "second.zip"), entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Errorf("the same sources are packed into %s and %s", first, second)
+	}
+
+	firstBytes, _ := os.ReadFile(filepath.Join(dir, "out", "first.zip"))
+	secondBytes, _ := os.ReadFile(filepath.Join(dir, "out", "second.zip"))
+	if string(firstBytes) != string(secondBytes) {
+		t.Error("the same sources are packed into different bytes")
+	}
+
+	// the content changed is packed into another one
+	writeFiles(t, dir, map[string]string{"a.js": "changed"})
+	third, err := writeZip(filepath.Join(dir, "out", "third.zip"), entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third == first {
+		t.Error("the changed sources are packed into the same one")
+	}
+}
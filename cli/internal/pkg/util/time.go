@@ -0,0 +1,26 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
+)
+
+// ParseTime parses the time in RFC3339, or the duration relative to now, e.g. 30m, 24h.
+// The empty one returns the zero time.
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, errorx.BadRequest(fmt.Sprintf("invalid time: %s, should be RFC3339 or a duration like 24h", value))
+}
@@ -2,22 +2,39 @@ package util
 
 import (
 	"archive/zip"
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/57blocks/auto-action/cli/internal/pkg/errorx"
 )
 
-func ValidateZipFiles(paths []string) error {
+// ValidateZipFiles checks the zip files contain the source files of the runtime,
+// both js and python files are accepted when the runtime is not specified.
+func ValidateZipFiles(runtime string, paths []string) error {
+	exts := runtimeExts(runtime)
+
 	for _, path := range paths {
-		if err := CheckJsActionZipFile(path); err != nil {
+		if err := CheckActionZipFile(path, exts); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func CheckJsActionZipFile(path string) error {
+// runtimeExts returns the extensions of the source files of the runtime.
+func runtimeExts(runtime string) []string {
+	switch {
+	case runtime == "":
+		return []string{".js", ".py"}
+	case strings.HasPrefix(runtime, "python"):
+		return []string{".py"}
+	default:
+		return []string{".js"}
+	}
+}
+
+func CheckActionZipFile(path string, exts []string) error {
 	zipFile, err := os.Open(path)
 	// check if the file exists
 	if err != nil {
@@ -36,18 +53,21 @@ func CheckJsActionZipFile(path string) error {
 		return errorx.Internal(err.Error() + ": " + path)
 	}
 
-	// check if the zip file contains any js files, will use ESLint to check the content at the sever side
-	validJsFiles := make([]string, 0)
+	// check if the zip file contains any source files at the top level,
+	// the content is checked by the validator of the runtime at the sever side
+	validFiles := make([]string, 0)
 	for _, file := range archive.File {
 		if strings.Contains(file.Name, "/") {
 			continue
 		}
-		if strings.HasSuffix(file.Name, ".js") {
-			validJsFiles = append(validJsFiles, file.Name)
+		for _, ext := range exts {
+			if strings.HasSuffix(file.Name, ext) {
+				validFiles = append(validFiles, file.Name)
+			}
 		}
 	}
-	if len(validJsFiles) == 0 {
-		return errorx.BadRequest("no valid js files found in: " + path)
+	if len(validFiles) == 0 {
+		return errorx.BadRequest(fmt.Sprintf("no valid %s files found in: %s", strings.Join(exts, "/"), path))
 	}
 
 	return nil
@@ -5,6 +5,8 @@ import (
 	_ "github.com/57blocks/auto-action/cli/internal/command/action"
 	_ "github.com/57blocks/auto-action/cli/internal/command/auth"
 	_ "github.com/57blocks/auto-action/cli/internal/command/general"
+	_ "github.com/57blocks/auto-action/cli/internal/command/kv"
+	_ "github.com/57blocks/auto-action/cli/internal/command/secret"
 	_ "github.com/57blocks/auto-action/cli/internal/command/wallet"
 )
 
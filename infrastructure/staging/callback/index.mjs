@@ -0,0 +1,17 @@
+// Reports the records of asynchronous invocations, delivered as the destination of the Lambdas,
+// back to the server along with the shared token.
+export const handler = async (record) => {
+  const resp = await fetch(`${process.env.CALLBACK_ENDPOINT}/callback/execution`, {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json",
+      "X-Callback-Token": process.env.CALLBACK_TOKEN,
+    },
+    body: JSON.stringify(record),
+  });
+
+  if (!resp.ok) {
+    // throw to retry the record by Lambda
+    throw new Error(`failed to report execution: ${record?.requestContext?.requestId}, status: ${resp.status}`);
+  }
+};
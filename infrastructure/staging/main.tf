@@ -193,12 +193,25 @@ module "ecs_task_role" {
           "lambda:CreateFunction",
           "lambda:DeleteFunction",
           "lambda:InvokeFunction",
+          "lambda:UpdateFunctionCode",
+          "lambda:UpdateFunctionConfiguration",
+          "lambda:PublishVersion",
+          "lambda:CreateAlias",
+          "lambda:UpdateAlias",
+          "lambda:GetAlias",
+          "lambda:GetFunctionEventInvokeConfig",
+          "lambda:PutFunctionEventInvokeConfig",
           "scheduler:ListSchedules",
           "scheduler:GetSchedule",
           "scheduler:CreateSchedule",
           "scheduler:DeleteSchedule",
+          "scheduler:UpdateSchedule",
+          "sqs:ReceiveMessage",
+          "sqs:DeleteMessage",
+          "sqs:GetQueueAttributes",
           "logs:DescribeLogStreams",
-          "logs:GetLogEvents"
+          "logs:GetLogEvents",
+          "logs:FilterLogEvents"
         ]
         Resource = "*"
       },
@@ -335,6 +348,91 @@ module "cs_key_pairs" {
   })
 }
 
+// Callback of asynchronous invocations
+module "callback_token" {
+  source = "./../modules/secretmanager"
+
+  secret_name = var.lambda_callback_token_name
+  secret_value = jsonencode({
+    token = var.lambda_callback_token
+  })
+}
+
+// Signing key of the runtime tokens injected into the Lambdas
+module "runtime_signing_key" {
+  source = "./../modules/secretmanager"
+
+  secret_name = var.runtime_signing_key_name
+  secret_value = jsonencode({
+    signing_key = var.runtime_signing_key
+  })
+}
+
+module "callback_role" {
+  source = "../modules/iam"
+
+  role_name        = "auac_callback_role"
+  role_description = "Execution role for the callback function of asynchronous invocations"
+
+  assume_role_policy = jsonencode({
+    "Version" : "2012-10-17",
+    "Statement" : [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = ["lambda.amazonaws.com"]
+        }
+        Action = ["sts:AssumeRole"]
+      }
+    ]
+  })
+
+  role_policy_name = "callback_role_policy"
+  policy = jsonencode({
+    "Version" : "2012-10-17",
+    "Statement" : [
+      {
+        "Effect" : "Allow",
+        "Action" : [
+          "logs:CreateLogGroup",
+          "logs:CreateLogStream",
+          "logs:PutLogEvents"
+        ],
+        "Resource" : "arn:aws:logs:${var.region}:${data.aws_caller_identity.current.account_id}:log-group:*"
+      }
+    ]
+  })
+}
+
+data "archive_file" "callback" {
+  type        = "zip"
+  source_file = "${path.module}/callback/index.mjs"
+  output_path = "${path.module}/callback/callback.zip"
+}
+
+resource "aws_lambda_function" "callback" {
+  function_name    = "auac-callback"
+  description      = "Reports the results of asynchronous invocations back to the server"
+  role             = module.callback_role.role_arn
+  runtime          = "nodejs20.x"
+  handler          = "index.handler"
+  filename         = data.archive_file.callback.output_path
+  source_code_hash = data.archive_file.callback.output_base64sha256
+  timeout          = 10
+
+  environment {
+    variables = {
+      CALLBACK_ENDPOINT = "http://${module.alb.alb_dns}"
+      CALLBACK_TOKEN    = var.lambda_callback_token
+    }
+  }
+}
+
+resource "aws_sqs_queue" "dead_letter" {
+  name                      = "auac-dead-letter"
+  message_retention_seconds = 1209600
+}
+
 module "ecs" {
   source = "./../modules/ecs"
 
@@ -346,6 +444,8 @@ module "ecs" {
     module.sg_ecs,
     module.jwt_key_pairs,
     module.ecr,
+    module.callback_token,
+    module.runtime_signing_key,
   ]
 
   ecs_cluster_name = var.ecs_cluster_name
@@ -436,6 +536,18 @@ module "ecs" {
             {
               name  = "AWS_SECRET_CREATE_SLEEP_TIME"
               value = 10
+            },
+            {
+              name  = "LAMBDA_CALLBACK_ARN"
+              value = aws_lambda_function.callback.arn
+            },
+            {
+              name  = "LAMBDA_DEAD_LETTER_ARN"
+              value = aws_sqs_queue.dead_letter.arn
+            },
+            {
+              name  = "LAMBDA_DEAD_LETTER_URL"
+              value = aws_sqs_queue.dead_letter.url
             }
           ]
           secrets = [
@@ -466,6 +578,14 @@ module "ecs" {
             {
               name      = "CS_ORGANIZATION"
               valueFrom = "${module.cs_key_pairs.secret_arn}:organization::"
+            },
+            {
+              name      = "LAMBDA_CALLBACK_TOKEN"
+              valueFrom = "${module.callback_token.secret_arn}:token::"
+            },
+            {
+              name      = "RUNTIME_SIGNING_KEY"
+              valueFrom = "${module.runtime_signing_key.secret_arn}:signing_key::"
             }
           ]
         }
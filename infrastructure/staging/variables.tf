@@ -175,3 +175,30 @@ variable "ecs_fargate_capacity_providers" {
   type        = any
   default     = {}
 }
+
+// Callback of asynchronous invocations
+variable "lambda_callback_token_name" {
+  description = "The name of the secret of the callback token"
+  type        = string
+  default     = ""
+}
+
+variable "lambda_callback_token" {
+  description = "The token shared by the callback function and the server"
+  type        = string
+  sensitive   = true
+  default     = ""
+}
+
+variable "runtime_signing_key_name" {
+  description = "The name of the secret of the runtime signing key"
+  type        = string
+  default     = ""
+}
+
+variable "runtime_signing_key" {
+  description = "The key signing the runtime tokens injected into the Lambdas"
+  type        = string
+  sensitive   = true
+  default     = ""
+}
@@ -11,6 +11,7 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/lambda v1.58.1
 	github.com/aws/aws-sdk-go-v2/service/scheduler v1.10.4
 	github.com/aws/aws-sdk-go-v2/service/secretsmanager v1.32.7
+	github.com/aws/aws-sdk-go-v2/service/sqs v1.34.8
 	github.com/aws/smithy-go v1.20.4
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/gin-gonic/gin v1.10.0
@@ -1,12 +1,16 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/57blocks/auto-action/server/internal/config"
 	"github.com/57blocks/auto-action/server/internal/constant"
 	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
-	"github.com/57blocks/auto-action/server/internal/third-party/eslint"
+	"github.com/57blocks/auto-action/server/internal/pkg/util"
+	"github.com/57blocks/auto-action/server/internal/repo"
 	"github.com/57blocks/auto-action/server/internal/third-party/jwtx"
 	"github.com/57blocks/auto-action/server/internal/third-party/logx"
 
@@ -68,6 +72,65 @@ func Authorization() gin.HandlerFunc {
 	}
 }
 
+// CallbackToken authenticates the callback function by the token shared with the server,
+// all the callbacks are rejected if none token configured.
+func CallbackToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := config.GlobalConfig.Lambda.Callback.Token
+		token := c.GetHeader(constant.CallbackTokenHeader)
+
+		if expected == "" || subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+			c.Error(errorx.UnauthorizedWithMsg("invalid callback token"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RuntimeToken authenticates the Lambdas calling the runtime API by the token injected into their environment,
+// the organization and account of the token are set as the ones in JWT, along with the function of it.
+// The token is revoked once the live version of the function carries another nonce, or the function is removed.
+func RuntimeToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader(constant.AuthHeader.Str()), "Bearer ")
+		if token == "" {
+			c.Error(errorx.UnauthorizedWithMsg("missing runtime token"))
+			c.Abort()
+			return
+		}
+
+		claims, err := util.ParseRuntimeToken(config.GlobalConfig.Runtime.SigningKey, token)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		nonce, err := repo.LambdaRepo.FindRuntimeNonce(c, claims.Function)
+		if err != nil {
+			e := new(errorx.Errorx)
+			if !errors.As(err, &e) || e.Status() != http.StatusNotFound {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+		}
+		if subtle.ConstantTimeCompare([]byte(nonce), []byte(claims.Nonce)) != 1 {
+			c.Error(errorx.UnauthorizedWithMsg("revoked runtime token"))
+			c.Abort()
+			return
+		}
+
+		c.Set(constant.ClaimSub.Str(), claims.Account)
+		c.Set(constant.ClaimIss.Str(), claims.Org)
+		c.Set(constant.RuntimeFunction.Str(), claims.Function)
+
+		c.Next()
+	}
+}
+
 func CORS() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
@@ -113,25 +176,3 @@ func PostHandleErr() gin.HandlerFunc {
 		return
 	}
 }
-
-func RegisterESLintCheck() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		if err := c.Request.ParseMultipartForm(10 << 20); err != nil {
-			c.Error(errorx.BadRequest(err.Error()))
-			c.Abort()
-			return
-		}
-
-		for _, fileHeader := range c.Request.MultipartForm.File {
-			for _, header := range fileHeader {
-				if err := eslint.Check(header); err != nil {
-					c.Error(errorx.BadRequest(err.Error()))
-					c.Abort()
-					return
-				}
-			}
-		}
-
-		c.Next()
-	}
-}
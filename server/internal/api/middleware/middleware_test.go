@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/pkg/util"
+	"github.com/57blocks/auto-action/server/internal/repo"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Before test, setup log and config
+func TestMain(m *testing.M) {
+	config.GlobalConfig = &config.Configuration{
+		Runtime: config.Runtime{SigningKey: "test-signing-key"},
+	}
+	logx.Setup(&config.Configuration{
+		Log: config.Log{
+			Level:    "debug",
+			Encoding: "json",
+		},
+	})
+
+	os.Exit(m.Run())
+}
+
+var testRuntimeClaims = util.RuntimeClaims{
+	Org:      "org_name",
+	Account:  "account_name",
+	Function: "org_name-account_name-file1",
+	Nonce:    "nonce",
+}
+
+// runtimeRequest runs the RuntimeToken middleware with the token, returns the context after it.
+func runtimeRequest(token string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/runtime/kv", nil)
+	ctx.Request.Header.Set(constant.AuthHeader.Str(), "Bearer "+token)
+
+	RuntimeToken()(ctx)
+
+	return ctx
+}
+
+func TestRuntimeTokenSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockLambRepo.EXPECT().FindRuntimeNonce(gomock.Any(), "org_name-account_name-file1").Return("nonce", nil).Times(1)
+	repo.LambdaRepo = mockLambRepo
+
+	ctx := runtimeRequest(util.RuntimeToken("test-signing-key", testRuntimeClaims))
+
+	assert.False(t, ctx.IsAborted())
+	assert.Equal(t, "account_name", ctx.GetString(constant.ClaimSub.Str()))
+	assert.Equal(t, "org_name", ctx.GetString(constant.ClaimIss.Str()))
+	assert.Equal(t, "org_name-account_name-file1", ctx.GetString(constant.RuntimeFunction.Str()))
+}
+
+func TestRuntimeTokenRevoked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	gomock.InOrder(
+		// rotated by a newer version
+		mockLambRepo.EXPECT().FindRuntimeNonce(gomock.Any(), gomock.Any()).Return("rotated", nil),
+		// removed along with the function
+		mockLambRepo.EXPECT().FindRuntimeNonce(gomock.Any(), gomock.Any()).
+			Return("", errorx.NotFound("none lambda found by: org_name-account_name-file1")),
+	)
+	repo.LambdaRepo = mockLambRepo
+
+	for range 2 {
+		ctx := runtimeRequest(util.RuntimeToken("test-signing-key", testRuntimeClaims))
+
+		assert.True(t, ctx.IsAborted())
+		assert.Equal(t, errorx.UnauthorizedWithMsg("revoked runtime token"), ctx.Errors.Last().Err)
+	}
+}
+
+func TestRuntimeTokenLookupError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockLambRepo.EXPECT().FindRuntimeNonce(gomock.Any(), gomock.Any()).Return("", errors.New("connection refused"))
+	repo.LambdaRepo = mockLambRepo
+
+	ctx := runtimeRequest(util.RuntimeToken("test-signing-key", testRuntimeClaims))
+
+	assert.True(t, ctx.IsAborted())
+	assert.EqualError(t, ctx.Errors.Last().Err, "connection refused")
+}
+
+func TestRuntimeTokenInvalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo.LambdaRepo = testdata.NewMockLambda(ctrl)
+
+	// signed by the key of JWT instead of the one of the runtime
+	ctx := runtimeRequest(util.RuntimeToken("test-jwt-key", testRuntimeClaims))
+
+	assert.True(t, ctx.IsAborted())
+	assert.Equal(t, errorx.UnauthorizedWithMsg("invalid runtime token"), ctx.Errors.Last().Err)
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 
 	"github.com/57blocks/auto-action/server/internal/api/middleware"
+	"github.com/57blocks/auto-action/server/internal/service/kv"
 	"github.com/57blocks/auto-action/server/internal/service/lambda"
 	"github.com/57blocks/auto-action/server/internal/service/oauth"
+	"github.com/57blocks/auto-action/server/internal/service/secret"
 	"github.com/57blocks/auto-action/server/internal/service/wallet"
 
 	"github.com/gin-gonic/gin"
@@ -22,20 +24,88 @@ func RegisterHandlers(g *gin.Engine) http.Handler {
 
 	lambdaGroup := g.Group("/lambda", middleware.Authentication(), middleware.Authorization())
 	{
-		lambdaGroup.POST("", middleware.RegisterESLintCheck(), lambda.ResourceImpl.Register)
+		lambdaGroup.POST("", lambda.ResourceImpl.Register)
 		lambdaGroup.POST("/:lambda", lambda.ResourceImpl.Invoke)
+		lambdaGroup.PUT("/:lambda", lambda.ResourceImpl.Update)
 		lambdaGroup.GET("", lambda.ResourceImpl.List)
+		lambdaGroup.GET("/schedule/preview", lambda.ResourceImpl.PreviewSchedule)
+		lambdaGroup.GET("/executions/:id", lambda.ResourceImpl.Execution)
+		lambdaGroup.GET("/triggers", lambda.ResourceImpl.Triggers)
+		lambdaGroup.DELETE("/triggers/:id", lambda.ResourceImpl.RemoveTrigger)
+		lambdaGroup.GET("/webhooks", lambda.ResourceImpl.Webhooks)
 		lambdaGroup.GET("/:lambda", lambda.ResourceImpl.Info)
 		lambdaGroup.GET("/:lambda/logs", lambda.ResourceImpl.Logs)
+		lambdaGroup.GET("/:lambda/logs/query", lambda.ResourceImpl.LogsQuery)
+		lambdaGroup.GET("/:lambda/executions", lambda.ResourceImpl.Executions)
+		lambdaGroup.GET("/:lambda/executions/:id", lambda.ResourceImpl.Execution)
+		lambdaGroup.GET("/:lambda/failures", lambda.ResourceImpl.Failures)
+		lambdaGroup.POST("/:lambda/failures/redrive", lambda.ResourceImpl.Redrive)
+		lambdaGroup.GET("/:lambda/versions", lambda.ResourceImpl.Versions)
+		lambdaGroup.POST("/:lambda/rollback", lambda.ResourceImpl.Rollback)
+		lambdaGroup.PUT("/:lambda/schedule", lambda.ResourceImpl.SetSchedule)
+		lambdaGroup.DELETE("/:lambda/schedule", lambda.ResourceImpl.RemoveSchedule)
+		lambdaGroup.POST("/:lambda/schedule/pause", lambda.ResourceImpl.PauseSchedule)
+		lambdaGroup.POST("/:lambda/schedule/resume", lambda.ResourceImpl.ResumeSchedule)
+		lambdaGroup.PUT("/:lambda/chain", lambda.ResourceImpl.SetChain)
+		lambdaGroup.POST("/:lambda/triggers", lambda.ResourceImpl.AddTrigger)
+		lambdaGroup.POST("/:lambda/webhook", lambda.ResourceImpl.CreateWebhook)
+		lambdaGroup.POST("/:lambda/webhook/rotate", lambda.ResourceImpl.RotateWebhook)
+		lambdaGroup.DELETE("/:lambda/webhook", lambda.ResourceImpl.RemoveWebhook)
+		lambdaGroup.GET("/:lambda/env", lambda.ResourceImpl.Env)
+		lambdaGroup.PUT("/:lambda/env", lambda.ResourceImpl.SetEnv)
+		lambdaGroup.DELETE("/:lambda/env", lambda.ResourceImpl.UnsetEnv)
 		lambdaGroup.DELETE("/:lambda", lambda.ResourceImpl.Remove)
 	}
 
+	// the results of asynchronous invocations reported by the callback function
+	callbackGroup := g.Group("/callback", middleware.CallbackToken())
+	{
+		callbackGroup.POST("/execution", lambda.ResourceImpl.Callback)
+	}
+
+	// the inbound webhooks called by the external systems, verified by the secret of each webhook
+	hookGroup := g.Group("/hooks")
+	{
+		hookGroup.POST("/:hookID", lambda.ResourceImpl.ReceiveHook)
+	}
+
 	walletGroup := g.Group("/wallet", middleware.Authentication(), middleware.Authorization())
 	{
 		walletGroup.GET("", wallet.ResourceImpl.List)
 		walletGroup.POST("", wallet.ResourceImpl.Create)
+		walletGroup.GET("/funding", wallet.ResourceImpl.Funding)
+		walletGroup.PUT("/funding", wallet.ResourceImpl.SetFunding)
+		walletGroup.GET("/:address", wallet.ResourceImpl.Info)
 		walletGroup.DELETE("/:address", wallet.ResourceImpl.Remove)
 		walletGroup.POST("/:address", wallet.ResourceImpl.Verify)
+		walletGroup.POST("/:address/fund", wallet.ResourceImpl.Fund)
+		walletGroup.POST("/:address/activate", wallet.ResourceImpl.Activate)
+		walletGroup.POST("/:address/transactions", wallet.ResourceImpl.Submit)
+	}
+
+	kvGroup := g.Group("/kv", middleware.Authentication(), middleware.Authorization())
+	{
+		kvGroup.GET("", kv.ResourceImpl.List)
+		kvGroup.GET("/entry", kv.ResourceImpl.Get)
+		kvGroup.PUT("/entry", kv.ResourceImpl.Put)
+		kvGroup.DELETE("/entry", kv.ResourceImpl.Remove)
+	}
+
+	secretGroup := g.Group("/secret", middleware.Authentication(), middleware.Authorization())
+	{
+		secretGroup.GET("", secret.ResourceImpl.List)
+		secretGroup.PUT("/:name", secret.ResourceImpl.Set)
+		secretGroup.DELETE("/:name", secret.ResourceImpl.Remove)
+	}
+
+	// the runtime API called by the Lambdas, authenticated by the runtime token injected into their environment
+	runtimeGroup := g.Group("/runtime", middleware.RuntimeToken())
+	{
+		runtimeGroup.GET("/kv", kv.ResourceImpl.List)
+		runtimeGroup.GET("/kv/entry", kv.ResourceImpl.Get)
+		runtimeGroup.PUT("/kv/entry", kv.ResourceImpl.Put)
+		runtimeGroup.DELETE("/kv/entry", kv.ResourceImpl.Remove)
+		runtimeGroup.GET("/secrets", secret.ResourceImpl.Runtime)
 	}
 
 	return g
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -18,16 +19,19 @@ var (
 
 type (
 	Configuration struct {
-		Mode   string `mapstructure:"mode"`
-		Amazon `mapstructure:"aws"`
-		Bound  `mapstructure:"bound"`
-		Log    `mapstructure:"log"`
-		RSA    `mapstructure:"rsa"`
-		JWT    `mapstructure:"jwt"`
-		RDS    `mapstructure:"rds"`
-		CS     `mapstructure:"cs"`
-		Wallet `mapstructure:"wallet"`
-		Lambda `mapstructure:"lambda"`
+		Mode    string `mapstructure:"mode"`
+		Amazon  `mapstructure:"aws"`
+		Bound   `mapstructure:"bound"`
+		Log     `mapstructure:"log"`
+		RSA     `mapstructure:"rsa"`
+		JWT     `mapstructure:"jwt"`
+		RDS     `mapstructure:"rds"`
+		CS      `mapstructure:"cs"`
+		Wallet  `mapstructure:"wallet"`
+		Lambda  `mapstructure:"lambda"`
+		Soroban `mapstructure:"soroban"`
+		KV      `mapstructure:"kv"`
+		Runtime `mapstructure:"runtime"`
 	}
 
 	Bound struct {
@@ -79,14 +83,135 @@ type (
 	Wallet struct {
 		_   struct{}
 		Max int `mapstructure:"max"`
+		// CacheTTL how long the account states of the wallets fetched from Horizon are reused.
+		CacheTTL   time.Duration    `mapstructure:"cache_ttl"`
+		Friendbot  WalletFriendbot  `mapstructure:"friendbot"`
+		Activation WalletActivation `mapstructure:"activation"`
+	}
+
+	// WalletActivation the activation of the new wallets by the funding wallet designated by the organization,
+	// the starting balance in XLM is sent to each, and the activations of each organization are capped per day.
+	// The base reserves of the new wallets are sponsored by the funding wallet when Sponsored.
+	WalletActivation struct {
+		_               struct{}
+		StartingBalance string `mapstructure:"starting_balance"`
+		DailyCap        int    `mapstructure:"daily_cap"`
+		Sponsored       bool   `mapstructure:"sponsored"`
+	}
+
+	// WalletFriendbot the Friendbot funding the wallets off the mainnet, disabled when the URL is empty.
+	WalletFriendbot struct {
+		_            struct{}
+		URL          string        `mapstructure:"url"`
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		Timeout      time.Duration `mapstructure:"timeout"`
+	}
+
+	// Soroban the RPC polled for the events emitted by the contracts, which the contract event triggers watch.
+	Soroban struct {
+		_            struct{}
+		RPC          string        `mapstructure:"rpc"`
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+	}
+
+	// KV the limits of the key-value store of each account, the number of the keys and the bytes of each value.
+	KV struct {
+		_            struct{}
+		MaxKeys      int `mapstructure:"max_keys"`
+		MaxValueSize int `mapstructure:"max_value_size"`
+	}
+
+	// Runtime the key signing the runtime tokens injected into the Lambdas.
+	Runtime struct {
+		_          struct{}
+		SigningKey string `mapstructure:"signing_key"`
 	}
 
 	Lambda struct {
+		_             struct{}
+		Max           int `mapstructure:"max"`
+		LambdaCeiling `mapstructure:",squash"`
+		// Orgs the ceilings overridden per organization, keyed by the organization name.
+		Orgs       map[string]LambdaCeiling `mapstructure:"orgs"`
+		Callback   LambdaCallback           `mapstructure:"callback"`
+		Logs       LambdaLogs               `mapstructure:"logs"`
+		DeadLetter LambdaDeadLetter         `mapstructure:"dead_letter"`
+		Trigger    LambdaTrigger            `mapstructure:"trigger"`
+		Webhook    LambdaWebhook            `mapstructure:"webhook"`
+	}
+
+	// LambdaWebhook the inbound webhooks, the signed requests are rejected when the timestamp of them
+	// is out of the tolerance from now, which limits the replays of them to the tolerance.
+	LambdaWebhook struct {
+		_         struct{}
+		Tolerance time.Duration `mapstructure:"tolerance"`
+	}
+
+	// LambdaTrigger the streaming of the account activities watched by the triggers, the triggers are reconciled
+	// with the ones streaming by the interval, and the stream broken is restarted backing off up to the max.
+	LambdaTrigger struct {
+		_                 struct{}
+		ReconcileInterval time.Duration `mapstructure:"reconcile_interval"`
+		MaxBackoff        time.Duration `mapstructure:"max_backoff"`
+	}
+
+	// LambdaDeadLetter the SQS queue which the schedulers send the events failed to deliver to,
+	// drained by the server into the failures of each Lambda.
+	LambdaDeadLetter struct {
 		_   struct{}
-		Max int `mapstructure:"max"`
+		Arn string `mapstructure:"arn"`
+		URL string `mapstructure:"url"`
+	}
+
+	// LambdaLogs the polling of the live logs, the interval is doubled up to the max backoff
+	// while none new log events found, and reset once found.
+	LambdaLogs struct {
+		_            struct{}
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		MaxBackoff   time.Duration `mapstructure:"max_backoff"`
+	}
+
+	// LambdaCallback the function set as the destination of asynchronous invocations,
+	// which reports the results back to the server along with the token.
+	LambdaCallback struct {
+		_     struct{}
+		Arn   string `mapstructure:"arn"`
+		Token string `mapstructure:"token"`
+	}
+
+	// LambdaCeiling the upper limits of the resources configured for each Lambda,
+	// timeout in seconds, memory and ephemeral storage in MB.
+	LambdaCeiling struct {
+		_                   struct{}
+		MaxTimeout          int32 `mapstructure:"max_timeout"`
+		MaxMemory           int32 `mapstructure:"max_memory"`
+		MaxEphemeralStorage int32 `mapstructure:"max_ephemeral_storage"`
 	}
 )
 
+// Ceiling returns the resource ceilings of the organization,
+// the ones not overridden by the organization fall back to the global ones.
+func (l Lambda) Ceiling(org string) LambdaCeiling {
+	ceiling := l.LambdaCeiling
+
+	override, ok := l.Orgs[org]
+	if !ok {
+		return ceiling
+	}
+
+	if override.MaxTimeout > 0 {
+		ceiling.MaxTimeout = override.MaxTimeout
+	}
+	if override.MaxMemory > 0 {
+		ceiling.MaxMemory = override.MaxMemory
+	}
+	if override.MaxEphemeralStorage > 0 {
+		ceiling.MaxEphemeralStorage = override.MaxEphemeralStorage
+	}
+
+	return ceiling
+}
+
 func Setup(cfgPath string) error {
 	cfgLogger := slog.Default()
 	slog.SetLogLoggerLevel(slog.LevelDebug)
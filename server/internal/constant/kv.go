@@ -0,0 +1,20 @@
+package constant
+
+// The limits of the key-value store applied when none configured,
+// the number of the keys of each account and the bytes of each value.
+const (
+	KVDefaultMaxKeys      = 1000
+	KVDefaultMaxValueSize = 64 * 1024
+)
+
+// KVKeyMax the bytes of each key of the key-value store.
+const KVKeyMax = 512
+
+// The number of the entries listed by each query of the key-value store, by default and at most.
+const (
+	KVListLimit    = 100
+	KVListLimitMax = 1000
+)
+
+// RuntimeTokenScope the scope of the runtime tokens, keeps them apart from the other HMACs keyed by the same key.
+const RuntimeTokenScope = "auto-action-runtime"
@@ -0,0 +1,163 @@
+package constant
+
+// LambdaAlias the alias managed for each published Lambda,
+// schedulers and invocations go through it, so a rollback only needs to repoint it.
+const LambdaAlias = "live"
+
+// LambdaUnpublished the version of Lambda which has never been published.
+const LambdaUnpublished = "$LATEST"
+
+// Platform environment variables injected into each Lambda,
+// the prefix is reserved, so that the user variables never override them.
+const (
+	LambdaEnvPrefix   = "AA_"
+	LambdaEnvRegion   = "AA_AWS_REGION"
+	LambdaEnvOrg      = "AA_ORGANIZATION"
+	LambdaEnvAccount  = "AA_ACCOUNT"
+	LambdaEnvNetwork  = "AA_NETWORK"
+	LambdaEnvEndpoint = "AA_ENDPOINT"
+
+	// LambdaEnvRuntimeToken the token of the runtime API, by which the Lambda reaches the key-value store,
+	// and the secrets granted to it.
+	LambdaEnvRuntimeToken = "AA_RUNTIME_TOKEN"
+
+	// LambdaEnvLegacyRegion the region variable of the Lambdas registered before the platform variables.
+	LambdaEnvLegacyRegion = "ENV_AWS_REGION"
+)
+
+// The resources of Lambda, timeout in seconds, memory and ephemeral storage in MB.
+// The defaults are applied when registering without specifying them,
+// the minimums are the same as AWS, and the maximums are limited by the ceilings in config.
+const (
+	LambdaDefaultTimeout          int32 = 30
+	LambdaDefaultMemory           int32 = 128
+	LambdaDefaultEphemeralStorage int32 = 512
+
+	LambdaMinTimeout          int32 = 1
+	LambdaMinMemory           int32 = 128
+	LambdaMinEphemeralStorage int32 = 512
+)
+
+// The runtimes allowed for Lambda, each of them has its own validator for the bundles.
+const (
+	LambdaRuntimeNodejs20  = "nodejs20.x"
+	LambdaRuntimeNodejs22  = "nodejs22.x"
+	LambdaRuntimePython312 = "python3.12"
+
+	// LambdaDefaultRuntime the runtime applied when registering without specifying one.
+	LambdaDefaultRuntime = LambdaRuntimeNodejs20
+)
+
+// LambdaRuntimes the allowlist of runtimes, in the order shown to users.
+var LambdaRuntimes = []string{LambdaRuntimeNodejs20, LambdaRuntimeNodejs22, LambdaRuntimePython312}
+
+// The status of the executions of asynchronous invocations.
+const (
+	ExecutionPending   = "pending"
+	ExecutionSucceeded = "succeeded"
+	ExecutionFailed    = "failed"
+)
+
+// The modes of the invocations recorded as executions.
+const (
+	ExecutionSync  = "sync"
+	ExecutionAsync = "async"
+)
+
+// ExecutionLogTail the number of log lines kept for each execution.
+const ExecutionLogTail = 50
+
+// ExecutionResultMax the bytes of the result kept for each execution, the rest is truncated.
+const ExecutionResultMax = 4096
+
+// The number of log events returned by each query of the logs, the max is the same as CloudWatch.
+const (
+	LogsQueryLimit    = 100
+	LogsQueryLimitMax = 10000
+)
+
+// The types of the frames of the live logs.
+const (
+	LogFrameEvent = "event"
+	LogFrameError = "error"
+)
+
+// The page size of execution history.
+const (
+	ExecutionPageSize    = 20
+	ExecutionPageSizeMax = 100
+)
+
+// The retry policy of the schedulers, the defaults and the limits are the same as EventBridge Scheduler,
+// the maximum event age in seconds.
+const (
+	SchedulerMaxRetryAttempts int32 = 185
+	SchedulerMinEventAge      int32 = 60
+	SchedulerMaxEventAge      int32 = 86400
+)
+
+// The messages received from the dead-letter queue each time, and the times of receiving at most in one drain.
+const (
+	DeadLetterBatch    int32 = 10
+	DeadLetterReceives       = 10
+)
+
+// The page size of dead-lettered failures.
+const (
+	FailurePageSize    = 20
+	FailurePageSizeMax = 100
+)
+
+// The conditions of the links in a chain, on which the downstream Lambda is invoked.
+const (
+	ChainOnSuccess = "success"
+	ChainOnFailure = "failure"
+)
+
+// The types of the account activities on Stellar which trigger the Lambda, streamed from Horizon.
+const (
+	TriggerPayment   = "payment"
+	TriggerOperation = "operation"
+	TriggerEffect    = "effect"
+)
+
+// TriggerContractEvent the type of the contract events on Soroban which trigger the Lambda, polled from the RPC.
+const TriggerContractEvent = "contract_event"
+
+// The limits of the topic filters of the contract event triggers, which are the ones of the Soroban RPC.
+const (
+	TriggerTopicsMax   = 5
+	TriggerSegmentsMax = 4
+)
+
+// TriggerEventsPage the size of the page of the contract events polled.
+const TriggerEventsPage = 100
+
+// TriggerCursorNow the cursor of the trigger none records handled yet, streamed from the time it starts.
+const TriggerCursorNow = "now"
+
+// TriggerInvoker the invoker of the executions started by the triggers.
+const TriggerInvoker = "trigger"
+
+// The headers of the requests of the inbound webhooks, the signature is the HMAC-SHA256 in hex of
+// the timestamp in unix seconds and the body joined by a dot, keyed by the secret of the webhook.
+const (
+	WebhookTimestampHeader = "X-AutoAction-Timestamp"
+	WebhookSignatureHeader = "X-AutoAction-Signature"
+	WebhookSignaturePrefix = "sha256="
+)
+
+// WebhookSecretPrefix the prefix of the secrets of the inbound webhooks.
+const WebhookSecretPrefix = "whsec_"
+
+// WebhookBodyMax the bytes of the body of the inbound webhooks accepted, the same as the event of Lambda.
+const WebhookBodyMax = 256 * 1024
+
+// WebhookHeadersMax the number of the headers picked into the event by each webhook.
+const WebhookHeadersMax = 10
+
+// WebhookInvoker the invoker of the executions started by the inbound webhooks.
+const WebhookInvoker = "webhook"
+
+// CallbackTokenHeader the header carries the token of the callback function.
+const CallbackTokenHeader = "X-Callback-Token"
@@ -17,6 +17,9 @@ const (
 	ClaimRaw OAuthCtxKey = "claim_raw"
 	ClaimSub OAuthCtxKey = "claim_sub"
 	ClaimIss OAuthCtxKey = "claim_iss"
+
+	// RuntimeFunction the function calling the runtime API, by the runtime token of it.
+	RuntimeFunction OAuthCtxKey = "runtime_function"
 )
 
 func (o OAuthCtxKey) Str() string {
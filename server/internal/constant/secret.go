@@ -0,0 +1,11 @@
+package constant
+
+// SecretMax the number of the secrets of each account.
+const SecretMax = 100
+
+// SecretValueMax the bytes of the value of each secret, decrypted.
+const SecretValueMax = 4 * 1024
+
+// SecretChunkSeparator joins the chunks of the value encrypted, each of them is encrypted by the RSA public key
+// of the server separately, as the size of the plaintext of RSA-OAEP is limited by the key.
+const SecretChunkSeparator = "."
@@ -6,3 +6,7 @@ const (
 	StellarNetworkTypeTestNet StellarNetworkType = "Horizon-Testnet"
 	StellarNetworkTypeMainNet StellarNetworkType = "Horizon"
 )
+
+// StellarBaseReserve the base reserve of the network in stroops, the minimum balance of an account is
+// (2 + subentries + sponsoring - sponsored) times of it.
+const StellarBaseReserve int64 = 5_000_000
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "lambda_version";
\ No newline at end of file
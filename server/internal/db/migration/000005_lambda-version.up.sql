@@ -0,0 +1,20 @@
+BEGIN;
+
+-- published versions of lambda
+DROP TABLE IF EXISTS "lambda_version";
+
+CREATE TABLE "lambda_version" (
+    "id" serial PRIMARY KEY,
+    "lambda_id" int4 NOT NULL,
+    "version" varchar NOT NULL,
+    "code_sha256" varchar NOT NULL,
+    "revision_id" varchar NOT NULL,
+    "uploader" varchar NOT NULL,
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    UNIQUE ("lambda_id", "version")
+);
+
+CREATE INDEX ON "lambda_version" ("lambda_id");
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "lambda_scheduler" DROP COLUMN IF EXISTS "state";
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- state of the scheduler, ENABLED or DISABLED
+ALTER TABLE "lambda_scheduler" ADD COLUMN "state" varchar NOT NULL DEFAULT 'ENABLED';
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "lambda" DROP COLUMN IF EXISTS "environment";
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- non-secret environment variables set by the user, the platform variables are injected on the fly
+ALTER TABLE "lambda" ADD COLUMN "environment" jsonb NOT NULL DEFAULT '{}';
+
+COMMIT;
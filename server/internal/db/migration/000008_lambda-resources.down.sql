@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "lambda" DROP COLUMN IF EXISTS "ephemeral_storage";
+ALTER TABLE "lambda" DROP COLUMN IF EXISTS "memory_size";
+ALTER TABLE "lambda" ALTER COLUMN "timeout" TYPE int2;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- int2 can't hold the timeout of Lambda, which is up to 900 seconds
+ALTER TABLE "lambda" ALTER COLUMN "timeout" TYPE int4;
+-- memory size and ephemeral storage of Lambda in MB, the defaults are the same as AWS
+ALTER TABLE "lambda" ADD COLUMN "memory_size" int4 NOT NULL DEFAULT 128;
+ALTER TABLE "lambda" ADD COLUMN "ephemeral_storage" int4 NOT NULL DEFAULT 512;
+
+COMMIT;
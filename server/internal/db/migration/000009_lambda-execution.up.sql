@@ -0,0 +1,25 @@
+BEGIN;
+
+-- executions of the asynchronous invocations of lambda, keyed by the request id of Lambda
+DROP TABLE IF EXISTS "lambda_execution";
+
+CREATE TABLE "lambda_execution" (
+    "id" serial PRIMARY KEY,
+    "lambda_id" int4 NOT NULL,
+    "execution_id" varchar NOT NULL UNIQUE,
+    "invoker" varchar NOT NULL DEFAULT '',
+    "payload" text NOT NULL DEFAULT '',
+    -- pending/succeeded/failed
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "executed_version" varchar NOT NULL DEFAULT '',
+    "result" text NOT NULL DEFAULT '',
+    "error" text NOT NULL DEFAULT '',
+    "log_tail" text NOT NULL DEFAULT '',
+    "finished_at" timestamptz,
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL
+);
+
+CREATE INDEX ON "lambda_execution" ("lambda_id");
+
+COMMIT;
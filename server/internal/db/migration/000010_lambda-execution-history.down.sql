@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS "lambda_execution_lambda_id_created_at_idx";
+ALTER TABLE "lambda_execution" DROP COLUMN IF EXISTS "duration";
+ALTER TABLE "lambda_execution" DROP COLUMN IF EXISTS "status_code";
+ALTER TABLE "lambda_execution" DROP COLUMN IF EXISTS "mode";
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+-- the synchronous invocations are recorded as well, the existing ones are all asynchronous
+ALTER TABLE "lambda_execution" ADD COLUMN "mode" varchar NOT NULL DEFAULT 'async';
+ALTER TABLE "lambda_execution" ADD COLUMN "status_code" int4 NOT NULL DEFAULT 0;
+-- the duration reported by Lambda in milliseconds
+ALTER TABLE "lambda_execution" ADD COLUMN "duration" float8 NOT NULL DEFAULT 0;
+
+CREATE INDEX ON "lambda_execution" ("lambda_id", "created_at" DESC);
+
+COMMIT;
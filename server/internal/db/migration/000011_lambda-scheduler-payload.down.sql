@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "lambda_scheduler" DROP COLUMN IF EXISTS "payload";
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- payload of the events sent by the scheduler, as given by the user, diffed by the manifest of the CLI
+ALTER TABLE "lambda_scheduler" ADD COLUMN "payload" text NOT NULL DEFAULT '';
+
+COMMIT;
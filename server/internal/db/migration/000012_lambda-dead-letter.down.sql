@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "lambda_failure";
+ALTER TABLE "lambda_scheduler" DROP COLUMN IF EXISTS "max_event_age";
+ALTER TABLE "lambda_scheduler" DROP COLUMN IF EXISTS "max_retry_attempts";
+
+COMMIT;
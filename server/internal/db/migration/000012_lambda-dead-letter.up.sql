@@ -0,0 +1,28 @@
+BEGIN;
+
+-- the retry policy of the scheduler, the defaults are the same as EventBridge Scheduler, the event age in seconds
+ALTER TABLE "lambda_scheduler" ADD COLUMN "max_retry_attempts" int4 NOT NULL DEFAULT 185;
+ALTER TABLE "lambda_scheduler" ADD COLUMN "max_event_age" int4 NOT NULL DEFAULT 86400;
+
+-- events the scheduler failed to deliver, drained from the dead-letter queue, keyed by the id of the message
+DROP TABLE IF EXISTS "lambda_failure";
+
+CREATE TABLE "lambda_failure" (
+    "id" serial PRIMARY KEY,
+    "lambda_id" int4 NOT NULL,
+    "message_id" varchar NOT NULL UNIQUE,
+    "payload" text NOT NULL DEFAULT '',
+    "error_code" varchar NOT NULL DEFAULT '',
+    "error_message" text NOT NULL DEFAULT '',
+    "retry_attempts" int4 NOT NULL DEFAULT 0,
+    "failed_at" timestamptz NOT NULL,
+    -- the execution started by redriving the event
+    "redrive_execution_id" varchar NOT NULL DEFAULT '',
+    "redriven_at" timestamptz,
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL
+);
+
+CREATE INDEX ON "lambda_failure" ("lambda_id", "failed_at" DESC);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE "lambda_execution" DROP COLUMN IF EXISTS "chain_id";
+
+ALTER TABLE "lambda" DROP COLUMN IF EXISTS "on_failure";
+ALTER TABLE "lambda" DROP COLUMN IF EXISTS "on_success";
+
+COMMIT;
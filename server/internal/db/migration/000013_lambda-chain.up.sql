@@ -0,0 +1,12 @@
+BEGIN;
+
+-- the downstream lambdas invoked with the output once the lambda succeeded or failed, by the id of lambda
+ALTER TABLE "lambda" ADD COLUMN "on_success" int4;
+ALTER TABLE "lambda" ADD COLUMN "on_failure" int4;
+
+-- the executions in a chain share the id, which is the execution id of the first one
+ALTER TABLE "lambda_execution" ADD COLUMN "chain_id" varchar NOT NULL DEFAULT '';
+
+CREATE INDEX ON "lambda_execution" ("chain_id") WHERE "chain_id" <> '';
+
+COMMIT;
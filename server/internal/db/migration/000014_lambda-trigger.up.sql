@@ -0,0 +1,21 @@
+BEGIN;
+
+-- the account activities on Stellar watched by streaming from Horizon, which invoke the lambda per record,
+-- the type is one of payment, operation and effect, the address is the watched account
+DROP TABLE IF EXISTS "lambda_trigger";
+
+CREATE TABLE "lambda_trigger" (
+    "id" serial PRIMARY KEY,
+    "lambda_id" int4 NOT NULL,
+    "type" varchar NOT NULL,
+    "address" varchar NOT NULL,
+    -- the paging token of the last record handled, streamed from by the next start, 'now' for none yet
+    "cursor" varchar NOT NULL DEFAULT 'now',
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    UNIQUE ("lambda_id", "type", "address")
+);
+
+CREATE INDEX ON "lambda_trigger" ("address");
+
+COMMIT;
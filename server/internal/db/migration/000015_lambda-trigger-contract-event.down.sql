@@ -0,0 +1,12 @@
+BEGIN;
+
+DELETE FROM "lambda_trigger" WHERE "type" = 'contract_event';
+
+ALTER TABLE "lambda_trigger" DROP CONSTRAINT IF EXISTS "lambda_trigger_lambda_id_type_address_topics_key";
+ALTER TABLE "lambda_trigger" ADD CONSTRAINT "lambda_trigger_lambda_id_type_address_key"
+    UNIQUE ("lambda_id", "type", "address");
+
+ALTER TABLE "lambda_trigger" DROP COLUMN IF EXISTS "ledger";
+ALTER TABLE "lambda_trigger" DROP COLUMN IF EXISTS "topics";
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- the contract events on Soroban polled from the RPC, the address is the contract ID of them,
+-- the topics are the filters of the segments in base64 xdr, in JSON, '[]' for none
+ALTER TABLE "lambda_trigger" ADD COLUMN "topics" text NOT NULL DEFAULT '[]';
+-- the ledger polled from by the next start, the events up to the cursor in it are skipped, 0 for none yet
+ALTER TABLE "lambda_trigger" ADD COLUMN "ledger" int8 NOT NULL DEFAULT 0;
+
+ALTER TABLE "lambda_trigger" DROP CONSTRAINT IF EXISTS "lambda_trigger_lambda_id_type_address_key";
+ALTER TABLE "lambda_trigger" ADD CONSTRAINT "lambda_trigger_lambda_id_type_address_topics_key"
+    UNIQUE ("lambda_id", "type", "address", "topics");
+
+COMMIT;
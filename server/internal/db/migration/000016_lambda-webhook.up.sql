@@ -0,0 +1,18 @@
+BEGIN;
+
+-- the inbound webhooks invoking the lambda by the external systems without the user token, one for each lambda,
+-- the hook_id is the public path of it, the requests are verified by the secret, either signed or as the bearer,
+-- the headers are the ones picked into the event payload along with the body, in JSON
+DROP TABLE IF EXISTS "lambda_webhook";
+
+CREATE TABLE "lambda_webhook" (
+    "id" serial PRIMARY KEY,
+    "lambda_id" int4 NOT NULL UNIQUE,
+    "hook_id" varchar NOT NULL UNIQUE,
+    "secret" varchar NOT NULL,
+    "headers" text NOT NULL DEFAULT '[]',
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+-- the key-value store persisting the data across the runs of the lambdas, namespaced by the account,
+-- and by the lambda when the lambda_id is not 0, shared by all the lambdas of the account otherwise.
+-- the version is increased by each put, by which the entry is compared and swapped,
+-- the entry expired is treated as absent, and the one with null expires_at never expires
+DROP TABLE IF EXISTS "kv_entry";
+
+CREATE TABLE "kv_entry" (
+    "id" serial PRIMARY KEY,
+    "account_id" int4 NOT NULL,
+    "lambda_id" int4 NOT NULL DEFAULT 0,
+    "key" varchar NOT NULL,
+    "value" text NOT NULL,
+    "version" int8 NOT NULL DEFAULT 1,
+    "expires_at" timestamptz,
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    UNIQUE ("account_id", "lambda_id", "key")
+);
+
+CREATE INDEX ON "kv_entry" ("expires_at");
+
+COMMIT;
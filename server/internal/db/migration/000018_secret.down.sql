@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "lambda_secret";
+DROP TABLE IF EXISTS "secret";
+
+COMMIT;
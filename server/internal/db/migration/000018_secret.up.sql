@@ -0,0 +1,29 @@
+BEGIN;
+
+-- the secrets of the account, the value is encrypted by the RSA public key of the server as the CLI sends,
+-- in the base64 chunks joined by dots, and decrypted only when the lambdas granted read them at runtime
+DROP TABLE IF EXISTS "secret";
+
+CREATE TABLE "secret" (
+    "id" serial PRIMARY KEY,
+    "account_id" int4 NOT NULL,
+    "name" varchar NOT NULL,
+    "value" text NOT NULL,
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    UNIQUE ("account_id", "name")
+);
+
+-- the secrets granted to each lambda
+DROP TABLE IF EXISTS "lambda_secret";
+
+CREATE TABLE "lambda_secret" (
+    "id" serial PRIMARY KEY,
+    "lambda_id" int4 NOT NULL,
+    "secret_id" int4 NOT NULL,
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    UNIQUE ("lambda_id", "secret_id")
+);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "wallet_activation";
+ALTER TABLE "organization" DROP COLUMN IF EXISTS "funding_wallet";
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+-- the wallet designated by the organization to activate the new wallets of its accounts,
+-- the address of a CubeSigner key, none designated when empty
+ALTER TABLE "organization" ADD COLUMN IF NOT EXISTS "funding_wallet" varchar NOT NULL DEFAULT '';
+
+-- the wallets activated by the funding wallets, counted per organization per day against the cap
+DROP TABLE IF EXISTS "wallet_activation";
+
+CREATE TABLE "wallet_activation" (
+    "id" serial PRIMARY KEY,
+    "organization_id" int4 NOT NULL,
+    "account_id" int4 NOT NULL,
+    "address" varchar NOT NULL,
+    "funder" varchar NOT NULL,
+    "hash" varchar NOT NULL,
+    "starting_balance" varchar NOT NULL,
+    "sponsored" bool NOT NULL DEFAULT false,
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    "updated_at" timestamptz DEFAULT CURRENT_TIMESTAMP(2) NOT NULL,
+    UNIQUE ("address")
+);
+
+CREATE INDEX ON "wallet_activation" ("organization_id", "created_at");
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE "lambda_version" DROP COLUMN IF EXISTS "runtime";
+ALTER TABLE "lambda_version" DROP COLUMN IF EXISTS "handler";
+ALTER TABLE "lambda_version" DROP COLUMN IF EXISTS "timeout";
+ALTER TABLE "lambda_version" DROP COLUMN IF EXISTS "memory_size";
+ALTER TABLE "lambda_version" DROP COLUMN IF EXISTS "ephemeral_storage";
+ALTER TABLE "lambda_version" DROP COLUMN IF EXISTS "environment";
+
+COMMIT;
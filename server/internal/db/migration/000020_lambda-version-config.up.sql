@@ -0,0 +1,11 @@
+BEGIN;
+
+-- configuration of each published version, restored on rollback, empty for the ones recorded before
+ALTER TABLE "lambda_version" ADD COLUMN "runtime" varchar NOT NULL DEFAULT '';
+ALTER TABLE "lambda_version" ADD COLUMN "handler" varchar NOT NULL DEFAULT '';
+ALTER TABLE "lambda_version" ADD COLUMN "timeout" int4 NOT NULL DEFAULT 0;
+ALTER TABLE "lambda_version" ADD COLUMN "memory_size" int4 NOT NULL DEFAULT 0;
+ALTER TABLE "lambda_version" ADD COLUMN "ephemeral_storage" int4 NOT NULL DEFAULT 0;
+ALTER TABLE "lambda_version" ADD COLUMN "environment" jsonb NOT NULL DEFAULT '{}';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE "lambda_version" DROP COLUMN IF EXISTS "runtime_nonce";
+ALTER TABLE "lambda" DROP COLUMN IF EXISTS "runtime_nonce";
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- nonce of the runtime token, rotated by each published version
+ALTER TABLE "lambda" ADD COLUMN "runtime_nonce" varchar NOT NULL DEFAULT '';
+ALTER TABLE "lambda_version" ADD COLUMN "runtime_nonce" varchar NOT NULL DEFAULT '';
+
+COMMIT;
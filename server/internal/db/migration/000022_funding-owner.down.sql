@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE "organization" DROP COLUMN IF EXISTS "funding_account_id";
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- account designated the funding wallet, the only one allowed to replace or clear it
+ALTER TABLE "organization" ADD COLUMN "funding_account_id" int4 NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -12,4 +12,8 @@ type (
 		Name   string `json:"name"`
 		RoleId string `json:"role_id"`
 	}
+
+	RespSignCsBlob struct {
+		Signature string `json:"signature"`
+	}
 )
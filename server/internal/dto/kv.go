@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+type (
+	// ReqKVEntry the entry of the key-value store, of the account, or of the action when given.
+	// The entry is removed only when the version is still the one given, if any.
+	ReqKVEntry struct {
+		Key     string `form:"key" json:"key"`
+		Action  string `form:"action" json:"action"`
+		Version *int64 `form:"version" json:"version"`
+	}
+
+	// ReqKVPut puts the value of the entry, expired after the TTL in seconds when given.
+	// The value is put only when the version is still the one given, if any, 0 for the entry absent.
+	ReqKVPut struct {
+		Key     string `json:"key"`
+		Value   string `json:"value"`
+		Action  string `json:"action"`
+		TTL     int64  `json:"ttl"`
+		Version *int64 `json:"version"`
+	}
+
+	ReqKVList struct {
+		Prefix string `form:"prefix"`
+		Action string `form:"action"`
+		Limit  int    `form:"limit"`
+	}
+
+	RespKVEntry struct {
+		Key       string     `json:"key"`
+		Value     string     `json:"value,omitempty"`
+		Version   int64      `json:"version"`
+		ExpiresAt *time.Time `json:"expires_at"`
+		CreatedAt *time.Time `json:"created_at"`
+		UpdatedAt *time.Time `json:"updated_at"`
+	}
+
+	// RespKVEntries the entries listed by the key order, without the values,
+	// truncated when there are more than the limit.
+	RespKVEntries struct {
+		Entries   []*RespKVEntry `json:"entries"`
+		Truncated bool           `json:"truncated"`
+	}
+)
@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"encoding/json"
+	"net/http"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
@@ -21,6 +23,7 @@ type (
 		_            struct{}
 		FunctionName string     `json:"function_name,omitempty"`
 		FunctionArn  string     `json:"function_arn,omitempty"`
+		Runtime      string     `json:"runtime,omitempty"`
 		Description  string     `json:"description,omitempty"`
 		CreatedAt    *time.Time `json:"created_at,omitempty"`
 	}
@@ -29,21 +32,28 @@ type (
 // Info
 type (
 	RespInfo struct {
-		_            struct{}
-		ID           uint64     `json:"-"`
-		AccountId    uint64     `json:"-"`
-		FunctionName string     `json:"function_name"`
-		FunctionArn  string     `json:"function_arn"`
-		Runtime      string     `json:"runtime"`
-		Role         string     `json:"role"`
-		Handler      string     `json:"handler"`
-		Description  string     `json:"description"`
-		CodeSHA256   string     `json:"code_sha256"`
-		Version      string     `json:"version"`
-		RevisionID   string     `json:"revision_id"`
-		Scheduler    Scheduler  `json:"scheduler" gorm:"foreignKey:lambda_id"`
-		CreatedAt    *time.Time `json:"created_at"`
-		UpdatedAt    *time.Time `json:"updated_at"`
+		_                struct{}
+		ID               uint64     `json:"-"`
+		AccountId        uint64     `json:"-"`
+		FunctionName     string     `json:"function_name"`
+		FunctionArn      string     `json:"function_arn"`
+		Runtime          string     `json:"runtime"`
+		Timeout          int32      `json:"timeout"`
+		MemorySize       int32      `json:"memory_size"`
+		EphemeralStorage int32      `json:"ephemeral_storage"`
+		Role             string     `json:"role"`
+		Handler          string     `json:"handler"`
+		Description      string     `json:"description"`
+		CodeSHA256       string     `json:"code_sha256"`
+		Version          string     `json:"version"`
+		RevisionID       string     `json:"revision_id"`
+		Environment      Env        `json:"environment,omitempty" gorm:"serializer:json"`
+		Scheduler        Scheduler  `json:"scheduler" gorm:"foreignKey:lambda_id"`
+		OnSuccess        *uint64    `json:"-"`
+		OnFailure        *uint64    `json:"-"`
+		Chain            *Chain     `json:"chain,omitempty" gorm:"-"`
+		CreatedAt        *time.Time `json:"created_at"`
+		UpdatedAt        *time.Time `json:"updated_at"`
 	}
 
 	Scheduler struct {
@@ -51,6 +61,11 @@ type (
 		ScheduleName string `json:"schedule_name,omitempty"`
 		ScheduleArn  string `json:"schedule_arn,omitempty"`
 		Expression   string `json:"expression,omitempty"`
+		State        string `json:"state,omitempty"`
+		Payload      string `json:"payload,omitempty"`
+		// MaxEventAge in seconds
+		MaxRetryAttempts *int32 `json:"max_retry_attempts,omitempty"`
+		MaxEventAge      int32  `json:"max_event_age,omitempty"`
 	}
 )
 
@@ -59,6 +74,8 @@ type (
 	ReqInvoke struct {
 		Lambda  string `uri:"lambda"`
 		Payload string `json:"payload"`
+		// Async invokes the Lambda asynchronously, the result is fetched by the execution ID returned.
+		Async bool `json:"async"`
 	}
 
 	RespInvoke struct {
@@ -83,6 +100,9 @@ type (
 
 		// Metadata pertaining to the operation's result.
 		ResultMetadata middleware.Metadata
+
+		// The ID of the execution of the asynchronous invocation, used to fetch the result.
+		ExecutionID string `json:",omitempty"`
 	}
 	RespInvokeOpt func(respTrigger *RespInvoke)
 )
@@ -114,8 +134,28 @@ type (
 		_          struct{}
 		Expression string
 		Payload    string
+		Runtime    string
+		Env        Env
+		Resources  Resources
+		Retry      RetryPolicy
+		Triggers   []*ReqTrigger
 		Files      []*ReqFile
 	}
+	// RetryPolicy the retry policy of the scheduler, the maximum event age in seconds,
+	// nil means not specified.
+	RetryPolicy struct {
+		_                struct{}
+		MaxRetryAttempts *int32 `json:"max_retry_attempts"`
+		MaxEventAge      *int32 `json:"max_event_age"`
+	}
+	// Resources the resources of Lambda, timeout in seconds, memory and ephemeral storage in MB,
+	// zero means not specified.
+	Resources struct {
+		_                struct{}
+		Timeout          int32
+		MemorySize       int32
+		EphemeralStorage int32
+	}
 	ReqFile struct {
 		_     struct{}
 		Name  string
@@ -124,24 +164,384 @@ type (
 
 	RespRegister struct {
 		_         struct{}
-		Lambda    *RespLamBrief `json:"lambda"`
-		Scheduler *RespSchBrief `json:"scheduler"`
+		Lambda    *RespLamBrief  `json:"lambda"`
+		Scheduler *RespSchBrief  `json:"scheduler"`
+		Triggers  []*RespTrigger `json:"triggers,omitempty"`
 	}
 
 	RespLamBrief struct {
-		_         struct{}
-		AccountId uint64 `json:"account_id,omitempty"`
-		Name      string `json:"function_name,omitempty"`
-		Arn       string `json:"function_arn,omitempty"`
-		Runtime   string `json:"runtime,omitempty"`
-		Handler   string `json:"handler,omitempty"`
-		Version   string `json:"version,omitempty"`
+		_          struct{}
+		AccountId  uint64 `json:"account_id,omitempty"`
+		Name       string `json:"function_name,omitempty"`
+		Arn        string `json:"function_arn,omitempty"`
+		Runtime    string `json:"runtime,omitempty"`
+		Handler    string `json:"handler,omitempty"`
+		Version    string `json:"version,omitempty"`
+		CodeSHA256 string `json:"code_sha256,omitempty"`
+		RevisionID string `json:"revision_id,omitempty"`
 	}
 	RespSchBrief struct {
 		_              struct{}
 		Arn            string `json:"schedule_arn,omitempty"`
 		Name           string `json:"schedule_name,omitempty"`
 		BoundLambdaArn string `json:"bound_lambda_arn,omitempty"`
+		Expression     string `json:"expression,omitempty"`
+		State          string `json:"state,omitempty"`
+		// MaxEventAge in seconds
+		MaxRetryAttempts *int32 `json:"max_retry_attempts,omitempty"`
+		MaxEventAge      int32  `json:"max_event_age,omitempty"`
+	}
+)
+
+// Schedule related
+type (
+	ReqSchedule struct {
+		Lambda     string `uri:"lambda"`
+		Expression string `json:"expression"`
+		Payload    string `json:"payload"`
+		RetryPolicy
+	}
+
+	ReqPreview struct {
+		Expression string `form:"expression"`
+		Count      int    `form:"count"`
+		Timezone   string `form:"timezone"`
+	}
+
+	RespPreview struct {
+		_          struct{}
+		Expression string      `json:"expression"`
+		Timezone   string      `json:"timezone"`
+		FireTimes  []time.Time `json:"fire_times"`
+	}
+)
+
+// Logs related
+type (
+	// ReqLogsQuery the query of the historical logs across all the log streams of the Lambda.
+	ReqLogsQuery struct {
+		_      struct{}
+		Lambda string    `uri:"lambda"`
+		Start  time.Time `form:"start"`
+		End    time.Time `form:"end"`
+		// Filter the filter pattern of CloudWatch Logs, e.g. ERROR, "?ERROR ?WARN"
+		Filter    string `form:"filter"`
+		Limit     int32  `form:"limit"`
+		NextToken string `form:"next_token"`
+	}
+
+	RespLogsQuery struct {
+		_      struct{}
+		Events []*RespLogEvent `json:"events"`
+		// NextToken continues the query when it's not empty.
+		NextToken string `json:"next_token,omitempty"`
+	}
+
+	RespLogEvent struct {
+		_         struct{}
+		Timestamp time.Time `json:"timestamp"`
+		Stream    string    `json:"stream"`
+		Message   string    `json:"message"`
+	}
+)
+
+// LogFrame the frame sent through the websocket of the live logs.
+type LogFrame struct {
+	_ struct{}
+	// Type event or error, the error ones carry the message only.
+	Type          string     `json:"type"`
+	Timestamp     *time.Time `json:"timestamp,omitempty"`
+	IngestionTime *time.Time `json:"ingestion_time,omitempty"`
+	Stream        string     `json:"stream,omitempty"`
+	Message       string     `json:"message"`
+}
+
+// Execution related
+type (
+	ReqExecution struct {
+		Lambda string `uri:"lambda"`
+		ID     string `uri:"id"`
+	}
+
+	// ReqExecutions the query of the execution history of the Lambda, paginated from the latest.
+	ReqExecutions struct {
+		_      struct{}
+		Lambda string    `uri:"lambda"`
+		Status string    `form:"status"`
+		Mode   string    `form:"mode"`
+		Since  time.Time `form:"since"`
+		Until  time.Time `form:"until"`
+		Page   int       `form:"page"`
+		Size   int       `form:"size"`
+	}
+
+	RespExecutions struct {
+		_          struct{}
+		Total      int64            `json:"total"`
+		Page       int              `json:"page"`
+		Size       int              `json:"size"`
+		Executions []*RespExecution `json:"executions"`
+	}
+
+	RespExecution struct {
+		_               struct{}
+		ID              uint64     `json:"-"`
+		LambdaID        uint64     `json:"-"`
+		ExecutionID     string     `json:"execution_id"`
+		FunctionName    string     `json:"function_name"`
+		Invoker         string     `json:"invoker"`
+		Payload         string     `json:"payload,omitempty"`
+		Mode            string     `json:"mode"`
+		Status          string     `json:"status"`
+		StatusCode      int32      `json:"status_code,omitempty"`
+		Duration        float64    `json:"duration,omitempty"`
+		ExecutedVersion string     `json:"executed_version,omitempty"`
+		Result          string     `json:"result,omitempty"`
+		Error           string     `json:"error,omitempty"`
+		LogTail         string     `json:"log_tail,omitempty"`
+		ChainID         string     `json:"chain_id,omitempty"`
+		CreatedAt       *time.Time `json:"created_at"`
+		FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	}
+
+	// ReqCallback the record delivered by the destination of asynchronous invocations,
+	// which is reported by the callback function as it is.
+	ReqCallback struct {
+		_              struct{}
+		Timestamp      time.Time `json:"timestamp"`
+		RequestContext struct {
+			RequestID   string `json:"requestId"`
+			FunctionArn string `json:"functionArn"`
+			// Condition Success, RetriesExhausted or EventAgeExceeded
+			Condition              string `json:"condition"`
+			ApproximateInvokeCount int    `json:"approximateInvokeCount"`
+		} `json:"requestContext"`
+		ResponseContext struct {
+			StatusCode      int    `json:"statusCode"`
+			ExecutedVersion string `json:"executedVersion"`
+			FunctionError   string `json:"functionError"`
+		} `json:"responseContext"`
+		RequestPayload  json.RawMessage `json:"requestPayload"`
+		ResponsePayload json.RawMessage `json:"responsePayload"`
+	}
+)
+
+// Chain related
+type (
+	// ReqChain sets the downstream Lambdas by the names or ARNs, the nil ones are kept and the empty ones are unset.
+	ReqChain struct {
+		_         struct{}
+		Lambda    string  `uri:"lambda"`
+		OnSuccess *string `json:"on_success"`
+		OnFailure *string `json:"on_failure"`
+	}
+
+	// Chain the downstream Lambdas of the Lambda, along with the links of the whole chain it belongs to.
+	Chain struct {
+		_         struct{}
+		OnSuccess string       `json:"on_success,omitempty"`
+		OnFailure string       `json:"on_failure,omitempty"`
+		Links     []*ChainLink `json:"links"`
+	}
+
+	// ChainLink the link from one Lambda to the downstream one, on success or on failure.
+	ChainLink struct {
+		_    struct{}
+		From string `json:"from"`
+		To   string `json:"to"`
+		On   string `json:"on"`
+	}
+)
+
+// Failure related
+type (
+	// ReqFailures the query of the events dead-lettered for the Lambda, paginated from the latest.
+	ReqFailures struct {
+		_      struct{}
+		Lambda string `uri:"lambda"`
+		Page   int    `form:"page"`
+		Size   int    `form:"size"`
+	}
+
+	RespFailures struct {
+		_        struct{}
+		Total    int64          `json:"total"`
+		Page     int            `json:"page"`
+		Size     int            `json:"size"`
+		Failures []*RespFailure `json:"failures"`
+	}
+
+	RespFailure struct {
+		_                  struct{}
+		ID                 uint64     `json:"id"`
+		LambdaID           uint64     `json:"-"`
+		Payload            string     `json:"payload"`
+		ErrorCode          string     `json:"error_code"`
+		ErrorMessage       string     `json:"error_message"`
+		RetryAttempts      int32      `json:"retry_attempts"`
+		FailedAt           *time.Time `json:"failed_at"`
+		RedriveExecutionID string     `json:"redrive_execution_id,omitempty"`
+		RedrivenAt         *time.Time `json:"redriven_at,omitempty"`
+	}
+
+	// ReqRedrive redrives the failures by the IDs, or all the ones not redriven yet when none given.
+	ReqRedrive struct {
+		_      struct{}
+		Lambda string   `uri:"lambda"`
+		IDs    []uint64 `json:"ids"`
+	}
+
+	RespRedrive struct {
+		_        struct{}
+		Redriven []*RespFailure `json:"redriven"`
+	}
+)
+
+// Trigger related
+type (
+	// ReqTrigger the account on Stellar watched, whose activities of the type trigger the Lambda,
+	// or the contract on Soroban, whose events matching any of the topics trigger the Lambda.
+	ReqTrigger struct {
+		_       struct{}
+		Type    string     `json:"type"`
+		Address string     `json:"address"`
+		Topics  [][]string `json:"topics,omitempty"`
+	}
+
+	// ReqAddTrigger adds the trigger to the Lambda registered by the name or ARN.
+	ReqAddTrigger struct {
+		_       struct{}
+		Lambda  string     `uri:"lambda" binding:"required"`
+		Type    string     `json:"type"`
+		Address string     `json:"address"`
+		Topics  [][]string `json:"topics"`
+	}
+
+	// ReqTriggers lists the triggers of the Lambda by the name or ARN, or all the ones of the account when none given.
+	ReqTriggers struct {
+		_      struct{}
+		Lambda string `form:"lambda"`
+	}
+
+	ReqURITrigger struct {
+		_  struct{}
+		ID uint64 `uri:"id" binding:"required"`
+	}
+
+	RespTrigger struct {
+		_         struct{}
+		ID        uint64     `json:"id"`
+		LambdaID  uint64     `json:"-"`
+		Lambda    string     `json:"lambda,omitempty"`
+		Type      string     `json:"type"`
+		Address   string     `json:"address"`
+		Topics    [][]string `json:"topics,omitempty" gorm:"serializer:json"`
+		Cursor    string     `json:"cursor"`
+		Ledger    uint32     `json:"ledger,omitempty"`
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		// Organization and Account the owner of the Lambda, set to the events of the watched ones.
+		Organization string `json:"-"`
+		Account      string `json:"-"`
+	}
+)
+
+// Webhook related
+type (
+	// ReqWebhook creates the webhook of the Lambda, or rotates the secret of it, the headers are picked
+	// into the event along with the body.
+	ReqWebhook struct {
+		_       struct{}
+		Lambda  string   `uri:"lambda" binding:"required"`
+		Headers []string `json:"headers"`
+	}
+
+	// ReqWebhooks lists the webhooks of the Lambda by the name or ARN, or all the ones of the account when none given.
+	ReqWebhooks struct {
+		_      struct{}
+		Lambda string `form:"lambda"`
+	}
+
+	// RespWebhook the webhook of the Lambda, the secret is only shown once created or rotated.
+	RespWebhook struct {
+		_         struct{}
+		ID        uint64     `json:"id"`
+		LambdaID  uint64     `json:"-"`
+		Lambda    string     `json:"lambda,omitempty"`
+		HookID    string     `json:"hook_id"`
+		Path      string     `json:"path" gorm:"-"`
+		Secret    string     `json:"secret,omitempty"`
+		Headers   []string   `json:"headers" gorm:"serializer:json"`
+		CreatedAt *time.Time `json:"created_at,omitempty"`
+		UpdatedAt *time.Time `json:"updated_at,omitempty"`
+		// Organization and Account the owner of the Lambda, set to the events.
+		Organization string `json:"-"`
+		Account      string `json:"-"`
+	}
+
+	// ReqHook the request of the inbound webhook, by the external systems.
+	ReqHook struct {
+		_      struct{}
+		HookID string `uri:"hookID" binding:"required"`
+		Header http.Header
+		Body   []byte
+	}
+
+	RespHook struct {
+		_         struct{}
+		RequestID string `json:"request_id"`
+	}
+)
+
+// Update related
+type (
+	ReqUpdate struct {
+		_         struct{}
+		Lambda    string
+		Runtime   string
+		Env       Env
+		Resources Resources
+		File      *ReqFile
+	}
+
+	RespUpdate struct {
+		_         struct{}
+		Lambda    *RespLamBrief `json:"lambda"`
+		Scheduler *RespSchBrief `json:"scheduler,omitempty"`
+	}
+)
+
+// Version related
+type (
+	RespVersion struct {
+		_          struct{}
+		Version    string     `json:"version"`
+		CodeSHA256 string     `json:"code_sha256"`
+		RevisionID string     `json:"revision_id"`
+		Uploader   string     `json:"uploader"`
+		Live       bool       `json:"live" gorm:"-"`
+		CreatedAt  *time.Time `json:"created_at"`
+		// the configuration of the version, restored on rollback.
+		Runtime          string `json:"runtime,omitempty"`
+		Handler          string `json:"handler,omitempty"`
+		Timeout          int32  `json:"-"`
+		MemorySize       int32  `json:"-"`
+		EphemeralStorage int32  `json:"-"`
+		Environment      Env    `json:"-" gorm:"serializer:json"`
+		RuntimeNonce     string `json:"-"`
+	}
+
+	ReqRollback struct {
+		Lambda  string `uri:"lambda"`
+		Version string `json:"version"`
+	}
+
+	RespRollback struct {
+		_               struct{}
+		Name            string `json:"function_name"`
+		Arn             string `json:"function_arn"`
+		PreviousVersion string `json:"previous_version"`
+		Version         string `json:"version"`
+		CodeSHA256      string `json:"code_sha256"`
 	}
 )
 
@@ -159,3 +559,27 @@ type RespRemove struct {
 	Lambdas   RespLamBrief `json:"lambda"`
 	Scheduler RespSchBrief `json:"scheduler"`
 }
+
+// Environment related
+type (
+	// Env the environment variables of Lambda
+	Env map[string]string
+
+	ReqSetEnv struct {
+		Lambda    string `uri:"lambda"`
+		Variables Env    `json:"variables"`
+	}
+
+	ReqUnsetEnv struct {
+		Lambda string   `uri:"lambda"`
+		Keys   []string `form:"key"`
+	}
+
+	RespEnv struct {
+		_         struct{}
+		Name      string `json:"function_name"`
+		Version   string `json:"version,omitempty"`
+		Variables Env    `json:"variables"`
+		Platform  Env    `json:"platform"`
+	}
+)
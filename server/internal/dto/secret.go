@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type (
+	// ReqSetSecret sets the value of the secret, encrypted by the RSA public key of the server,
+	// in the base64 chunks joined by dots. The actions granted are replaced when given.
+	ReqSetSecret struct {
+		Name    string    `uri:"name"`
+		Value   string    `json:"value"`
+		Actions *[]string `json:"actions"`
+	}
+
+	ReqURISecret struct {
+		Name string `uri:"name"`
+	}
+
+	// RespSecret the secret without the value, along with the actions granted.
+	RespSecret struct {
+		ID        uint64     `json:"-"`
+		Name      string     `json:"name"`
+		Actions   []string   `json:"actions" gorm:"-"`
+		CreatedAt *time.Time `json:"created_at"`
+		UpdatedAt *time.Time `json:"updated_at"`
+	}
+
+	// RespSecretGrant the action granted with the secret.
+	RespSecretGrant struct {
+		SecretID uint64 `json:"secret_id"`
+		Lambda   string `json:"lambda"`
+	}
+
+	// RespRuntimeSecrets the values of the secrets granted to the function, by the names.
+	RespRuntimeSecrets struct {
+		Secrets map[string]string `json:"secrets"`
+	}
+)
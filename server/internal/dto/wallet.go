@@ -9,8 +9,14 @@ type (
 		Address string `uri:"address"`
 	}
 
+	ReqListWallets struct {
+		Balances bool `form:"balances"`
+	}
+
 	RespListWallet struct {
-		Address string `json:"address"`
+		Address   string               `json:"address"`
+		Activated *bool                `json:"activated,omitempty"`
+		Balances  []*RespWalletBalance `json:"balances,omitempty"`
 	}
 
 	RespListWallets struct {
@@ -25,4 +31,102 @@ type (
 		Address string `json:"address"`
 		IsValid bool   `json:"is_valid"`
 	}
+
+	ReqWalletInfo struct {
+		Address string `uri:"address"`
+	}
+
+	RespWalletInfo struct {
+		Address        string               `json:"address"`
+		Activated      bool                 `json:"activated"`
+		Sequence       string               `json:"sequence,omitempty"`
+		SubentryCount  int32                `json:"subentry_count"`
+		NumSponsoring  uint32               `json:"num_sponsoring"`
+		NumSponsored   uint32               `json:"num_sponsored"`
+		MinimumReserve string               `json:"minimum_reserve,omitempty"`
+		Balances       []*RespWalletBalance `json:"balances"`
+		Signers        []*RespWalletSigner  `json:"signers"`
+		Thresholds     RespWalletThresholds `json:"thresholds"`
+		Flags          RespWalletFlags      `json:"flags"`
+	}
+
+	RespWalletBalance struct {
+		AssetType                         string `json:"asset_type"`
+		AssetCode                         string `json:"asset_code,omitempty"`
+		AssetIssuer                       string `json:"asset_issuer,omitempty"`
+		LiquidityPoolID                   string `json:"liquidity_pool_id,omitempty"`
+		Balance                           string `json:"balance"`
+		Limit                             string `json:"limit,omitempty"`
+		BuyingLiabilities                 string `json:"buying_liabilities,omitempty"`
+		SellingLiabilities                string `json:"selling_liabilities,omitempty"`
+		IsAuthorized                      *bool  `json:"is_authorized,omitempty"`
+		IsAuthorizedToMaintainLiabilities *bool  `json:"is_authorized_to_maintain_liabilities,omitempty"`
+		IsClawbackEnabled                 *bool  `json:"is_clawback_enabled,omitempty"`
+	}
+
+	RespWalletSigner struct {
+		Key    string `json:"key"`
+		Type   string `json:"type"`
+		Weight int32  `json:"weight"`
+	}
+
+	RespWalletThresholds struct {
+		Low    byte `json:"low"`
+		Medium byte `json:"medium"`
+		High   byte `json:"high"`
+	}
+
+	RespWalletFlags struct {
+		AuthRequired        bool `json:"auth_required"`
+		AuthRevocable       bool `json:"auth_revocable"`
+		AuthImmutable       bool `json:"auth_immutable"`
+		AuthClawbackEnabled bool `json:"auth_clawback_enabled"`
+	}
+
+	ReqFundWallet struct {
+		Address string `uri:"address"`
+	}
+
+	RespFundWallet struct {
+		Address string `json:"address"`
+		Balance string `json:"balance"`
+	}
+
+	ReqSubmitTransaction struct {
+		Address string `uri:"address"`
+		XDR     string `json:"xdr"`
+	}
+
+	RespSubmitTransaction struct {
+		Hash        string   `json:"hash"`
+		Successful  bool     `json:"successful"`
+		Ledger      int32    `json:"ledger,omitempty"`
+		Transaction string   `json:"transaction_code,omitempty"`
+		Operations  []string `json:"operation_codes,omitempty"`
+	}
+
+	ReqSetFundingWallet struct {
+		Address string `json:"address"`
+	}
+
+	RespFundingWallet struct {
+		Address         string `json:"address"`
+		StartingBalance string `json:"starting_balance"`
+		Sponsored       bool   `json:"sponsored"`
+		DailyCap        int    `json:"daily_cap"`
+		ActivatedToday  int64  `json:"activated_today"`
+		AccountID       uint64 `json:"-"`
+	}
+
+	ReqActivateWallet struct {
+		Address string `uri:"address"`
+	}
+
+	RespActivateWallet struct {
+		RespSubmitTransaction
+		Address         string `json:"address"`
+		Funder          string `json:"funder"`
+		StartingBalance string `json:"starting_balance"`
+		Sponsored       bool   `json:"sponsored"`
+	}
 )
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// KVEntry the entry of the key-value store of the account, namespaced by the Lambda when the LambdaID is not zero.
+type KVEntry struct {
+	ICU
+	AccountID uint64 `json:"account_id"`
+	LambdaID  uint64 `json:"lambda_id"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+	// Version increased by each put, by which the entry is compared and swapped.
+	Version   int64      `json:"version"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (k *KVEntry) TableName() string {
+	return "kv_entry"
+}
+
+func (k *KVEntry) TableNameWithAbbr() string {
+	return "kv_entry AS kv"
+}
+
+func TabNameKVEntry() string {
+	return (&KVEntry{}).TableName()
+}
+
+func TabNameKVEntryAbbr() string {
+	return (&KVEntry{}).TableNameWithAbbr()
+}
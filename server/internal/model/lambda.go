@@ -1,6 +1,9 @@
 package model
 
 import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 )
 
@@ -11,13 +14,23 @@ type Lambda struct {
 	FunctionName string `json:"function_name"`
 	FunctionArn  string `json:"function_arn"`
 	Runtime      string `json:"runtime"`
-	Timeout      uint8  `json:"timeout"`
+	Timeout      int32  `json:"timeout"`
 	Role         string `json:"role"`
 	Handler      string `json:"handler"`
 	Description  string `json:"description"`
 	CodeSHA256   string `json:"code_sha256"`
 	Version      string `json:"version"`
 	RevisionID   string `json:"revision_id"`
+	// MemorySize and EphemeralStorage in MB
+	MemorySize       int32 `json:"memory_size"`
+	EphemeralStorage int32 `json:"ephemeral_storage"`
+	// Environment the variables set by the user, excluding the platform ones.
+	Environment map[string]string `json:"environment" gorm:"serializer:json"`
+	// OnSuccess and OnFailure the IDs of the Lambdas invoked with the output once this one succeeded or failed.
+	OnSuccess *uint64 `json:"on_success"`
+	OnFailure *uint64 `json:"on_failure"`
+	// RuntimeNonce the nonce of the runtime token injected into the live version.
+	RuntimeNonce string `json:"-"`
 }
 
 func (l *Lambda) TableName() string {
@@ -43,6 +56,11 @@ type LambdaScheduler struct {
 	ScheduleName string `json:"schedule_name"`
 	ScheduleArn  string `json:"schedule_arn"`
 	Expression   string `json:"expression"`
+	State        string `json:"state"`
+	Payload      string `json:"payload"`
+	// MaxEventAge in seconds
+	MaxRetryAttempts int32 `json:"max_retry_attempts"`
+	MaxEventAge      int32 `json:"max_event_age"`
 }
 
 func (l *LambdaScheduler) TableName() string {
@@ -61,10 +79,179 @@ func TabNameLambdaSchAbbr() string {
 	return (&LambdaScheduler{}).TableNameWithAbbr()
 }
 
+// LambdaVersion model
+type LambdaVersion struct {
+	ICU
+	LambdaID   uint64 `json:"lambda_id"`
+	Version    string `json:"version"`
+	CodeSHA256 string `json:"code_sha256"`
+	RevisionID string `json:"revision_id"`
+	Uploader   string `json:"uploader"`
+	// Runtime, Handler, Timeout, MemorySize, EphemeralStorage and Environment the configuration of the version.
+	Runtime          string            `json:"runtime"`
+	Handler          string            `json:"handler"`
+	Timeout          int32             `json:"timeout"`
+	MemorySize       int32             `json:"memory_size"`
+	EphemeralStorage int32             `json:"ephemeral_storage"`
+	Environment      map[string]string `json:"environment" gorm:"serializer:json"`
+	RuntimeNonce     string            `json:"-"`
+}
+
+func (l *LambdaVersion) TableName() string {
+	return "lambda_version"
+}
+
+func (l *LambdaVersion) TableNameWithAbbr() string {
+	return "lambda_version AS lv"
+}
+
+func TabNameLambdaVersion() string {
+	return (&LambdaVersion{}).TableName()
+}
+
+func TabNameLambdaVersionAbbr() string {
+	return (&LambdaVersion{}).TableNameWithAbbr()
+}
+
+// LambdaExecution model
+type LambdaExecution struct {
+	ICU
+	LambdaID    uint64 `json:"lambda_id"`
+	ExecutionID string `json:"execution_id"`
+	Invoker     string `json:"invoker"`
+	Payload     string `json:"payload"`
+	// Mode sync or async
+	Mode       string `json:"mode"`
+	Status     string `json:"status"`
+	StatusCode int32  `json:"status_code"`
+	// Duration the duration reported by Lambda in milliseconds.
+	Duration float64 `json:"duration"`
+	// ExecutedVersion the version of Lambda which handled the invocation.
+	ExecutedVersion string     `json:"executed_version"`
+	Result          string     `json:"result"`
+	Error           string     `json:"error"`
+	LogTail         string     `json:"log_tail"`
+	FinishedAt      *time.Time `json:"finished_at"`
+	// ChainID the execution ID of the first one in the chain, empty for the ones not chained.
+	ChainID string `json:"chain_id"`
+}
+
+func (l *LambdaExecution) TableName() string {
+	return "lambda_execution"
+}
+
+func (l *LambdaExecution) TableNameWithAbbr() string {
+	return "lambda_execution AS le"
+}
+
+func TabNameLambdaExecution() string {
+	return (&LambdaExecution{}).TableName()
+}
+
+func TabNameLambdaExecutionAbbr() string {
+	return (&LambdaExecution{}).TableNameWithAbbr()
+}
+
+// LambdaFailure model
+type LambdaFailure struct {
+	ICU
+	LambdaID  uint64 `json:"lambda_id"`
+	MessageID string `json:"message_id"`
+	// Payload the event failed to deliver or failed in the handler, as sent by the scheduler.
+	Payload       string    `json:"payload"`
+	ErrorCode     string    `json:"error_code"`
+	ErrorMessage  string    `json:"error_message"`
+	RetryAttempts int32     `json:"retry_attempts"`
+	FailedAt      time.Time `json:"failed_at"`
+	// RedriveExecutionID the execution started by redriving the event.
+	RedriveExecutionID string     `json:"redrive_execution_id"`
+	RedrivenAt         *time.Time `json:"redriven_at"`
+}
+
+func (l *LambdaFailure) TableName() string {
+	return "lambda_failure"
+}
+
+func (l *LambdaFailure) TableNameWithAbbr() string {
+	return "lambda_failure AS lf"
+}
+
+func TabNameLambdaFailure() string {
+	return (&LambdaFailure{}).TableName()
+}
+
+func TabNameLambdaFailureAbbr() string {
+	return (&LambdaFailure{}).TableNameWithAbbr()
+}
+
+// LambdaTrigger model
+type LambdaTrigger struct {
+	ICU
+	LambdaID uint64 `json:"lambda_id"`
+	// Type payment, operation, effect or contract_event
+	Type string `json:"type"`
+	// Address the account watched, or the contract ID of the contract events.
+	Address string `json:"address"`
+	// Topics the filters of the contract events, each is the segments of the topic in base64 XDR.
+	Topics [][]string `json:"topics" gorm:"serializer:json"`
+	// Cursor the paging token of the last record handled, or the ID of the last contract event.
+	Cursor string `json:"cursor"`
+	// Ledger the ledger the contract events polled from, the ones up to the cursor skipped.
+	Ledger uint32 `json:"ledger"`
+}
+
+func (l *LambdaTrigger) TableName() string {
+	return "lambda_trigger"
+}
+
+func (l *LambdaTrigger) TableNameWithAbbr() string {
+	return "lambda_trigger AS lt"
+}
+
+func TabNameLambdaTrigger() string {
+	return (&LambdaTrigger{}).TableName()
+}
+
+func TabNameLambdaTriggerAbbr() string {
+	return (&LambdaTrigger{}).TableNameWithAbbr()
+}
+
+// LambdaWebhook model
+type LambdaWebhook struct {
+	ICU
+	LambdaID uint64 `json:"lambda_id"`
+	// HookID the public ID of the webhook in the path of it.
+	HookID string `json:"hook_id"`
+	Secret string `json:"secret"`
+	// Headers the names of the request headers picked into the event.
+	Headers []string `json:"headers" gorm:"serializer:json"`
+}
+
+func (l *LambdaWebhook) TableName() string {
+	return "lambda_webhook"
+}
+
+func (l *LambdaWebhook) TableNameWithAbbr() string {
+	return "lambda_webhook AS lw"
+}
+
+func TabNameLambdaWebhook() string {
+	return (&LambdaWebhook{}).TableName()
+}
+
+func TabNameLambdaWebhookAbbr() string {
+	return (&LambdaWebhook{}).TableNameWithAbbr()
+}
+
 // model builders and builder options
 type (
-	LambdaOpt    func(l *Lambda)
-	SchedulerOpt func(l *LambdaScheduler)
+	LambdaOpt        func(l *Lambda)
+	SchedulerOpt     func(l *LambdaScheduler)
+	LambdaVersionOpt func(l *LambdaVersion)
+	ExecutionOpt     func(l *LambdaExecution)
+	FailureOpt       func(l *LambdaFailure)
+	TriggerOpt       func(l *LambdaTrigger)
+	WebhookOpt       func(l *LambdaWebhook)
 )
 
 // BuildLambda
@@ -84,7 +271,11 @@ func WithLambdaResp(resp *lambda.CreateFunctionOutput) LambdaOpt {
 		l.FunctionName = *resp.FunctionName
 		l.FunctionArn = *resp.FunctionArn
 		l.Runtime = string(resp.Runtime)
-		l.Timeout = uint8(*resp.Timeout)
+		l.Timeout = *resp.Timeout
+		l.MemorySize = aws.ToInt32(resp.MemorySize)
+		if resp.EphemeralStorage != nil {
+			l.EphemeralStorage = aws.ToInt32(resp.EphemeralStorage.Size)
+		}
 		l.Role = *resp.Role
 		l.Handler = *resp.Handler
 		l.Description = *resp.Description
@@ -94,12 +285,41 @@ func WithLambdaResp(resp *lambda.CreateFunctionOutput) LambdaOpt {
 	}
 }
 
+// WithLambdaPublished refreshes the runtime, code, revision and resources related fields
+// by the output of the latest published version.
+func WithLambdaPublished(resp *lambda.PublishVersionOutput) LambdaOpt {
+	return func(l *Lambda) {
+		l.Runtime = string(resp.Runtime)
+		l.Handler = *resp.Handler
+		l.CodeSHA256 = *resp.CodeSha256
+		l.Version = *resp.Version
+		l.RevisionID = *resp.RevisionId
+		l.Timeout = aws.ToInt32(resp.Timeout)
+		l.MemorySize = aws.ToInt32(resp.MemorySize)
+		if resp.EphemeralStorage != nil {
+			l.EphemeralStorage = aws.ToInt32(resp.EphemeralStorage.Size)
+		}
+	}
+}
+
 func WithAccountID(accountID uint64) LambdaOpt {
 	return func(l *Lambda) {
 		l.AccountID = accountID
 	}
 }
 
+func WithEnvironment(env map[string]string) LambdaOpt {
+	return func(l *Lambda) {
+		l.Environment = env
+	}
+}
+
+func WithRuntimeNonce(nonce string) LambdaOpt {
+	return func(l *Lambda) {
+		l.RuntimeNonce = nonce
+	}
+}
+
 // BuildScheduler
 // build the LambdaScheduler bound with Lambda in optional pattern
 func BuildScheduler(opts ...SchedulerOpt) *LambdaScheduler {
@@ -129,3 +349,239 @@ func WithSchName(name string) SchedulerOpt {
 		l.ScheduleName = name
 	}
 }
+
+func WithSchState(state string) SchedulerOpt {
+	return func(l *LambdaScheduler) {
+		l.State = state
+	}
+}
+
+func WithSchPayload(payload string) SchedulerOpt {
+	return func(l *LambdaScheduler) {
+		l.Payload = payload
+	}
+}
+
+// WithSchRetry sets the retry policy of the scheduler, the maximum event age in seconds.
+func WithSchRetry(maxRetryAttempts, maxEventAge int32) SchedulerOpt {
+	return func(l *LambdaScheduler) {
+		l.MaxRetryAttempts = maxRetryAttempts
+		l.MaxEventAge = maxEventAge
+	}
+}
+
+func WithSchLambdaID(lambdaID uint64) SchedulerOpt {
+	return func(l *LambdaScheduler) {
+		l.LambdaID = lambdaID
+	}
+}
+
+// BuildLambdaVersion
+// build the LambdaVersion published by Lambda in optional pattern
+func BuildLambdaVersion(opts ...LambdaVersionOpt) *LambdaVersion {
+	lv := new(LambdaVersion)
+
+	for _, opt := range opts {
+		opt(lv)
+	}
+
+	return lv
+}
+
+func WithVersion(version, codeSHA256, revisionID string) LambdaVersionOpt {
+	return func(l *LambdaVersion) {
+		l.Version = version
+		l.CodeSHA256 = codeSHA256
+		l.RevisionID = revisionID
+	}
+}
+
+// WithVersionConfig records the configuration of the Lambda along with the version.
+func WithVersionConfig(lamb *Lambda) LambdaVersionOpt {
+	return func(l *LambdaVersion) {
+		l.Runtime = lamb.Runtime
+		l.Handler = lamb.Handler
+		l.Timeout = lamb.Timeout
+		l.MemorySize = lamb.MemorySize
+		l.EphemeralStorage = lamb.EphemeralStorage
+		l.Environment = lamb.Environment
+		l.RuntimeNonce = lamb.RuntimeNonce
+	}
+}
+
+func WithUploader(uploader string) LambdaVersionOpt {
+	return func(l *LambdaVersion) {
+		l.Uploader = uploader
+	}
+}
+
+func WithLambdaID(lambdaID uint64) LambdaVersionOpt {
+	return func(l *LambdaVersion) {
+		l.LambdaID = lambdaID
+	}
+}
+
+// BuildLambdaExecution
+// build the LambdaExecution of the Lambda invoked in optional pattern
+func BuildLambdaExecution(opts ...ExecutionOpt) *LambdaExecution {
+	le := new(LambdaExecution)
+
+	for _, opt := range opts {
+		opt(le)
+	}
+
+	return le
+}
+
+func WithExecution(lambdaID uint64, executionID string) ExecutionOpt {
+	return func(l *LambdaExecution) {
+		l.LambdaID = lambdaID
+		l.ExecutionID = executionID
+	}
+}
+
+func WithInvocation(invoker, payload string) ExecutionOpt {
+	return func(l *LambdaExecution) {
+		l.Invoker = invoker
+		l.Payload = payload
+	}
+}
+
+func WithExecutionMode(mode string) ExecutionOpt {
+	return func(l *LambdaExecution) {
+		l.Mode = mode
+	}
+}
+
+func WithExecutionStatus(status string) ExecutionOpt {
+	return func(l *LambdaExecution) {
+		l.Status = status
+	}
+}
+
+// WithExecutionResult sets the result reported when the execution is finished.
+func WithExecutionResult(executedVersion, result, errMsg string, finishedAt time.Time) ExecutionOpt {
+	return func(l *LambdaExecution) {
+		l.ExecutedVersion = executedVersion
+		l.Result = result
+		l.Error = errMsg
+		l.FinishedAt = &finishedAt
+	}
+}
+
+func WithExecutionReport(statusCode int32, duration float64) ExecutionOpt {
+	return func(l *LambdaExecution) {
+		l.StatusCode = statusCode
+		l.Duration = duration
+	}
+}
+
+func WithLogTail(logTail string) ExecutionOpt {
+	return func(l *LambdaExecution) {
+		l.LogTail = logTail
+	}
+}
+
+func WithChainID(chainID string) ExecutionOpt {
+	return func(l *LambdaExecution) {
+		l.ChainID = chainID
+	}
+}
+
+// BuildLambdaFailure
+// build the LambdaFailure of the event dead-lettered in optional pattern
+func BuildLambdaFailure(opts ...FailureOpt) *LambdaFailure {
+	lf := new(LambdaFailure)
+
+	for _, opt := range opts {
+		opt(lf)
+	}
+
+	return lf
+}
+
+func WithFailure(lambdaID uint64, messageID string) FailureOpt {
+	return func(l *LambdaFailure) {
+		l.LambdaID = lambdaID
+		l.MessageID = messageID
+	}
+}
+
+// WithFailedEvent sets the event and the error reported by the scheduler or the callback after the retries exhausted.
+func WithFailedEvent(payload, errorCode, errorMessage string, retryAttempts int32, failedAt time.Time) FailureOpt {
+	return func(l *LambdaFailure) {
+		l.Payload = payload
+		l.ErrorCode = errorCode
+		l.ErrorMessage = errorMessage
+		l.RetryAttempts = retryAttempts
+		l.FailedAt = failedAt
+	}
+}
+
+func WithRedrive(executionID string, redrivenAt time.Time) FailureOpt {
+	return func(l *LambdaFailure) {
+		l.RedriveExecutionID = executionID
+		l.RedrivenAt = &redrivenAt
+	}
+}
+
+// BuildLambdaTrigger
+// build the LambdaTrigger watching the account in optional pattern
+func BuildLambdaTrigger(opts ...TriggerOpt) *LambdaTrigger {
+	lt := &LambdaTrigger{Topics: [][]string{}}
+
+	for _, opt := range opts {
+		opt(lt)
+	}
+
+	return lt
+}
+
+func WithTrigger(triggerType, address string) TriggerOpt {
+	return func(l *LambdaTrigger) {
+		l.Type = triggerType
+		l.Address = address
+	}
+}
+
+func WithTriggerCursor(cursor string) TriggerOpt {
+	return func(l *LambdaTrigger) {
+		l.Cursor = cursor
+	}
+}
+
+func WithTriggerTopics(topics [][]string) TriggerOpt {
+	return func(l *LambdaTrigger) {
+		if topics != nil {
+			l.Topics = topics
+		}
+	}
+}
+
+// BuildLambdaWebhook
+// build the LambdaWebhook in optional pattern
+func BuildLambdaWebhook(opts ...WebhookOpt) *LambdaWebhook {
+	lw := &LambdaWebhook{Headers: []string{}}
+
+	for _, opt := range opts {
+		opt(lw)
+	}
+
+	return lw
+}
+
+func WithWebhook(lambdaID uint64, hookID, secret string) WebhookOpt {
+	return func(l *LambdaWebhook) {
+		l.LambdaID = lambdaID
+		l.HookID = hookID
+		l.Secret = secret
+	}
+}
+
+func WithWebhookHeaders(headers []string) WebhookOpt {
+	return func(l *LambdaWebhook) {
+		if headers != nil {
+			l.Headers = headers
+		}
+	}
+}
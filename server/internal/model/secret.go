@@ -0,0 +1,48 @@
+package model
+
+// Secret the secret of the account, the value is kept encrypted by the RSA public key of the server.
+type Secret struct {
+	ICU
+	AccountID uint64 `json:"account_id"`
+	Name      string `json:"name"`
+	Value     string `json:"value"`
+}
+
+func (s *Secret) TableName() string {
+	return "secret"
+}
+
+func (s *Secret) TableNameWithAbbr() string {
+	return "secret AS s"
+}
+
+func TabNameSecret() string {
+	return (&Secret{}).TableName()
+}
+
+func TabNameSecretAbbr() string {
+	return (&Secret{}).TableNameWithAbbr()
+}
+
+// LambdaSecret the secret granted to the Lambda.
+type LambdaSecret struct {
+	ICU
+	LambdaID uint64 `json:"lambda_id"`
+	SecretID uint64 `json:"secret_id"`
+}
+
+func (l *LambdaSecret) TableName() string {
+	return "lambda_secret"
+}
+
+func (l *LambdaSecret) TableNameWithAbbr() string {
+	return "lambda_secret AS ls"
+}
+
+func TabNameLambdaSecret() string {
+	return (&LambdaSecret{}).TableName()
+}
+
+func TabNameLambdaSecretAbbr() string {
+	return (&LambdaSecret{}).TableNameWithAbbr()
+}
@@ -0,0 +1,36 @@
+package model
+
+// WalletActivation the wallet activated by the funding wallet of the organization.
+type WalletActivation struct {
+	ICU
+	OrganizationID  uint64 `json:"organization_id"`
+	AccountID       uint64 `json:"account_id"`
+	Address         string `json:"address"`
+	Funder          string `json:"funder"`
+	Hash            string `json:"hash"`
+	StartingBalance string `json:"starting_balance"`
+	// Sponsored whether the base reserves of the wallet are sponsored by the funder.
+	Sponsored bool `json:"sponsored"`
+}
+
+// FundingWallet the wallet designated by the organization, along with the account designated it.
+type FundingWallet struct {
+	Address   string
+	AccountID uint64
+}
+
+func (w *WalletActivation) TableName() string {
+	return "wallet_activation"
+}
+
+func (w *WalletActivation) TableNameWithAbbr() string {
+	return "wallet_activation AS wa"
+}
+
+func TabNameWalletActivation() string {
+	return (&WalletActivation{}).TableName()
+}
+
+func TabNameWalletActivationAbbr() string {
+	return (&WalletActivation{}).TableNameWithAbbr()
+}
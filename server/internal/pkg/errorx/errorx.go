@@ -64,6 +64,11 @@ func NotFound(msg string) error {
 	return fmt.Errorf("%w", newErr(http.StatusNotFound, 404, msg))
 }
 
+// Conflict returns an error with status 409 and message.
+func Conflict(msg string) error {
+	return fmt.Errorf("%w", newErr(http.StatusConflict, 409, msg))
+}
+
 // Internal returns an error with status 404 and message.
 func Internal(msg string) error {
 	logx.Logger.ERROR(msg)
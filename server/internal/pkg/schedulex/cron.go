@@ -0,0 +1,311 @@
+package schedulex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	minYear = 1970
+	maxYear = 2199
+)
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	weekdayNames = map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}
+)
+
+type (
+	// cronSchedule fires on the wall clock matched by all the fields,
+	// which is evaluated in the timezone of the schedule.
+	cronSchedule struct {
+		minutes []bool
+		hours   []bool
+		months  []bool
+		years   []bool
+		// day matches the date by either the day-of-month or the day-of-week field,
+		// as only one of them is allowed to be specified.
+		day dayMatcher
+	}
+
+	dayMatcher func(date time.Time) bool
+)
+
+// parseCron parses the six fields of cron: minutes, hours, day-of-month, month, day-of-week and year.
+func parseCron(body string) (*cronSchedule, error) {
+	fields := strings.Fields(body)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("cron requires 6 fields: minutes hours day-of-month month day-of-week year, got %d", len(fields))
+	}
+
+	var (
+		s   = new(cronSchedule)
+		err error
+	)
+
+	if s.minutes, err = parseField("minutes", fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if s.hours, err = parseField("hours", fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if s.months, err = parseField("month", fields[3], 1, 12, monthNames); err != nil {
+		return nil, err
+	}
+	if s.years, err = parseField("year", fields[5], minYear, maxYear, nil); err != nil {
+		return nil, err
+	}
+
+	dom, dow := fields[2], fields[4]
+	switch {
+	case dom == "?" && dow == "?":
+		return nil, fmt.Errorf("only one of day-of-month and day-of-week can be ?")
+	case dom != "?" && dow != "?":
+		return nil, fmt.Errorf("one of day-of-month and day-of-week must be ?")
+	case dow == "?":
+		s.day, err = parseDayOfMonth(dom)
+	default:
+		s.day, err = parseDayOfWeek(dow)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	start := t.Truncate(time.Minute).Add(time.Minute)
+
+	date := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	for date.Year() <= maxYear {
+		if date.Year() < minYear || !s.years[date.Year()-minYear] {
+			date = time.Date(date.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.months[int(date.Month())-1] {
+			date = time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+
+		if s.day(date) {
+			if fire := s.fireOn(date, start, loc); !fire.IsZero() {
+				return fire
+			}
+		}
+
+		date = date.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}
+}
+
+// fireOn returns the first fire time on the date which is not before the start.
+func (s *cronSchedule) fireOn(date, start time.Time, loc *time.Location) time.Time {
+	for h := 0; h < 24; h++ {
+		if !s.hours[h] {
+			continue
+		}
+
+		for m := 0; m < 60; m++ {
+			if !s.minutes[m] {
+				continue
+			}
+
+			fire := time.Date(date.Year(), date.Month(), date.Day(), h, m, 0, 0, loc)
+			// skip the wall clock which doesn't exist, because of the daylight saving time
+			if fire.Hour() != h || fire.Minute() != m {
+				continue
+			}
+			if fire.Before(start) {
+				continue
+			}
+
+			return fire
+		}
+	}
+
+	return time.Time{}
+}
+
+// parseField parses the field with the wildcards of , - * and /,
+// the result is indexed by the value minus the minimum.
+func parseField(name, field string, min, max int, names map[string]int) ([]bool, error) {
+	set := make([]bool, max-min+1)
+
+	for _, item := range strings.Split(field, ",") {
+		rng, step := item, 1
+
+		if i := strings.Index(item, "/"); i >= 0 {
+			rng = item[:i]
+
+			v, err := strconv.Atoi(item[i+1:])
+			if err != nil || v <= 0 {
+				return nil, fmt.Errorf("invalid step of %s: %s", name, item)
+			}
+			step = v
+		}
+
+		var from, to int
+		switch {
+		case rng == "*":
+			from, to = min, max
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+
+			var err error
+			if from, err = parseValue(name, bounds[0], min, max, names); err != nil {
+				return nil, err
+			}
+			if to, err = parseValue(name, bounds[1], min, max, names); err != nil {
+				return nil, err
+			}
+			if from > to {
+				return nil, fmt.Errorf("invalid range of %s: %s", name, rng)
+			}
+		default:
+			v, err := parseValue(name, rng, min, max, names)
+			if err != nil {
+				return nil, err
+			}
+
+			from, to = v, v
+			if step > 1 {
+				to = max
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			set[v-min] = true
+		}
+	}
+
+	return set, nil
+}
+
+func parseValue(name, value string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(value)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("invalid value of %s: %s, should be in %d-%d", name, value, min, max)
+	}
+
+	return v, nil
+}
+
+// parseDayOfMonth parses the day-of-month field, which supports L, LW and nW besides the common wildcards.
+func parseDayOfMonth(field string) (dayMatcher, error) {
+	switch {
+	case field == "L":
+		return func(date time.Time) bool {
+			return date.Day() == lastDay(date)
+		}, nil
+	case field == "LW":
+		return func(date time.Time) bool {
+			return date.Day() == nearestWeekday(date, lastDay(date))
+		}, nil
+	case strings.HasSuffix(field, "W"):
+		day, err := parseValue("day-of-month", strings.TrimSuffix(field, "W"), 1, 31, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(date time.Time) bool {
+			if day > lastDay(date) {
+				return false
+			}
+
+			return date.Day() == nearestWeekday(date, day)
+		}, nil
+	}
+
+	days, err := parseField("day-of-month", field, 1, 31, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(date time.Time) bool {
+		return days[date.Day()-1]
+	}, nil
+}
+
+// parseDayOfWeek parses the day-of-week field, which supports L, nL and n#k besides the common wildcards.
+// The days of week are numbered from 1 (SUN) to 7 (SAT).
+func parseDayOfWeek(field string) (dayMatcher, error) {
+	switch {
+	case field == "L":
+		return func(date time.Time) bool {
+			return date.Weekday() == time.Saturday
+		}, nil
+	case strings.HasSuffix(field, "L"):
+		weekday, err := parseValue("day-of-week", strings.TrimSuffix(field, "L"), 1, 7, weekdayNames)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(date time.Time) bool {
+			return int(date.Weekday())+1 == weekday && date.Day()+7 > lastDay(date)
+		}, nil
+	case strings.Contains(field, "#"):
+		parts := strings.SplitN(field, "#", 2)
+
+		weekday, err := parseValue("day-of-week", parts[0], 1, 7, weekdayNames)
+		if err != nil {
+			return nil, err
+		}
+
+		nth, err := strconv.Atoi(parts[1])
+		if err != nil || nth < 1 || nth > 5 {
+			return nil, fmt.Errorf("invalid nth of day-of-week: %s, should be in 1-5", field)
+		}
+
+		return func(date time.Time) bool {
+			return int(date.Weekday())+1 == weekday && (date.Day()-1)/7+1 == nth
+		}, nil
+	}
+
+	weekdays, err := parseField("day-of-week", field, 1, 7, weekdayNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(date time.Time) bool {
+		return weekdays[date.Weekday()]
+	}, nil
+}
+
+func lastDay(date time.Time) int {
+	return time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// nearestWeekday returns the weekday nearest to the day in the month of the date,
+// which never crosses the boundaries of the month.
+func nearestWeekday(date time.Time, day int) int {
+	target := time.Date(date.Year(), date.Month(), day, 0, 0, 0, 0, time.UTC)
+
+	switch target.Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == lastDay(date) {
+			return day - 2
+		}
+		return day + 1
+	default:
+		return day
+	}
+}
@@ -0,0 +1,140 @@
+package schedulex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expression := range []string{
+		"cron(0 12 * * ?)",
+		"cron(0 12 * * ? * *)",
+		"cron(60 12 * * ? *)",
+		"cron(0 24 * * ? *)",
+		"cron(0 12 32 * ? *)",
+		"cron(0 12 * 13 ? *)",
+		"cron(0 12 * FOO ? *)",
+		"cron(0 12 * * 8 *)",
+		"cron(0 12 * * ? 2200)",
+		"cron(0 12 * * * *)",
+		"cron(0 12 ? * ? *)",
+		"cron(0/0 12 * * ? *)",
+		"cron(0 12 10-5 * ? *)",
+		"cron(0 12 ? * MON#6 *)",
+		"cron(0 12 32W * ? *)",
+	} {
+		_, err := Parse(expression)
+
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestCronNextSuccess(t *testing.T) {
+	cases := []struct {
+		expression string
+		from       time.Time
+		expected   []time.Time
+	}{
+		{
+			// every 15 minutes
+			expression: "cron(0/15 * * * ? *)",
+			from:       time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			// 10:15 on weekdays
+			expression: "cron(15 10 ? * MON-FRI *)",
+			from:       time.Date(2024, 1, 5, 11, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 8, 10, 15, 0, 0, time.UTC),
+				time.Date(2024, 1, 9, 10, 15, 0, 0, time.UTC),
+			},
+		},
+		{
+			// 12:00 on the last day of month
+			expression: "cron(0 12 L * ? *)",
+			from:       time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// the weekday nearest to the 1st, 2024-06-01 is a Saturday
+			expression: "cron(0 8 1W * ? *)",
+			from:       time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC),
+				time.Date(2024, 7, 1, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// the last weekday of month, 2024-08-31 is a Saturday
+			expression: "cron(0 8 LW * ? *)",
+			from:       time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 8, 30, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// the third Friday of month
+			expression: "cron(0 9 ? * 6#3 *)",
+			from:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 19, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 16, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// the last Monday of month
+			expression: "cron(0 9 ? * MONL *)",
+			from:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 29, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 26, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// limited by year
+			expression: "cron(0 0 1 JAN ? 2025-2026)",
+			from:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		s, err := Parse(c.expression)
+		assert.NoError(t, err, c.expression)
+
+		assert.Equal(t, c.expected, NextN(s, c.from, 3)[:len(c.expected)], c.expression)
+	}
+}
+
+func TestCronNextSkipDaylightSavingGap(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	s, err := Parse("cron(30 2 * * ? *)")
+	assert.NoError(t, err)
+
+	// 2024-03-10 02:30 doesn't exist in New York
+	from := time.Date(2024, 3, 9, 3, 0, 0, 0, loc)
+
+	assert.Equal(t, time.Date(2024, 3, 11, 2, 30, 0, 0, loc), s.Next(from))
+}
+
+func TestCronNextExhausted(t *testing.T) {
+	s, err := Parse("cron(0 0 1 JAN ? 2024)")
+	assert.NoError(t, err)
+
+	assert.True(t, s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+}
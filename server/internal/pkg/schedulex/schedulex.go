@@ -0,0 +1,137 @@
+package schedulex
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+)
+
+// Schedule a parsed schedule expression of EventBridge Scheduler.
+type Schedule interface {
+	// Next returns the first fire time strictly after t, evaluated in the location of t.
+	// The zero time is returned when the schedule never fires again.
+	Next(t time.Time) time.Time
+}
+
+const atLayout = "2006-01-02T15:04:05"
+
+var (
+	exprRegex = regexp.MustCompile(`^(cron|rate|at)\((.*)\)$`)
+	rateUnits = map[string]time.Duration{
+		"minute":  time.Minute,
+		"minutes": time.Minute,
+		"hour":    time.Hour,
+		"hours":   time.Hour,
+		"day":     24 * time.Hour,
+		"days":    24 * time.Hour,
+	}
+)
+
+// Parse parses the expression with the semantics of EventBridge Scheduler,
+// which is one of cron(...), rate(...) and at(...).
+// The error returned is a bad request, which describes the invalid part of the expression.
+func Parse(expression string) (Schedule, error) {
+	matches := exprRegex.FindStringSubmatch(strings.TrimSpace(expression))
+	if matches == nil {
+		return nil, invalid(expression, "should be one of cron(...), rate(...) and at(...)")
+	}
+
+	body := strings.TrimSpace(matches[2])
+
+	switch matches[1] {
+	case "cron":
+		s, err := parseCron(body)
+		if err != nil {
+			return nil, invalid(expression, err.Error())
+		}
+
+		return s, nil
+	case "rate":
+		return parseRate(expression, body)
+	default:
+		return parseAt(expression, body)
+	}
+}
+
+// NextN returns at most n fire times of the schedule after from, evaluated in the location of from.
+func NextN(s Schedule, from time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+
+	for t := from; len(times) < n; {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+
+		times = append(times, t)
+	}
+
+	return times
+}
+
+func invalid(expression, reason string) error {
+	return errorx.BadRequest(fmt.Sprintf("invalid schedule expression: %s, %s", expression, reason))
+}
+
+// rateSchedule fires by a fixed interval, starting from the time it is created.
+type rateSchedule struct {
+	interval time.Duration
+}
+
+func parseRate(expression, body string) (Schedule, error) {
+	fields := strings.Fields(body)
+	if len(fields) != 2 {
+		return nil, invalid(expression, "the format of rate should be rate(value unit)")
+	}
+
+	value, err := strconv.Atoi(fields[0])
+	if err != nil || value <= 0 {
+		return nil, invalid(expression, "the value of rate should be a positive integer")
+	}
+
+	unit, ok := rateUnits[fields[1]]
+	if !ok {
+		return nil, invalid(expression, "the unit of rate should be one of minutes, hours and days")
+	}
+	// EventBridge takes the singular unit for the value of 1 only, e.g. rate(1 minute) and rate(5 minutes).
+	if plural := strings.HasSuffix(fields[1], "s"); plural == (value == 1) {
+		return nil, invalid(expression, "the unit of rate should be singular for the value of 1, and plural otherwise")
+	}
+
+	return &rateSchedule{interval: time.Duration(value) * unit}, nil
+}
+
+func (r *rateSchedule) Next(t time.Time) time.Time {
+	return t.Add(r.interval)
+}
+
+// atSchedule fires only once, the wall clock is evaluated in the timezone of the schedule.
+type atSchedule struct {
+	wall time.Time
+}
+
+func parseAt(expression, body string) (Schedule, error) {
+	wall, err := time.Parse(atLayout, body)
+	if err != nil {
+		return nil, invalid(expression, "the format of at should be at(yyyy-mm-ddThh:mm:ss)")
+	}
+
+	return &atSchedule{wall: wall}, nil
+}
+
+func (a *atSchedule) Next(t time.Time) time.Time {
+	fire := time.Date(
+		a.wall.Year(), a.wall.Month(), a.wall.Day(),
+		a.wall.Hour(), a.wall.Minute(), a.wall.Second(), 0,
+		t.Location(),
+	)
+	if !fire.After(t) {
+		return time.Time{}
+	}
+
+	return fire
+}
@@ -0,0 +1,78 @@
+package schedulex
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInvalidFormat(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"every 5 minutes",
+		"rate(minutes)",
+		"rate(0 minutes)",
+		"rate(5 weeks)",
+		"rate(1 minutes)",
+		"rate(5 minute)",
+		"at(2024-13-01T00:00:00)",
+		"at(2024-01-01 00:00:00)",
+	} {
+		_, err := Parse(expression)
+
+		assert.Error(t, err, expression)
+
+		var e *errorx.Errorx
+		assert.ErrorAs(t, err, &e)
+		assert.Equal(t, http.StatusBadRequest, e.Status())
+	}
+}
+
+func TestRateNextSuccess(t *testing.T) {
+	s, err := Parse("rate(5 minutes)")
+	assert.NoError(t, err)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 10, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 15, 0, 0, time.UTC),
+	}, NextN(s, from, 3))
+}
+
+func TestRateSingularUnitSuccess(t *testing.T) {
+	s, err := Parse("rate(1 day)")
+	assert.NoError(t, err)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), s.Next(from))
+}
+
+func TestAtNextSuccess(t *testing.T) {
+	s, err := Parse("at(2024-06-01T12:30:00)")
+	assert.NoError(t, err)
+
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	assert.NoError(t, err)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 6, 1, 12, 30, 0, 0, loc),
+	}, NextN(s, from, 3))
+}
+
+func TestAtPassedNoFireTime(t *testing.T) {
+	s, err := Parse("at(2024-06-01T12:30:00)")
+	assert.NoError(t, err)
+
+	from := time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	assert.Empty(t, NextN(s, from, 3))
+}
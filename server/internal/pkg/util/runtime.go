@@ -0,0 +1,66 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+)
+
+// RuntimeClaims the organization, account and function the runtime token is issued to,
+// along with the nonce of the function version carrying it.
+type RuntimeClaims struct {
+	Org      string
+	Account  string
+	Function string
+	Nonce    string
+}
+
+// RuntimeToken generates the token of the runtime API for the claims,
+// in the format of <base64url of the claims>.<HMAC-SHA256 in hex>, signed by the key derived from the secret.
+// The nonce is rotated by each published version, which revokes the tokens of the others.
+func RuntimeToken(secret string, claims RuntimeClaims) string {
+	subject := base64.RawURLEncoding.EncodeToString([]byte(strings.Join(
+		[]string{claims.Org, claims.Account, claims.Function, claims.Nonce}, "\n")))
+
+	return subject + "." + signRuntime(secret, subject)
+}
+
+// ParseRuntimeToken verifies the runtime token by the secret, and returns the claims of it.
+func ParseRuntimeToken(secret, token string) (*RuntimeClaims, error) {
+	subject, signature, ok := strings.Cut(token, ".")
+	if !ok || secret == "" {
+		return nil, errorx.UnauthorizedWithMsg("invalid runtime token")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(signRuntime(secret, subject))) {
+		return nil, errorx.UnauthorizedWithMsg("invalid runtime token")
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(subject)
+	if err != nil {
+		return nil, errorx.UnauthorizedWithMsg("invalid runtime token")
+	}
+
+	parts := strings.Split(string(decoded), "\n")
+	if len(parts) != 4 || slices.Contains(parts, "") {
+		return nil, errorx.UnauthorizedWithMsg("invalid runtime token")
+	}
+
+	return &RuntimeClaims{Org: parts[0], Account: parts[1], Function: parts[2], Nonce: parts[3]}, nil
+}
+
+func signRuntime(secret, subject string) string {
+	derived := hmac.New(sha256.New, []byte(secret))
+	derived.Write([]byte(constant.RuntimeTokenScope))
+
+	mac := hmac.New(sha256.New, derived.Sum(nil))
+	mac.Write([]byte(subject))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRuntimeClaims = RuntimeClaims{
+	Org:      "test-org",
+	Account:  "test-account",
+	Function: "test-org-test-account-func",
+	Nonce:    "test-nonce",
+}
+
+func TestRuntimeTokenSuccess(t *testing.T) {
+	token := RuntimeToken("test-secret", testRuntimeClaims)
+
+	claims, err := ParseRuntimeToken("test-secret", token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testRuntimeClaims, *claims)
+	assert.Equal(t, token, RuntimeToken("test-secret", testRuntimeClaims))
+}
+
+func TestRuntimeTokenRotated(t *testing.T) {
+	rotated := testRuntimeClaims
+	rotated.Nonce = "other-nonce"
+
+	assert.NotEqual(t, RuntimeToken("test-secret", testRuntimeClaims), RuntimeToken("test-secret", rotated))
+}
+
+func TestParseRuntimeTokenInvalid(t *testing.T) {
+	token := RuntimeToken("test-secret", testRuntimeClaims)
+
+	other := testRuntimeClaims
+	other.Function = "test-org-test-account-other"
+	forged := RuntimeToken("test-secret", other)
+
+	// the token without nonce issued before the rotation
+	legacy := testRuntimeClaims
+	legacy.Nonce = ""
+
+	for _, invalid := range []string{
+		"",
+		"invalid",
+		token[:len(token)-1],
+		forged[:len(forged)-64] + token[len(token)-64:],
+		RuntimeToken("test-secret", legacy),
+	} {
+		_, err := ParseRuntimeToken("test-secret", invalid)
+		assert.Equal(t, errorx.UnauthorizedWithMsg("invalid runtime token"), err)
+	}
+
+	_, err := ParseRuntimeToken("other-secret", token)
+	assert.Equal(t, errorx.UnauthorizedWithMsg("invalid runtime token"), err)
+}
@@ -0,0 +1,225 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/db"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination ../testdata/kv_mock.go -package testdata -source kv.go KV
+type (
+	KV interface {
+		FindEntry(c context.Context, acnID, lambdaID uint64, key string) (*dto.RespKVEntry, error)
+		PutEntry(c context.Context, entry *model.KVEntry, version *int64, maxKeys int) error
+		DeleteEntry(c context.Context, acnID, lambdaID uint64, key string, version *int64) error
+		ListEntries(c context.Context, acnID, lambdaID uint64, prefix string, limit int) ([]*dto.RespKVEntry, error)
+	}
+	kv struct {
+		Instance *db.Instance
+	}
+)
+
+var KVRepo KV
+
+func NewKV() {
+	if KVRepo == nil {
+		KVRepo = &kv{
+			Instance: db.Inst,
+		}
+	}
+}
+
+// liveEntries filters the entries not expired yet.
+func liveEntries(now time.Time) clause.Expr {
+	return clause.Expr{SQL: "expires_at IS NULL OR expires_at > ?", Vars: []interface{}{now}}
+}
+
+// FindEntry finds the entry of the key in the namespace of the account and the Lambda, the expired one is treated as absent.
+func (k *kv) FindEntry(c context.Context, acnID, lambdaID uint64, key string) (*dto.RespKVEntry, error) {
+	resp := new(dto.RespKVEntry)
+
+	if err := k.Instance.Conn(c).Table(model.TabNameKVEntry()).
+		Where("account_id = ? AND lambda_id = ? AND key = ?", acnID, lambdaID, key).
+		Where(liveEntries(time.Now().UTC())).
+		Take(resp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none entry found by: %s", key))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query kv entry: %s, err: %s", key, err.Error()))
+	}
+
+	return resp, nil
+}
+
+// PutEntry puts the value of the entry, the version of it is increased by each put.
+// When the version is given, the entry is put only if it's still at the version, 0 for the one absent or expired,
+// the conflict error returned otherwise. The entry is filled with the one put.
+// The entries new are limited by the max keys of the account, which is locked meanwhile,
+// so the concurrent ones are counted in turn.
+func (k *kv) PutEntry(c context.Context, entry *model.KVEntry, version *int64, maxKeys int) error {
+	now := time.Now().UTC()
+
+	if version != nil && *version > 0 {
+		result := k.Instance.Conn(c).Model(entry).
+			Clauses(clause.Returning{}).
+			Where("account_id = ? AND lambda_id = ? AND key = ? AND version = ?",
+				entry.AccountID, entry.LambdaID, entry.Key, *version).
+			Where(liveEntries(now)).
+			Updates(map[string]interface{}{
+				"value":      entry.Value,
+				"version":    gorm.Expr("version + 1"),
+				"expires_at": entry.ExpiresAt,
+				"updated_at": now,
+			})
+		if err := result.Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to put kv entry: %s, err: %s", entry.Key, err.Error()))
+		}
+		if result.RowsAffected == 0 {
+			return errorx.Conflict(fmt.Sprintf("the entry: %s is not at version: %d", entry.Key, *version))
+		}
+
+		return nil
+	}
+
+	onConflict := clause.OnConflict{
+		Columns: []clause.Column{{Name: "account_id"}, {Name: "lambda_id"}, {Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"value":      entry.Value,
+			"version":    gorm.Expr(`"kv_entry"."version" + 1`),
+			"expires_at": entry.ExpiresAt,
+			"updated_at": now,
+		}),
+	}
+	if version != nil {
+		// only the expired one is replaced
+		onConflict.Where = clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: `"kv_entry"."expires_at" <= ?`, Vars: []interface{}{now}},
+		}}
+	}
+
+	return k.Instance.Conn(c).Transaction(func(tx *gorm.DB) error {
+		var acnID uint64
+		if err := tx.Table(model.TabNameUser()).
+			Select("id").
+			Where("id = ?", entry.AccountID).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Take(&acnID).Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to lock account: %d, err: %s", entry.AccountID, err.Error()))
+		}
+
+		if err := checkKeys(tx, entry, maxKeys, now); err != nil {
+			return err
+		}
+
+		entry.Version = 1
+		result := tx.Table(model.TabNameKVEntry()).
+			Clauses(onConflict, clause.Returning{}).
+			Create(entry)
+		if err := result.Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to put kv entry: %s, err: %s", entry.Key, err.Error()))
+		}
+		if result.RowsAffected == 0 {
+			return errorx.Conflict(fmt.Sprintf("the entry: %s exists already", entry.Key))
+		}
+
+		return nil
+	})
+}
+
+// checkKeys checks the number of the entries of the account before putting the entry,
+// the expired ones are purged beforehand, and the key existing is not counted again.
+func checkKeys(tx *gorm.DB, entry *model.KVEntry, maxKeys int, now time.Time) error {
+	var existing int64
+	if err := tx.Table(model.TabNameKVEntry()).
+		Where("account_id = ? AND lambda_id = ? AND key = ?", entry.AccountID, entry.LambdaID, entry.Key).
+		Where(liveEntries(now)).
+		Count(&existing).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to query kv entry: %s, err: %s", entry.Key, err.Error()))
+	}
+	if existing > 0 {
+		return nil
+	}
+
+	if err := tx.
+		Where("account_id = ? AND expires_at <= ?", entry.AccountID, now).
+		Delete(&model.KVEntry{}).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to purge expired kv entries, err: %s", err.Error()))
+	}
+
+	var count int64
+	if err := tx.Table(model.TabNameKVEntry()).
+		Where("account_id = ?", entry.AccountID).
+		Count(&count).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to count kv entries, err: %s", err.Error()))
+	}
+	if count >= int64(maxKeys) {
+		return errorx.BadRequest(fmt.Sprintf("the number of kv entries is limited to %d", maxKeys))
+	}
+
+	return nil
+}
+
+// DeleteEntry deletes the entry of the key, only if it's still at the version when given.
+func (k *kv) DeleteEntry(c context.Context, acnID, lambdaID uint64, key string, version *int64) error {
+	query := k.Instance.Conn(c).
+		Where("account_id = ? AND lambda_id = ? AND key = ?", acnID, lambdaID, key).
+		Where(liveEntries(time.Now().UTC()))
+	if version != nil {
+		query = query.Where("version = ?", *version)
+	}
+
+	result := query.Delete(&model.KVEntry{})
+	if err := result.Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to delete kv entry: %s, err: %s", key, err.Error()))
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	if version != nil {
+		if _, err := k.FindEntry(c, acnID, lambdaID, key); err == nil {
+			return errorx.Conflict(fmt.Sprintf("the entry: %s is not at version: %d", key, *version))
+		}
+	}
+
+	return errorx.NotFound(fmt.Sprintf("none entry found by: %s", key))
+}
+
+// ListEntries lists the entries of the keys starting with the prefix, in the order of the keys, without the values.
+func (k *kv) ListEntries(
+	c context.Context,
+	acnID, lambdaID uint64,
+	prefix string,
+	limit int,
+) ([]*dto.RespKVEntry, error) {
+	query := k.Instance.Conn(c).Table(model.TabNameKVEntry()).
+		Select("key, version, expires_at, created_at, updated_at").
+		Where("account_id = ? AND lambda_id = ?", acnID, lambdaID).
+		Where(liveEntries(time.Now().UTC()))
+	if prefix != "" {
+		query = query.Where("key LIKE ?", escapeLike(prefix)+"%")
+	}
+
+	entries := make([]*dto.RespKVEntry, 0)
+	if err := query.Order("key").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query kv entries, err: %s", err.Error()))
+	}
+
+	return entries, nil
+}
+
+// escapeLike escapes the wildcards of LIKE in the pattern, by the default escape character of Postgres.
+func escapeLike(pattern string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(pattern)
+}
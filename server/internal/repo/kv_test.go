@@ -0,0 +1,259 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/db"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindEntrySuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "kv_entry" WHERE \(account_id = \$1 AND lambda_id = \$2 AND key = \$3\) `+
+		`AND \(expires_at IS NULL OR expires_at > \$4\)`).
+		WithArgs(1, 0, "counter", sqlmock.AnyArg(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value", "version"}).AddRow("counter", "42", 3))
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	entry, err := repo.FindEntry(ctx, 1, 0, "counter")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "42", entry.Value)
+	assert.Equal(t, int64(3), entry.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindEntryNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "kv_entry"`).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}))
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	entry, err := repo.FindEntry(ctx, 1, 0, "counter")
+
+	assert.Nil(t, entry)
+	assert.Equal(t, errorx.NotFound("none entry found by: counter"), err)
+}
+
+func TestPutEntryUpsert(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM "user" WHERE id = \$1 LIMIT \$2 FOR UPDATE`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "kv_entry" WHERE \(account_id = \$1 AND lambda_id = \$2 AND key = \$3\) `+
+		`AND \(expires_at IS NULL OR expires_at > \$4\)`).
+		WithArgs(1, 0, "counter", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`INSERT INTO "kv_entry" (.+) ON CONFLICT \("account_id","lambda_id","key"\) DO UPDATE SET ` +
+		`"expires_at"=\$\d+,"updated_at"=\$\d+,"value"=\$\d+,"version"="kv_entry"."version" \+ 1 RETURNING \*`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key", "value", "version"}).AddRow(1, "counter", "43", 4))
+	mock.ExpectCommit()
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	entry := &model.KVEntry{AccountID: 1, Key: "counter", Value: "43"}
+	err := repo.PutEntry(ctx, entry, nil, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), entry.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPutEntryExists(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM "user" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "kv_entry"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`INSERT INTO "kv_entry" (.+) ON CONFLICT (.+) DO UPDATE SET (.+) ` +
+		`WHERE "kv_entry"."expires_at" <= \$\d+ RETURNING \*`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	version := int64(0)
+	err := repo.PutEntry(ctx, &model.KVEntry{AccountID: 1, Key: "counter", Value: "43"}, &version, 2)
+
+	assert.Equal(t, errorx.Conflict("the entry: counter exists already"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPutEntryNewCounted(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM "user" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "kv_entry" WHERE \(account_id = \$1 AND lambda_id = \$2 AND key = \$3\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`DELETE FROM "kv_entry" WHERE account_id = \$1 AND expires_at <= \$2`).
+		WithArgs(1, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "kv_entry" WHERE account_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`INSERT INTO "kv_entry" (.+) RETURNING \*`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key", "value", "version"}).AddRow(1, "counter", "42", 1))
+	mock.ExpectCommit()
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	entry := &model.KVEntry{AccountID: 1, Key: "counter", Value: "42"}
+	err := repo.PutEntry(ctx, entry, nil, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), entry.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPutEntryKeysLimited(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM "user" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "kv_entry"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`DELETE FROM "kv_entry"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "kv_entry"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectRollback()
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.PutEntry(ctx, &model.KVEntry{AccountID: 1, Key: "counter", Value: "42"}, nil, 2)
+
+	assert.Equal(t, errorx.BadRequest("the number of kv entries is limited to 2"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPutEntryVersionConflict(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE "kv_entry" SET "expires_at"=\$1,"updated_at"=\$2,"value"=\$3,"version"=version \+ 1 `+
+		`WHERE \(account_id = \$4 AND lambda_id = \$5 AND key = \$6 AND version = \$7\) `+
+		`AND \(expires_at IS NULL OR expires_at > \$8\) RETURNING \*`).
+		WithArgs(nil, sqlmock.AnyArg(), "43", 1, 2, "counter", 3, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	version := int64(3)
+	err := repo.PutEntry(ctx, &model.KVEntry{AccountID: 1, LambdaID: 2, Key: "counter", Value: "43"}, &version, 2)
+
+	assert.Equal(t, errorx.Conflict("the entry: counter is not at version: 3"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteEntryVersionConflict(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "kv_entry" WHERE \(account_id = \$1 AND lambda_id = \$2 AND key = \$3\) `+
+		`AND \(expires_at IS NULL OR expires_at > \$4\) AND version = \$5`).
+		WithArgs(1, 0, "counter", sqlmock.AnyArg(), 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT \* FROM "kv_entry"`).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "version"}).AddRow("counter", 4))
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	version := int64(3)
+	err := repo.DeleteEntry(ctx, 1, 0, "counter", &version)
+
+	assert.Equal(t, errorx.Conflict("the entry: counter is not at version: 3"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteEntryNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "kv_entry"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.DeleteEntry(ctx, 1, 0, "counter", nil)
+
+	assert.Equal(t, errorx.NotFound("none entry found by: counter"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListEntriesEscapePrefix(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT key, version, expires_at, created_at, updated_at FROM "kv_entry" `+
+		`WHERE \(account_id = \$1 AND lambda_id = \$2\) AND \(expires_at IS NULL OR expires_at > \$3\) `+
+		`AND key LIKE \$4 ORDER BY key LIMIT \$5`).
+		WithArgs(1, 2, sqlmock.AnyArg(), `user\_1\%%`, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "version"}).AddRow("user_1%a", 1))
+
+	repo := &kv{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	entries, err := repo.ListEntries(ctx, 1, 2, "user_1%", 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "user_1%a", entries[0].Key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,9 +3,12 @@ package repo
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/57blocks/auto-action/server/internal/constant"
 	"github.com/57blocks/auto-action/server/internal/db"
 	"github.com/57blocks/auto-action/server/internal/dto"
 	"github.com/57blocks/auto-action/server/internal/model"
@@ -14,6 +17,7 @@ import (
 	"github.com/57blocks/auto-action/server/internal/third-party/logx"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -destination ../testdata/lambda_mock.go -package testdata -source lambda.go Lambda
@@ -23,6 +27,39 @@ type (
 		PersistRegResult(c context.Context, fc func(tx *gorm.DB) error, opts ...*sql.TxOptions) error
 		FindByAccount(c context.Context, accountId uint64) ([]*dto.RespInfo, error)
 		DeleteLambdaTX(c context.Context, f func(tx *gorm.DB) error, opts ...*sql.TxOptions) error
+		UpdateLambdaTX(c context.Context, f func(tx *gorm.DB) error, opts ...*sql.TxOptions) error
+		FindVersions(c context.Context, lambdaID uint64) ([]*dto.RespVersion, error)
+		FindVersion(c context.Context, lambdaID uint64, version string) (*dto.RespVersion, error)
+		LatestVersion(c context.Context, lambdaID uint64) (string, error)
+		SaveScheduler(c context.Context, sch *model.LambdaScheduler) error
+		DeleteScheduler(c context.Context, lambdaID uint64) error
+		SaveExecution(c context.Context, exe *model.LambdaExecution) error
+		FindExecution(c context.Context, acnID uint64, executionID string) (*dto.RespExecution, error)
+		UpdateExecution(c context.Context, executionID string, exe *model.LambdaExecution) error
+		FinishExecution(c context.Context, executionID string, exe *model.LambdaExecution) (bool, error)
+		ListExecutions(c context.Context, lambdaID uint64, q *dto.ReqExecutions) ([]*dto.RespExecution, int64, error)
+		FindScheduledLambda(c context.Context, scheduleArn string) (uint64, error)
+		SaveFailure(c context.Context, failure *model.LambdaFailure) error
+		ListFailures(c context.Context, lambdaID uint64, q *dto.ReqFailures) ([]*dto.RespFailure, int64, error)
+		FindFailures(c context.Context, lambdaID uint64, ids []uint64) ([]*dto.RespFailure, error)
+		UpdateFailure(c context.Context, id uint64, failure *model.LambdaFailure) error
+		FindLambda(c context.Context, lambdaID uint64) (*dto.RespInfo, error)
+		FindLambdaByArn(c context.Context, functionArn string) (*dto.RespInfo, error)
+		FindRuntimeNonce(c context.Context, functionName string) (string, error)
+		SetChain(c context.Context, lambdaID uint64, onSuccess, onFailure *uint64) error
+		TrackedExecution(c context.Context, executionID string) (*dto.RespExecution, error)
+		ListTriggers(c context.Context, acnID, lambdaID uint64) ([]*dto.RespTrigger, error)
+		DeleteTrigger(c context.Context, acnID, id uint64) error
+		WatchedTriggers(c context.Context) ([]*dto.RespTrigger, error)
+		FindTrigger(c context.Context, id uint64) (*dto.RespTrigger, error)
+		AdvanceCursor(c context.Context, id uint64, from, to string) (bool, error)
+		SaveTrigger(c context.Context, trigger *model.LambdaTrigger) error
+		AdvanceLedger(c context.Context, id uint64, from string, ledger uint32, to string) (bool, error)
+		SaveWebhook(c context.Context, webhook *model.LambdaWebhook) error
+		ListWebhooks(c context.Context, acnID, lambdaID uint64) ([]*dto.RespWebhook, error)
+		RotateWebhook(c context.Context, lambdaID uint64, secret string, headers []string) (*model.LambdaWebhook, error)
+		DeleteWebhook(c context.Context, lambdaID uint64) error
+		FindWebhook(c context.Context, hookID string) (*dto.RespWebhook, error)
 	}
 	lambda struct {
 		Instance *db.Instance
@@ -92,3 +129,606 @@ func (l *lambda) DeleteLambdaTX(c context.Context, f func(tx *gorm.DB) error, op
 
 	return nil
 }
+
+func (l *lambda) UpdateLambdaTX(c context.Context, f func(tx *gorm.DB) error, opts ...*sql.TxOptions) error {
+	if err := l.Instance.Conn(c).Transaction(f, opts...); err != nil {
+		logx.Logger.ERROR(fmt.Sprintf("update lambda failed, err: %s", err.Error()))
+
+		return errorx.Internal("failed to update lambda")
+	}
+
+	return nil
+}
+
+func (l *lambda) FindVersions(c context.Context, lambdaID uint64) ([]*dto.RespVersion, error) {
+	resp := make([]*dto.RespVersion, 0)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaVersion()).
+		Where("lambda_id = ?", lambdaID).
+		Order("id DESC").
+		Find(&resp).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda versions, err: %s", err.Error()))
+	}
+
+	return resp, nil
+}
+
+func (l *lambda) FindVersion(c context.Context, lambdaID uint64, version string) (*dto.RespVersion, error) {
+	resp := new(dto.RespVersion)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaVersion()).
+		Where("lambda_id = ? and version = ?", lambdaID, version).
+		First(resp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none version found by: %s", version))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda version: %s, err: %s", version, err.Error()))
+	}
+
+	return resp, nil
+}
+
+// LatestVersion returns the version published last, which is empty when none published.
+func (l *lambda) LatestVersion(c context.Context, lambdaID uint64) (string, error) {
+	versions := make([]string, 0, 1)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaVersion()).
+		Select("version").
+		Where("lambda_id = ?", lambdaID).
+		Order("id DESC").
+		Limit(1).
+		Find(&versions).Error; err != nil {
+		return "", errorx.Internal(fmt.Sprintf("failed to query the latest lambda version, err: %s", err.Error()))
+	}
+	if len(versions) == 0 {
+		return "", nil
+	}
+
+	return versions[0], nil
+}
+
+// SaveScheduler creates the scheduler of Lambda, or refreshes it when the scheduler with the same name exists.
+func (l *lambda) SaveScheduler(c context.Context, sch *model.LambdaScheduler) error {
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaSch()).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "schedule_name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"schedule_arn", "expression", "state", "payload", "max_retry_attempts", "max_event_age", "updated_at",
+			}),
+		}).
+		Create(sch).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to save lambda scheduler: %s, err: %s", sch.ScheduleName, err.Error()))
+	}
+
+	return nil
+}
+
+func (l *lambda) DeleteScheduler(c context.Context, lambdaID uint64) error {
+	if err := l.Instance.Conn(c).
+		Where("lambda_id = ?", lambdaID).
+		Delete(&model.LambdaScheduler{}).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to delete lambda scheduler, err: %s", err.Error()))
+	}
+
+	return nil
+}
+
+func (l *lambda) SaveExecution(c context.Context, exe *model.LambdaExecution) error {
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaExecution()).
+		Create(exe).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to save lambda execution: %s, err: %s", exe.ExecutionID, err.Error()))
+	}
+
+	return nil
+}
+
+// FindExecution finds the execution among the Lambdas of the account.
+func (l *lambda) FindExecution(c context.Context, acnID uint64, executionID string) (*dto.RespExecution, error) {
+	resp := new(dto.RespExecution)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaExecutionAbbr()).
+		Select("le.*, l.function_name").
+		Joins("JOIN lambda AS l ON l.id = le.lambda_id").
+		Where("l.account_id = ? and le.execution_id = ?", acnID, executionID).
+		Take(resp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none execution found by: %s", executionID))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda execution: %s, err: %s", executionID, err.Error()))
+	}
+
+	return resp, nil
+}
+
+// UpdateExecution updates the execution by the fields set, the zero ones stay untouched.
+func (l *lambda) UpdateExecution(c context.Context, executionID string, exe *model.LambdaExecution) error {
+	result := l.Instance.Conn(c).Table(model.TabNameLambdaExecution()).
+		Where("execution_id = ?", executionID).
+		Updates(exe)
+	if err := result.Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to update lambda execution: %s, err: %s", executionID, err.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.NotFound(fmt.Sprintf("none execution found by: %s", executionID))
+	}
+
+	return nil
+}
+
+// FinishExecution updates the execution by the result only when it's still pending,
+// which claims the chaining of it, false returned when it has been finished by others.
+func (l *lambda) FinishExecution(c context.Context, executionID string, exe *model.LambdaExecution) (bool, error) {
+	result := l.Instance.Conn(c).Table(model.TabNameLambdaExecution()).
+		Where("execution_id = ? AND status = ?", executionID, constant.ExecutionPending).
+		Updates(exe)
+	if err := result.Error; err != nil {
+		return false, errorx.Internal(fmt.Sprintf("failed to finish lambda execution: %s, err: %s", executionID, err.Error()))
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ListExecutions lists the executions of the Lambda by the filters in the query, from the latest,
+// along with the total number of the filtered ones.
+func (l *lambda) ListExecutions(
+	c context.Context,
+	lambdaID uint64,
+	q *dto.ReqExecutions,
+) ([]*dto.RespExecution, int64, error) {
+	filtered := func() *gorm.DB {
+		query := l.Instance.Conn(c).Table(model.TabNameLambdaExecutionAbbr()).
+			Joins("JOIN lambda AS l ON l.id = le.lambda_id").
+			Where("le.lambda_id = ?", lambdaID)
+		if q.Status != "" {
+			query = query.Where("le.status = ?", q.Status)
+		}
+		if q.Mode != "" {
+			query = query.Where("le.mode = ?", q.Mode)
+		}
+		if !q.Since.IsZero() {
+			query = query.Where("le.created_at >= ?", q.Since)
+		}
+		if !q.Until.IsZero() {
+			query = query.Where("le.created_at < ?", q.Until)
+		}
+
+		return query
+	}
+
+	var total int64
+	if err := filtered().Count(&total).Error; err != nil {
+		return nil, 0, errorx.Internal(fmt.Sprintf("failed to count lambda executions, err: %s", err.Error()))
+	}
+
+	exes := make([]*dto.RespExecution, 0, q.Size)
+	if total == 0 {
+		return exes, 0, nil
+	}
+
+	if err := filtered().Select("le.*, l.function_name").
+		Order("le.created_at DESC").
+		Offset((q.Page - 1) * q.Size).
+		Limit(q.Size).
+		Find(&exes).Error; err != nil {
+		return nil, 0, errorx.Internal(fmt.Sprintf("failed to query lambda executions, err: %s", err.Error()))
+	}
+
+	return exes, total, nil
+}
+
+// FindScheduledLambda finds the ID of the Lambda which the scheduler is bound with.
+func (l *lambda) FindScheduledLambda(c context.Context, scheduleArn string) (uint64, error) {
+	sch := new(model.LambdaScheduler)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaSch()).
+		Where("schedule_arn = ?", scheduleArn).
+		Take(sch).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, errorx.NotFound(fmt.Sprintf("none scheduler found by: %s", scheduleArn))
+		}
+
+		return 0, errorx.Internal(fmt.Sprintf("failed to query lambda scheduler: %s, err: %s", scheduleArn, err.Error()))
+	}
+
+	return sch.LambdaID, nil
+}
+
+// SaveFailure saves the event dead-lettered, the one with the same message ID is saved only once,
+// since the message may be received again before it's deleted from the queue.
+func (l *lambda) SaveFailure(c context.Context, failure *model.LambdaFailure) error {
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaFailure()).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "message_id"}},
+			DoNothing: true,
+		}).
+		Create(failure).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to save lambda failure: %s, err: %s", failure.MessageID, err.Error()))
+	}
+
+	return nil
+}
+
+// ListFailures lists the failures of the Lambda from the latest, along with the total number of them.
+func (l *lambda) ListFailures(
+	c context.Context,
+	lambdaID uint64,
+	q *dto.ReqFailures,
+) ([]*dto.RespFailure, int64, error) {
+	query := func() *gorm.DB {
+		return l.Instance.Conn(c).Table(model.TabNameLambdaFailure()).
+			Where("lambda_id = ?", lambdaID)
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, errorx.Internal(fmt.Sprintf("failed to count lambda failures, err: %s", err.Error()))
+	}
+
+	failures := make([]*dto.RespFailure, 0, q.Size)
+	if total == 0 {
+		return failures, 0, nil
+	}
+
+	if err := query().
+		Order("failed_at DESC").
+		Offset((q.Page - 1) * q.Size).
+		Limit(q.Size).
+		Find(&failures).Error; err != nil {
+		return nil, 0, errorx.Internal(fmt.Sprintf("failed to query lambda failures, err: %s", err.Error()))
+	}
+
+	return failures, total, nil
+}
+
+// FindFailures finds the failures of the Lambda by the IDs in the order of failing,
+// or all the ones not redriven yet when none IDs given.
+func (l *lambda) FindFailures(c context.Context, lambdaID uint64, ids []uint64) ([]*dto.RespFailure, error) {
+	query := l.Instance.Conn(c).Table(model.TabNameLambdaFailure()).
+		Where("lambda_id = ?", lambdaID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	} else {
+		query = query.Where("redriven_at IS NULL")
+	}
+
+	failures := make([]*dto.RespFailure, 0)
+	if err := query.Order("failed_at").Find(&failures).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda failures, err: %s", err.Error()))
+	}
+
+	return failures, nil
+}
+
+// UpdateFailure updates the failure by the fields set, the zero ones stay untouched.
+func (l *lambda) UpdateFailure(c context.Context, id uint64, failure *model.LambdaFailure) error {
+	result := l.Instance.Conn(c).Table(model.TabNameLambdaFailure()).
+		Where("id = ?", id).
+		Updates(failure)
+	if err := result.Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to update lambda failure: %d, err: %s", id, err.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.NotFound(fmt.Sprintf("none failure found by: %d", id))
+	}
+
+	return nil
+}
+
+// FindLambda finds the Lambda by its ID, regardless of the account.
+func (l *lambda) FindLambda(c context.Context, lambdaID uint64) (*dto.RespInfo, error) {
+	resp := new(dto.RespInfo)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambda()).
+		Where("id = ?", lambdaID).
+		Take(resp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none lambda found by: %d", lambdaID))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda: %d, err: %s", lambdaID, err.Error()))
+	}
+
+	return resp, nil
+}
+
+// FindLambdaByArn finds the Lambda by its unqualified ARN, regardless of the account.
+func (l *lambda) FindLambdaByArn(c context.Context, functionArn string) (*dto.RespInfo, error) {
+	resp := new(dto.RespInfo)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambda()).
+		Where("function_arn = ?", functionArn).
+		Take(resp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none lambda found by: %s", functionArn))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda: %s, err: %s", functionArn, err.Error()))
+	}
+
+	return resp, nil
+}
+
+// FindRuntimeNonce returns the nonce of the runtime token injected into the live version of the function.
+func (l *lambda) FindRuntimeNonce(c context.Context, functionName string) (string, error) {
+	var nonce string
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambda()).
+		Select("runtime_nonce").
+		Where("function_name = ?", functionName).
+		Take(&nonce).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errorx.NotFound(fmt.Sprintf("none lambda found by: %s", functionName))
+		}
+
+		return "", errorx.Internal(fmt.Sprintf("failed to query runtime nonce: %s, err: %s", functionName, err.Error()))
+	}
+
+	return nonce, nil
+}
+
+// SetChain sets the downstream Lambdas of the Lambda, the nil ones are unset.
+func (l *lambda) SetChain(c context.Context, lambdaID uint64, onSuccess, onFailure *uint64) error {
+	result := l.Instance.Conn(c).Table(model.TabNameLambda()).
+		Where("id = ?", lambdaID).
+		Updates(map[string]interface{}{
+			"on_success": onSuccess,
+			"on_failure": onFailure,
+		})
+	if err := result.Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to set chain of lambda: %d, err: %s", lambdaID, err.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.NotFound(fmt.Sprintf("none lambda found by: %d", lambdaID))
+	}
+
+	return nil
+}
+
+// TrackedExecution finds the execution by its ID, regardless of the account, which is reported by the callback.
+func (l *lambda) TrackedExecution(c context.Context, executionID string) (*dto.RespExecution, error) {
+	resp := new(dto.RespExecution)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaExecution()).
+		Where("execution_id = ?", executionID).
+		Take(resp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none execution found by: %s", executionID))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda execution: %s, err: %s", executionID, err.Error()))
+	}
+
+	return resp, nil
+}
+
+// ListTriggers lists the triggers of the Lambda, or all the ones of the account when the Lambda is zero.
+func (l *lambda) ListTriggers(c context.Context, acnID, lambdaID uint64) ([]*dto.RespTrigger, error) {
+	query := l.Instance.Conn(c).Table(model.TabNameLambdaTriggerAbbr()).
+		Select("lt.*, l.function_name AS lambda").
+		Joins("JOIN lambda AS l ON l.id = lt.lambda_id").
+		Where("l.account_id = ?", acnID)
+	if lambdaID > 0 {
+		query = query.Where("lt.lambda_id = ?", lambdaID)
+	}
+
+	triggers := make([]*dto.RespTrigger, 0)
+	if err := query.Order("lt.id").Find(&triggers).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda triggers, err: %s", err.Error()))
+	}
+
+	return triggers, nil
+}
+
+// DeleteTrigger deletes the trigger of the Lambdas owned by the account.
+func (l *lambda) DeleteTrigger(c context.Context, acnID, id uint64) error {
+	owned := l.Instance.Conn(c).Table(model.TabNameLambda()).
+		Select("id").
+		Where("account_id = ?", acnID)
+
+	result := l.Instance.Conn(c).
+		Where("id = ? AND lambda_id IN (?)", id, owned).
+		Delete(&model.LambdaTrigger{})
+	if err := result.Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to delete lambda trigger: %d, err: %s", id, err.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.NotFound(fmt.Sprintf("none trigger found by: %d", id))
+	}
+
+	return nil
+}
+
+// WatchedTriggers lists all the triggers, along with the organization and the account owning the Lambda.
+func (l *lambda) WatchedTriggers(c context.Context) ([]*dto.RespTrigger, error) {
+	triggers := make([]*dto.RespTrigger, 0)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaTriggerAbbr()).
+		Select("lt.*, l.function_name AS lambda, o.name AS organization, u.account AS account").
+		Joins("JOIN lambda AS l ON l.id = lt.lambda_id").
+		Joins(`JOIN "user" AS u ON u.id = l.account_id`).
+		Joins("JOIN organization AS o ON o.id = u.organization_id").
+		Order("lt.id").
+		Find(&triggers).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda triggers, err: %s", err.Error()))
+	}
+
+	return triggers, nil
+}
+
+// FindTrigger finds the trigger by its ID, regardless of the account.
+func (l *lambda) FindTrigger(c context.Context, id uint64) (*dto.RespTrigger, error) {
+	resp := new(dto.RespTrigger)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaTrigger()).
+		Where("id = ?", id).
+		Take(resp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none trigger found by: %d", id))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda trigger: %d, err: %s", id, err.Error()))
+	}
+
+	return resp, nil
+}
+
+// AdvanceCursor moves the cursor of the trigger only when it's still at the one given,
+// which claims the record of the cursor moved to, false returned when it has been moved by others.
+func (l *lambda) AdvanceCursor(c context.Context, id uint64, from, to string) (bool, error) {
+	result := l.Instance.Conn(c).Table(model.TabNameLambdaTrigger()).
+		Where("id = ? AND cursor = ?", id, from).
+		Updates(map[string]interface{}{
+			"cursor":     to,
+			"updated_at": time.Now().UTC(),
+		})
+	if err := result.Error; err != nil {
+		return false, errorx.Internal(fmt.Sprintf("failed to advance cursor of lambda trigger: %d, err: %s", id, err.Error()))
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// SaveTrigger saves the trigger of the Lambda, the same one existed already is rejected.
+func (l *lambda) SaveTrigger(c context.Context, trigger *model.LambdaTrigger) error {
+	result := l.Instance.Conn(c).Table(model.TabNameLambdaTrigger()).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "lambda_id"}, {Name: "type"}, {Name: "address"}, {Name: "topics"}},
+			DoNothing: true,
+		}).
+		Create(trigger)
+	if err := result.Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to save lambda trigger: %s, err: %s", trigger.Address, err.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.BadRequest(fmt.Sprintf("the %s trigger of: %s exists already", trigger.Type, trigger.Address))
+	}
+
+	return nil
+}
+
+// AdvanceLedger moves the ledger polled from of the contract event trigger, along with the cursor of it,
+// only when the cursor is still at the one given, false returned when it has been moved by others.
+func (l *lambda) AdvanceLedger(c context.Context, id uint64, from string, ledger uint32, to string) (bool, error) {
+	result := l.Instance.Conn(c).Table(model.TabNameLambdaTrigger()).
+		Where("id = ? AND cursor = ?", id, from).
+		Updates(map[string]interface{}{
+			"cursor":     to,
+			"ledger":     ledger,
+			"updated_at": time.Now().UTC(),
+		})
+	if err := result.Error; err != nil {
+		return false, errorx.Internal(fmt.Sprintf("failed to advance ledger of lambda trigger: %d, err: %s", id, err.Error()))
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// SaveWebhook saves the webhook of the Lambda, the one of the Lambda existed already is rejected.
+func (l *lambda) SaveWebhook(c context.Context, webhook *model.LambdaWebhook) error {
+	result := l.Instance.Conn(c).Table(model.TabNameLambdaWebhook()).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "lambda_id"}},
+			DoNothing: true,
+		}).
+		Create(webhook)
+	if err := result.Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to save lambda webhook of: %d, err: %s", webhook.LambdaID, err.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.BadRequest("the webhook of the lambda exists already, rotate the secret of it instead")
+	}
+
+	return nil
+}
+
+// ListWebhooks lists the webhooks of the Lambda, or all the ones of the account when the Lambda is zero,
+// without the secrets.
+func (l *lambda) ListWebhooks(c context.Context, acnID, lambdaID uint64) ([]*dto.RespWebhook, error) {
+	query := l.Instance.Conn(c).Table(model.TabNameLambdaWebhookAbbr()).
+		Select("lw.id, lw.lambda_id, lw.hook_id, lw.headers, lw.created_at, lw.updated_at, l.function_name AS lambda").
+		Joins("JOIN lambda AS l ON l.id = lw.lambda_id").
+		Where("l.account_id = ?", acnID)
+	if lambdaID > 0 {
+		query = query.Where("lw.lambda_id = ?", lambdaID)
+	}
+
+	webhooks := make([]*dto.RespWebhook, 0)
+	if err := query.Order("lw.id").Find(&webhooks).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda webhooks, err: %s", err.Error()))
+	}
+
+	return webhooks, nil
+}
+
+// RotateWebhook replaces the secret of the webhook of the Lambda, along with the headers when given.
+func (l *lambda) RotateWebhook(
+	c context.Context,
+	lambdaID uint64,
+	secret string,
+	headers []string,
+) (*model.LambdaWebhook, error) {
+	updates := map[string]interface{}{
+		"secret":     secret,
+		"updated_at": time.Now().UTC(),
+	}
+	if headers != nil {
+		encoded, err := json.Marshal(headers)
+		if err != nil {
+			return nil, errorx.Internal(fmt.Sprintf("failed to marshal headers, err: %s", err.Error()))
+		}
+		updates["headers"] = string(encoded)
+	}
+
+	webhook := new(model.LambdaWebhook)
+	result := l.Instance.Conn(c).Model(webhook).
+		Clauses(clause.Returning{}).
+		Where("lambda_id = ?", lambdaID).
+		Updates(updates)
+	if err := result.Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to rotate lambda webhook of: %d, err: %s", lambdaID, err.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return nil, errorx.NotFound(fmt.Sprintf("none webhook found of lambda: %d", lambdaID))
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook deletes the webhook of the Lambda.
+func (l *lambda) DeleteWebhook(c context.Context, lambdaID uint64) error {
+	result := l.Instance.Conn(c).
+		Where("lambda_id = ?", lambdaID).
+		Delete(&model.LambdaWebhook{})
+	if err := result.Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to delete lambda webhook of: %d, err: %s", lambdaID, err.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.NotFound(fmt.Sprintf("none webhook found of lambda: %d", lambdaID))
+	}
+
+	return nil
+}
+
+// FindWebhook finds the webhook by the hook ID, along with the secret, and the organization and the account
+// owning the Lambda.
+func (l *lambda) FindWebhook(c context.Context, hookID string) (*dto.RespWebhook, error) {
+	resp := new(dto.RespWebhook)
+
+	if err := l.Instance.Conn(c).Table(model.TabNameLambdaWebhookAbbr()).
+		Select("lw.*, l.function_name AS lambda, o.name AS organization, u.account AS account").
+		Joins("JOIN lambda AS l ON l.id = lw.lambda_id").
+		Joins(`JOIN "user" AS u ON u.id = l.account_id`).
+		Joins("JOIN organization AS o ON o.id = u.organization_id").
+		Where("lw.hook_id = ?", hookID).
+		Take(resp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none webhook found by: %s", hookID))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda webhook: %s, err: %s", hookID, err.Error()))
+	}
+
+	return resp, nil
+}
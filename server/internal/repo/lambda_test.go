@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/57blocks/auto-action/server/internal/constant"
 	"github.com/57blocks/auto-action/server/internal/db"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
 	"github.com/57blocks/auto-action/server/internal/pkg/util"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -26,8 +30,8 @@ func TestLambdaInfoSuccess(t *testing.T) {
 	testFuncArn := "testArn"
 	testSchedulerExpression := "0 * * * *"
 
-	lambdaRows := sqlmock.NewRows([]string{"id", "function_name", "function_arn"}).
-		AddRow(1, util.GenLambdaFuncName(ctx, testFuncName), testFuncArn)
+	lambdaRows := sqlmock.NewRows([]string{"id", "function_name", "function_arn", "environment"}).
+		AddRow(1, util.GenLambdaFuncName(ctx, testFuncName), testFuncArn, []byte(`{"FOO":"bar"}`))
 	mock.ExpectQuery(`SELECT \* FROM "lambda"`).
 		WillReturnRows(lambdaRows)
 
@@ -44,6 +48,7 @@ func TestLambdaInfoSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, util.GenLambdaFuncName(ctx, testFuncName), lambdaInfo.FunctionName)
 	assert.Equal(t, testFuncArn, lambdaInfo.FunctionArn)
+	assert.Equal(t, dto.Env{"FOO": "bar"}, lambdaInfo.Environment)
 	assert.NotNil(t, lambdaInfo.Scheduler)
 	assert.Equal(t, uint64(1), lambdaInfo.Scheduler.LambdaID)
 	assert.Equal(t, testSchedulerExpression, lambdaInfo.Scheduler.Expression)
@@ -205,3 +210,1060 @@ func TestDeleteLambdaTXError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "failed to remove lambda", err.Error())
 }
+
+func TestUpdateLambdaTXSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.UpdateLambdaTX(ctx, func(tx *gorm.DB) error {
+		return nil
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateLambdaTXError(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.UpdateLambdaTX(ctx, func(tx *gorm.DB) error {
+		return errors.New("update error")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "failed to update lambda", err.Error())
+}
+
+func TestFindVersionsSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	versionRows := sqlmock.NewRows([]string{"id", "lambda_id", "version", "code_sha256", "uploader"}).
+		AddRow(2, 1, "2", "sha256_2", "test-account").
+		AddRow(1, 1, "1", "sha256_1", "test-account")
+	mock.ExpectQuery(`SELECT \* FROM "lambda_version" WHERE lambda_id = \$1 ORDER BY id DESC`).
+		WithArgs(1).
+		WillReturnRows(versionRows)
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	versions, err := repo.FindVersions(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(versions))
+	assert.Equal(t, "2", versions[0].Version)
+	assert.Equal(t, "sha256_2", versions[0].CodeSHA256)
+	assert.Equal(t, "test-account", versions[0].Uploader)
+}
+
+func TestFindVersionsError(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda_version"`).
+		WillReturnError(errors.New("find record error"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	versions, err := repo.FindVersions(ctx, 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, "failed to query lambda versions, err: find record error", err.Error())
+	assert.Nil(t, versions)
+}
+
+func TestFindVersionSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	versionRows := sqlmock.NewRows([]string{"id", "lambda_id", "version", "code_sha256"}).
+		AddRow(1, 1, "1", "sha256_1")
+	mock.ExpectQuery(`SELECT \* FROM "lambda_version"`).
+		WillReturnRows(versionRows)
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	version, err := repo.FindVersion(ctx, 1, "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", version.Version)
+	assert.Equal(t, "sha256_1", version.CodeSHA256)
+}
+
+func TestFindVersionNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda_version"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lambda_id", "version"}))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	version, err := repo.FindVersion(ctx, 1, "3")
+
+	assert.Error(t, err)
+	assert.Equal(t, "none version found by: 3", err.Error())
+	assert.Nil(t, version)
+}
+
+func TestLatestVersionSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT version FROM "lambda_version" WHERE lambda_id = \$1 ORDER BY id DESC LIMIT \$2`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow("3"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	version, err := repo.LatestVersion(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "3", version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLatestVersionNonePublished(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT version FROM "lambda_version"`).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	version, err := repo.LatestVersion(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Empty(t, version)
+}
+
+func TestSaveSchedulerSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "lambda_scheduler" .* ON CONFLICT \("schedule_name"\) DO UPDATE SET`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SaveScheduler(ctx, model.BuildScheduler(
+		model.WithSchLambdaID(1),
+		model.WithSchName("testSch"),
+		model.WithExpression("rate(1 minute)"),
+		model.WithSchState("ENABLED"),
+	))
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveSchedulerError(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "lambda_scheduler"`).
+		WillReturnError(errors.New("insert error"))
+	mock.ExpectRollback()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SaveScheduler(ctx, model.BuildScheduler(model.WithSchName("testSch")))
+
+	assert.Error(t, err)
+	assert.Equal(t, "failed to save lambda scheduler: testSch, err: insert error", err.Error())
+}
+
+func TestDeleteSchedulerSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "lambda_scheduler" WHERE lambda_id = \$1`).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.DeleteScheduler(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSchedulerError(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "lambda_scheduler"`).
+		WillReturnError(errors.New("delete error"))
+	mock.ExpectRollback()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.DeleteScheduler(ctx, 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, "failed to delete lambda scheduler, err: delete error", err.Error())
+}
+
+func TestSaveExecutionSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "lambda_execution"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SaveExecution(ctx, model.BuildLambdaExecution(
+		model.WithExecution(1, "request_id"),
+		model.WithInvocation("account_name", "{}"),
+		model.WithExecutionStatus("pending"),
+	))
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveExecutionError(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "lambda_execution"`).
+		WillReturnError(errors.New("insert error"))
+	mock.ExpectRollback()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SaveExecution(ctx, model.BuildLambdaExecution(model.WithExecution(1, "request_id")))
+
+	assert.Error(t, err)
+	assert.Equal(t, "failed to save lambda execution: request_id, err: insert error", err.Error())
+}
+
+func TestFindExecutionSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT le.\*, l.function_name FROM lambda_execution AS le JOIN lambda AS l ON l.id = le.lambda_id WHERE l.account_id = \$1 and le.execution_id = \$2`).
+		WithArgs(1, "request_id", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"execution_id", "function_name", "status"}).
+			AddRow("request_id", "testFunction", "succeeded"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	exe, err := repo.FindExecution(ctx, 1, "request_id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "testFunction", exe.FunctionName)
+	assert.Equal(t, "succeeded", exe.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindExecutionNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT le.\*, l.function_name FROM lambda_execution AS le`).
+		WillReturnRows(sqlmock.NewRows([]string{"execution_id"}))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	exe, err := repo.FindExecution(ctx, 1, "request_id")
+
+	assert.Nil(t, exe)
+	assert.Equal(t, errorx.NotFound("none execution found by: request_id"), err)
+}
+
+func TestUpdateExecutionSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda_execution" SET .* WHERE execution_id = \$\d+`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.UpdateExecution(ctx, "request_id", model.BuildLambdaExecution(
+		model.WithExecutionStatus("succeeded"),
+	))
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateExecutionNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda_execution" SET`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.UpdateExecution(ctx, "request_id", model.BuildLambdaExecution(
+		model.WithExecutionStatus("succeeded"),
+	))
+
+	assert.Equal(t, errorx.NotFound("none execution found by: request_id"), err)
+}
+
+func TestFinishExecutionClaimed(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda_execution" SET "updated_at"=\$1,"status"=\$2 WHERE execution_id = \$3 AND status = \$4`).
+		WithArgs(sqlmock.AnyArg(), "succeeded", "request_id", "pending").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	finished, err := repo.FinishExecution(ctx, "request_id", model.BuildLambdaExecution(
+		model.WithExecutionStatus("succeeded"),
+	))
+
+	assert.NoError(t, err)
+	assert.True(t, finished)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFinishExecutionFinished(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda_execution" SET`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	finished, err := repo.FinishExecution(ctx, "request_id", model.BuildLambdaExecution(
+		model.WithExecutionStatus("succeeded"),
+	))
+
+	assert.NoError(t, err)
+	assert.False(t, finished)
+}
+
+func TestListExecutionsSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM lambda_execution AS le JOIN lambda AS l ON l.id = le.lambda_id WHERE le.lambda_id = \$1 AND le.status = \$2`).
+		WithArgs(1, "failed").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT le.\*, l.function_name FROM lambda_execution AS le JOIN lambda AS l ON l.id = le.lambda_id WHERE le.lambda_id = \$1 AND le.status = \$2 ORDER BY le.created_at DESC LIMIT \$3 OFFSET \$4`).
+		WithArgs(1, "failed", 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"execution_id", "function_name", "status"}).
+			AddRow("request_id", "testFunction", "failed"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	exes, total, err := repo.ListExecutions(ctx, 1, &dto.ReqExecutions{Status: "failed", Page: 2, Size: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, exes, 1)
+	assert.Equal(t, "request_id", exes[0].ExecutionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListExecutionsEmpty(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM lambda_execution AS le`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	exes, total, err := repo.ListExecutions(ctx, 1, &dto.ReqExecutions{Page: 1, Size: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, exes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListExecutionsError(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM lambda_execution AS le`).
+		WillReturnError(errors.New("query error"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	exes, total, err := repo.ListExecutions(ctx, 1, &dto.ReqExecutions{Page: 1, Size: 20})
+
+	assert.Nil(t, exes)
+	assert.Equal(t, int64(0), total)
+	assert.Equal(t, "failed to count lambda executions, err: query error", err.Error())
+}
+
+func TestFindScheduledLambdaSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda_scheduler" WHERE schedule_arn = \$1`).
+		WithArgs("schedule_arn", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lambda_id"}).AddRow(1, 2))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	lambdaID, err := repo.FindScheduledLambda(ctx, "schedule_arn")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), lambdaID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindScheduledLambdaNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda_scheduler"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	lambdaID, err := repo.FindScheduledLambda(ctx, "schedule_arn")
+
+	assert.Zero(t, lambdaID)
+	assert.Equal(t, errorx.NotFound("none scheduler found by: schedule_arn"), err)
+}
+
+func TestSaveFailureSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "lambda_failure" .* ON CONFLICT \("message_id"\) DO NOTHING`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SaveFailure(ctx, model.BuildLambdaFailure(
+		model.WithFailure(1, "message_id"),
+		model.WithFailedEvent("{}", "Lambda.TooManyRequestsException", "Rate exceeded", 3, time.Now()),
+	))
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveFailureError(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "lambda_failure"`).
+		WillReturnError(errors.New("insert error"))
+	mock.ExpectRollback()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SaveFailure(ctx, model.BuildLambdaFailure(model.WithFailure(1, "message_id")))
+
+	assert.Error(t, err)
+	assert.Equal(t, "failed to save lambda failure: message_id, err: insert error", err.Error())
+}
+
+func TestListFailuresSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "lambda_failure" WHERE lambda_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT \* FROM "lambda_failure" WHERE lambda_id = \$1 ORDER BY failed_at DESC LIMIT \$2 OFFSET \$3`).
+		WithArgs(1, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "error_code"}).
+			AddRow(3, "Lambda.TooManyRequestsException"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	failures, total, err := repo.ListFailures(ctx, 1, &dto.ReqFailures{Page: 2, Size: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, failures, 1)
+	assert.Equal(t, "Lambda.TooManyRequestsException", failures[0].ErrorCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListFailuresEmpty(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "lambda_failure"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	failures, total, err := repo.ListFailures(ctx, 1, &dto.ReqFailures{Page: 1, Size: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, failures)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindFailuresPending(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda_failure" WHERE lambda_id = \$1 AND redriven_at IS NULL ORDER BY failed_at`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	failures, err := repo.FindFailures(ctx, 1, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, failures, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindFailuresByIDs(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda_failure" WHERE lambda_id = \$1 AND id IN \(\$2,\$3\) ORDER BY failed_at`).
+		WithArgs(1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	failures, err := repo.FindFailures(ctx, 1, []uint64{2, 3})
+
+	assert.NoError(t, err)
+	assert.Len(t, failures, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateFailureNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda_failure" SET .* WHERE id = \$\d+`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.UpdateFailure(ctx, 1, model.BuildLambdaFailure(model.WithRedrive("request_id", time.Now())))
+
+	assert.Equal(t, errorx.NotFound("none failure found by: 1"), err)
+}
+
+func TestFindLambdaSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda" WHERE id = \$1`).
+		WithArgs(2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "function_name", "on_success"}).AddRow(2, "func2", 3))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	lamb, err := repo.FindLambda(ctx, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "func2", lamb.FunctionName)
+	assert.Equal(t, uint64(3), *lamb.OnSuccess)
+	assert.Nil(t, lamb.OnFailure)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindLambdaByArnNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda" WHERE function_arn = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	lamb, err := repo.FindLambdaByArn(ctx, "function_arn")
+
+	assert.Nil(t, lamb)
+	assert.Equal(t, errorx.NotFound("none lambda found by: function_arn"), err)
+}
+
+func TestFindRuntimeNonceSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT runtime_nonce FROM "lambda" WHERE function_name = \$1`).
+		WithArgs("function_name", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"runtime_nonce"}).AddRow("nonce"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	nonce, err := repo.FindRuntimeNonce(ctx, "function_name")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "nonce", nonce)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindRuntimeNonceNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT runtime_nonce FROM "lambda"`).
+		WillReturnRows(sqlmock.NewRows([]string{"runtime_nonce"}))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	nonce, err := repo.FindRuntimeNonce(ctx, "function_name")
+
+	assert.Empty(t, nonce)
+	assert.Equal(t, errorx.NotFound("none lambda found by: function_name"), err)
+}
+
+func TestSetChainSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+	onSuccess := uint64(2)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda" SET "on_failure"=\$1,"on_success"=\$2 WHERE id = \$3`).
+		WithArgs(nil, onSuccess, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SetChain(ctx, 1, &onSuccess, nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetChainNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda" SET`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SetChain(ctx, 1, nil, nil)
+
+	assert.Equal(t, errorx.NotFound("none lambda found by: 1"), err)
+}
+
+func TestTrackedExecutionSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda_execution" WHERE execution_id = \$1`).
+		WithArgs("request_id", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lambda_id", "execution_id", "status", "chain_id"}).
+			AddRow(1, 2, "request_id", constant.ExecutionPending, "chain_id"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	exe, err := repo.TrackedExecution(ctx, "request_id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), exe.LambdaID)
+	assert.Equal(t, "chain_id", exe.ChainID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListTriggersByLambda(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT lt.\*, l.function_name AS lambda FROM lambda_trigger AS lt JOIN lambda AS l ON l.id = lt.lambda_id `+
+		`WHERE l.account_id = \$1 AND lt.lambda_id = \$2 ORDER BY lt.id`).
+		WithArgs(123, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lambda_id", "lambda", "type", "address", "cursor"}).
+			AddRow(1, 1, "testFunc", constant.TriggerPayment, "GADDRESS", "now"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	triggers, err := repo.ListTriggers(ctx, 123, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, "testFunc", triggers[0].Lambda)
+	assert.Equal(t, constant.TriggerPayment, triggers[0].Type)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTriggerNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "lambda_trigger" WHERE id = \$1 AND lambda_id IN \(SELECT id FROM "lambda" WHERE account_id = \$2\)`).
+		WithArgs(7, 123).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.DeleteTrigger(ctx, 123, 7)
+
+	assert.Equal(t, errorx.NotFound("none trigger found by: 7"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWatchedTriggersSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT lt.\*, l.function_name AS lambda, o.name AS organization, u.account AS account FROM lambda_trigger AS lt`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lambda_id", "type", "address", "cursor", "organization", "account"}).
+			AddRow(1, 1, constant.TriggerEffect, "GADDRESS", "123-1", "test-org", "test-account"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	triggers, err := repo.WatchedTriggers(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, triggers, 1)
+	assert.Equal(t, "test-org", triggers[0].Organization)
+	assert.Equal(t, "test-account", triggers[0].Account)
+	assert.Equal(t, "123-1", triggers[0].Cursor)
+}
+
+func TestFindTriggerNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT \* FROM "lambda_trigger" WHERE id = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	trigger, err := repo.FindTrigger(ctx, 7)
+
+	assert.Nil(t, trigger)
+	assert.Equal(t, errorx.NotFound("none trigger found by: 7"), err)
+}
+
+func TestAdvanceCursorClaimed(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda_trigger" SET "cursor"=\$1,"updated_at"=\$2 WHERE id = \$3 AND cursor = \$4`).
+		WithArgs("124", sqlmock.AnyArg(), 1, "123").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	claimed, err := repo.AdvanceCursor(ctx, 1, "123", "124")
+
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdvanceCursorMoved(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda_trigger" SET`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	claimed, err := repo.AdvanceCursor(ctx, 1, "123", "124")
+
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+}
+
+func TestSaveTriggerExisted(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "lambda_trigger" (.+) ON CONFLICT \("lambda_id","type","address","topics"\) DO NOTHING`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SaveTrigger(ctx, &model.LambdaTrigger{
+		LambdaID: 1,
+		Type:     constant.TriggerContractEvent,
+		Address:  "CCONTRACT",
+		Topics:   [][]string{{"AAAADwAAAAh0cmFuc2Zlcg==", "*"}},
+		Cursor:   constant.TriggerCursorNow,
+	})
+
+	assert.Equal(t, errorx.BadRequest("the contract_event trigger of: CCONTRACT exists already"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdvanceLedgerClaimed(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "lambda_trigger" SET "cursor"=\$1,"ledger"=\$2,"updated_at"=\$3 WHERE id = \$4 AND cursor = \$5`).
+		WithArgs("0004294967296000-0000000001", 1000, sqlmock.AnyArg(), 1, "now").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	claimed, err := repo.AdvanceLedger(ctx, 1, "now", 1000, "0004294967296000-0000000001")
+
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListWebhooksByLambda(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT lw.id, lw.lambda_id, lw.hook_id, lw.headers, lw.created_at, lw.updated_at, l.function_name AS lambda `+
+		`FROM lambda_webhook AS lw JOIN lambda AS l ON l.id = lw.lambda_id WHERE l.account_id = \$1 AND lw.lambda_id = \$2 ORDER BY lw.id`).
+		WithArgs(123, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lambda_id", "hook_id", "headers", "lambda"}).
+			AddRow(1, 1, "hook1", `["X-Github-Event"]`, "testFunc"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	webhooks, err := repo.ListWebhooks(ctx, 123, 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.Equal(t, "hook1", webhooks[0].HookID)
+	assert.Equal(t, []string{"X-Github-Event"}, webhooks[0].Headers)
+	assert.Empty(t, webhooks[0].Secret)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRotateWebhookNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE "lambda_webhook" SET "secret"=\$1,"updated_at"=\$2 WHERE lambda_id = \$3 RETURNING \*`).
+		WithArgs("whsec_new", sqlmock.AnyArg(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	webhook, err := repo.RotateWebhook(ctx, 1, "whsec_new", nil)
+
+	assert.Nil(t, webhook)
+	assert.Equal(t, errorx.NotFound("none webhook found of lambda: 1"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFindWebhookSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT lw.\*, l.function_name AS lambda, o.name AS organization, u.account AS account FROM lambda_webhook AS lw `+
+		`(.+) WHERE lw.hook_id = \$1`).
+		WithArgs("hook1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "lambda_id", "hook_id", "secret", "headers", "organization", "account"}).
+			AddRow(1, 2, "hook1", "whsec_test", `[]`, "test-org", "test-account"))
+
+	repo := &lambda{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	webhook, err := repo.FindWebhook(ctx, "hook1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "whsec_test", webhook.Secret)
+	assert.Equal(t, "test-account", webhook.Account)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,169 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/db"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination ../testdata/secret_mock.go -package testdata -source secret.go Secret
+type (
+	Secret interface {
+		SaveSecret(c context.Context, secret *model.Secret) error
+		GrantSecret(c context.Context, secretID uint64, lambdaIDs []uint64) error
+		ListSecrets(c context.Context, acnID uint64) ([]*dto.RespSecret, error)
+		ListGrants(c context.Context, secretIDs []uint64) ([]*dto.RespSecretGrant, error)
+		DeleteSecret(c context.Context, acnID uint64, name string) error
+		LambdaSecrets(c context.Context, acnID uint64, functionName string) ([]*model.Secret, error)
+	}
+	secret struct {
+		Instance *db.Instance
+	}
+)
+
+var SecretRepo Secret
+
+func NewSecret() {
+	if SecretRepo == nil {
+		SecretRepo = &secret{
+			Instance: db.Inst,
+		}
+	}
+}
+
+// SaveSecret saves the secret, the value of the one existing is replaced, the ID of it is filled.
+func (s *secret) SaveSecret(c context.Context, secret *model.Secret) error {
+	if err := s.Instance.Conn(c).Table(model.TabNameSecret()).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "account_id"}, {Name: "name"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"value":      secret.Value,
+				"updated_at": time.Now().UTC(),
+			}),
+		}, clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Create(secret).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to save secret: %s, err: %s", secret.Name, err.Error()))
+	}
+
+	return nil
+}
+
+// GrantSecret replaces the Lambdas granted with the secret.
+func (s *secret) GrantSecret(c context.Context, secretID uint64, lambdaIDs []uint64) error {
+	return s.Instance.Conn(c).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("secret_id = ?", secretID).
+			Delete(&model.LambdaSecret{}).Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to revoke secret: %d, err: %s", secretID, err.Error()))
+		}
+
+		if len(lambdaIDs) == 0 {
+			return nil
+		}
+
+		grants := make([]*model.LambdaSecret, 0, len(lambdaIDs))
+		for _, lambdaID := range lambdaIDs {
+			grants = append(grants, &model.LambdaSecret{LambdaID: lambdaID, SecretID: secretID})
+		}
+		if err := tx.Table(model.TabNameLambdaSecret()).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&grants).Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to grant secret: %d, err: %s", secretID, err.Error()))
+		}
+
+		return nil
+	})
+}
+
+// ListSecrets lists the secrets of the account by the names, without the values.
+func (s *secret) ListSecrets(c context.Context, acnID uint64) ([]*dto.RespSecret, error) {
+	secrets := make([]*dto.RespSecret, 0)
+	if err := s.Instance.Conn(c).Table(model.TabNameSecret()).
+		Select("id, name, created_at, updated_at").
+		Where("account_id = ?", acnID).
+		Order("name").
+		Find(&secrets).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query secrets, err: %s", err.Error()))
+	}
+
+	return secrets, nil
+}
+
+// ListGrants lists the Lambdas granted with the secrets.
+func (s *secret) ListGrants(c context.Context, secretIDs []uint64) ([]*dto.RespSecretGrant, error) {
+	grants := make([]*dto.RespSecretGrant, 0)
+	if len(secretIDs) == 0 {
+		return grants, nil
+	}
+
+	if err := s.Instance.Conn(c).Table(model.TabNameLambdaSecretAbbr()).
+		Select("ls.secret_id, l.function_name AS lambda").
+		Joins("JOIN lambda AS l ON l.id = ls.lambda_id").
+		Where("ls.secret_id IN ?", secretIDs).
+		Order("l.function_name").
+		Find(&grants).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query secret grants, err: %s", err.Error()))
+	}
+
+	return grants, nil
+}
+
+// DeleteSecret deletes the secret of the account, along with the grants of it.
+func (s *secret) DeleteSecret(c context.Context, acnID uint64, name string) error {
+	return s.Instance.Conn(c).Transaction(func(tx *gorm.DB) error {
+		deleted := new(model.Secret)
+		result := tx.Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("account_id = ? AND name = ?", acnID, name).
+			Delete(deleted)
+		if err := result.Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to delete secret: %s, err: %s", name, err.Error()))
+		}
+		if result.RowsAffected == 0 {
+			return errorx.NotFound(fmt.Sprintf("none secret found by: %s", name))
+		}
+
+		if err := tx.
+			Where("secret_id = ?", deleted.ID).
+			Delete(&model.LambdaSecret{}).Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to revoke secret: %s, err: %s", name, err.Error()))
+		}
+
+		return nil
+	})
+}
+
+// LambdaSecrets finds the secrets granted to the function of the account, along with the encrypted values.
+func (s *secret) LambdaSecrets(c context.Context, acnID uint64, functionName string) ([]*model.Secret, error) {
+	var lambdaID uint64
+	if err := s.Instance.Conn(c).Table(model.TabNameLambda()).
+		Select("id").
+		Where("account_id = ? AND function_name = ?", acnID, functionName).
+		Take(&lambdaID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.NotFound(fmt.Sprintf("none lambda found by: %s", functionName))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query lambda: %s, err: %s", functionName, err.Error()))
+	}
+
+	secrets := make([]*model.Secret, 0)
+	if err := s.Instance.Conn(c).Table(model.TabNameSecretAbbr()).
+		Select("s.*").
+		Joins("JOIN lambda_secret AS ls ON ls.secret_id = s.id").
+		Where("ls.lambda_id = ?", lambdaID).
+		Order("s.name").
+		Find(&secrets).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query secrets of lambda: %s, err: %s", functionName, err.Error()))
+	}
+
+	return secrets, nil
+}
@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/db"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveSecretSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "secret" (.+) ON CONFLICT \("account_id","name"\) DO UPDATE SET ` +
+		`"updated_at"=\$\d+,"value"=\$\d+ RETURNING "id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectCommit()
+
+	repo := &secret{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	secret := &model.Secret{AccountID: 1, Name: "API_KEY", Value: "encrypted"}
+	err := repo.SaveSecret(ctx, secret)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), secret.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGrantSecretSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "lambda_secret" WHERE secret_id = \$1`).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO "lambda_secret" (.+) ON CONFLICT DO NOTHING RETURNING "id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	repo := &secret{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.GrantSecret(ctx, 3, []uint64{4, 5})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListGrantsSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT ls.secret_id, l.function_name AS lambda FROM lambda_secret AS ls `+
+		`JOIN lambda AS l ON l.id = ls.lambda_id WHERE ls.secret_id IN \(\$1,\$2\)`).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"secret_id", "lambda"}).AddRow(1, "org-acn-func"))
+
+	repo := &secret{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	grants, err := repo.ListGrants(ctx, []uint64{1, 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, grants, 1)
+	assert.Equal(t, "org-acn-func", grants[0].Lambda)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSecretNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`DELETE FROM "secret" WHERE account_id = \$1 AND name = \$2 RETURNING "id"`).
+		WithArgs(1, "API_KEY").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	repo := &secret{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.DeleteSecret(ctx, 1, "API_KEY")
+
+	assert.Equal(t, errorx.NotFound("none secret found by: API_KEY"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLambdaSecretsSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT id FROM "lambda" WHERE account_id = \$1 AND function_name = \$2`).
+		WithArgs(1, "org-acn-func", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectQuery(`SELECT s.\* FROM secret AS s JOIN lambda_secret AS ls ON ls.secret_id = s.id ` +
+		`WHERE ls.lambda_id = \$1 ORDER BY s.name`).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "value"}).AddRow(3, "API_KEY", "encrypted"))
+
+	repo := &secret{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	secrets, err := repo.LambdaSecrets(ctx, 1, "org-acn-func")
+
+	assert.NoError(t, err)
+	assert.Len(t, secrets, 1)
+	assert.Equal(t, "encrypted", secrets[0].Value)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
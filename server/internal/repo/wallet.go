@@ -0,0 +1,155 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/db"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -destination ../testdata/wallet_mock.go -package testdata -source wallet.go Wallet
+type (
+	Wallet interface {
+		FundingWallet(c context.Context, orgID uint64) (*model.FundingWallet, error)
+		SetFundingWallet(c context.Context, orgID, accountID uint64, address string) error
+		CountActivations(c context.Context, orgID uint64, since time.Time) (int64, error)
+		ReserveActivation(c context.Context, activation *model.WalletActivation, since time.Time, dailyCap int) error
+		CompleteActivation(c context.Context, id uint64, hash string) error
+		ReleaseActivation(c context.Context, id uint64) error
+	}
+	wallet struct {
+		Instance *db.Instance
+	}
+)
+
+var WalletRepo Wallet
+
+func NewWallet() {
+	if WalletRepo == nil {
+		WalletRepo = &wallet{
+			Instance: db.Inst,
+		}
+	}
+}
+
+// FundingWallet finds the wallet designated by the organization to activate the new wallets, empty when none.
+func (w *wallet) FundingWallet(c context.Context, orgID uint64) (*model.FundingWallet, error) {
+	funding := new(model.FundingWallet)
+	if err := w.Instance.Conn(c).Table(model.TabNameOrg()).
+		Select("funding_wallet AS address, funding_account_id AS account_id").
+		Where("id = ?", orgID).
+		Take(funding).Error; err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query funding wallet of organization: %d, err: %s", orgID, err.Error()))
+	}
+
+	return funding, nil
+}
+
+// SetFundingWallet designates the wallet of the account for the organization, the empty address clears it.
+// The one designated by another account is kept.
+func (w *wallet) SetFundingWallet(c context.Context, orgID, accountID uint64, address string) error {
+	owner := accountID
+	if address == "" {
+		owner = 0
+	}
+
+	result := w.Instance.Conn(c).Table(model.TabNameOrg()).
+		Where("id = ? AND funding_account_id IN (0, ?)", orgID, accountID).
+		Updates(map[string]interface{}{
+			"funding_wallet":     address,
+			"funding_account_id": owner,
+			"updated_at":         time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return errorx.Internal(fmt.Sprintf("failed to set funding wallet of organization: %d, err: %s", orgID, result.Error.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.ForbiddenWithMsg("the funding wallet of the organization is designated by another account")
+	}
+
+	return nil
+}
+
+// CountActivations counts the wallets activated for the organization since the time.
+func (w *wallet) CountActivations(c context.Context, orgID uint64, since time.Time) (int64, error) {
+	var count int64
+	if err := w.Instance.Conn(c).Table(model.TabNameWalletActivation()).
+		Where("organization_id = ? AND created_at >= ?", orgID, since).
+		Count(&count).Error; err != nil {
+		return 0, errorx.Internal(fmt.Sprintf("failed to count wallet activations, err: %s", err.Error()))
+	}
+
+	return count, nil
+}
+
+// ReserveActivation records the activation pending for the hash, when the activations of the organization
+// since the time are under the cap. The organization is locked meanwhile, so the concurrent ones are counted in turn.
+func (w *wallet) ReserveActivation(
+	c context.Context,
+	activation *model.WalletActivation,
+	since time.Time,
+	dailyCap int,
+) error {
+	return w.Instance.Conn(c).Transaction(func(tx *gorm.DB) error {
+		var orgID uint64
+		if err := tx.Table(model.TabNameOrg()).
+			Select("id").
+			Where("id = ?", activation.OrganizationID).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Take(&orgID).Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to lock organization: %d, err: %s", activation.OrganizationID, err.Error()))
+		}
+
+		var count int64
+		if err := tx.Table(model.TabNameWalletActivation()).
+			Where("organization_id = ? AND created_at >= ?", activation.OrganizationID, since).
+			Count(&count).Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to count wallet activations, err: %s", err.Error()))
+		}
+		if count >= int64(dailyCap) {
+			return errorx.BadRequest(fmt.Sprintf("the number of wallet activations is limited to %d per day", dailyCap))
+		}
+
+		if err := tx.Table(model.TabNameWalletActivation()).
+			Create(activation).Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to save wallet activation: %s, err: %s", activation.Address, err.Error()))
+		}
+
+		return nil
+	})
+}
+
+// CompleteActivation records the hash of the transaction submitted for the activation reserved.
+func (w *wallet) CompleteActivation(c context.Context, id uint64, hash string) error {
+	result := w.Instance.Conn(c).Table(model.TabNameWalletActivation()).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"hash":       hash,
+			"updated_at": time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return errorx.Internal(fmt.Sprintf("failed to update wallet activation: %d, err: %s", id, result.Error.Error()))
+	}
+	if result.RowsAffected == 0 {
+		return errorx.Internal(fmt.Sprintf("none wallet activation found by: %d", id))
+	}
+
+	return nil
+}
+
+// ReleaseActivation deletes the activation reserved, which is failed to submit.
+func (w *wallet) ReleaseActivation(c context.Context, id uint64) error {
+	if err := w.Instance.Conn(c).Table(model.TabNameWalletActivation()).
+		Where("id = ?", id).
+		Delete(&model.WalletActivation{}).Error; err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to delete wallet activation: %d, err: %s", id, err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/db"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFundingWalletSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectQuery(`SELECT funding_wallet AS address, funding_account_id AS account_id FROM "organization" WHERE id = \$1 LIMIT \$2`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"address", "account_id"}).AddRow("GFUNDER", 3))
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	funding, err := repo.FundingWallet(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &model.FundingWallet{Address: "GFUNDER", AccountID: 3}, funding)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetFundingWalletSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "organization" SET "funding_account_id"=\$1,"funding_wallet"=\$2,"updated_at"=\$3 `+
+		`WHERE id = \$4 AND funding_account_id IN \(0, \$5\)`).
+		WithArgs(3, "GFUNDER", sqlmock.AnyArg(), 1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SetFundingWallet(ctx, 1, 3, "GFUNDER")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetFundingWalletClear(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "organization" SET "funding_account_id"=\$1,"funding_wallet"=\$2`).
+		WithArgs(0, "", sqlmock.AnyArg(), 1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SetFundingWallet(ctx, 1, 3, "")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetFundingWalletDesignatedByOther(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "organization"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.SetFundingWallet(ctx, 1, 3, "GFUNDER")
+
+	assert.Equal(t, errorx.ForbiddenWithMsg("the funding wallet of the organization is designated by another account"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountActivationsSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+	since := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "wallet_activation" WHERE organization_id = \$1 AND created_at >= \$2`).
+		WithArgs(1, since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	count, err := repo.CountActivations(ctx, 1, since)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReserveActivationSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+	since := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM "organization" WHERE id = \$1 LIMIT \$2 FOR UPDATE`).
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "wallet_activation" WHERE organization_id = \$1 AND created_at >= \$2`).
+		WithArgs(1, since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(9))
+	mock.ExpectQuery(`INSERT INTO "wallet_activation" (.+) RETURNING "id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectCommit()
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	activation := &model.WalletActivation{
+		OrganizationID:  1,
+		AccountID:       2,
+		Address:         "GNEW",
+		Funder:          "GFUNDER",
+		StartingBalance: "1.5",
+	}
+	err := repo.ReserveActivation(ctx, activation, since, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), activation.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReserveActivationCapReached(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM "organization" .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(`SELECT count\(\*\) FROM "wallet_activation"`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+	mock.ExpectRollback()
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.ReserveActivation(ctx, &model.WalletActivation{OrganizationID: 1, Address: "GNEW"}, time.Now(), 10)
+
+	assert.Equal(t, errorx.BadRequest("the number of wallet activations is limited to 10 per day"), err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompleteActivationSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "wallet_activation" SET "hash"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+		WithArgs("test_hash", sqlmock.AnyArg(), 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.CompleteActivation(ctx, 5, "test_hash")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompleteActivationNotFound(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "wallet_activation"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.CompleteActivation(ctx, 5, "test_hash")
+
+	assert.Equal(t, errorx.Internal("none wallet activation found by: 5"), err)
+}
+
+func TestReleaseActivationSuccess(t *testing.T) {
+	sqldb, gormdb, mock := DbMock(t)
+	defer sqldb.Close()
+
+	ctx := new(gin.Context)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "wallet_activation" WHERE id = \$1`).
+		WithArgs(5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &wallet{
+		Instance: &db.Instance{DB: gormdb},
+	}
+	err := repo.ReleaseActivation(ctx, 5)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
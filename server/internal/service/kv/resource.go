@@ -0,0 +1,107 @@
+package kv
+
+import (
+	"net/http"
+
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	Resource interface {
+		Get(c *gin.Context)
+		Put(c *gin.Context)
+		Remove(c *gin.Context)
+		List(c *gin.Context)
+	}
+	resource struct {
+		service KVService
+	}
+)
+
+var ResourceImpl Resource
+
+func NewKVResource() {
+	if ResourceImpl == nil {
+		ResourceImpl = &resource{
+			service: KVServiceImpl,
+		}
+	}
+}
+
+func (re *resource) Get(c *gin.Context) {
+	req := new(dto.ReqKVEntry)
+
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Get(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Put(c *gin.Context) {
+	req := new(dto.ReqKVPut)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Put(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Remove(c *gin.Context) {
+	req := new(dto.ReqKVEntry)
+
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := re.service.Remove(c, req); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
+
+func (re *resource) List(c *gin.Context) {
+	req := new(dto.ReqKVList)
+
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.List(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
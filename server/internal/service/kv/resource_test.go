@@ -0,0 +1,128 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceGetSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/kv/entry?key=user%2F1&action=action1&version=2", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	mockService := testdata.NewMockKVService(ctrl)
+
+	mockResp := &dto.RespKVEntry{Key: "user/1", Value: "42", Version: 2}
+	mockService.EXPECT().Get(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqKVEntry) (*dto.RespKVEntry, error) {
+			assert.Equal(t, "user/1", r.Key)
+			assert.Equal(t, "action1", r.Action)
+			assert.Equal(t, int64(2), *r.Version)
+			return mockResp, nil
+		})
+
+	re := &resource{
+		service: mockService,
+	}
+
+	re.Get(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+
+	resp := &dto.RespKVEntry{}
+	err := json.Unmarshal(w.Body.Bytes(), resp)
+	assert.Nil(t, err)
+	assert.Equal(t, mockResp, resp)
+}
+
+func TestResourcePutSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("PUT", "/kv/entry",
+		bytes.NewBufferString(`{"key":"counter","value":"42","ttl":60,"version":0}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	mockService := testdata.NewMockKVService(ctrl)
+
+	mockService.EXPECT().Put(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqKVPut) (*dto.RespKVEntry, error) {
+			assert.Equal(t, "counter", r.Key)
+			assert.Equal(t, int64(60), r.TTL)
+			assert.Equal(t, int64(0), *r.Version)
+			return &dto.RespKVEntry{Key: "counter", Version: 1}, nil
+		})
+
+	re := &resource{
+		service: mockService,
+	}
+
+	re.Put(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourcePutInvalidBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("PUT", "/kv/entry", bytes.NewBufferString(`{"key":`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	re := &resource{
+		service: testdata.NewMockKVService(ctrl),
+	}
+
+	re.Put(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
+
+func TestResourceListServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/kv?prefix=user", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	mockService := testdata.NewMockKVService(ctrl)
+	mockService.EXPECT().List(ctx, gomock.Any()).Return(nil, errors.New("error"))
+
+	re := &resource{
+		service: mockService,
+	}
+
+	re.List(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "error", ctx.Errors.Last().Error())
+}
@@ -0,0 +1,210 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/repo"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:generate mockgen -destination ../../testdata/kv_service_mock.go -package testdata -source service.go KVService
+type (
+	KVService interface {
+		Get(c context.Context, r *dto.ReqKVEntry) (*dto.RespKVEntry, error)
+		Put(c context.Context, r *dto.ReqKVPut) (*dto.RespKVEntry, error)
+		Remove(c context.Context, r *dto.ReqKVEntry) error
+		List(c context.Context, r *dto.ReqKVList) (*dto.RespKVEntries, error)
+	}
+	service struct {
+		oauthRepo  repo.OAuth
+		lambdaRepo repo.Lambda
+		kvRepo     repo.KV
+	}
+)
+
+var KVServiceImpl KVService
+
+func NewKVService() {
+	if KVServiceImpl == nil {
+		repo.NewOAuth()
+		repo.NewLambda()
+		repo.NewKV()
+
+		KVServiceImpl = &service{
+			oauthRepo:  repo.OAuthRepo,
+			lambdaRepo: repo.LambdaRepo,
+			kvRepo:     repo.KVRepo,
+		}
+	}
+}
+
+// Get gets the entry of the key, of the account, or of the action when given.
+func (svc *service) Get(c context.Context, r *dto.ReqKVEntry) (*dto.RespKVEntry, error) {
+	if err := validateKey(r.Key); err != nil {
+		return nil, err
+	}
+
+	acnID, lambdaID, err := svc.namespace(c, r.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.kvRepo.FindEntry(c, acnID, lambdaID, r.Key)
+}
+
+// Put puts the value of the entry, compared and swapped when the version is given.
+// The number of the entries of each account and the size of each value are limited by the config.
+func (svc *service) Put(c context.Context, r *dto.ReqKVPut) (*dto.RespKVEntry, error) {
+	if err := validateKey(r.Key); err != nil {
+		return nil, err
+	}
+	if maxSize := maxValueSize(); len(r.Value) > maxSize {
+		return nil, errorx.BadRequest(fmt.Sprintf("the size of the value is limited to %d bytes", maxSize))
+	}
+	if r.TTL < 0 {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid ttl: %d, should be the seconds after which the entry expires", r.TTL))
+	}
+	if r.Version != nil && *r.Version < 0 {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid version: %d", *r.Version))
+	}
+
+	acnID, lambdaID, err := svc.namespace(c, r.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &model.KVEntry{
+		AccountID: acnID,
+		LambdaID:  lambdaID,
+		Key:       r.Key,
+		Value:     r.Value,
+	}
+	if r.TTL > 0 {
+		expiresAt := time.Now().UTC().Add(time.Duration(r.TTL) * time.Second)
+		entry.ExpiresAt = &expiresAt
+	}
+
+	// only the entries new are limited, the ones existing are always updatable
+	if err := svc.kvRepo.PutEntry(c, entry, r.Version, maxKeys()); err != nil {
+		return nil, err
+	}
+
+	return &dto.RespKVEntry{
+		Key:       entry.Key,
+		Version:   entry.Version,
+		ExpiresAt: entry.ExpiresAt,
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}, nil
+}
+
+// Remove removes the entry of the key, only when it's still at the version if given.
+func (svc *service) Remove(c context.Context, r *dto.ReqKVEntry) error {
+	if err := validateKey(r.Key); err != nil {
+		return err
+	}
+
+	acnID, lambdaID, err := svc.namespace(c, r.Action)
+	if err != nil {
+		return err
+	}
+
+	return svc.kvRepo.DeleteEntry(c, acnID, lambdaID, r.Key, r.Version)
+}
+
+// List lists the entries of the keys starting with the prefix, without the values,
+// truncated by the limit.
+func (svc *service) List(c context.Context, r *dto.ReqKVList) (*dto.RespKVEntries, error) {
+	if r.Limit == 0 {
+		r.Limit = constant.KVListLimit
+	}
+	if r.Limit < 0 || r.Limit > constant.KVListLimitMax {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid limit: %d, should be between 1 and %d",
+			r.Limit, constant.KVListLimitMax))
+	}
+
+	acnID, lambdaID, err := svc.namespace(c, r.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	// one more to tell whether there are more than the limit
+	entries, err := svc.kvRepo.ListEntries(c, acnID, lambdaID, r.Prefix, r.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	truncated := len(entries) > r.Limit
+	if truncated {
+		entries = entries[:r.Limit]
+	}
+
+	return &dto.RespKVEntries{
+		Entries:   entries,
+		Truncated: truncated,
+	}, nil
+}
+
+// namespace resolves the account in JWT, and the Lambda of the action when given, 0 for the one shared by the account.
+func (svc *service) namespace(c context.Context, action string) (uint64, uint64, error) {
+	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if action == "" {
+		return user.ID, 0, nil
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, action)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return user.ID, lamb.ID, nil
+}
+
+func maxKeys() int {
+	if keys := config.GlobalConfig.KV.MaxKeys; keys > 0 {
+		return keys
+	}
+
+	return constant.KVDefaultMaxKeys
+}
+
+func maxValueSize() int {
+	if size := config.GlobalConfig.KV.MaxValueSize; size > 0 {
+		return size
+	}
+
+	return constant.KVDefaultMaxValueSize
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return errorx.BadRequest("the key is required")
+	}
+	if len(key) > constant.KVKeyMax {
+		return errorx.BadRequest(fmt.Sprintf("the size of the key is limited to %d bytes", constant.KVKeyMax))
+	}
+	if !utf8.ValidString(key) {
+		return errorx.BadRequest("the key should be valid UTF-8")
+	}
+
+	return nil
+}
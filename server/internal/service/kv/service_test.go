@@ -0,0 +1,226 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Before test, setup log and config
+func TestMain(m *testing.M) {
+	config.GlobalConfig = &config.Configuration{
+		KV: config.KV{
+			MaxKeys:      2,
+			MaxValueSize: 8,
+		},
+	}
+	logx.Setup(&config.Configuration{
+		Log: config.Log{
+			Level:    "debug",
+			Encoding: "json",
+		},
+	})
+
+	os.Exit(m.Run())
+}
+
+func testContext() *gin.Context {
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	return ctx
+}
+
+func TestGetSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockKVRepo := testdata.NewMockKV(ctrl)
+
+	ctx := testContext()
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, req *dto.ReqOrgAcn) (*dto.RespUser, error) {
+			assert.Equal(t, "org_name", req.OrgName)
+			assert.Equal(t, "account_name", req.AcnName)
+			return &dto.RespUser{ID: 1}, nil
+		})
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(1), "action1").Times(1).
+		Return(&dto.RespInfo{ID: 2}, nil)
+	mockKVRepo.EXPECT().FindEntry(ctx, uint64(1), uint64(2), "counter").Times(1).
+		Return(&dto.RespKVEntry{Key: "counter", Value: "42", Version: 3}, nil)
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		lambdaRepo: mockLambRepo,
+		kvRepo:     mockKVRepo,
+	}
+
+	resp, err := svc.Get(ctx, &dto.ReqKVEntry{Key: "counter", Action: "action1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "42", resp.Value)
+	assert.Equal(t, int64(3), resp.Version)
+}
+
+func TestGetInvalidKey(t *testing.T) {
+	svc := &service{}
+
+	_, err := svc.Get(testContext(), &dto.ReqKVEntry{})
+	assert.Equal(t, errorx.BadRequest("the key is required"), err)
+}
+
+func TestPutNewEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockKVRepo := testdata.NewMockKV(ctrl)
+
+	ctx := testContext()
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockKVRepo.EXPECT().PutEntry(ctx, gomock.Any(), nil, 2).Times(1).
+		DoAndReturn(func(_ context.Context, entry *model.KVEntry, _ *int64, _ int) error {
+			assert.Equal(t, uint64(1), entry.AccountID)
+			assert.Equal(t, uint64(0), entry.LambdaID)
+			assert.Equal(t, "42", entry.Value)
+			assert.NotNil(t, entry.ExpiresAt)
+			entry.Version = 1
+			return nil
+		})
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		kvRepo:    mockKVRepo,
+	}
+
+	resp, err := svc.Put(ctx, &dto.ReqKVPut{Key: "counter", Value: "42", TTL: 60})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Version)
+	assert.NotNil(t, resp.ExpiresAt)
+}
+
+func TestPutKeysLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockKVRepo := testdata.NewMockKV(ctrl)
+
+	ctx := testContext()
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockKVRepo.EXPECT().PutEntry(ctx, gomock.Any(), nil, 2).Times(1).
+		Return(errorx.BadRequest("the number of kv entries is limited to 2"))
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		kvRepo:    mockKVRepo,
+	}
+
+	_, err := svc.Put(ctx, &dto.ReqKVPut{Key: "counter", Value: "42"})
+	assert.Equal(t, errorx.BadRequest("the number of kv entries is limited to 2"), err)
+}
+
+func TestPutCompareAndSwap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockKVRepo := testdata.NewMockKV(ctrl)
+
+	ctx := testContext()
+	version := int64(3)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockKVRepo.EXPECT().PutEntry(ctx, gomock.Any(), &version, 2).Times(1).
+		Return(errorx.Conflict("the entry: counter is not at version: 3"))
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		kvRepo:    mockKVRepo,
+	}
+
+	_, err := svc.Put(ctx, &dto.ReqKVPut{Key: "counter", Value: "43", Version: &version})
+	assert.Equal(t, errorx.Conflict("the entry: counter is not at version: 3"), err)
+}
+
+func TestPutValueTooLarge(t *testing.T) {
+	svc := &service{}
+
+	_, err := svc.Put(testContext(), &dto.ReqKVPut{Key: "counter", Value: "123456789"})
+	assert.Equal(t, errorx.BadRequest("the size of the value is limited to 8 bytes"), err)
+}
+
+func TestRemoveSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockKVRepo := testdata.NewMockKV(ctrl)
+
+	ctx := testContext()
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockKVRepo.EXPECT().DeleteEntry(ctx, uint64(1), uint64(0), "counter", nil).Times(1).
+		Return(nil)
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		kvRepo:    mockKVRepo,
+	}
+
+	assert.NoError(t, svc.Remove(ctx, &dto.ReqKVEntry{Key: "counter"}))
+}
+
+func TestListTruncated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockKVRepo := testdata.NewMockKV(ctrl)
+
+	ctx := testContext()
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockKVRepo.EXPECT().ListEntries(ctx, uint64(1), uint64(0), "user/", 3).Times(1).
+		Return([]*dto.RespKVEntry{{Key: "user/1"}, {Key: "user/2"}, {Key: "user/3"}}, nil)
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		kvRepo:    mockKVRepo,
+	}
+
+	resp, err := svc.List(ctx, &dto.ReqKVList{Prefix: "user/", Limit: 2})
+	assert.NoError(t, err)
+	assert.True(t, resp.Truncated)
+	assert.Len(t, resp.Entries, 2)
+	assert.Equal(t, "user/2", resp.Entries[1].Key)
+}
+
+func TestListInvalidLimit(t *testing.T) {
+	svc := &service{}
+
+	_, err := svc.List(testContext(), &dto.ReqKVList{Limit: constant.KVListLimitMax + 1})
+	assert.Equal(t, errorx.BadRequest("invalid limit: 1001, should be between 1 and 1000"), err)
+}
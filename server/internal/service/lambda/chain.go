@@ -0,0 +1,332 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// chainScheduledInvoker the invoker of the executions chained from the scheduled invocations, which are not tracked.
+const chainScheduledInvoker = "scheduler"
+
+// SetChain sets the downstream Lambdas of the Lambda, which are invoked asynchronously with its output
+// once it succeeded or failed. The chains are driven by the callback of the asynchronous invocations,
+// and the ones making a cycle are rejected.
+func (svc *service) SetChain(c context.Context, r *dto.ReqChain) (*dto.Chain, error) {
+	callbackARN := config.GlobalConfig.Lambda.Callback.Arn
+	if callbackARN == "" {
+		return nil, errorx.BadRequest("action chaining is not enabled on the server")
+	}
+
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	onSuccess, err := svc.chainTarget(c, user.ID, r.OnSuccess, lamb.OnSuccess)
+	if err != nil {
+		return nil, err
+	}
+	onFailure, err := svc.chainTarget(c, user.ID, r.OnFailure, lamb.OnFailure)
+	if err != nil {
+		return nil, err
+	}
+
+	lambs, err := svc.lambdaRepo.FindByAccount(c, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lambs {
+		if l.ID == lamb.ID {
+			l.OnSuccess, l.OnFailure = onSuccess, onFailure
+		}
+	}
+
+	if cycle := chainCycle(lambs, lamb.ID); len(cycle) > 0 {
+		return nil, errorx.BadRequest(fmt.Sprintf("chain cycle detected: %s", strings.Join(cycle, " -> ")))
+	}
+
+	if onSuccess != nil || onFailure != nil {
+		// the scheduled invocations are reported to the callback as well, so that they are chained too
+		if err := svc.ensureDestination(c, lamb, callbackARN); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := svc.lambdaRepo.SetChain(c, lamb.ID, onSuccess, onFailure); err != nil {
+		return nil, err
+	}
+
+	return chainGraph(lambs, lamb.ID), nil
+}
+
+// chainTarget resolves the ID of the downstream Lambda by the name or ARN,
+// the current one is kept when none given, and unset by the empty one.
+func (svc *service) chainTarget(c context.Context, acnID uint64, target *string, current *uint64) (*uint64, error) {
+	if target == nil {
+		return current, nil
+	}
+	if strings.TrimSpace(*target) == "" {
+		return nil, nil
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, acnID, strings.TrimSpace(*target))
+	if err != nil {
+		return nil, err
+	}
+
+	return &lamb.ID, nil
+}
+
+func chainTargets(lamb *dto.RespInfo) []uint64 {
+	targets := make([]uint64, 0, 2)
+	if lamb.OnSuccess != nil {
+		targets = append(targets, *lamb.OnSuccess)
+	}
+	if lamb.OnFailure != nil {
+		targets = append(targets, *lamb.OnFailure)
+	}
+
+	return targets
+}
+
+// chainCycle finds the cycle back to the Lambda through the downstream ones, by the names along it.
+// The chains are kept acyclic, so any cycle made by changing the Lambda passes through it.
+func chainCycle(lambs []*dto.RespInfo, start uint64) []string {
+	byID := make(map[uint64]*dto.RespInfo, len(lambs))
+	for _, lamb := range lambs {
+		byID[lamb.ID] = lamb
+	}
+
+	visited := make(map[uint64]bool, len(lambs))
+	var walk func(id uint64, path []string) []string
+	walk = func(id uint64, path []string) []string {
+		lamb, ok := byID[id]
+		if !ok {
+			return nil
+		}
+		path = append(path, lamb.FunctionName)
+
+		for _, next := range chainTargets(lamb) {
+			if next == start {
+				return append(path, byID[start].FunctionName)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+
+			if cycle := walk(next, path); len(cycle) > 0 {
+				return cycle
+			}
+		}
+
+		return nil
+	}
+
+	return walk(start, nil)
+}
+
+// chainGraph returns the downstream Lambdas of the Lambda, along with the links of the chain it belongs to,
+// which are all the ones connected with it, upstream or downstream.
+func chainGraph(lambs []*dto.RespInfo, id uint64) *dto.Chain {
+	byID := make(map[uint64]*dto.RespInfo, len(lambs))
+	neighbours := make(map[uint64][]uint64, len(lambs))
+	for _, lamb := range lambs {
+		byID[lamb.ID] = lamb
+		for _, target := range chainTargets(lamb) {
+			neighbours[lamb.ID] = append(neighbours[lamb.ID], target)
+			neighbours[target] = append(neighbours[target], lamb.ID)
+		}
+	}
+
+	graph := &dto.Chain{Links: make([]*dto.ChainLink, 0)}
+	self, ok := byID[id]
+	if !ok {
+		return graph
+	}
+	if self.OnSuccess != nil && byID[*self.OnSuccess] != nil {
+		graph.OnSuccess = byID[*self.OnSuccess].FunctionName
+	}
+	if self.OnFailure != nil && byID[*self.OnFailure] != nil {
+		graph.OnFailure = byID[*self.OnFailure].FunctionName
+	}
+
+	connected := map[uint64]bool{id: true}
+	for queue := []uint64{id}; len(queue) > 0; queue = queue[1:] {
+		for _, next := range neighbours[queue[0]] {
+			if !connected[next] {
+				connected[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	for lambID := range connected {
+		from := byID[lambID]
+		if from == nil {
+			continue
+		}
+
+		for on, target := range map[string]*uint64{
+			constant.ChainOnSuccess: from.OnSuccess,
+			constant.ChainOnFailure: from.OnFailure,
+		} {
+			if target == nil || byID[*target] == nil {
+				continue
+			}
+
+			graph.Links = append(graph.Links, &dto.ChainLink{
+				From: from.FunctionName,
+				To:   byID[*target].FunctionName,
+				On:   on,
+			})
+		}
+	}
+
+	sort.Slice(graph.Links, func(i, j int) bool {
+		if graph.Links[i].From != graph.Links[j].From {
+			return graph.Links[i].From < graph.Links[j].From
+		}
+		return graph.Links[i].On > graph.Links[j].On
+	})
+
+	return graph
+}
+
+// chainNext invokes the downstream Lambda of the finished one asynchronously, with the output of it.
+// The execution has been finished, so the failure of chaining is logged rather than returned.
+func (svc *service) chainNext(
+	c context.Context,
+	source *dto.RespInfo,
+	chainID, invoker string,
+	succeeded bool,
+	input, output []byte,
+) {
+	target := source.OnSuccess
+	if !succeeded {
+		target = source.OnFailure
+	}
+	if target == nil {
+		return
+	}
+
+	next, err := svc.lambdaRepo.FindLambda(c, *target)
+	if err != nil {
+		logx.Logger.WARN(fmt.Sprintf("failed to chain lambda: %s, err: %s", source.FunctionName, err.Error()))
+		return
+	}
+
+	payload, err := chainPayload(input, output, chainID)
+	if err != nil {
+		logx.Logger.WARN(fmt.Sprintf("failed to chain lambda: %s, err: %s", source.FunctionName, err.Error()))
+		return
+	}
+
+	if _, err := svc.invokeAsync(c, invoker, next, chainID, payload); err != nil {
+		logx.Logger.WARN(fmt.Sprintf("failed to chain lambda: %s to: %s, err: %s",
+			source.FunctionName, next.FunctionName, err.Error()))
+	}
+}
+
+// chainPayload builds the event of the downstream Lambda by the output of the upstream one,
+// the output not being a JSON object is set to the payload field. The organization and the account
+// of the upstream event are kept, along with the chain ID.
+func chainPayload(input, output []byte, chainID string) ([]byte, error) {
+	event := make(map[string]interface{})
+	if len(output) > 0 && json.Unmarshal(output, &event) != nil {
+		var value interface{}
+		if err := json.Unmarshal(output, &value); err != nil {
+			value = string(output)
+		}
+
+		event = map[string]interface{}{"payload": value}
+	}
+
+	upstream := make(map[string]interface{})
+	if len(input) > 0 {
+		_ = json.Unmarshal(input, &upstream)
+	}
+	for _, key := range []string{"organization", "account"} {
+		if value, ok := upstream[key]; ok {
+			event[key] = value
+		}
+	}
+	event["chain_id"] = chainID
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to marshal payload: %s", err.Error()))
+	}
+
+	return payload, nil
+}
+
+// chainUntracked chains the asynchronous invocation not started by the server, such as the scheduled one,
+// by the Lambda of the callback record. The chain starts from it, keyed by its request ID.
+// The failed one is saved as the failure of the Lambda beforehand, which could be redriven.
+func (svc *service) chainUntracked(c context.Context, r *dto.ReqCallback, succeeded bool) error {
+	source, err := svc.lambdaRepo.FindLambdaByArn(c, unqualifiedArn(r.RequestContext.FunctionArn))
+	if err != nil {
+		e := new(errorx.Errorx)
+		if errors.As(err, &e) && e.Status() == http.StatusNotFound {
+			logx.Logger.DEBUG(fmt.Sprintf("callback of the execution not tracked: %s", r.RequestContext.RequestID))
+			return nil
+		}
+
+		return err
+	}
+
+	if !succeeded {
+		failedAt := r.Timestamp
+		if failedAt.IsZero() {
+			failedAt = time.Now().UTC()
+		}
+
+		// keyed by the request ID, the record delivered again is saved only once
+		if err := svc.lambdaRepo.SaveFailure(c, model.BuildLambdaFailure(
+			model.WithFailure(source.ID, r.RequestContext.RequestID),
+			model.WithFailedEvent(string(r.RequestPayload), r.RequestContext.Condition, callbackError(r),
+				int32(max(r.RequestContext.ApproximateInvokeCount-1, 0)), failedAt),
+		)); err != nil {
+			return err
+		}
+	}
+
+	svc.chainNext(c, source, r.RequestContext.RequestID, chainScheduledInvoker, succeeded,
+		r.RequestPayload, r.ResponsePayload)
+
+	return nil
+}
+
+// unqualifiedArn trims the version or the alias off the ARN of Lambda.
+func unqualifiedArn(functionArn string) string {
+	// arn:aws:lambda:<region>:<account>:function:<name>[:<qualifier>]
+	parts := strings.Split(functionArn, ":")
+	if len(parts) > 7 {
+		parts = parts[:7]
+	}
+
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,283 @@
+package lambda
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func destinedConfig() *lambda.GetFunctionEventInvokeConfigOutput {
+	return &lambda.GetFunctionEventInvokeConfigOutput{
+		DestinationConfig: &lambTypes.DestinationConfig{
+			OnSuccess: &lambTypes.OnSuccess{Destination: aws.String(testCallbackARN)},
+			OnFailure: &lambTypes.OnFailure{Destination: aws.String(testCallbackARN)},
+		},
+	}
+}
+
+func chainedLambdas() []*dto.RespInfo {
+	return []*dto.RespInfo{
+		{ID: 1, FunctionName: "fetch"},
+		{ID: 2, FunctionName: "compute", OnSuccess: aws.Uint64(3), OnFailure: aws.Uint64(4)},
+		{ID: 3, FunctionName: "submit"},
+		{ID: 4, FunctionName: "alert"},
+		{ID: 5, FunctionName: "standalone"},
+	}
+}
+
+func TestSetChainSuccess(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "fetch").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: "fetch"}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "compute").Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: "compute"}, nil)
+	mockLambRepo.EXPECT().FindByAccount(ctx, uint64(123)).Times(1).
+		Return(chainedLambdas(), nil)
+	mockAmazon.EXPECT().GetLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		Return(destinedConfig(), nil)
+	mockLambRepo.EXPECT().SetChain(ctx, uint64(1), aws.Uint64(2), nil).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetChain(ctx, &dto.ReqChain{Lambda: "fetch", OnSuccess: aws.String("compute")})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.Chain{
+		OnSuccess: "compute",
+		Links: []*dto.ChainLink{
+			{From: "compute", To: "submit", On: constant.ChainOnSuccess},
+			{From: "compute", To: "alert", On: constant.ChainOnFailure},
+			{From: "fetch", To: "compute", On: constant.ChainOnSuccess},
+		},
+	}, resp)
+}
+
+func TestSetChainCycle(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	lambs := chainedLambdas()
+	lambs[0].OnSuccess = aws.Uint64(2)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "alert").Times(1).
+		Return(&dto.RespInfo{ID: 4, FunctionName: "alert"}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "fetch").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: "fetch"}, nil)
+	mockLambRepo.EXPECT().FindByAccount(ctx, uint64(123)).Times(1).
+		Return(lambs, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetChain(ctx, &dto.ReqChain{Lambda: "alert", OnFailure: aws.String("fetch")})
+	assert.Equal(t, errorx.BadRequest("chain cycle detected: alert -> fetch -> compute -> alert"), err)
+	assert.Nil(t, resp)
+}
+
+func TestSetChainUnset(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "compute").Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: "compute", OnSuccess: aws.Uint64(3), OnFailure: aws.Uint64(4)}, nil)
+	mockLambRepo.EXPECT().FindByAccount(ctx, uint64(123)).Times(1).
+		Return(chainedLambdas(), nil)
+	mockLambRepo.EXPECT().SetChain(ctx, uint64(2), nil, nil).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetChain(ctx, &dto.ReqChain{Lambda: "compute", OnSuccess: aws.String(""), OnFailure: aws.String("")})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.Chain{Links: []*dto.ChainLink{}}, resp)
+}
+
+func TestSetChainNotEnabled(t *testing.T) {
+	setCallbackARN(t, "")
+
+	cd := &service{}
+
+	resp, err := cd.SetChain(new(gin.Context), &dto.ReqChain{Lambda: "fetch", OnSuccess: aws.String("compute")})
+	assert.Equal(t, errorx.BadRequest("action chaining is not enabled on the server"), err)
+	assert.Nil(t, resp)
+}
+
+func TestChainPayload(t *testing.T) {
+	input := []byte(`{"organization":"org_name","account":"account_name","symbol":"XLM"}`)
+
+	payload, err := chainPayload(input, []byte(`{"price":0.1,"account":"spoofed"}`), "chain_id")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"price":0.1,"organization":"org_name","account":"account_name","chain_id":"chain_id"}`, string(payload))
+
+	payload, err = chainPayload(input, []byte(`[1,2]`), "chain_id")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"payload":[1,2],"organization":"org_name","account":"account_name","chain_id":"chain_id"}`, string(payload))
+
+	payload, err = chainPayload(nil, []byte(`not json`), "chain_id")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"payload":"not json","chain_id":"chain_id"}`, string(payload))
+}
+
+func TestCallbackChained(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	req := new(dto.ReqCallback)
+	req.RequestContext.RequestID = "request_id"
+	req.RequestContext.Condition = "RetriesExhausted"
+	req.ResponseContext.FunctionError = "Unhandled"
+	req.RequestPayload = json.RawMessage(`{"organization":"org_name","account":"account_name"}`)
+	req.ResponsePayload = json.RawMessage(`{"errorType":"Error","errorMessage":"boom"}`)
+
+	mockLambRepo.EXPECT().TrackedExecution(ctx, "request_id").Times(1).
+		Return(&dto.RespExecution{LambdaID: 2, ExecutionID: "request_id", Invoker: "account_name",
+			Status: constant.ExecutionPending, ChainID: "head_id"}, nil)
+	mockLambRepo.EXPECT().FinishExecution(ctx, "request_id", gomock.Any()).Times(1).
+		Return(true, nil)
+	mockLambRepo.EXPECT().FindLambda(ctx, uint64(2)).Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: "compute", OnSuccess: aws.Uint64(3), OnFailure: aws.Uint64(4)}, nil)
+	mockLambRepo.EXPECT().FindLambda(ctx, uint64(4)).Times(1).
+		Return(&dto.RespInfo{ID: 4, FunctionName: "alert"}, nil)
+
+	metadata := middleware.Metadata{}
+	awsmiddleware.SetRequestIDMetadata(&metadata, "next_request_id")
+	mockAmazon.EXPECT().GetLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		Return(destinedConfig(), nil)
+	mockAmazon.EXPECT().InvokeLambda(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			assert.Equal(t, "alert", *input.FunctionName)
+			assert.Equal(t, lambTypes.InvocationTypeEvent, input.InvocationType)
+			assert.JSONEq(t, `{"errorType":"Error","errorMessage":"boom",
+				"organization":"org_name","account":"account_name","chain_id":"head_id"}`, string(input.Payload))
+			return &lambda.InvokeOutput{StatusCode: 202, ResultMetadata: metadata}, nil
+		})
+	mockLambRepo.EXPECT().SaveExecution(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, exe *model.LambdaExecution) error {
+			assert.Equal(t, uint64(4), exe.LambdaID)
+			assert.Equal(t, "next_request_id", exe.ExecutionID)
+			assert.Equal(t, "account_name", exe.Invoker)
+			assert.Equal(t, "head_id", exe.ChainID)
+			return nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+	}
+
+	assert.NoError(t, cd.Callback(ctx, req))
+}
+
+func TestCallbackUntrackedChained(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	req := new(dto.ReqCallback)
+	req.RequestContext.RequestID = "scheduled_request_id"
+	req.RequestContext.FunctionArn = "arn:aws:lambda:us-east-2:123456789012:function:fetch:live"
+	req.RequestContext.Condition = "Success"
+	req.ResponsePayload = json.RawMessage(`{"price":0.1}`)
+
+	mockLambRepo.EXPECT().TrackedExecution(ctx, "scheduled_request_id").Times(1).
+		Return(nil, errorx.NotFound("none execution found by: scheduled_request_id"))
+	mockLambRepo.EXPECT().FindLambdaByArn(ctx, "arn:aws:lambda:us-east-2:123456789012:function:fetch").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: "fetch", OnSuccess: aws.Uint64(2)}, nil)
+	mockLambRepo.EXPECT().FindLambda(ctx, uint64(2)).Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: "compute"}, nil)
+
+	metadata := middleware.Metadata{}
+	awsmiddleware.SetRequestIDMetadata(&metadata, "next_request_id")
+	mockAmazon.EXPECT().GetLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		Return(destinedConfig(), nil)
+	mockAmazon.EXPECT().InvokeLambda(ctx, gomock.Any()).Times(1).
+		Return(&lambda.InvokeOutput{StatusCode: 202, ResultMetadata: metadata}, nil)
+	mockLambRepo.EXPECT().SaveExecution(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, exe *model.LambdaExecution) error {
+			assert.Equal(t, chainScheduledInvoker, exe.Invoker)
+			assert.Equal(t, "scheduled_request_id", exe.ChainID)
+			assert.JSONEq(t, `{"price":0.1,"chain_id":"scheduled_request_id"}`, exe.Payload)
+			return nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+	}
+
+	assert.NoError(t, cd.Callback(ctx, req))
+}
+
+func TestUnqualifiedArn(t *testing.T) {
+	assert.Equal(t, "arn:aws:lambda:us-east-2:123456789012:function:fetch",
+		unqualifiedArn("arn:aws:lambda:us-east-2:123456789012:function:fetch:$LATEST"))
+	assert.Equal(t, "arn:aws:lambda:us-east-2:123456789012:function:fetch",
+		unqualifiedArn("arn:aws:lambda:us-east-2:123456789012:function:fetch"))
+}
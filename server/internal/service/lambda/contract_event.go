@@ -0,0 +1,180 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/sorobanx"
+)
+
+// contractEventPollInterval the default interval of polling the contract events, when it's not configured.
+const contractEventPollInterval = 5 * time.Second
+
+// pollContractEvents polls the events of the contract matching the topics of the trigger by the interval,
+// from the ledger of it, until the context is done. It stops once any event failed to hand,
+// which is polled again by the next start.
+func (svc *service) pollContractEvents(c context.Context, trigger *dto.RespTrigger) error {
+	interval := config.GlobalConfig.Soroban.PollInterval
+	if interval <= 0 {
+		interval = contractEventPollInterval
+	}
+
+	for {
+		if err := svc.pollContractEventsOnce(c, trigger); err != nil {
+			if c.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		select {
+		case <-c.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// pollContractEventsOnce polls the events up to the latest ledger, and invokes the Lambda with each of them,
+// before moving the cursor of the trigger to it. So each event is handed at least once, the ID of it is set
+// as the dedup key in the payload, for the ones handed more than once to be skipped by the Lambda.
+func (svc *service) pollContractEventsOnce(c context.Context, trigger *dto.RespTrigger) error {
+	if trigger.Ledger == 0 {
+		latest, err := svc.soroban.GetLatestLedger(c)
+		if err != nil {
+			return err
+		}
+
+		if err := svc.advanceLedger(c, trigger, latest.Sequence, trigger.Cursor); err != nil {
+			return err
+		}
+	}
+
+	req := &sorobanx.EventsRequest{
+		StartLedger: trigger.Ledger,
+		Filters: []sorobanx.EventFilter{{
+			EventType:   "contract",
+			ContractIDs: []string{trigger.Address},
+			Topics:      trigger.Topics,
+		}},
+		Pagination: &sorobanx.Pagination{Limit: constant.TriggerEventsPage},
+	}
+
+	for {
+		resp, err := svc.soroban.GetEvents(c, req)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range resp.Events {
+			// the ones up to the cursor in the ledger polled from are handed already
+			if trigger.Cursor != constant.TriggerCursorNow && event.ID <= trigger.Cursor {
+				continue
+			}
+
+			if err := svc.fireContractEvent(c, trigger, event); err != nil {
+				return err
+			}
+		}
+
+		if len(resp.Events) < constant.TriggerEventsPage {
+			// the events of the latest ledger may not be all there yet, which is polled from by the next
+			if resp.LatestLedger > trigger.Ledger {
+				return svc.advanceLedger(c, trigger, resp.LatestLedger, trigger.Cursor)
+			}
+			return nil
+		}
+
+		// the start ledger is not allowed along with the cursor
+		req.StartLedger = 0
+		req.Pagination.Cursor = resp.Events[len(resp.Events)-1].ID
+	}
+}
+
+// fireContractEvent invokes the Lambda with the event, and moves the cursor of the trigger to it then.
+func (svc *service) fireContractEvent(c context.Context, trigger *dto.RespTrigger, event *sorobanx.Event) error {
+	// the event invoked is recorded to the end, even the polling is stopping
+	c = context.WithoutCancel(c)
+
+	lamb, err := svc.lambdaRepo.FindLambda(c, trigger.LambdaID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := contractEventPayload(trigger, event)
+	if err != nil {
+		return err
+	}
+
+	if _, err := svc.invokeEvent(c, constant.TriggerInvoker, lamb, payload); err != nil {
+		return err
+	}
+
+	return svc.advanceLedger(c, trigger, event.Ledger, event.ID)
+}
+
+// advanceLedger moves the ledger and the cursor of the trigger, errCursorMoved returned when it's moved by others.
+func (svc *service) advanceLedger(c context.Context, trigger *dto.RespTrigger, ledger uint32, cursor string) error {
+	claimed, err := svc.lambdaRepo.AdvanceLedger(c, trigger.ID, trigger.Cursor, ledger, cursor)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errCursorMoved
+	}
+
+	trigger.Ledger = ledger
+	trigger.Cursor = cursor
+
+	return nil
+}
+
+// contractEventPayload builds the event of the Lambda by the contract event, whose topics and value are decoded
+// from XDR, along with the trigger and the organization and the account owning the Lambda.
+func contractEventPayload(trigger *dto.RespTrigger, event *sorobanx.Event) ([]byte, error) {
+	topics := make([]interface{}, 0, len(event.Topic))
+	for _, topic := range event.Topic {
+		decoded, err := sorobanx.DecodeScVal(topic)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, decoded)
+	}
+
+	value, err := sorobanx.DecodeScVal(event.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"organization": trigger.Organization,
+		"account":      trigger.Account,
+		"trigger": map[string]interface{}{
+			"id":      trigger.ID,
+			"type":    trigger.Type,
+			"address": trigger.Address,
+		},
+		"dedup_key": fmt.Sprintf("%d:%s", trigger.ID, event.ID),
+		"event": map[string]interface{}{
+			"id":                          event.ID,
+			"ledger":                      event.Ledger,
+			"ledger_closed_at":            event.LedgerClosedAt,
+			"contract_id":                 event.ContractID,
+			"tx_hash":                     event.TxHash,
+			"in_successful_contract_call": event.InSuccessfulContractCall,
+			"topics":                      topics,
+			"value":                       value,
+		},
+	})
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to marshal payload: %s", err.Error()))
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,247 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+	"github.com/57blocks/auto-action/server/internal/third-party/sorobanx"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func testContractTrigger(ledger uint32, cursor string) *dto.RespTrigger {
+	return &dto.RespTrigger{
+		ID:           1,
+		LambdaID:     2,
+		Type:         constant.TriggerContractEvent,
+		Address:      testTriggerContract,
+		Topics:       [][]string{{testTopicTransfer, "*"}},
+		Cursor:       cursor,
+		Ledger:       ledger,
+		Organization: "org_name",
+		Account:      "account_name",
+	}
+}
+
+func testContractEvent(ledger uint32, id string) *sorobanx.Event {
+	return &sorobanx.Event{
+		EventType:                "contract",
+		Ledger:                   ledger,
+		LedgerClosedAt:           "2026-01-01T00:00:00Z",
+		ContractID:               testTriggerContract,
+		ID:                       id,
+		InSuccessfulContractCall: true,
+		TxHash:                   "tx_hash",
+		Topic:                    []string{testTopicTransfer},
+		// the u32 of 10
+		Value: "AAAAAwAAAAo=",
+	}
+}
+
+func TestPollContractEventsSkipHanded(t *testing.T) {
+	setCallbackARN(t, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockSoroban := testdata.NewMockSoroban(ctrl)
+
+	mockSoroban.EXPECT().GetEvents(gomock.Any(), &sorobanx.EventsRequest{
+		StartLedger: 1000,
+		Filters: []sorobanx.EventFilter{{
+			EventType:   "contract",
+			ContractIDs: []string{testTriggerContract},
+			Topics:      [][]string{{testTopicTransfer, "*"}},
+		}},
+		Pagination: &sorobanx.Pagination{Limit: constant.TriggerEventsPage},
+	}).Times(1).
+		Return(&sorobanx.EventsResponse{
+			Events: []*sorobanx.Event{
+				testContractEvent(1000, "0004294967296000-0000000001"),
+				testContractEvent(1001, "0004299262263296-0000000001"),
+			},
+			LatestLedger: 1010,
+		}, nil)
+
+	gomock.InOrder(
+		mockLambRepo.EXPECT().AdvanceLedger(gomock.Any(), uint64(1), "0004294967296000-0000000001",
+			uint32(1001), "0004299262263296-0000000001").Times(1).
+			Return(true, nil),
+		mockLambRepo.EXPECT().AdvanceLedger(gomock.Any(), uint64(1), "0004299262263296-0000000001",
+			uint32(1010), "0004299262263296-0000000001").Times(1).
+			Return(true, nil),
+	)
+	mockLambRepo.EXPECT().FindLambda(gomock.Any(), uint64(2)).Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+
+	var payload map[string]interface{}
+	mockAmazon.EXPECT().InvokeLambda(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, input *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			assert.NoError(t, json.Unmarshal(input.Payload, &payload))
+			return &lambda.InvokeOutput{StatusCode: 202}, nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		soroban:    mockSoroban,
+	}
+
+	trigger := testContractTrigger(1000, "0004294967296000-0000000001")
+	err := cd.pollContractEventsOnce(context.Background(), trigger)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1010), trigger.Ledger)
+	assert.Equal(t, "0004299262263296-0000000001", trigger.Cursor)
+
+	assert.Equal(t, "org_name", payload["organization"])
+	assert.Equal(t, "1:0004299262263296-0000000001", payload["dedup_key"])
+	event := payload["event"].(map[string]interface{})
+	assert.Equal(t, "0004299262263296-0000000001", event["id"])
+	assert.Equal(t, float64(1001), event["ledger"])
+	assert.Equal(t, []interface{}{"transfer"}, event["topics"])
+	assert.Equal(t, float64(10), event["value"])
+}
+
+func TestPollContractEventsFromLatest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockSoroban := testdata.NewMockSoroban(ctrl)
+
+	mockSoroban.EXPECT().GetLatestLedger(gomock.Any()).Times(1).
+		Return(&sorobanx.LatestLedger{Sequence: 1000}, nil)
+	mockLambRepo.EXPECT().AdvanceLedger(gomock.Any(), uint64(1), constant.TriggerCursorNow,
+		uint32(1000), constant.TriggerCursorNow).Times(1).
+		Return(true, nil)
+	mockSoroban.EXPECT().GetEvents(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, req *sorobanx.EventsRequest) (*sorobanx.EventsResponse, error) {
+			assert.Equal(t, uint32(1000), req.StartLedger)
+			return &sorobanx.EventsResponse{LatestLedger: 1000}, nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		soroban:    mockSoroban,
+	}
+
+	trigger := testContractTrigger(0, constant.TriggerCursorNow)
+	err := cd.pollContractEventsOnce(context.Background(), trigger)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1000), trigger.Ledger)
+}
+
+func TestPollContractEventsInvokeFailed(t *testing.T) {
+	setCallbackARN(t, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockSoroban := testdata.NewMockSoroban(ctrl)
+
+	mockSoroban.EXPECT().GetEvents(gomock.Any(), gomock.Any()).Times(1).
+		Return(&sorobanx.EventsResponse{
+			Events:       []*sorobanx.Event{testContractEvent(1001, "0004299262263296-0000000001")},
+			LatestLedger: 1010,
+		}, nil)
+	mockLambRepo.EXPECT().FindLambda(gomock.Any(), uint64(2)).Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+	mockAmazon.EXPECT().InvokeLambda(gomock.Any(), gomock.Any()).Times(1).
+		Return(nil, errors.New("throttled"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		soroban:    mockSoroban,
+	}
+
+	// the cursor is kept, the event is polled again by the next start
+	trigger := testContractTrigger(1000, constant.TriggerCursorNow)
+	err := cd.pollContractEventsOnce(context.Background(), trigger)
+	assert.Equal(t, errorx.Internal("failed to invoke lambda: "+testFunctionName+", error: throttled"), err)
+	assert.Equal(t, constant.TriggerCursorNow, trigger.Cursor)
+	assert.Equal(t, uint32(1000), trigger.Ledger)
+}
+
+func TestPollContractEventsCursorMoved(t *testing.T) {
+	setCallbackARN(t, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockSoroban := testdata.NewMockSoroban(ctrl)
+
+	mockSoroban.EXPECT().GetEvents(gomock.Any(), gomock.Any()).Times(1).
+		Return(&sorobanx.EventsResponse{
+			Events:       []*sorobanx.Event{testContractEvent(1001, "0004299262263296-0000000001")},
+			LatestLedger: 1010,
+		}, nil)
+	mockLambRepo.EXPECT().FindLambda(gomock.Any(), uint64(2)).Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+	mockAmazon.EXPECT().InvokeLambda(gomock.Any(), gomock.Any()).Times(1).
+		Return(&lambda.InvokeOutput{StatusCode: 202}, nil)
+	mockLambRepo.EXPECT().AdvanceLedger(gomock.Any(), uint64(1), constant.TriggerCursorNow,
+		uint32(1001), "0004299262263296-0000000001").Times(1).
+		Return(false, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		soroban:    mockSoroban,
+	}
+
+	err := cd.pollContractEventsOnce(context.Background(), testContractTrigger(1000, constant.TriggerCursorNow))
+	assert.True(t, errors.Is(err, errCursorMoved))
+}
+
+func TestAddContractEventTrigger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockSoroban := testdata.NewMockSoroban(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockSoroban.EXPECT().Enabled().Times(1).Return(true)
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+	mockLambRepo.EXPECT().SaveTrigger(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		soroban:    mockSoroban,
+	}
+
+	resp, err := cd.AddTrigger(ctx, &dto.ReqAddTrigger{
+		Lambda:  "file1",
+		Type:    constant.TriggerContractEvent,
+		Address: testTriggerContract,
+		Topics:  [][]string{{"transfer", "*"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, testFunctionName, resp.Lambda)
+	assert.Equal(t, [][]string{{testTopicTransfer, "*"}}, resp.Topics)
+	assert.Equal(t, constant.TriggerCursorNow, resp.Cursor)
+}
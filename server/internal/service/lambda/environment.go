@@ -0,0 +1,247 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/pkg/util"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/gin-gonic/gin"
+)
+
+// envMaxSize the total size of all the environment variables of Lambda is limited to 4 KB.
+const envMaxSize = 4 * 1024
+
+var (
+	envKeyRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]*$`)
+	// the keys reserved by Lambda runtime, which are rejected when configuring the function.
+	lambdaReservedEnvKeys = []string{"LAMBDA_TASK_ROOT", "LAMBDA_RUNTIME_DIR"}
+)
+
+func (svc *service) Env(c context.Context, r *dto.ReqURILambda) (*dto.RespEnv, error) {
+	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := lamb.Environment
+	if variables == nil {
+		variables = dto.Env{}
+	}
+
+	return &dto.RespEnv{
+		Name:      lamb.FunctionName,
+		Version:   lamb.Version,
+		Variables: variables,
+		Platform:  platformEnv(c),
+	}, nil
+}
+
+// SetEnv adds or overrides the environment variables of the Lambda, then publishes a new version with them.
+func (svc *service) SetEnv(c context.Context, r *dto.ReqSetEnv) (*dto.RespEnv, error) {
+	if len(r.Variables) == 0 {
+		return nil, errorx.BadRequest("at least one environment variable is required to set")
+	}
+	if err := validateEnv(r.Variables); err != nil {
+		return nil, err
+	}
+
+	return svc.changeEnv(c, r.Lambda, func(current dto.Env) (dto.Env, error) {
+		return mergeEnv(current, r.Variables), nil
+	})
+}
+
+// UnsetEnv removes the environment variables of the Lambda, then publishes a new version without them.
+func (svc *service) UnsetEnv(c context.Context, r *dto.ReqUnsetEnv) (*dto.RespEnv, error) {
+	if len(r.Keys) == 0 {
+		return nil, errorx.BadRequest("at least one environment variable is required to unset")
+	}
+
+	return svc.changeEnv(c, r.Lambda, func(current dto.Env) (dto.Env, error) {
+		changed := mergeEnv(current, nil)
+
+		for _, key := range r.Keys {
+			if _, ok := changed[key]; !ok {
+				return nil, errorx.BadRequest(fmt.Sprintf("none environment variable found by: %s", key))
+			}
+
+			delete(changed, key)
+		}
+
+		return changed, nil
+	})
+}
+
+func (svc *service) changeEnv(
+	c context.Context,
+	distinguish string,
+	change func(current dto.Env) (dto.Env, error),
+) (*dto.RespEnv, error) {
+	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, distinguish)
+	if err != nil {
+		return nil, err
+	}
+
+	// the version is published from $LATEST, which is left ahead of the live version after rolling back,
+	// publishing from it would redeploy the code and configuration rolled back.
+	if isPublished(lamb.Version) {
+		latest, err := svc.lambdaRepo.LatestVersion(c, lamb.ID)
+		if err != nil {
+			return nil, err
+		}
+		if latest != lamb.Version {
+			return nil, errorx.BadRequest(fmt.Sprintf("lambda: %s is rolled back to version %s, "+
+				"update the code before changing the environment", lamb.FunctionName, lamb.Version))
+		}
+	}
+
+	variables, err := change(lamb.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	environment, nonce, err := lambdaEnv(c, lamb.FunctionName, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := svc.amazon.UpdateLambdaConfig(c, &lambda.UpdateFunctionConfigurationInput{
+		FunctionName: aws.String(lamb.FunctionName),
+		Environment:  environment,
+	}); err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to update lambda config: %s, err: %s", lamb.FunctionName, err.Error()))
+	}
+
+	published, err := svc.publishLambda(c, lamb.FunctionName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := svc.pointAlias(c, lamb.FunctionName, *published.Version, isPublished(lamb.Version)); err != nil {
+		return nil, err
+	}
+
+	if err := svc.savePublished(c, lamb, published, jwtAccount.(string),
+		model.WithEnvironment(variables), model.WithRuntimeNonce(nonce)); err != nil {
+		return nil, err
+	}
+
+	return &dto.RespEnv{
+		Name:      lamb.FunctionName,
+		Version:   *published.Version,
+		Variables: variables,
+		Platform:  platformEnv(c),
+	}, nil
+}
+
+// validateEnv checks the keys of the user variables, the platform prefix and the keys reserved by AWS are rejected.
+func validateEnv(env dto.Env) error {
+	for key := range env {
+		switch {
+		case !envKeyRegex.MatchString(key):
+			return errorx.BadRequest(fmt.Sprintf("invalid environment variable: %s, "+
+				"should start with a letter, and contain only letters, numbers and underscores", key))
+		case strings.HasPrefix(key, constant.LambdaEnvPrefix), key == constant.LambdaEnvLegacyRegion:
+			return errorx.BadRequest(fmt.Sprintf("environment variable: %s is reserved by the platform", key))
+		case strings.HasPrefix(key, "AWS_"), slices.Contains(lambdaReservedEnvKeys, key):
+			return errorx.BadRequest(fmt.Sprintf("environment variable: %s is reserved by AWS Lambda", key))
+		}
+	}
+
+	return nil
+}
+
+// mergeEnv returns a copy of the current variables, overridden by the changes.
+func mergeEnv(current, changes dto.Env) dto.Env {
+	merged := make(dto.Env, len(current)+len(changes))
+
+	for key, value := range current {
+		merged[key] = value
+	}
+	for key, value := range changes {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// platformEnv returns the platform variables injected into the Lambdas of the organization and account in JWT.
+func platformEnv(c context.Context) dto.Env {
+	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	region := config.GlobalConfig.Amazon.Region
+
+	return dto.Env{
+		constant.LambdaEnvRegion:       region,
+		constant.LambdaEnvOrg:          jwtOrg.(string),
+		constant.LambdaEnvAccount:      jwtAccount.(string),
+		constant.LambdaEnvNetwork:      config.GlobalConfig.Bound.Name,
+		constant.LambdaEnvEndpoint:     config.GlobalConfig.Bound.EndPoint,
+		constant.LambdaEnvLegacyRegion: region,
+	}
+}
+
+// lambdaEnv builds the environment of the function, with the user variables and the platform ones,
+// along with the runtime token of the function, which is kept out of the platform variables displayed.
+// The nonce of the token is returned to be recorded with the version published by the environment.
+func lambdaEnv(c context.Context, functionName string, variables dto.Env) (*lambTypes.Environment, string, error) {
+	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	nonce, err := randomHex(16)
+	if err != nil {
+		return nil, "", err
+	}
+
+	merged := mergeEnv(variables, platformEnv(c))
+	merged[constant.LambdaEnvRuntimeToken] = util.RuntimeToken(config.GlobalConfig.Runtime.SigningKey, util.RuntimeClaims{
+		Org:      jwtOrg.(string),
+		Account:  jwtAccount.(string),
+		Function: functionName,
+		Nonce:    nonce,
+	})
+
+	size := 0
+	for key, value := range merged {
+		size += len(key) + len(value)
+	}
+	if size > envMaxSize {
+		return nil, "", errorx.BadRequest(fmt.Sprintf("the total size of environment variables is limited to %d bytes", envMaxSize))
+	}
+
+	return &lambTypes.Environment{Variables: merged}, nonce, nil
+}
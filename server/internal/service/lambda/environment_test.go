@@ -0,0 +1,243 @@
+package lambda
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			Version:      "2",
+			Environment:  dto.Env{"FOO": "bar"},
+		}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Env(ctx, &dto.ReqURILambda{Lambda: "file1"})
+	assert.NoError(t, err)
+	assert.Equal(t, testFunctionName, resp.Name)
+	assert.Equal(t, "2", resp.Version)
+	assert.Equal(t, dto.Env{"FOO": "bar"}, resp.Variables)
+	assert.Equal(t, "org_name", resp.Platform[constant.LambdaEnvOrg])
+	assert.Equal(t, "account_name", resp.Platform[constant.LambdaEnvAccount])
+	assert.NotContains(t, resp.Platform, constant.LambdaEnvRuntimeToken)
+}
+
+func TestSetEnvSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqSetEnv{
+		Lambda:    "file1",
+		Variables: dto.Env{"FOO": "baz", "NEW": "value"},
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			FunctionArn:  testFunctionARN,
+			Version:      "2",
+			Environment:  dto.Env{"FOO": "bar", "KEEP": "kept"},
+		}, nil)
+
+	mockLambRepo.EXPECT().LatestVersion(ctx, uint64(1)).Times(1).
+		Return("2", nil)
+
+	mockAmazon.EXPECT().UpdateLambdaConfig(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.UpdateFunctionConfigurationInput) (*lambda.UpdateFunctionConfigurationOutput, error) {
+			assert.Equal(t, testFunctionName, *input.FunctionName)
+			assert.Nil(t, input.Handler)
+			assert.Equal(t, "baz", input.Environment.Variables["FOO"])
+			assert.Equal(t, "kept", input.Environment.Variables["KEEP"])
+			assert.Equal(t, "value", input.Environment.Variables["NEW"])
+			assert.Equal(t, "org_name", input.Environment.Variables[constant.LambdaEnvOrg])
+			assert.NotEmpty(t, input.Environment.Variables[constant.LambdaEnvRuntimeToken])
+			return &lambda.UpdateFunctionConfigurationOutput{}, nil
+		})
+
+	mockAmazon.EXPECT().WaitLambdaUpdated(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	mockAmazon.EXPECT().PublishLambdaVersion(ctx, gomock.Any()).Times(1).
+		Return(&lambda.PublishVersionOutput{
+			Handler:    aws.String("file1.handler"),
+			Version:    aws.String("3"),
+			CodeSha256: aws.String("code_sha256"),
+			RevisionId: aws.String("revision_id"),
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateLambdaAlias(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.UpdateAliasInput) (*lambda.UpdateAliasOutput, error) {
+			assert.Equal(t, "3", *input.FunctionVersion)
+			return &lambda.UpdateAliasOutput{AliasArn: aws.String(testFunctionARN + ":live")}, nil
+		})
+
+	mockLambRepo.EXPECT().UpdateLambdaTX(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetEnv(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", resp.Version)
+	assert.Equal(t, dto.Env{"FOO": "baz", "KEEP": "kept", "NEW": "value"}, resp.Variables)
+}
+
+func TestSetEnvAfterRollback(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	// rolled back from version 3 to 1, $LATEST still holds the code of version 3.
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			Version:      "1",
+			Environment:  dto.Env{"FOO": "bar"},
+		}, nil)
+
+	mockLambRepo.EXPECT().LatestVersion(ctx, uint64(1)).Times(1).
+		Return("3", nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetEnv(ctx, &dto.ReqSetEnv{
+		Lambda:    "file1",
+		Variables: dto.Env{"FOO": "baz"},
+	})
+	assert.Equal(t, errorx.BadRequest(fmt.Sprintf("lambda: %s is rolled back to version 1, "+
+		"update the code before changing the environment", testFunctionName)), err)
+	assert.Nil(t, resp)
+}
+
+func TestSetEnvReserved(t *testing.T) {
+	cd := &service{}
+
+	for _, key := range []string{"AA_NETWORK", "ENV_AWS_REGION", "AWS_REGION", "LAMBDA_TASK_ROOT"} {
+		resp, err := cd.SetEnv(new(gin.Context), &dto.ReqSetEnv{
+			Lambda:    "file1",
+			Variables: dto.Env{key: "value"},
+		})
+		assert.Error(t, err, key)
+		assert.Contains(t, err.Error(), "is reserved by", key)
+		assert.Nil(t, resp)
+	}
+}
+
+func TestSetEnvInvalidKey(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.SetEnv(new(gin.Context), &dto.ReqSetEnv{
+		Lambda:    "file1",
+		Variables: dto.Env{"1-FOO": "value"},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid environment variable: 1-FOO")
+	assert.Nil(t, resp)
+}
+
+func TestUnsetEnvNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			Environment:  dto.Env{"FOO": "bar"},
+		}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.UnsetEnv(ctx, &dto.ReqUnsetEnv{
+		Lambda: "file1",
+		Keys:   []string{"FOO", "MISSING"},
+	})
+	assert.Equal(t, errorx.BadRequest("none environment variable found by: MISSING"), err)
+	assert.Nil(t, resp)
+}
+
+func TestUnsetEnvWithoutKeys(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.UnsetEnv(new(gin.Context), &dto.ReqUnsetEnv{Lambda: "file1"})
+	assert.Equal(t, errorx.BadRequest("at least one environment variable is required to unset"), err)
+	assert.Nil(t, resp)
+}
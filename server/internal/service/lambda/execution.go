@@ -0,0 +1,492 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// logTailPages the pages of log events read at most for the tail of an execution.
+const logTailPages = 5
+
+// reportDuration matches the duration in the REPORT line of the logs, rather than the billed one.
+var reportDuration = regexp.MustCompile(`(?m)^REPORT RequestId:.*?\tDuration: ([0-9.]+) ms`)
+
+// recordInvocation records the synchronous invocation as a finished execution, and returns its ID.
+// The invocation has been done, so the failure of recording is logged rather than returned.
+func (svc *service) recordInvocation(
+	c context.Context,
+	invoker string,
+	lamb *dto.RespInfo,
+	payload []byte,
+	invokedAt time.Time,
+	output *lambda.InvokeOutput,
+) string {
+	executionID, ok := awsmiddleware.GetRequestIDMetadata(output.ResultMetadata)
+	if !ok || executionID == "" {
+		executionID = uuid.NewString()
+	}
+
+	finishedAt := time.Now().UTC()
+	logResult := aws.ToString(output.LogResult)
+
+	duration := float64(finishedAt.Sub(invokedAt).Microseconds()) / 1000
+	if matches := reportDuration.FindStringSubmatch(logResult); len(matches) == 2 {
+		if reported, err := strconv.ParseFloat(matches[1], 64); err == nil {
+			duration = reported
+		}
+	}
+
+	status := constant.ExecutionSucceeded
+	errMsg := ""
+	if fnErr := aws.ToString(output.FunctionError); fnErr != "" {
+		status = constant.ExecutionFailed
+		errMsg = fnErr
+	}
+
+	if err := svc.lambdaRepo.SaveExecution(c, model.BuildLambdaExecution(
+		model.WithExecution(lamb.ID, executionID),
+		model.WithInvocation(invoker, string(payload)),
+		model.WithExecutionMode(constant.ExecutionSync),
+		model.WithExecutionStatus(status),
+		model.WithExecutionReport(output.StatusCode, duration),
+		model.WithExecutionResult(aws.ToString(output.ExecutedVersion),
+			truncate(string(output.Payload), constant.ExecutionResultMax), errMsg, finishedAt),
+		model.WithLogTail(tailLines(logResult, constant.ExecutionLogTail)),
+	)); err != nil {
+		logx.Logger.WARN(fmt.Sprintf("failed to record invocation of lambda: %s, err: %s", lamb.FunctionName, err.Error()))
+	}
+
+	return executionID
+}
+
+// truncate cuts the string to at most max bytes, without breaking the characters.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	return strings.ToValidUTF8(s[:max], "")
+}
+
+// tailLines keeps the last n lines of the logs.
+func tailLines(logs string, n int) string {
+	lines := strings.Split(strings.TrimRight(logs, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// invokeAsync invokes the Lambda with the Event type, the result is reported by the callback
+// function set as the destination, and saved to the execution keyed by the request ID.
+// The chain ID is empty unless the invocation is chained from the upstream Lambda.
+func (svc *service) invokeAsync(
+	c context.Context,
+	invoker string,
+	lamb *dto.RespInfo,
+	chainID string,
+	payload []byte,
+) (*dto.RespInvoke, error) {
+	callback := config.GlobalConfig.Lambda.Callback
+	if callback.Arn == "" {
+		return nil, errorx.BadRequest("asynchronous invocation is not enabled on the server")
+	}
+
+	if err := svc.ensureDestination(c, lamb, callback.Arn); err != nil {
+		return nil, err
+	}
+
+	input := &lambda.InvokeInput{
+		FunctionName:   aws.String(lamb.FunctionName),
+		InvocationType: lambTypes.InvocationTypeEvent,
+		Payload:        payload,
+	}
+	if isPublished(lamb.Version) {
+		input.Qualifier = aws.String(constant.LambdaAlias)
+	}
+
+	invokeOutput, err := svc.amazon.InvokeLambda(c, input)
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to invoke lambda: %s, error: %s", lamb.FunctionName, err.Error()))
+	}
+
+	executionID, ok := awsmiddleware.GetRequestIDMetadata(invokeOutput.ResultMetadata)
+	if !ok || executionID == "" {
+		return nil, errorx.Internal(fmt.Sprintf("none request id returned by invoking lambda: %s", lamb.FunctionName))
+	}
+
+	if err := svc.lambdaRepo.SaveExecution(c, model.BuildLambdaExecution(
+		model.WithExecution(lamb.ID, executionID),
+		model.WithInvocation(invoker, string(payload)),
+		model.WithExecutionMode(constant.ExecutionAsync),
+		model.WithExecutionStatus(constant.ExecutionPending),
+		model.WithChainID(chainID),
+	)); err != nil {
+		return nil, err
+	}
+
+	return &dto.RespInvoke{
+		StatusCode:  invokeOutput.StatusCode,
+		ExecutionID: executionID,
+	}, nil
+}
+
+// ensureDestination points both the success and failure destinations of the Lambda to the callback function,
+// and grants the role of the Lambda to invoke it, skipped when it has been done.
+func (svc *service) ensureDestination(c context.Context, lamb *dto.RespInfo, callbackARN string) error {
+	var qualifier *string
+	if isPublished(lamb.Version) {
+		qualifier = aws.String(constant.LambdaAlias)
+	}
+
+	current, err := svc.amazon.GetLambdaEventInvokeConfig(c, &lambda.GetFunctionEventInvokeConfigInput{
+		FunctionName: aws.String(lamb.FunctionName),
+		Qualifier:    qualifier,
+	})
+	if err != nil {
+		var notFound *lambTypes.ResourceNotFoundException
+		if !errors.As(err, &notFound) {
+			return errorx.Internal(fmt.Sprintf("failed to get event invoke config of lambda: %s, err: %s",
+				lamb.FunctionName, err.Error()))
+		}
+
+		current = nil
+	}
+	if destinedTo(current, callbackARN) {
+		return nil
+	}
+
+	roleName := lamb.Role[strings.LastIndex(lamb.Role, "/")+1:]
+	policyDocument := fmt.Sprintf(`{
+	"Version": "2012-10-17",
+	"Statement": [
+		{
+			"Effect": "Allow",
+			"Action": "lambda:InvokeFunction",
+			"Resource": "%s"
+		}
+	]
+}`, callbackARN)
+	if _, err := svc.amazon.PutRolePolicy(c, &iam.PutRolePolicyInput{
+		PolicyName:     aws.String(fmt.Sprintf("%s-callback-policy", roleName)),
+		PolicyDocument: aws.String(policyDocument),
+		RoleName:       aws.String(roleName),
+	}); err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to grant role: %s to invoke callback, err: %s", roleName, err.Error()))
+	}
+
+	input := &lambda.PutFunctionEventInvokeConfigInput{
+		FunctionName: aws.String(lamb.FunctionName),
+		Qualifier:    qualifier,
+		DestinationConfig: &lambTypes.DestinationConfig{
+			OnSuccess: &lambTypes.OnSuccess{Destination: aws.String(callbackARN)},
+			OnFailure: &lambTypes.OnFailure{Destination: aws.String(callbackARN)},
+		},
+	}
+	if current != nil {
+		input.MaximumRetryAttempts = current.MaximumRetryAttempts
+		input.MaximumEventAgeInSeconds = current.MaximumEventAgeInSeconds
+	}
+
+	if _, err := svc.amazon.PutLambdaEventInvokeConfig(c, input); err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to put event invoke config of lambda: %s, err: %s",
+			lamb.FunctionName, err.Error()))
+	}
+
+	return nil
+}
+
+func destinedTo(current *lambda.GetFunctionEventInvokeConfigOutput, callbackARN string) bool {
+	if current == nil || current.DestinationConfig == nil {
+		return false
+	}
+
+	onSuccess, onFailure := current.DestinationConfig.OnSuccess, current.DestinationConfig.OnFailure
+
+	return onSuccess != nil && aws.ToString(onSuccess.Destination) == callbackARN &&
+		onFailure != nil && aws.ToString(onFailure.Destination) == callbackARN
+}
+
+// Callback saves the result of the asynchronous invocation reported by the callback function,
+// and invokes the downstream Lambda in the chain. The invocations not started by the server,
+// such as the scheduled ones, are chained, and saved as the failures when failed.
+func (svc *service) Callback(c context.Context, r *dto.ReqCallback) error {
+	executionID := r.RequestContext.RequestID
+	if executionID == "" {
+		return errorx.BadRequest("none request id found in the callback record")
+	}
+
+	status := constant.ExecutionSucceeded
+	errMsg := ""
+	if r.RequestContext.Condition != "Success" || r.ResponseContext.FunctionError != "" {
+		status = constant.ExecutionFailed
+		errMsg = callbackError(r)
+	}
+
+	exe, err := svc.lambdaRepo.TrackedExecution(c, executionID)
+	if err != nil {
+		e := new(errorx.Errorx)
+		if errors.As(err, &e) && e.Status() == http.StatusNotFound {
+			return svc.chainUntracked(c, r, status == constant.ExecutionSucceeded)
+		}
+
+		return err
+	}
+
+	finishedAt := r.Timestamp
+	if finishedAt.IsZero() {
+		finishedAt = time.Now().UTC()
+	}
+
+	finished, err := svc.lambdaRepo.FinishExecution(c, executionID, model.BuildLambdaExecution(
+		model.WithExecutionStatus(status),
+		model.WithExecutionResult(r.ResponseContext.ExecutedVersion,
+			truncate(string(r.ResponsePayload), constant.ExecutionResultMax), errMsg, finishedAt),
+	))
+	if err != nil {
+		return err
+	}
+	if !finished {
+		// the record delivered again, which has been finished and chained by the first one
+		return nil
+	}
+
+	source, err := svc.lambdaRepo.FindLambda(c, exe.LambdaID)
+	if err != nil {
+		logx.Logger.WARN(fmt.Sprintf("failed to chain the execution: %s, err: %s", executionID, err.Error()))
+		return nil
+	}
+
+	chainID := exe.ChainID
+	if chainID == "" {
+		chainID = executionID
+	}
+	svc.chainNext(c, source, chainID, exe.Invoker, status == constant.ExecutionSucceeded,
+		r.RequestPayload, r.ResponsePayload)
+
+	return nil
+}
+
+// callbackError extracts the error from the payload of the failed invocation,
+// or describes the condition if none payload returned.
+func callbackError(r *dto.ReqCallback) string {
+	fnErr := struct {
+		ErrorType    string `json:"errorType"`
+		ErrorMessage string `json:"errorMessage"`
+	}{}
+	if len(r.ResponsePayload) > 0 && json.Unmarshal(r.ResponsePayload, &fnErr) == nil && fnErr.ErrorMessage != "" {
+		if fnErr.ErrorType == "" {
+			return fnErr.ErrorMessage
+		}
+
+		return fmt.Sprintf("%s: %s", fnErr.ErrorType, fnErr.ErrorMessage)
+	}
+
+	if r.ResponseContext.FunctionError != "" {
+		return r.ResponseContext.FunctionError
+	}
+
+	return r.RequestContext.Condition
+}
+
+// Execution returns the execution of the asynchronous invocation,
+// the log tail is fetched and kept once the execution is finished.
+func (svc *service) Execution(c context.Context, r *dto.ReqExecution) (*dto.RespExecution, error) {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	exe, err := svc.lambdaRepo.FindExecution(c, user.ID, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Lambda != "" {
+		lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+		if err != nil {
+			return nil, err
+		}
+		if lamb.ID != exe.LambdaID {
+			return nil, errorx.NotFound(fmt.Sprintf("none execution found by: %s", r.ID))
+		}
+	}
+
+	if exe.Status == constant.ExecutionPending || exe.LogTail != "" {
+		return exe, nil
+	}
+
+	logTail, err := svc.logTail(c, exe.FunctionName, exe.ExecutionID)
+	if err != nil {
+		// the result is still useful without the logs
+		logx.Logger.WARN(fmt.Sprintf("failed to fetch log tail of execution: %s, err: %s", exe.ExecutionID, err.Error()))
+		return exe, nil
+	}
+	if logTail == "" {
+		return exe, nil
+	}
+
+	if err := svc.lambdaRepo.UpdateExecution(c, exe.ExecutionID, model.BuildLambdaExecution(
+		model.WithLogTail(logTail),
+	)); err != nil {
+		return nil, err
+	}
+	exe.LogTail = logTail
+
+	return exe, nil
+}
+
+// Executions lists the execution history of the Lambda, paginated from the latest.
+func (svc *service) Executions(c context.Context, r *dto.ReqExecutions) (*dto.RespExecutions, error) {
+	if err := validateExecutionsQuery(r); err != nil {
+		return nil, err
+	}
+
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	exes, total, err := svc.lambdaRepo.ListExecutions(c, lamb.ID, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RespExecutions{
+		Total:      total,
+		Page:       r.Page,
+		Size:       r.Size,
+		Executions: exes,
+	}, nil
+}
+
+// validateExecutionsQuery checks the filters of the query, and defaults the pagination.
+func validateExecutionsQuery(r *dto.ReqExecutions) error {
+	statuses := []string{constant.ExecutionPending, constant.ExecutionSucceeded, constant.ExecutionFailed}
+	if r.Status != "" && !slices.Contains(statuses, r.Status) {
+		return errorx.BadRequest(fmt.Sprintf("invalid status: %s, should be one of: %s",
+			r.Status, strings.Join(statuses, ", ")))
+	}
+
+	modes := []string{constant.ExecutionSync, constant.ExecutionAsync}
+	if r.Mode != "" && !slices.Contains(modes, r.Mode) {
+		return errorx.BadRequest(fmt.Sprintf("invalid mode: %s, should be one of: %s",
+			r.Mode, strings.Join(modes, ", ")))
+	}
+
+	if !r.Since.IsZero() && !r.Until.IsZero() && !r.Since.Before(r.Until) {
+		return errorx.BadRequest("since should be before until")
+	}
+
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Page < 0 {
+		return errorx.BadRequest(fmt.Sprintf("invalid page: %d", r.Page))
+	}
+
+	if r.Size == 0 {
+		r.Size = constant.ExecutionPageSize
+	}
+	if r.Size < 0 || r.Size > constant.ExecutionPageSizeMax {
+		return errorx.BadRequest(fmt.Sprintf("invalid size: %d, should be between 1 and %d",
+			r.Size, constant.ExecutionPageSizeMax))
+	}
+
+	return nil
+}
+
+// logTail reads the logs of the execution, from its START line to its REPORT line,
+// and keeps the last lines of them.
+func (svc *service) logTail(c context.Context, functionName, executionID string) (string, error) {
+	logGroupName := "/aws/lambda/" + functionName
+
+	filtered, err := svc.amazon.FilterLogEvents(c, &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName:  aws.String(logGroupName),
+		FilterPattern: aws.String(fmt.Sprintf(`"START RequestId: %s"`, executionID)),
+		Limit:         aws.Int32(1),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(filtered.Events) == 0 {
+		return "", nil
+	}
+	start := filtered.Events[0]
+
+	input := &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String(logGroupName),
+		LogStreamName: start.LogStreamName,
+		StartTime:     start.Timestamp,
+		StartFromHead: aws.Bool(true),
+	}
+
+	lines := make([]string, 0, constant.ExecutionLogTail)
+	started := false
+	for page := 0; page < logTailPages; page++ {
+		output, err := svc.amazon.GetLogEvents(c, input)
+		if err != nil {
+			return "", err
+		}
+
+		for _, event := range output.Events {
+			message := strings.TrimRight(aws.ToString(event.Message), "\n")
+			if !started {
+				started = strings.HasPrefix(message, "START RequestId: "+executionID)
+				if !started {
+					continue
+				}
+			}
+
+			lines = append(lines, message)
+			if len(lines) > constant.ExecutionLogTail {
+				lines = lines[1:]
+			}
+
+			if strings.HasPrefix(message, "REPORT RequestId: "+executionID) {
+				return strings.Join(lines, "\n"), nil
+			}
+		}
+
+		if len(output.Events) == 0 || aws.ToString(output.NextForwardToken) == aws.ToString(input.NextToken) {
+			break
+		}
+		input.NextToken = output.NextForwardToken
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,573 @@
+package lambda
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCallbackARN = "arn:aws:lambda:us-east-1:123456789012:function:callback"
+
+func setCallbackARN(t *testing.T, arn string) {
+	origin := config.GlobalConfig.Lambda.Callback
+	t.Cleanup(func() {
+		config.GlobalConfig.Lambda.Callback = origin
+	})
+
+	config.GlobalConfig.Lambda.Callback.Arn = arn
+}
+
+func TestInvokeAsyncNotEnabled(t *testing.T) {
+	setCallbackARN(t, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{FunctionName: testFunctionName}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	invoke, err := cd.Invoke(ctx, &dto.ReqInvoke{Lambda: "file1", Async: true})
+	assert.Equal(t, errorx.BadRequest("asynchronous invocation is not enabled on the server"), err)
+	assert.Nil(t, invoke)
+}
+
+func TestInvokeAsyncSuccess(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			Role:         "arn:aws:iam::123456789012:role/org_name-account_name-role",
+			Version:      "1",
+		}, nil)
+
+	mockAmazon.EXPECT().GetLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		Return(nil, &lambTypes.ResourceNotFoundException{Message: aws.String("not found")})
+
+	mockAmazon.EXPECT().PutRolePolicy(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *iam.PutRolePolicyInput) (*iam.PutRolePolicyOutput, error) {
+			assert.Equal(t, "org_name-account_name-role", *input.RoleName)
+			assert.Equal(t, "org_name-account_name-role-callback-policy", *input.PolicyName)
+			assert.Contains(t, *input.PolicyDocument, testCallbackARN)
+			return &iam.PutRolePolicyOutput{}, nil
+		})
+
+	mockAmazon.EXPECT().PutLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.PutFunctionEventInvokeConfigInput) (*lambda.PutFunctionEventInvokeConfigOutput, error) {
+			assert.Equal(t, constant.LambdaAlias, *input.Qualifier)
+			assert.Equal(t, testCallbackARN, *input.DestinationConfig.OnSuccess.Destination)
+			assert.Equal(t, testCallbackARN, *input.DestinationConfig.OnFailure.Destination)
+			return &lambda.PutFunctionEventInvokeConfigOutput{}, nil
+		})
+
+	invokeOutput := &lambda.InvokeOutput{StatusCode: 202}
+	awsmiddleware.SetRequestIDMetadata(&invokeOutput.ResultMetadata, "request_id")
+	mockAmazon.EXPECT().InvokeLambda(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			assert.Equal(t, lambTypes.InvocationTypeEvent, input.InvocationType)
+			assert.Equal(t, constant.LambdaAlias, *input.Qualifier)
+			return invokeOutput, nil
+		})
+
+	mockLambRepo.EXPECT().SaveExecution(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, exe *model.LambdaExecution) error {
+			assert.Equal(t, uint64(1), exe.LambdaID)
+			assert.Equal(t, "request_id", exe.ExecutionID)
+			assert.Equal(t, "account_name", exe.Invoker)
+			assert.Equal(t, constant.ExecutionPending, exe.Status)
+			return nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		amazon:     mockAmazon,
+	}
+
+	invoke, err := cd.Invoke(ctx, &dto.ReqInvoke{Lambda: "file1", Async: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(202), invoke.StatusCode)
+	assert.Equal(t, "request_id", invoke.ExecutionID)
+}
+
+func TestEnsureDestinationConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+
+	mockAmazon.EXPECT().GetLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		Return(&lambda.GetFunctionEventInvokeConfigOutput{
+			DestinationConfig: &lambTypes.DestinationConfig{
+				OnSuccess: &lambTypes.OnSuccess{Destination: aws.String(testCallbackARN)},
+				OnFailure: &lambTypes.OnFailure{Destination: aws.String(testCallbackARN)},
+			},
+		}, nil)
+
+	cd := &service{
+		amazon: mockAmazon,
+	}
+
+	err := cd.ensureDestination(ctx, &dto.RespInfo{FunctionName: testFunctionName}, testCallbackARN)
+	assert.NoError(t, err)
+}
+
+func TestCallbackSucceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+
+	ctx := new(gin.Context)
+	req := new(dto.ReqCallback)
+	req.Timestamp = time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	req.RequestContext.RequestID = "request_id"
+	req.RequestContext.Condition = "Success"
+	req.ResponseContext.ExecutedVersion = "2"
+	req.ResponsePayload = json.RawMessage(`{"foo":"bar"}`)
+
+	mockLambRepo.EXPECT().TrackedExecution(ctx, "request_id").Times(1).
+		Return(&dto.RespExecution{LambdaID: 1, ExecutionID: "request_id", Status: constant.ExecutionPending}, nil)
+	mockLambRepo.EXPECT().FinishExecution(ctx, "request_id", gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, _ string, exe *model.LambdaExecution) (bool, error) {
+			assert.Equal(t, constant.ExecutionSucceeded, exe.Status)
+			assert.Equal(t, "2", exe.ExecutedVersion)
+			assert.Equal(t, `{"foo":"bar"}`, exe.Result)
+			assert.Empty(t, exe.Error)
+			assert.Equal(t, req.Timestamp, *exe.FinishedAt)
+			return true, nil
+		})
+	mockLambRepo.EXPECT().FindLambda(ctx, uint64(1)).Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+	}
+
+	assert.NoError(t, cd.Callback(ctx, req))
+}
+
+func TestCallbackFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+
+	ctx := new(gin.Context)
+	req := new(dto.ReqCallback)
+	req.RequestContext.RequestID = "request_id"
+	req.RequestContext.Condition = "RetriesExhausted"
+	req.ResponseContext.FunctionError = "Unhandled"
+	req.ResponsePayload = json.RawMessage(`{"errorType":"Error","errorMessage":"boom"}`)
+
+	mockLambRepo.EXPECT().TrackedExecution(ctx, "request_id").Times(1).
+		Return(&dto.RespExecution{LambdaID: 1, ExecutionID: "request_id", Status: constant.ExecutionPending}, nil)
+	mockLambRepo.EXPECT().FinishExecution(ctx, "request_id", gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, _ string, exe *model.LambdaExecution) (bool, error) {
+			assert.Equal(t, constant.ExecutionFailed, exe.Status)
+			assert.Equal(t, "Error: boom", exe.Error)
+			assert.NotNil(t, exe.FinishedAt)
+			return true, nil
+		})
+	mockLambRepo.EXPECT().FindLambda(ctx, uint64(1)).Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+	}
+
+	assert.NoError(t, cd.Callback(ctx, req))
+}
+
+func TestCallbackDuplicated(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	req := new(dto.ReqCallback)
+	req.RequestContext.RequestID = "request_id"
+	req.RequestContext.Condition = "Success"
+	req.ResponsePayload = json.RawMessage(`{"foo":"bar"}`)
+
+	// both the records delivered read the execution as pending, only the one finishing it chains
+	mockLambRepo.EXPECT().TrackedExecution(ctx, "request_id").Times(2).
+		Return(&dto.RespExecution{LambdaID: 1, ExecutionID: "request_id", Invoker: "account_name",
+			Status: constant.ExecutionPending}, nil)
+	gomock.InOrder(
+		mockLambRepo.EXPECT().FinishExecution(ctx, "request_id", gomock.Any()).Times(1).
+			Return(true, nil),
+		mockLambRepo.EXPECT().FinishExecution(ctx, "request_id", gomock.Any()).Times(1).
+			Return(false, nil),
+	)
+	mockLambRepo.EXPECT().FindLambda(ctx, uint64(1)).Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName, OnSuccess: aws.Uint64(2)}, nil)
+	mockLambRepo.EXPECT().FindLambda(ctx, uint64(2)).Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: "compute"}, nil)
+
+	metadata := middleware.Metadata{}
+	awsmiddleware.SetRequestIDMetadata(&metadata, "next_request_id")
+	mockAmazon.EXPECT().GetLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		Return(destinedConfig(), nil)
+	mockAmazon.EXPECT().InvokeLambda(ctx, gomock.Any()).Times(1).
+		Return(&lambda.InvokeOutput{StatusCode: 202, ResultMetadata: metadata}, nil)
+	mockLambRepo.EXPECT().SaveExecution(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+	}
+
+	assert.NoError(t, cd.Callback(ctx, req))
+	assert.NoError(t, cd.Callback(ctx, req))
+}
+
+func TestCallbackUntracked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+
+	ctx := new(gin.Context)
+	req := new(dto.ReqCallback)
+	req.RequestContext.RequestID = "scheduled_request_id"
+	req.RequestContext.FunctionArn = "arn:aws:lambda:us-east-2:123456789012:function:file1:live"
+	req.RequestContext.Condition = "Success"
+
+	mockLambRepo.EXPECT().TrackedExecution(ctx, "scheduled_request_id").Times(1).
+		Return(nil, errorx.NotFound("none execution found by: scheduled_request_id"))
+	mockLambRepo.EXPECT().FindLambdaByArn(ctx, "arn:aws:lambda:us-east-2:123456789012:function:file1").Times(1).
+		Return(nil, errorx.NotFound("none lambda found by: arn:aws:lambda:us-east-2:123456789012:function:file1"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+	}
+
+	assert.NoError(t, cd.Callback(ctx, req))
+}
+
+func TestCallbackNoneRequestID(t *testing.T) {
+	cd := &service{}
+
+	err := cd.Callback(new(gin.Context), new(dto.ReqCallback))
+	assert.Equal(t, errorx.BadRequest("none request id found in the callback record"), err)
+}
+
+func TestCallbackError(t *testing.T) {
+	req := new(dto.ReqCallback)
+	req.RequestContext.Condition = "EventAgeExceeded"
+	assert.Equal(t, "EventAgeExceeded", callbackError(req))
+
+	req.ResponseContext.FunctionError = "Unhandled"
+	assert.Equal(t, "Unhandled", callbackError(req))
+
+	req.ResponsePayload = json.RawMessage(`{"errorMessage":"Task timed out after 3.00 seconds"}`)
+	assert.Equal(t, "Task timed out after 3.00 seconds", callbackError(req))
+}
+
+func TestExecutionPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().FindExecution(ctx, uint64(123), "request_id").Times(1).
+		Return(&dto.RespExecution{
+			ExecutionID: "request_id",
+			Status:      constant.ExecutionPending,
+		}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	exe, err := cd.Execution(ctx, &dto.ReqExecution{ID: "request_id"})
+	assert.NoError(t, err)
+	assert.Equal(t, constant.ExecutionPending, exe.Status)
+}
+
+func TestExecutionOfOtherLambda(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().FindExecution(ctx, uint64(123), "request_id").Times(1).
+		Return(&dto.RespExecution{LambdaID: 1, ExecutionID: "request_id"}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file2").Times(1).
+		Return(&dto.RespInfo{ID: 2}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	exe, err := cd.Execution(ctx, &dto.ReqExecution{Lambda: "file2", ID: "request_id"})
+	assert.Equal(t, errorx.NotFound("none execution found by: request_id"), err)
+	assert.Nil(t, exe)
+}
+
+func TestExecutionLogTail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().FindExecution(ctx, uint64(123), "request_id").Times(1).
+		Return(&dto.RespExecution{
+			FunctionName: testFunctionName,
+			ExecutionID:  "request_id",
+			Status:       constant.ExecutionSucceeded,
+		}, nil)
+
+	mockAmazon.EXPECT().FilterLogEvents(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *cloudwatchlogs.FilterLogEventsInput) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+			assert.Equal(t, "/aws/lambda/"+testFunctionName, *input.LogGroupName)
+			assert.Equal(t, `"START RequestId: request_id"`, *input.FilterPattern)
+			return &cloudwatchlogs.FilterLogEventsOutput{
+				Events: []cwTypes.FilteredLogEvent{{
+					LogStreamName: aws.String("stream"),
+					Timestamp:     aws.Int64(1000),
+				}},
+			}, nil
+		})
+
+	mockAmazon.EXPECT().GetLogEvents(ctx, gomock.Any()).Times(1).
+		Return(&cloudwatchlogs.GetLogEventsOutput{
+			Events: []cwTypes.OutputLogEvent{
+				{Message: aws.String("END RequestId: previous_id\n")},
+				{Message: aws.String("START RequestId: request_id Version: 1\n")},
+				{Message: aws.String("INFO hello\n")},
+				{Message: aws.String("END RequestId: request_id\n")},
+				{Message: aws.String("REPORT RequestId: request_id Duration: 1.00 ms\n")},
+				{Message: aws.String("START RequestId: next_id Version: 1\n")},
+			},
+			NextForwardToken: aws.String("token"),
+		}, nil)
+
+	expected := "START RequestId: request_id Version: 1\nINFO hello\nEND RequestId: request_id\nREPORT RequestId: request_id Duration: 1.00 ms"
+	mockLambRepo.EXPECT().UpdateExecution(ctx, "request_id", gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, _ string, exe *model.LambdaExecution) error {
+			assert.Equal(t, expected, exe.LogTail)
+			return nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		amazon:     mockAmazon,
+	}
+
+	exe, err := cd.Execution(ctx, &dto.ReqExecution{ID: "request_id"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, exe.LogTail)
+}
+
+func TestInvokeRecordsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+
+	logResult := "START RequestId: request_id Version: $LATEST\n" +
+		"ERROR boom\n" +
+		"END RequestId: request_id\n" +
+		"REPORT RequestId: request_id\tDuration: 12.34 ms\tBilled Duration: 13 ms\n"
+	invokeOutput := &lambda.InvokeOutput{
+		StatusCode:      200,
+		FunctionError:   aws.String("Unhandled"),
+		ExecutedVersion: aws.String("$LATEST"),
+		LogResult:       aws.String(base64.StdEncoding.EncodeToString([]byte(logResult))),
+		Payload:         []byte(base64.StdEncoding.EncodeToString([]byte(`{"errorMessage":"boom"}`))),
+	}
+	awsmiddleware.SetRequestIDMetadata(&invokeOutput.ResultMetadata, "request_id")
+	mockAmazon.EXPECT().InvokeLambda(ctx, gomock.Any()).Times(1).
+		Return(invokeOutput, nil)
+
+	mockLambRepo.EXPECT().SaveExecution(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, exe *model.LambdaExecution) error {
+			assert.Equal(t, uint64(1), exe.LambdaID)
+			assert.Equal(t, "request_id", exe.ExecutionID)
+			assert.Equal(t, constant.ExecutionSync, exe.Mode)
+			assert.Equal(t, constant.ExecutionFailed, exe.Status)
+			assert.Equal(t, "Unhandled", exe.Error)
+			assert.Equal(t, int32(200), exe.StatusCode)
+			assert.Equal(t, 12.34, exe.Duration)
+			assert.Equal(t, `{"errorMessage":"boom"}`, exe.Result)
+			assert.Equal(t, strings.TrimRight(logResult, "\n"), exe.LogTail)
+			assert.NotNil(t, exe.FinishedAt)
+			return errorx.Internal("insert error")
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		amazon:     mockAmazon,
+	}
+
+	// the failure of recording doesn't fail the invocation
+	invoke, err := cd.Invoke(ctx, &dto.ReqInvoke{Lambda: "file1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "request_id", invoke.ExecutionID)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 3))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	// the broken character is dropped
+	assert.Equal(t, "a", truncate("a中", 2))
+}
+
+func TestTailLines(t *testing.T) {
+	assert.Equal(t, "b\nc", tailLines("a\nb\nc\n", 2))
+	assert.Equal(t, "a", tailLines("a", 2))
+}
+
+func TestExecutionsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1}, nil)
+	mockLambRepo.EXPECT().ListExecutions(ctx, uint64(1), gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, _ uint64, q *dto.ReqExecutions) ([]*dto.RespExecution, int64, error) {
+			assert.Equal(t, 1, q.Page)
+			assert.Equal(t, constant.ExecutionPageSize, q.Size)
+			return []*dto.RespExecution{{ExecutionID: "request_id"}}, 21, nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Executions(ctx, &dto.ReqExecutions{Lambda: "file1", Status: constant.ExecutionFailed})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), resp.Total)
+	assert.Equal(t, 1, resp.Page)
+	assert.Len(t, resp.Executions, 1)
+}
+
+func TestValidateExecutionsQuery(t *testing.T) {
+	since := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		req *dto.ReqExecutions
+		err error
+	}{
+		{&dto.ReqExecutions{Status: "running"},
+			errorx.BadRequest("invalid status: running, should be one of: pending, succeeded, failed")},
+		{&dto.ReqExecutions{Mode: "batch"},
+			errorx.BadRequest("invalid mode: batch, should be one of: sync, async")},
+		{&dto.ReqExecutions{Since: since, Until: since},
+			errorx.BadRequest("since should be before until")},
+		{&dto.ReqExecutions{Page: -1},
+			errorx.BadRequest("invalid page: -1")},
+		{&dto.ReqExecutions{Size: 101},
+			errorx.BadRequest("invalid size: 101, should be between 1 and 100")},
+		{&dto.ReqExecutions{Status: constant.ExecutionSucceeded, Mode: constant.ExecutionSync, Page: 2, Size: 100}, nil},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.err, validateExecutionsQuery(c.req))
+	}
+}
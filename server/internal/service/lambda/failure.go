@@ -0,0 +1,311 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	scheTypes "github.com/aws/aws-sdk-go-v2/service/scheduler/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqsTypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gin-gonic/gin"
+)
+
+// The message attributes set by EventBridge Scheduler on the events sent to the dead-letter queue.
+const (
+	deadLetterScheduleArn   = "SCHEDULE_ARN"
+	deadLetterErrorCode     = "ERROR_CODE"
+	deadLetterErrorMessage  = "ERROR_MESSAGE"
+	deadLetterRetryAttempts = "RETRY_ATTEMPTS"
+)
+
+// deadLetter grants the role of the scheduler to send the events failed to deliver to the dead-letter queue,
+// and returns the config pointing to the queue, or nil when the queue is not configured on the server.
+func (svc *service) deadLetter(c context.Context, roleARN string) (*scheTypes.DeadLetterConfig, error) {
+	queueARN := config.GlobalConfig.Lambda.DeadLetter.Arn
+	if queueARN == "" {
+		return nil, nil
+	}
+
+	roleName := roleARN[strings.LastIndex(roleARN, "/")+1:]
+	policyDocument := fmt.Sprintf(`{
+	"Version": "2012-10-17",
+	"Statement": [
+		{
+			"Effect": "Allow",
+			"Action": "sqs:SendMessage",
+			"Resource": "%s"
+		}
+	]
+}`, queueARN)
+	if _, err := svc.amazon.PutRolePolicy(c, &iam.PutRolePolicyInput{
+		PolicyName:     aws.String(fmt.Sprintf("%s-dead-letter-policy", roleName)),
+		PolicyDocument: aws.String(policyDocument),
+		RoleName:       aws.String(roleName),
+	}); err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to grant role: %s to send dead letters, err: %s", roleName, err.Error()))
+	}
+
+	return &scheTypes.DeadLetterConfig{Arn: aws.String(queueARN)}, nil
+}
+
+// scheduledDestination points the destinations of the Lambda bound with the scheduler to the callback function,
+// so that the handler errors of the scheduled invocations are saved as the failures as well,
+// skipped when the callback is not configured on the server.
+func (svc *service) scheduledDestination(c context.Context, lamb *dto.RespInfo) error {
+	callbackARN := config.GlobalConfig.Lambda.Callback.Arn
+	if callbackARN == "" {
+		return nil
+	}
+
+	return svc.ensureDestination(c, lamb, callbackARN)
+}
+
+// drainDeadLetters moves the events in the dead-letter queue into the failures of the Lambdas bound with the schedulers.
+// The messages are deleted from the queue once saved, the ones failed to save are received again later.
+func (svc *service) drainDeadLetters(c context.Context) error {
+	queueURL := config.GlobalConfig.Lambda.DeadLetter.URL
+	if queueURL == "" {
+		return nil
+	}
+
+	for i := 0; i < constant.DeadLetterReceives; i++ {
+		received, err := svc.amazon.ReceiveMessage(c, &sqs.ReceiveMessageInput{
+			QueueUrl:                    aws.String(queueURL),
+			MaxNumberOfMessages:         constant.DeadLetterBatch,
+			MessageAttributeNames:       []string{"All"},
+			MessageSystemAttributeNames: []sqsTypes.MessageSystemAttributeName{sqsTypes.MessageSystemAttributeNameSentTimestamp},
+		})
+		if err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to receive dead letters, err: %s", err.Error()))
+		}
+		if len(received.Messages) == 0 {
+			return nil
+		}
+
+		entries := make([]sqsTypes.DeleteMessageBatchRequestEntry, 0, len(received.Messages))
+		for idx, msg := range received.Messages {
+			if err := svc.saveDeadLetter(c, msg); err != nil {
+				logx.Logger.WARN(fmt.Sprintf("failed to save dead letter: %s, err: %s", aws.ToString(msg.MessageId), err.Error()))
+				continue
+			}
+
+			entries = append(entries, sqsTypes.DeleteMessageBatchRequestEntry{
+				Id:            aws.String(strconv.Itoa(idx)),
+				ReceiptHandle: msg.ReceiptHandle,
+			})
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		deleted, err := svc.amazon.DeleteMessageBatch(c, &sqs.DeleteMessageBatchInput{
+			QueueUrl: aws.String(queueURL),
+			Entries:  entries,
+		})
+		if err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to delete dead letters, err: %s", err.Error()))
+		}
+		for _, failed := range deleted.Failed {
+			// saved already, the message received again is skipped by its ID
+			logx.Logger.WARN(fmt.Sprintf("failed to delete dead letter, err: %s", aws.ToString(failed.Message)))
+		}
+	}
+
+	return nil
+}
+
+// saveDeadLetter saves the message as the failure of the Lambda which the scheduler is bound with,
+// the ones of the schedulers removed are dropped.
+func (svc *service) saveDeadLetter(c context.Context, msg sqsTypes.Message) error {
+	attribute := func(name string) string {
+		if value, ok := msg.MessageAttributes[name]; ok {
+			return aws.ToString(value.StringValue)
+		}
+		return ""
+	}
+
+	scheduleArn := attribute(deadLetterScheduleArn)
+	lambdaID, err := svc.lambdaRepo.FindScheduledLambda(c, scheduleArn)
+	if err != nil {
+		e := new(errorx.Errorx)
+		if errors.As(err, &e) && e.Status() == http.StatusNotFound {
+			logx.Logger.WARN(fmt.Sprintf("dead letter of the scheduler not bound: %s, dropped", scheduleArn))
+			return nil
+		}
+
+		return err
+	}
+
+	retryAttempts, _ := strconv.ParseInt(attribute(deadLetterRetryAttempts), 10, 32)
+
+	failedAt := time.Now().UTC()
+	if sent, err := strconv.ParseInt(msg.Attributes[string(sqsTypes.MessageSystemAttributeNameSentTimestamp)], 10, 64); err == nil {
+		failedAt = time.UnixMilli(sent).UTC()
+	}
+
+	return svc.lambdaRepo.SaveFailure(c, model.BuildLambdaFailure(
+		model.WithFailure(lambdaID, aws.ToString(msg.MessageId)),
+		model.WithFailedEvent(aws.ToString(msg.Body), attribute(deadLetterErrorCode), attribute(deadLetterErrorMessage),
+			int32(retryAttempts), failedAt),
+	))
+}
+
+// Failures lists the events of the Lambda dead-lettered by the scheduler or failed in the handler,
+// paginated from the latest, the dead-letter queue is drained beforehand.
+func (svc *service) Failures(c context.Context, r *dto.ReqFailures) (*dto.RespFailures, error) {
+	if err := validateFailuresQuery(r); err != nil {
+		return nil, err
+	}
+
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.drainDeadLetters(c); err != nil {
+		// the failures drained before are still useful
+		logx.Logger.WARN(fmt.Sprintf("failed to drain dead letters, err: %s", err.Error()))
+	}
+
+	failures, total, err := svc.lambdaRepo.ListFailures(c, lamb.ID, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RespFailures{
+		Total:    total,
+		Page:     r.Page,
+		Size:     r.Size,
+		Failures: failures,
+	}, nil
+}
+
+func validateFailuresQuery(r *dto.ReqFailures) error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Page < 0 {
+		return errorx.BadRequest(fmt.Sprintf("invalid page: %d", r.Page))
+	}
+
+	if r.Size == 0 {
+		r.Size = constant.FailurePageSize
+	}
+	if r.Size < 0 || r.Size > constant.FailurePageSizeMax {
+		return errorx.BadRequest(fmt.Sprintf("invalid size: %d, should be between 1 and %d",
+			r.Size, constant.FailurePageSizeMax))
+	}
+
+	return nil
+}
+
+// Redrive invokes the Lambda again with the events of the failures, in the order of failing.
+// The failures are picked by the IDs, or all the ones not redriven yet when none given.
+func (svc *service) Redrive(c context.Context, r *dto.ReqRedrive) (*dto.RespRedrive, error) {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.drainDeadLetters(c); err != nil {
+		logx.Logger.WARN(fmt.Sprintf("failed to drain dead letters, err: %s", err.Error()))
+	}
+
+	failures, err := svc.lambdaRepo.FindFailures(c, lamb.ID, r.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint64]bool, len(failures))
+	for _, failure := range failures {
+		found[failure.ID] = true
+	}
+	for _, id := range r.IDs {
+		if !found[id] {
+			return nil, errorx.NotFound(fmt.Sprintf("none failure found by: %d", id))
+		}
+	}
+
+	redriven := make([]*dto.RespFailure, 0, len(failures))
+	for _, failure := range failures {
+		executionID, err := svc.invokeEvent(c, jwtAccount.(string), lamb, []byte(failure.Payload))
+		if err != nil {
+			return nil, err
+		}
+
+		redrivenAt := time.Now().UTC()
+		if err := svc.lambdaRepo.UpdateFailure(c, failure.ID, model.BuildLambdaFailure(
+			model.WithRedrive(executionID, redrivenAt),
+		)); err != nil {
+			return nil, err
+		}
+
+		failure.RedriveExecutionID = executionID
+		failure.RedrivenAt = &redrivenAt
+		redriven = append(redriven, failure)
+	}
+
+	return &dto.RespRedrive{Redriven: redriven}, nil
+}
+
+// invokeEvent invokes the Lambda with the event asynchronously, the same as the scheduler does, to redrive or trigger it.
+// It's tracked as an execution when the asynchronous invocation is enabled, the request ID is returned either way.
+func (svc *service) invokeEvent(c context.Context, invoker string, lamb *dto.RespInfo, payload []byte) (string, error) {
+	if config.GlobalConfig.Lambda.Callback.Arn != "" {
+		resp, err := svc.invokeAsync(c, invoker, lamb, "", payload)
+		if err != nil {
+			return "", err
+		}
+
+		return resp.ExecutionID, nil
+	}
+
+	input := &lambda.InvokeInput{
+		FunctionName:   aws.String(lamb.FunctionName),
+		InvocationType: lambTypes.InvocationTypeEvent,
+		Payload:        payload,
+	}
+	if isPublished(lamb.Version) {
+		input.Qualifier = aws.String(constant.LambdaAlias)
+	}
+
+	output, err := svc.amazon.InvokeLambda(c, input)
+	if err != nil {
+		return "", errorx.Internal(fmt.Sprintf("failed to invoke lambda: %s, error: %s", lamb.FunctionName, err.Error()))
+	}
+
+	requestID, _ := awsmiddleware.GetRequestIDMetadata(output.ResultMetadata)
+
+	return requestID, nil
+}
@@ -0,0 +1,335 @@
+package lambda
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqsTypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testDeadLetterARN = "arn:aws:sqs:us-east-2:123456789012:auac-dead-letter"
+	testDeadLetterURL = "https://sqs.us-east-2.amazonaws.com/123456789012/auac-dead-letter"
+)
+
+func setDeadLetter(t *testing.T, arn, url string) {
+	origin := config.GlobalConfig.Lambda.DeadLetter
+	t.Cleanup(func() {
+		config.GlobalConfig.Lambda.DeadLetter = origin
+	})
+
+	config.GlobalConfig.Lambda.DeadLetter.Arn = arn
+	config.GlobalConfig.Lambda.DeadLetter.URL = url
+}
+
+func deadLetterMessage(id, scheduleArn string) sqsTypes.Message {
+	return sqsTypes.Message{
+		MessageId:     aws.String(id),
+		ReceiptHandle: aws.String("receipt-" + id),
+		Body:          aws.String(`{"foo":"bar"}`),
+		Attributes: map[string]string{
+			"SentTimestamp": "1767225600000",
+		},
+		MessageAttributes: map[string]sqsTypes.MessageAttributeValue{
+			"SCHEDULE_ARN":   {DataType: aws.String("String"), StringValue: aws.String(scheduleArn)},
+			"ERROR_CODE":     {DataType: aws.String("String"), StringValue: aws.String("Lambda.TooManyRequestsException")},
+			"ERROR_MESSAGE":  {DataType: aws.String("String"), StringValue: aws.String("Rate exceeded")},
+			"RETRY_ATTEMPTS": {DataType: aws.String("String"), StringValue: aws.String("3")},
+		},
+	}
+}
+
+func TestFailuresDrainDeadLetters(t *testing.T) {
+	setDeadLetter(t, testDeadLetterARN, testDeadLetterURL)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	removedScheduleARN := "arn:aws:scheduler:us-east-2:123456789012:schedule/default/removed"
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+
+	gomock.InOrder(
+		mockAmazon.EXPECT().ReceiveMessage(ctx, gomock.Any()).Times(1).
+			DoAndReturn(func(_ *gin.Context, input *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+				assert.Equal(t, testDeadLetterURL, *input.QueueUrl)
+				assert.Equal(t, []string{"All"}, input.MessageAttributeNames)
+				return &sqs.ReceiveMessageOutput{Messages: []sqsTypes.Message{
+					deadLetterMessage("message-1", testScheduleARN),
+					deadLetterMessage("message-2", removedScheduleARN),
+				}}, nil
+			}),
+		mockAmazon.EXPECT().ReceiveMessage(ctx, gomock.Any()).Times(1).
+			Return(&sqs.ReceiveMessageOutput{}, nil),
+	)
+
+	mockLambRepo.EXPECT().FindScheduledLambda(ctx, testScheduleARN).Times(1).
+		Return(uint64(1), nil)
+	mockLambRepo.EXPECT().FindScheduledLambda(ctx, removedScheduleARN).Times(1).
+		Return(uint64(0), errorx.NotFound("none scheduler found by: "+removedScheduleARN))
+
+	mockLambRepo.EXPECT().SaveFailure(ctx, model.BuildLambdaFailure(
+		model.WithFailure(1, "message-1"),
+		model.WithFailedEvent(`{"foo":"bar"}`, "Lambda.TooManyRequestsException", "Rate exceeded", 3,
+			time.UnixMilli(1767225600000).UTC()),
+	)).Times(1).Return(nil)
+
+	// the dead letter of the removed scheduler is dropped along with the saved one
+	mockAmazon.EXPECT().DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+		QueueUrl: aws.String(testDeadLetterURL),
+		Entries: []sqsTypes.DeleteMessageBatchRequestEntry{
+			{Id: aws.String("0"), ReceiptHandle: aws.String("receipt-message-1")},
+			{Id: aws.String("1"), ReceiptHandle: aws.String("receipt-message-2")},
+		},
+	}).Times(1).Return(&sqs.DeleteMessageBatchOutput{}, nil)
+
+	failedAt := time.UnixMilli(1767225600000).UTC()
+	failures := []*dto.RespFailure{{ID: 1, LambdaID: 1, Payload: `{"foo":"bar"}`, FailedAt: &failedAt}}
+	mockLambRepo.EXPECT().ListFailures(ctx, uint64(1), &dto.ReqFailures{Lambda: "file1", Page: 1, Size: 20}).Times(1).
+		Return(failures, int64(1), nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Failures(ctx, &dto.ReqFailures{Lambda: "file1"})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespFailures{Total: 1, Page: 1, Size: 20, Failures: failures}, resp)
+}
+
+func TestFailuresDeadLetterNotConfigured(t *testing.T) {
+	setDeadLetter(t, "", "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+	mockLambRepo.EXPECT().ListFailures(ctx, uint64(1), gomock.Any()).Times(1).
+		Return([]*dto.RespFailure{}, int64(0), nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Failures(ctx, &dto.ReqFailures{Lambda: "file1", Page: 2, Size: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), resp.Total)
+	assert.Equal(t, 2, resp.Page)
+	assert.Equal(t, 10, resp.Size)
+}
+
+func TestFailuresInvalidSize(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.Failures(new(gin.Context), &dto.ReqFailures{Lambda: "file1", Size: 101})
+	assert.Equal(t, errorx.BadRequest("invalid size: 101, should be between 1 and 100"), err)
+	assert.Nil(t, resp)
+}
+
+func TestRedriveSuccess(t *testing.T) {
+	setDeadLetter(t, "", "")
+	setCallbackARN(t, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName, Version: "2"}, nil)
+	mockLambRepo.EXPECT().FindFailures(ctx, uint64(1), nil).Times(1).
+		Return([]*dto.RespFailure{{ID: 7, LambdaID: 1, Payload: `{"foo":"bar"}`}}, nil)
+
+	metadata := middleware.Metadata{}
+	awsmiddleware.SetRequestIDMetadata(&metadata, "request_id")
+	mockAmazon.EXPECT().InvokeLambda(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String(testFunctionName),
+		InvocationType: lambTypes.InvocationTypeEvent,
+		Payload:        []byte(`{"foo":"bar"}`),
+		Qualifier:      aws.String(constant.LambdaAlias),
+	}).Times(1).
+		Return(&lambda.InvokeOutput{StatusCode: 202, ResultMetadata: metadata}, nil)
+
+	mockLambRepo.EXPECT().UpdateFailure(ctx, uint64(7), gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, _ uint64, failure *model.LambdaFailure) error {
+			assert.Equal(t, "request_id", failure.RedriveExecutionID)
+			assert.NotNil(t, failure.RedrivenAt)
+			return nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Redrive(ctx, &dto.ReqRedrive{Lambda: "file1"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Redriven, 1)
+	assert.Equal(t, uint64(7), resp.Redriven[0].ID)
+	assert.Equal(t, "request_id", resp.Redriven[0].RedriveExecutionID)
+	assert.NotNil(t, resp.Redriven[0].RedrivenAt)
+}
+
+func TestRedriveNotFound(t *testing.T) {
+	setDeadLetter(t, "", "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+	mockLambRepo.EXPECT().FindFailures(ctx, uint64(1), []uint64{7, 8}).Times(1).
+		Return([]*dto.RespFailure{{ID: 7, LambdaID: 1}}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Redrive(ctx, &dto.ReqRedrive{Lambda: "file1", IDs: []uint64{7, 8}})
+	assert.Equal(t, errorx.NotFound("none failure found by: 8"), err)
+	assert.Nil(t, resp)
+}
+
+func TestScheduledDestination(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+
+	mockAmazon.EXPECT().GetLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		Return(nil, &lambTypes.ResourceNotFoundException{Message: aws.String("none event invoke config")})
+	mockAmazon.EXPECT().PutRolePolicy(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *iam.PutRolePolicyInput) (*iam.PutRolePolicyOutput, error) {
+			assert.Equal(t, "AA-org_name-account_name-Role", *input.RoleName)
+			assert.Contains(t, *input.PolicyDocument, testCallbackARN)
+			return &iam.PutRolePolicyOutput{}, nil
+		})
+	mockAmazon.EXPECT().PutLambdaEventInvokeConfig(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.PutFunctionEventInvokeConfigInput) (*lambda.PutFunctionEventInvokeConfigOutput, error) {
+			assert.Equal(t, testFunctionName, *input.FunctionName)
+			assert.Equal(t, constant.LambdaAlias, *input.Qualifier)
+			assert.Equal(t, testCallbackARN, *input.DestinationConfig.OnFailure.Destination)
+			return &lambda.PutFunctionEventInvokeConfigOutput{}, nil
+		})
+
+	cd := &service{
+		amazon: mockAmazon,
+	}
+
+	err := cd.scheduledDestination(ctx, &dto.RespInfo{
+		FunctionName: testFunctionName,
+		Version:      "2",
+		Role:         "arn:aws:iam::123456789012:role/AA-org_name-account_name-Role",
+	})
+	assert.NoError(t, err)
+}
+
+func TestScheduledDestinationNotConfigured(t *testing.T) {
+	setCallbackARN(t, "")
+
+	cd := &service{}
+
+	assert.NoError(t, cd.scheduledDestination(new(gin.Context), &dto.RespInfo{FunctionName: testFunctionName}))
+}
+
+func TestCallbackScheduledHandlerError(t *testing.T) {
+	setCallbackARN(t, testCallbackARN)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+
+	ctx := new(gin.Context)
+	failedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := new(dto.ReqCallback)
+	req.Timestamp = failedAt
+	req.RequestContext.RequestID = "scheduled_request_id"
+	req.RequestContext.FunctionArn = testFunctionARN + ":live"
+	req.RequestContext.Condition = "RetriesExhausted"
+	req.RequestContext.ApproximateInvokeCount = 3
+	req.ResponseContext.FunctionError = "Unhandled"
+	req.RequestPayload = json.RawMessage(`{"foo":"bar"}`)
+	req.ResponsePayload = json.RawMessage(`{"errorType":"Error","errorMessage":"boom"}`)
+
+	mockLambRepo.EXPECT().TrackedExecution(ctx, "scheduled_request_id").Times(1).
+		Return(nil, errorx.NotFound("none execution found by: scheduled_request_id"))
+	mockLambRepo.EXPECT().FindLambdaByArn(ctx, testFunctionARN).Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+	mockLambRepo.EXPECT().SaveFailure(ctx, &model.LambdaFailure{
+		LambdaID:      1,
+		MessageID:     "scheduled_request_id",
+		Payload:       `{"foo":"bar"}`,
+		ErrorCode:     "RetriesExhausted",
+		ErrorMessage:  "Error: boom",
+		RetryAttempts: 2,
+		FailedAt:      failedAt,
+	}).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+	}
+
+	assert.NoError(t, cd.Callback(ctx, req))
+}
@@ -0,0 +1,327 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/amazonx"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// The heartbeat and the following of the live logs.
+const (
+	logsWriteWait  = 10 * time.Second
+	logsPongWait   = 60 * time.Second
+	logsPingPeriod = logsPongWait * 9 / 10
+	// logsLookback the live logs start from a while before connected, to catch the ones just printed.
+	logsLookback = time.Minute
+	// logsStreamsFollowed the number of the latest log streams followed in each poll.
+	logsStreamsFollowed = 5
+)
+
+// Logs streams the live logs of the Lambda through the websocket, following the new log streams as they appear.
+// It stops once the client goes away or the server shuts down.
+func (svc *service) Logs(c context.Context, req *dto.ReqURILambda, upgrader *websocket.Upgrader) error {
+	ctx, ok := c.(*gin.Context)
+	if !ok {
+		return errorx.GinContextConv()
+	}
+
+	jwtAccount, _ := ctx.Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return err
+	}
+
+	// the Lambda is checked before upgrading, or the removed one would be followed forever.
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, req.Lambda)
+	if err != nil {
+		return err
+	}
+
+	wsConn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to upgrade websocket: %s", err.Error()))
+	}
+	defer wsConn.Close()
+
+	// the context of the request is cancelled by the server shutdown,
+	// and the reading below cancels it once the client goes away.
+	done, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+
+	_ = wsConn.SetReadDeadline(time.Now().Add(logsPongWait))
+	wsConn.SetPongHandler(func(string) error {
+		return wsConn.SetReadDeadline(time.Now().Add(logsPongWait))
+	})
+	go func() {
+		defer cancel()
+
+		// the messages from the client are discarded, the reading handles the control frames
+		for {
+			if _, _, err := wsConn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	follower := newLogFollower(svc.amazon, "/aws/lambda/"+lamb.FunctionName, time.Now().Add(-logsLookback))
+	logsCfg := logsConfig()
+	interval := logsCfg.PollInterval
+
+	poll := time.NewTimer(0)
+	defer poll.Stop()
+	ping := time.NewTicker(logsPingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-done.Done():
+			_ = wsConn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(logsWriteWait))
+			return nil
+		case <-ping.C:
+			if err := wsConn.WriteControl(websocket.PingMessage, nil, time.Now().Add(logsWriteWait)); err != nil {
+				return nil
+			}
+		case <-poll.C:
+			frames, err := follower.poll(done)
+			if err != nil {
+				if done.Err() != nil {
+					continue
+				}
+
+				logx.Logger.WARN(fmt.Sprintf("failed to poll logs of: %s, err: %s", follower.group, err.Error()))
+				frames = append(frames, &dto.LogFrame{
+					Type:    constant.LogFrameError,
+					Message: fmt.Sprintf("failed to fetch logs, retry in %s", nextInterval(interval, false, logsCfg)),
+				})
+			}
+
+			for _, frame := range frames {
+				_ = wsConn.SetWriteDeadline(time.Now().Add(logsWriteWait))
+				if err := wsConn.WriteJSON(frame); err != nil {
+					return nil
+				}
+			}
+
+			interval = nextInterval(interval, err == nil && len(frames) > 0, logsCfg)
+			poll.Reset(interval)
+		}
+	}
+}
+
+// logsConfig returns the polling of the live logs, with the defaults for the absent ones.
+func logsConfig() config.LambdaLogs {
+	logsCfg := config.GlobalConfig.Lambda.Logs
+	if logsCfg.PollInterval <= 0 {
+		logsCfg.PollInterval = 5 * time.Second
+	}
+	if logsCfg.MaxBackoff < logsCfg.PollInterval {
+		logsCfg.MaxBackoff = logsCfg.PollInterval
+	}
+
+	return logsCfg
+}
+
+// nextInterval resets the interval once new log events found, otherwise doubles it up to the max backoff.
+func nextInterval(current time.Duration, found bool, logsCfg config.LambdaLogs) time.Duration {
+	if found || current < logsCfg.PollInterval {
+		return logsCfg.PollInterval
+	}
+
+	return min(current*2, logsCfg.MaxBackoff)
+}
+
+// logFollower follows the latest log streams of the log group, by the forward token of each stream.
+type logFollower struct {
+	amazon amazonx.Amazon
+	group  string
+	// since the time the streams seen for the first time are read from
+	since  time.Time
+	tokens map[string]*string
+}
+
+func newLogFollower(amazon amazonx.Amazon, group string, since time.Time) *logFollower {
+	return &logFollower{
+		amazon: amazon,
+		group:  group,
+		since:  since,
+		tokens: make(map[string]*string),
+	}
+}
+
+// poll reads the new log events of the latest log streams, the streams appeared are followed from then on.
+func (f *logFollower) poll(c context.Context) ([]*dto.LogFrame, error) {
+	described, err := f.amazon.DescribeLogStreams(c, &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName: aws.String(f.group),
+		OrderBy:      types.OrderByLastEventTime,
+		Descending:   aws.Bool(true),
+		Limit:        aws.Int32(logsStreamsFollowed),
+	})
+	if err != nil {
+		// the log group is created by the first invocation
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	frames := make([]*dto.LogFrame, 0)
+	for _, stream := range described.LogStreams {
+		streamFrames, err := f.read(c, aws.ToString(stream.LogStreamName))
+		frames = append(frames, streamFrames...)
+		if err != nil {
+			return frames, err
+		}
+	}
+
+	return frames, nil
+}
+
+// read reads the log stream forward from where it was left, until none new log events.
+func (f *logFollower) read(c context.Context, stream string) ([]*dto.LogFrame, error) {
+	input := &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String(f.group),
+		LogStreamName: aws.String(stream),
+		StartFromHead: aws.Bool(true),
+	}
+	if token, followed := f.tokens[stream]; followed {
+		input.NextToken = token
+	} else {
+		input.StartTime = aws.Int64(f.since.UnixMilli())
+	}
+
+	frames := make([]*dto.LogFrame, 0)
+	for {
+		output, err := f.amazon.GetLogEvents(c, input)
+		if err != nil {
+			return frames, err
+		}
+
+		for _, event := range output.Events {
+			timestamp := time.UnixMilli(aws.ToInt64(event.Timestamp)).UTC()
+			ingestionTime := time.UnixMilli(aws.ToInt64(event.IngestionTime)).UTC()
+
+			frames = append(frames, &dto.LogFrame{
+				Type:          constant.LogFrameEvent,
+				Timestamp:     &timestamp,
+				IngestionTime: &ingestionTime,
+				Stream:        stream,
+				Message:       strings.TrimRight(aws.ToString(event.Message), "\n"),
+			})
+		}
+
+		if output.NextForwardToken != nil {
+			f.tokens[stream] = output.NextForwardToken
+		}
+
+		// the same token is returned at the end of the stream
+		if len(output.Events) == 0 || output.NextForwardToken == nil ||
+			aws.ToString(output.NextForwardToken) == aws.ToString(input.NextToken) {
+			return frames, nil
+		}
+		input.NextToken = output.NextForwardToken
+		input.StartTime = nil
+	}
+}
+
+// LogsQuery queries the historical logs across all the log streams of the Lambda,
+// paginated by the next token.
+func (svc *service) LogsQuery(c context.Context, r *dto.ReqLogsQuery) (*dto.RespLogsQuery, error) {
+	if err := validateLogsQuery(r); err != nil {
+		return nil, err
+	}
+
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String("/aws/lambda/" + lamb.FunctionName),
+		Limit:        aws.Int32(r.Limit),
+	}
+	if !r.Start.IsZero() {
+		input.StartTime = aws.Int64(r.Start.UnixMilli())
+	}
+	if !r.End.IsZero() {
+		input.EndTime = aws.Int64(r.End.UnixMilli())
+	}
+	if r.Filter != "" {
+		input.FilterPattern = aws.String(r.Filter)
+	}
+	if r.NextToken != "" {
+		input.NextToken = aws.String(r.NextToken)
+	}
+
+	output, err := svc.amazon.FilterLogEvents(c, input)
+	if err != nil {
+		// the log group is created by the first invocation
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return &dto.RespLogsQuery{Events: make([]*dto.RespLogEvent, 0)}, nil
+		}
+
+		var invalid *types.InvalidParameterException
+		if errors.As(err, &invalid) {
+			return nil, errorx.BadRequest(fmt.Sprintf("invalid logs query: %s", aws.ToString(invalid.Message)))
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to filter log events: %s", err.Error()))
+	}
+
+	events := make([]*dto.RespLogEvent, 0, len(output.Events))
+	for _, event := range output.Events {
+		events = append(events, &dto.RespLogEvent{
+			Timestamp: time.UnixMilli(aws.ToInt64(event.Timestamp)).UTC(),
+			Stream:    aws.ToString(event.LogStreamName),
+			Message:   strings.TrimRight(aws.ToString(event.Message), "\n"),
+		})
+	}
+
+	return &dto.RespLogsQuery{
+		Events:    events,
+		NextToken: aws.ToString(output.NextToken),
+	}, nil
+}
+
+// validateLogsQuery checks the time range and the limit of the query, and defaults the limit.
+func validateLogsQuery(r *dto.ReqLogsQuery) error {
+	if !r.Start.IsZero() && !r.End.IsZero() && !r.Start.Before(r.End) {
+		return errorx.BadRequest("start should be before end")
+	}
+
+	if r.Limit == 0 {
+		r.Limit = constant.LogsQueryLimit
+	}
+	if r.Limit < 0 || r.Limit > constant.LogsQueryLimitMax {
+		return errorx.BadRequest(fmt.Sprintf("invalid limit: %d, should be between 1 and %d",
+			r.Limit, constant.LogsQueryLimitMax))
+	}
+
+	return nil
+}
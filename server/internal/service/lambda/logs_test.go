@@ -0,0 +1,419 @@
+package lambda
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogsQuerySuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	start := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{FunctionName: testFunctionName}, nil)
+
+	mockAmazon.EXPECT().FilterLogEvents(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *cloudwatchlogs.FilterLogEventsInput) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+			assert.Equal(t, "/aws/lambda/"+testFunctionName, *input.LogGroupName)
+			assert.Equal(t, start.UnixMilli(), *input.StartTime)
+			assert.Equal(t, end.UnixMilli(), *input.EndTime)
+			assert.Equal(t, "ERROR", *input.FilterPattern)
+			assert.Equal(t, int32(constant.LogsQueryLimit), *input.Limit)
+			assert.Equal(t, "token1", *input.NextToken)
+			return &cloudwatchlogs.FilterLogEventsOutput{
+				Events: []cwTypes.FilteredLogEvent{{
+					LogStreamName: aws.String("stream"),
+					Timestamp:     aws.Int64(start.Add(time.Minute).UnixMilli()),
+					Message:       aws.String("ERROR boom\n"),
+				}},
+				NextToken: aws.String("token2"),
+			}, nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		amazon:     mockAmazon,
+	}
+
+	resp, err := cd.LogsQuery(ctx, &dto.ReqLogsQuery{
+		Lambda:    "file1",
+		Start:     start,
+		End:       end,
+		Filter:    "ERROR",
+		NextToken: "token1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "token2", resp.NextToken)
+	assert.Len(t, resp.Events, 1)
+	assert.Equal(t, "ERROR boom", resp.Events[0].Message)
+	assert.Equal(t, "stream", resp.Events[0].Stream)
+	assert.Equal(t, start.Add(time.Minute), resp.Events[0].Timestamp)
+}
+
+func TestLogsQueryNoneLogGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{FunctionName: testFunctionName}, nil)
+	mockAmazon.EXPECT().FilterLogEvents(ctx, gomock.Any()).Times(1).
+		Return(nil, &cwTypes.ResourceNotFoundException{Message: aws.String("log group does not exist")})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		amazon:     mockAmazon,
+	}
+
+	resp, err := cd.LogsQuery(ctx, &dto.ReqLogsQuery{Lambda: "file1"})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Events)
+	assert.Empty(t, resp.NextToken)
+}
+
+func TestLogsQueryInvalidPattern(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{FunctionName: testFunctionName}, nil)
+	mockAmazon.EXPECT().FilterLogEvents(ctx, gomock.Any()).Times(1).
+		Return(nil, &cwTypes.InvalidParameterException{Message: aws.String("Invalid filter pattern")})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		amazon:     mockAmazon,
+	}
+
+	resp, err := cd.LogsQuery(ctx, &dto.ReqLogsQuery{Lambda: "file1", Filter: "{ $.level = "})
+	assert.Equal(t, errorx.BadRequest("invalid logs query: Invalid filter pattern"), err)
+	assert.Nil(t, resp)
+}
+
+func TestValidateLogsQuery(t *testing.T) {
+	start := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	req := &dto.ReqLogsQuery{}
+	assert.NoError(t, validateLogsQuery(req))
+	assert.Equal(t, int32(constant.LogsQueryLimit), req.Limit)
+
+	assert.Equal(t, errorx.BadRequest("start should be before end"),
+		validateLogsQuery(&dto.ReqLogsQuery{Start: start, End: start.Add(-time.Hour)}))
+	assert.Equal(t, errorx.BadRequest("invalid limit: 10001, should be between 1 and 10000"),
+		validateLogsQuery(&dto.ReqLogsQuery{Limit: 10001}))
+}
+
+func setLogsPolling(t *testing.T) {
+	origin := config.GlobalConfig.Lambda.Logs
+	t.Cleanup(func() {
+		config.GlobalConfig.Lambda.Logs = origin
+	})
+
+	config.GlobalConfig.Lambda.Logs = config.LambdaLogs{
+		PollInterval: 10 * time.Millisecond,
+		MaxBackoff:   20 * time.Millisecond,
+	}
+}
+
+// serveLogs serves the live logs by the service, the returned channel receives what the Logs returned.
+func serveLogs(svc *service, baseCtx context.Context) (*httptest.Server, chan error) {
+	returned := make(chan error, 1)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, _ := gin.CreateTestContext(w)
+		ctx.Request = r
+		ctx.Set(constant.ClaimSub.Str(), "account_name")
+		ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+		upgrader := &websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				return true
+			},
+		}
+
+		returned <- svc.Logs(ctx, &dto.ReqURILambda{Lambda: "file1"}, upgrader)
+	}))
+	server.Config.BaseContext = func(net.Listener) context.Context {
+		return baseCtx
+	}
+	server.Start()
+
+	return server, returned
+}
+
+// logsService returns the service following the live logs of the Lambda registered.
+func logsService(ctrl *gomock.Controller, mockAmazon *testdata.MockAmazon) *service {
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(gomock.Any(), "account_name").AnyTimes().
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(gomock.Any(), uint64(123), "file1").AnyTimes().
+		Return(&dto.RespInfo{FunctionName: "org_name-account_name-file1"}, nil)
+
+	return &service{amazon: mockAmazon, oauthRepo: mockOAuthRepo, lambdaRepo: mockLambRepo}
+}
+
+func dialLogs(t *testing.T, server *httptest.Server) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect to WebSocket server: %v", err)
+	}
+
+	return conn
+}
+
+func waitReturned(t *testing.T, returned chan error) {
+	select {
+	case err := <-returned:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Logs didn't return")
+	}
+}
+
+func TestLogsFramesAndClientClose(t *testing.T) {
+	setLogsPolling(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	mockAmazon.EXPECT().DescribeLogStreams(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, input *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+			assert.Equal(t, "/aws/lambda/org_name-account_name-file1", aws.ToString(input.LogGroupName))
+			return &cloudwatchlogs.DescribeLogStreamsOutput{
+				LogStreams: []cwTypes.LogStream{{LogStreamName: aws.String("stream1")}},
+			}, nil
+		})
+
+	mockAmazon.EXPECT().GetLogEvents(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, input *cloudwatchlogs.GetLogEventsInput) (*cloudwatchlogs.GetLogEventsOutput, error) {
+			if input.NextToken == nil {
+				assert.NotNil(t, input.StartTime)
+				return &cloudwatchlogs.GetLogEventsOutput{
+					Events: []cwTypes.OutputLogEvent{{
+						Timestamp:     aws.Int64(1714857600000),
+						IngestionTime: aws.Int64(1714857601000),
+						Message:       aws.String("log-message\n"),
+					}},
+					NextForwardToken: aws.String("token1"),
+				}, nil
+			}
+
+			return &cloudwatchlogs.GetLogEventsOutput{NextForwardToken: aws.String("token1")}, nil
+		})
+
+	server, returned := serveLogs(logsService(ctrl, mockAmazon), context.Background())
+	defer server.Close()
+
+	conn := dialLogs(t, server)
+
+	frame := new(dto.LogFrame)
+	assert.NoError(t, conn.ReadJSON(frame))
+	assert.Equal(t, constant.LogFrameEvent, frame.Type)
+	assert.Equal(t, "stream1", frame.Stream)
+	assert.Equal(t, "log-message", frame.Message)
+	assert.True(t, time.UnixMilli(1714857600000).Equal(*frame.Timestamp))
+	assert.True(t, time.UnixMilli(1714857601000).Equal(*frame.IngestionTime))
+
+	// the live logs stop once the client goes away
+	assert.NoError(t, conn.Close())
+	waitReturned(t, returned)
+}
+
+func TestLogsFollowNewStream(t *testing.T) {
+	setLogsPolling(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	described := 0
+	mockAmazon.EXPECT().DescribeLogStreams(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(context.Context, *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+			described++
+			if described == 1 {
+				return nil, &cwTypes.ResourceNotFoundException{Message: aws.String("log group does not exist")}
+			}
+
+			return &cloudwatchlogs.DescribeLogStreamsOutput{
+				LogStreams: []cwTypes.LogStream{{LogStreamName: aws.String("stream2")}},
+			}, nil
+		})
+
+	mockAmazon.EXPECT().GetLogEvents(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, input *cloudwatchlogs.GetLogEventsInput) (*cloudwatchlogs.GetLogEventsOutput, error) {
+			if input.NextToken == nil {
+				return &cloudwatchlogs.GetLogEventsOutput{
+					Events:           []cwTypes.OutputLogEvent{{Message: aws.String(aws.ToString(input.LogStreamName) + "-message")}},
+					NextForwardToken: aws.String("token"),
+				}, nil
+			}
+
+			return &cloudwatchlogs.GetLogEventsOutput{NextForwardToken: input.NextToken}, nil
+		})
+
+	server, returned := serveLogs(logsService(ctrl, mockAmazon), context.Background())
+	defer server.Close()
+
+	conn := dialLogs(t, server)
+
+	frame := new(dto.LogFrame)
+	assert.NoError(t, conn.ReadJSON(frame))
+	assert.Equal(t, "stream2", frame.Stream)
+	assert.Equal(t, "stream2-message", frame.Message)
+
+	assert.NoError(t, conn.Close())
+	waitReturned(t, returned)
+}
+
+func TestLogsErrorFrame(t *testing.T) {
+	setLogsPolling(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	mockAmazon.EXPECT().DescribeLogStreams(gomock.Any(), gomock.Any()).AnyTimes().
+		Return(nil, errorx.BadRequest("throttled"))
+
+	server, returned := serveLogs(logsService(ctrl, mockAmazon), context.Background())
+	defer server.Close()
+
+	conn := dialLogs(t, server)
+
+	frame := new(dto.LogFrame)
+	assert.NoError(t, conn.ReadJSON(frame))
+	assert.Equal(t, constant.LogFrameError, frame.Type)
+	assert.Equal(t, "failed to fetch logs, retry in 20ms", frame.Message)
+
+	assert.NoError(t, conn.Close())
+	waitReturned(t, returned)
+}
+
+func TestLogsServerShutdown(t *testing.T) {
+	setLogsPolling(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	mockAmazon.EXPECT().DescribeLogStreams(gomock.Any(), gomock.Any()).AnyTimes().
+		Return(&cloudwatchlogs.DescribeLogStreamsOutput{}, nil)
+
+	baseCtx, cancel := context.WithCancel(context.Background())
+	server, returned := serveLogs(logsService(ctrl, mockAmazon), baseCtx)
+	defer server.Close()
+
+	conn := dialLogs(t, server)
+	defer conn.Close()
+
+	cancel()
+	waitReturned(t, returned)
+
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+}
+
+func TestLogsLambdaNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(gomock.Any(), "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(gomock.Any(), uint64(123), "file1").Times(1).
+		Return(nil, errorx.NotFound("none lambda found by: file1"))
+
+	server, returned := serveLogs(&service{oauthRepo: mockOAuthRepo, lambdaRepo: mockLambRepo}, context.Background())
+	defer server.Close()
+
+	// the connection is refused without upgrading
+	_, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	assert.Error(t, err)
+	assert.NotEqual(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	select {
+	case err := <-returned:
+		assert.Equal(t, errorx.NotFound("none lambda found by: file1"), err)
+	case <-time.After(time.Second):
+		t.Fatal("Logs didn't return")
+	}
+}
+
+func TestNextInterval(t *testing.T) {
+	logsCfg := config.LambdaLogs{PollInterval: 5 * time.Second, MaxBackoff: time.Minute}
+
+	assert.Equal(t, 10*time.Second, nextInterval(5*time.Second, false, logsCfg))
+	assert.Equal(t, time.Minute, nextInterval(40*time.Second, false, logsCfg))
+	assert.Equal(t, 5*time.Second, nextInterval(40*time.Second, true, logsCfg))
+}
+
+func TestLogsConfig(t *testing.T) {
+	assert.Equal(t, config.LambdaLogs{PollInterval: 5 * time.Second, MaxBackoff: time.Minute}, logsConfig())
+
+	setLogsPolling(t)
+	config.GlobalConfig.Lambda.Logs = config.LambdaLogs{}
+	assert.Equal(t, config.LambdaLogs{PollInterval: 5 * time.Second, MaxBackoff: 5 * time.Second}, logsConfig())
+}
@@ -1,11 +1,15 @@
 package lambda
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/57blocks/auto-action/server/internal/constant"
 	"github.com/57blocks/auto-action/server/internal/dto"
 	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
 	"github.com/gorilla/websocket"
@@ -16,11 +20,37 @@ import (
 type (
 	Resource interface {
 		Register(c *gin.Context)
+		Update(c *gin.Context)
 		Invoke(c *gin.Context)
 		List(c *gin.Context)
 		Info(c *gin.Context)
 		Logs(c *gin.Context)
+		LogsQuery(c *gin.Context)
 		Remove(c *gin.Context)
+		Versions(c *gin.Context)
+		Rollback(c *gin.Context)
+		SetSchedule(c *gin.Context)
+		PauseSchedule(c *gin.Context)
+		ResumeSchedule(c *gin.Context)
+		RemoveSchedule(c *gin.Context)
+		PreviewSchedule(c *gin.Context)
+		Env(c *gin.Context)
+		SetEnv(c *gin.Context)
+		UnsetEnv(c *gin.Context)
+		Execution(c *gin.Context)
+		Executions(c *gin.Context)
+		Callback(c *gin.Context)
+		Failures(c *gin.Context)
+		Redrive(c *gin.Context)
+		SetChain(c *gin.Context)
+		Triggers(c *gin.Context)
+		AddTrigger(c *gin.Context)
+		RemoveTrigger(c *gin.Context)
+		CreateWebhook(c *gin.Context)
+		Webhooks(c *gin.Context)
+		RotateWebhook(c *gin.Context)
+		RemoveWebhook(c *gin.Context)
+		ReceiveHook(c *gin.Context)
 	}
 	resource struct {
 		service LambdaService
@@ -38,38 +68,130 @@ func NewLambdaResource() {
 }
 
 func (re *resource) Register(c *gin.Context) {
-	r := c.Request
+	reqFiles, err := parseReqFiles(c.Request)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
 
-	// Parse the multipart form
-	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB max memory
-		c.Error(errorx.Internal(fmt.Sprintf("failed to parse multipart form: %s", err.Error())))
+	env, err := parseReqEnv(c.Request)
+	if err != nil {
+		c.Error(err)
 		c.Abort()
 		return
 	}
 
-	if r.MultipartForm == nil {
-		c.Error(errorx.Internal("multipart form is nil"))
+	resources, err := parseReqResources(c.Request)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	retry, err := parseReqRetry(c.Request)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Register(c, &dto.ReqRegister{
+		Expression: c.Request.Form.Get("expression"),
+		Payload:    c.Request.Form.Get("payload"),
+		Runtime:    strings.TrimSpace(c.Request.Form.Get("runtime")),
+		Env:        env,
+		Resources:  resources,
+		Retry:      retry,
+		Triggers:   parseReqTriggers(c.Request),
+		Files:      reqFiles,
+	})
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Update(c *gin.Context) {
+	req := new(dto.ReqURILambda)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	reqFiles, err := parseReqFiles(c.Request)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	if len(reqFiles) != 1 {
+		c.Error(errorx.BadRequest("exactly one file is required to update a lambda"))
+		c.Abort()
+		return
+	}
+
+	env, err := parseReqEnv(c.Request)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	resources, err := parseReqResources(c.Request)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Update(c, &dto.ReqUpdate{
+		Lambda:    req.Lambda,
+		Runtime:   strings.TrimSpace(c.Request.Form.Get("runtime")),
+		Env:       env,
+		Resources: resources,
+		File:      reqFiles[0],
+	})
+	if err != nil {
+		c.Error(err)
 		c.Abort()
 		return
 	}
 
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseReqFiles reads the uploaded files in the multipart form,
+// the name of each file is trimmed to the part before the first dot.
+func parseReqFiles(r *http.Request) ([]*dto.ReqFile, error) {
+	// Parse the multipart form
+	if err := r.ParseMultipartForm(10 << 20); err != nil { // 10 MB max memory
+		return nil, errorx.Internal(fmt.Sprintf("failed to parse multipart form: %s", err.Error()))
+	}
+
+	if r.MultipartForm == nil {
+		return nil, errorx.Internal("multipart form is nil")
+	}
+
 	reqFiles := make([]*dto.ReqFile, 0, len(r.MultipartForm.File))
 	for _, headers := range r.MultipartForm.File {
 		header := headers[0]
 
 		file, err := header.Open()
 		if err != nil {
-			c.Error(errorx.Internal(fmt.Sprintf("failed to open file: %s, err: %s", header.Filename, err.Error())))
-			c.Abort()
-			return
+			return nil, errorx.Internal(fmt.Sprintf("failed to open file: %s, err: %s", header.Filename, err.Error()))
 		}
 
 		bytes, err := io.ReadAll(file)
 		file.Close()
 		if err != nil {
-			c.Error(errorx.Internal(fmt.Sprintf("failed to read file: %s, err: %s", header.Filename, err.Error())))
-			c.Abort()
-			return
+			return nil, errorx.Internal(fmt.Sprintf("failed to read file: %s, err: %s", header.Filename, err.Error()))
 		}
 
 		splits := strings.Split(header.Filename, ".")
@@ -81,18 +203,102 @@ func (re *resource) Register(c *gin.Context) {
 		})
 	}
 
-	resp, err := re.service.Register(c, &dto.ReqRegister{
-		Expression: r.Form.Get("expression"),
-		Payload:    r.Form.Get("payload"),
-		Files:      reqFiles,
-	})
-	if err != nil {
-		c.Error(err)
-		c.Abort()
-		return
+	return reqFiles, nil
+}
+
+// parseReqEnv reads the environment variables in the multipart form, which is a JSON object of strings.
+func parseReqEnv(r *http.Request) (dto.Env, error) {
+	raw := strings.TrimSpace(r.Form.Get("env"))
+	if raw == "" {
+		return nil, nil
 	}
 
-	c.JSON(http.StatusOK, resp)
+	env := make(dto.Env)
+	if err := json.Unmarshal([]byte(raw), &env); err != nil {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid environment variables: %s", err.Error()))
+	}
+
+	return env, nil
+}
+
+// parseReqResources reads the resources in the multipart form, the ones absent are left as zero.
+func parseReqResources(r *http.Request) (dto.Resources, error) {
+	var resources dto.Resources
+
+	fields := []struct {
+		name  string
+		value *int32
+	}{
+		{"timeout", &resources.Timeout},
+		{"memory", &resources.MemorySize},
+		{"ephemeral_storage", &resources.EphemeralStorage},
+	}
+
+	for _, field := range fields {
+		raw := strings.TrimSpace(r.Form.Get(field.name))
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return dto.Resources{}, errorx.BadRequest(fmt.Sprintf("invalid %s: %s", field.name, raw))
+		}
+
+		*field.value = int32(value)
+	}
+
+	return resources, nil
+}
+
+// parseReqRetry reads the retry policy of the scheduler in the multipart form, the ones absent are left as nil.
+func parseReqRetry(r *http.Request) (dto.RetryPolicy, error) {
+	var retry dto.RetryPolicy
+
+	fields := []struct {
+		name  string
+		value **int32
+	}{
+		{"max_retry_attempts", &retry.MaxRetryAttempts},
+		{"max_event_age", &retry.MaxEventAge},
+	}
+
+	for _, field := range fields {
+		raw := strings.TrimSpace(r.Form.Get(field.name))
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return dto.RetryPolicy{}, errorx.BadRequest(fmt.Sprintf("invalid %s: %s", field.name, raw))
+		}
+
+		parsed := int32(value)
+		*field.value = &parsed
+	}
+
+	return retry, nil
+}
+
+// parseReqTriggers reads the addresses watched in the multipart form, by the field of each trigger type.
+func parseReqTriggers(r *http.Request) []*dto.ReqTrigger {
+	triggers := make([]*dto.ReqTrigger, 0)
+
+	for _, triggerType := range []string{constant.TriggerPayment, constant.TriggerOperation, constant.TriggerEffect} {
+		for _, address := range r.Form["on_"+triggerType] {
+			if strings.TrimSpace(address) == "" {
+				continue
+			}
+
+			triggers = append(triggers, &dto.ReqTrigger{
+				Type:    triggerType,
+				Address: strings.TrimSpace(address),
+			})
+		}
+	}
+
+	return triggers
 }
 
 func (re *resource) Invoke(c *gin.Context) {
@@ -181,6 +387,31 @@ func (re *resource) Logs(c *gin.Context) {
 	}
 }
 
+func (re *resource) LogsQuery(c *gin.Context) {
+	req := new(dto.ReqLogsQuery)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.BindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.LogsQuery(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 func (re *resource) Remove(c *gin.Context) {
 	req := new(dto.ReqURILambda)
 
@@ -199,3 +430,522 @@ func (re *resource) Remove(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+func (re *resource) Versions(c *gin.Context) {
+	req := new(dto.ReqURILambda)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Versions(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Rollback(c *gin.Context) {
+	req := new(dto.ReqRollback)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if strings.TrimSpace(req.Version) == "" {
+		c.Error(errorx.BadRequest("the version to roll back to is required"))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Rollback(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) SetSchedule(c *gin.Context) {
+	req := new(dto.ReqSchedule)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.SetSchedule(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) PauseSchedule(c *gin.Context) {
+	re.handleSchedule(c, re.service.PauseSchedule)
+}
+
+func (re *resource) ResumeSchedule(c *gin.Context) {
+	re.handleSchedule(c, re.service.ResumeSchedule)
+}
+
+func (re *resource) RemoveSchedule(c *gin.Context) {
+	re.handleSchedule(c, re.service.RemoveSchedule)
+}
+
+func (re *resource) PreviewSchedule(c *gin.Context) {
+	req := new(dto.ReqPreview)
+
+	if err := c.BindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Preview(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) handleSchedule(
+	c *gin.Context,
+	handle func(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error),
+) {
+	req := new(dto.ReqURILambda)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := handle(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Env(c *gin.Context) {
+	req := new(dto.ReqURILambda)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Env(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) SetEnv(c *gin.Context) {
+	req := new(dto.ReqSetEnv)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.SetEnv(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) UnsetEnv(c *gin.Context) {
+	req := new(dto.ReqUnsetEnv)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.BindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.UnsetEnv(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Execution(c *gin.Context) {
+	req := new(dto.ReqExecution)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Execution(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Executions(c *gin.Context) {
+	req := new(dto.ReqExecutions)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.BindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Executions(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Failures(c *gin.Context) {
+	req := new(dto.ReqFailures)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.BindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Failures(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Redrive(c *gin.Context) {
+	req := new(dto.ReqRedrive)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Redrive(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) SetChain(c *gin.Context) {
+	req := new(dto.ReqChain)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.SetChain(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Triggers(c *gin.Context) {
+	req := new(dto.ReqTriggers)
+
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Triggers(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) AddTrigger(c *gin.Context) {
+	req := new(dto.ReqAddTrigger)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.AddTrigger(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) RemoveTrigger(c *gin.Context) {
+	req := new(dto.ReqURITrigger)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := re.service.RemoveTrigger(c, req); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
+
+func (re *resource) CreateWebhook(c *gin.Context) {
+	req := new(dto.ReqWebhook)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.CreateWebhook(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Webhooks(c *gin.Context) {
+	req := new(dto.ReqWebhooks)
+
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Webhooks(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) RotateWebhook(c *gin.Context) {
+	req := new(dto.ReqWebhook)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.RotateWebhook(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) RemoveWebhook(c *gin.Context) {
+	req := new(dto.ReqURILambda)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := re.service.RemoveWebhook(c, req); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
+
+// ReceiveHook receives the requests of the inbound webhooks from the external systems, verified by
+// the secrets of the webhooks instead of the user tokens.
+func (re *resource) ReceiveHook(c *gin.Context) {
+	req := new(dto.ReqHook)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, constant.WebhookBodyMax+1))
+	if err != nil {
+		c.Error(errorx.BadRequest(fmt.Sprintf("failed to read body, err: %s", err.Error())))
+		c.Abort()
+		return
+	}
+	if len(body) > constant.WebhookBodyMax {
+		c.Error(errorx.BadRequest(fmt.Sprintf("body too large, should be at most %d bytes", constant.WebhookBodyMax)))
+		c.Abort()
+		return
+	}
+	req.Header = c.Request.Header
+	req.Body = body
+
+	resp, err := re.service.ReceiveHook(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// Callback receives the results of asynchronous invocations from the callback function.
+func (re *resource) Callback(c *gin.Context) {
+	req := new(dto.ReqCallback)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := re.service.Callback(c, req); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
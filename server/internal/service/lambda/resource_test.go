@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/57blocks/auto-action/server/internal/constant"
 	"github.com/57blocks/auto-action/server/internal/dto"
 	"github.com/57blocks/auto-action/server/internal/testdata"
 
@@ -121,6 +123,115 @@ func TestResourceRegisterServiceError(t *testing.T) {
 	assert.Equal(t, "service error", ctx.Errors.Last().Error())
 }
 
+func TestResourceUpdateSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, _ := writer.CreateFormFile("file", "test.zip")
+	part.Write([]byte("test content"))
+	writer.Close()
+
+	req := httptest.NewRequest("PUT", "/lambda/test-func", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockUpdateResp := &dto.RespUpdate{
+		Lambda: &dto.RespLamBrief{
+			Name:       "test-func",
+			CodeSHA256: "code_sha256",
+		},
+	}
+	mockService.EXPECT().Update(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqUpdate) (*dto.RespUpdate, error) {
+			assert.Equal(t, "test-func", r.Lambda)
+			assert.Equal(t, "test", r.File.Name)
+			assert.Equal(t, []byte("test content"), r.File.Bytes)
+			return mockUpdateResp, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Update(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, ctx.Errors)
+
+	var actualResp *dto.RespUpdate
+	err := json.Unmarshal(w.Body.Bytes(), &actualResp)
+	assert.NoError(t, err)
+	assert.Equal(t, mockUpdateResp.Lambda.Name, actualResp.Lambda.Name)
+	assert.Equal(t, mockUpdateResp.Lambda.CodeSHA256, actualResp.Lambda.CodeSHA256)
+	assert.Nil(t, actualResp.Scheduler)
+}
+
+func TestResourceUpdateNoneFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("expression", "test expression")
+	writer.Close()
+
+	req := httptest.NewRequest("PUT", "/lambda/test-func", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	cd := &resource{}
+
+	cd.Update(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "exactly one file is required to update a lambda", ctx.Errors.Last().Error())
+}
+
+func TestResourceUpdateServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, _ := writer.CreateFormFile("file", "test.zip")
+	part.Write([]byte("test content"))
+	writer.Close()
+
+	req := httptest.NewRequest("PUT", "/lambda/test-func", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockService.EXPECT().Update(ctx, gomock.Any()).
+		Return(nil, errors.New("service error"))
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Update(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "service error", ctx.Errors.Last().Error())
+}
+
 func TestResourceInvokeSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -429,3 +540,779 @@ func TestResourceRemoveServiceError(t *testing.T) {
 	assert.NotNil(t, ctx.Errors)
 	assert.Equal(t, "service error", ctx.Errors.Last().Error())
 }
+
+func TestResourceVersionsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/lambda/test-func/versions", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockVersionsResp := []*dto.RespVersion{
+		{Version: "2", Live: true},
+		{Version: "1"},
+	}
+	mockService.EXPECT().Versions(ctx, &dto.ReqURILambda{Lambda: "test-func"}).Return(mockVersionsResp, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Versions(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, ctx.Errors)
+
+	var actualResp []*dto.RespVersion
+	err := json.Unmarshal(w.Body.Bytes(), &actualResp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(actualResp))
+	assert.True(t, actualResp[0].Live)
+}
+
+func TestResourceVersionsServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/lambda/test-func/versions", nil)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockService.EXPECT().Versions(ctx, gomock.Any()).Return(nil, errors.New("service error"))
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Versions(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "service error", ctx.Errors.Last().Error())
+}
+
+func TestResourceRollbackSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := bytes.NewBufferString(`{"version":"1"}`)
+	req := httptest.NewRequest("POST", "/lambda/test-func/rollback", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockRollbackResp := &dto.RespRollback{
+		Name:            "test-func",
+		PreviousVersion: "2",
+		Version:         "1",
+	}
+	mockService.EXPECT().Rollback(ctx, &dto.ReqRollback{Lambda: "test-func", Version: "1"}).
+		Return(mockRollbackResp, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Rollback(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, ctx.Errors)
+
+	var actualResp *dto.RespRollback
+	err := json.Unmarshal(w.Body.Bytes(), &actualResp)
+	assert.NoError(t, err)
+	assert.Equal(t, mockRollbackResp, actualResp)
+}
+
+func TestResourceRollbackVersionRequired(t *testing.T) {
+	req := httptest.NewRequest("POST", "/lambda/test-func/rollback", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+
+	cd := &resource{}
+
+	cd.Rollback(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "the version to roll back to is required", ctx.Errors.Last().Error())
+}
+
+func TestResourceSetScheduleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := bytes.NewBufferString(`{"expression":"rate(5 minutes)","payload":"{\"foo\":\"bar\"}"}`)
+	req := httptest.NewRequest("PUT", "/lambda/test-func/schedule", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockScheduleResp := &dto.RespSchBrief{
+		Name:       "test-func",
+		Expression: "rate(5 minutes)",
+		State:      "ENABLED",
+	}
+	mockService.EXPECT().SetSchedule(ctx, &dto.ReqSchedule{
+		Lambda:     "test-func",
+		Expression: "rate(5 minutes)",
+		Payload:    `{"foo":"bar"}`,
+	}).Return(mockScheduleResp, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.SetSchedule(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, ctx.Errors)
+
+	var actualResp *dto.RespSchBrief
+	err := json.Unmarshal(w.Body.Bytes(), &actualResp)
+	assert.NoError(t, err)
+	assert.Equal(t, mockScheduleResp, actualResp)
+}
+
+func TestResourcePauseScheduleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("POST", "/lambda/test-func/schedule/pause", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockService.EXPECT().PauseSchedule(ctx, &dto.ReqURILambda{Lambda: "test-func"}).
+		Return(&dto.RespSchBrief{State: "DISABLED"}, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.PauseSchedule(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, ctx.Errors)
+	assert.JSONEq(t, `{"state":"DISABLED"}`, w.Body.String())
+}
+
+func TestResourceRemoveScheduleServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("DELETE", "/lambda/test-func/schedule", nil)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockService.EXPECT().RemoveSchedule(ctx, gomock.Any()).Return(nil, errors.New("service error"))
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.RemoveSchedule(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "service error", ctx.Errors.Last().Error())
+}
+
+func TestResourcePreviewScheduleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/schedule/preview?expression=rate(5%20minutes)&count=2&timezone=Asia/Shanghai", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockResp := &dto.RespPreview{
+		Expression: "rate(5 minutes)",
+		Timezone:   "Asia/Shanghai",
+	}
+
+	mockService.EXPECT().Preview(ctx, &dto.ReqPreview{
+		Expression: "rate(5 minutes)",
+		Count:      2,
+		Timezone:   "Asia/Shanghai",
+	}).Return(mockResp, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.PreviewSchedule(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourcePreviewScheduleBindQueryError(t *testing.T) {
+	req := httptest.NewRequest("GET", "/schedule/preview?expression=rate(5%20minutes)&count=invalid", nil)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+
+	cd := &resource{}
+
+	cd.PreviewSchedule(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
+
+func TestResourceRegisterInvalidEnv(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, _ := writer.CreateFormFile("file", "test.txt")
+	part.Write([]byte("test content"))
+
+	writer.WriteField("env", "FOO=bar")
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/register", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+
+	cd := &resource{}
+
+	cd.Register(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
+
+func TestResourceSetEnvSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := bytes.NewBufferString(`{"variables":{"FOO":"bar"}}`)
+	req := httptest.NewRequest("PUT", "/lambda/test-func/env", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockResp := &dto.RespEnv{
+		Name:      "test-func",
+		Version:   "2",
+		Variables: dto.Env{"FOO": "bar"},
+		Platform:  dto.Env{"AA_ACCOUNT": "account_name"},
+	}
+	mockService.EXPECT().SetEnv(ctx, &dto.ReqSetEnv{
+		Lambda:    "test-func",
+		Variables: dto.Env{"FOO": "bar"},
+	}).Return(mockResp, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.SetEnv(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, ctx.Errors)
+
+	var actualResp *dto.RespEnv
+	err := json.Unmarshal(w.Body.Bytes(), &actualResp)
+	assert.NoError(t, err)
+	assert.Equal(t, mockResp, actualResp)
+}
+
+func TestResourceUnsetEnvSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("DELETE", "/lambda/test-func/env?key=FOO&key=BAR", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockService.EXPECT().UnsetEnv(ctx, &dto.ReqUnsetEnv{
+		Lambda: "test-func",
+		Keys:   []string{"FOO", "BAR"},
+	}).Return(&dto.RespEnv{Name: "test-func"}, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.UnsetEnv(ctx)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceUpdateInvalidTimeout(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, _ := writer.CreateFormFile("file", "test.zip")
+	part.Write([]byte("test content"))
+
+	writer.WriteField("timeout", "2m")
+	writer.Close()
+
+	req := httptest.NewRequest("PUT", "/lambda/test-func", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "lambda", Value: "test-func"}}
+
+	cd := &resource{}
+
+	cd.Update(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "invalid timeout: 2m", ctx.Errors.Last().Error())
+}
+
+func TestResourceRegisterWithRuntime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, _ := writer.CreateFormFile("file", "test.zip")
+	part.Write([]byte("test content"))
+
+	writer.WriteField("runtime", "python3.12")
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/register", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+
+	mockService.EXPECT().Register(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqRegister) ([]*dto.RespRegister, error) {
+			assert.Equal(t, "python3.12", r.Runtime)
+			assert.Equal(t, "test", r.Files[0].Name)
+			return []*dto.RespRegister{}, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Register(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceExecutionSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("GET", "/lambda/executions/request_id", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "request_id"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().Execution(ctx, &dto.ReqExecution{ID: "request_id"}).
+		Return(&dto.RespExecution{ExecutionID: "request_id", Status: "succeeded"}, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Execution(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+
+	var actualResp *dto.RespExecution
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actualResp))
+	assert.Equal(t, "succeeded", actualResp.Status)
+}
+
+func TestResourceCallbackSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jsonBody := []byte(`{"requestContext":{"requestId":"request_id","condition":"Success"},"responsePayload":{"foo":"bar"}}`)
+	req := httptest.NewRequest("POST", "/callback/execution", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().Callback(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqCallback) error {
+			assert.Equal(t, "request_id", r.RequestContext.RequestID)
+			assert.JSONEq(t, `{"foo":"bar"}`, string(r.ResponsePayload))
+			return nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Callback(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceExecutionsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("GET", "/lambda/file1/executions?status=failed&since=2024-09-01T00:00:00Z&page=2", nil)
+	ctx.Params = gin.Params{{Key: "lambda", Value: "file1"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().Executions(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqExecutions) (*dto.RespExecutions, error) {
+			assert.Equal(t, "file1", r.Lambda)
+			assert.Equal(t, "failed", r.Status)
+			assert.Equal(t, 2, r.Page)
+			assert.Equal(t, "2024-09-01T00:00:00Z", r.Since.UTC().Format(time.RFC3339))
+			return &dto.RespExecutions{Total: 1, Page: 2, Size: 20}, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Executions(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceExecutionsBindQueryError(t *testing.T) {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("GET", "/lambda/file1/executions?since=yesterday", nil)
+	ctx.Params = gin.Params{{Key: "lambda", Value: "file1"}}
+
+	cd := &resource{}
+
+	cd.Executions(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
+
+func TestResourceLogsQuerySuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("GET", "/lambda/file1/logs/query?start=2024-09-01T00:00:00Z&filter=ERROR&limit=50&next_token=token1", nil)
+	ctx.Params = gin.Params{{Key: "lambda", Value: "file1"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().LogsQuery(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqLogsQuery) (*dto.RespLogsQuery, error) {
+			assert.Equal(t, "file1", r.Lambda)
+			assert.Equal(t, "ERROR", r.Filter)
+			assert.Equal(t, int32(50), r.Limit)
+			assert.Equal(t, "token1", r.NextToken)
+			assert.True(t, r.End.IsZero())
+			return &dto.RespLogsQuery{NextToken: "token2"}, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.LogsQuery(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+
+	var actualResp *dto.RespLogsQuery
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &actualResp))
+	assert.Equal(t, "token2", actualResp.NextToken)
+}
+
+func TestResourceFailuresSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("GET", "/lambda/file1/failures?page=2&size=10", nil)
+	ctx.Params = gin.Params{{Key: "lambda", Value: "file1"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().Failures(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqFailures) (*dto.RespFailures, error) {
+			assert.Equal(t, "file1", r.Lambda)
+			assert.Equal(t, 2, r.Page)
+			assert.Equal(t, 10, r.Size)
+			return &dto.RespFailures{Total: 1, Page: 2, Size: 10}, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Failures(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceRedriveSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("POST", "/lambda/file1/failures/redrive", bytes.NewBufferString(`{"ids":[7,8]}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "lambda", Value: "file1"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().Redrive(ctx, &dto.ReqRedrive{Lambda: "file1", IDs: []uint64{7, 8}}).
+		Return(&dto.RespRedrive{Redriven: []*dto.RespFailure{{ID: 7}, {ID: 8}}}, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Redrive(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceRedriveBindError(t *testing.T) {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("POST", "/lambda/file1/failures/redrive", bytes.NewBufferString(`{"ids":"all"}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "lambda", Value: "file1"}}
+
+	cd := &resource{}
+
+	cd.Redrive(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
+
+func TestResourceSetChainSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("PUT", "/lambda/fetch/chain", bytes.NewBufferString(`{"on_success":"compute","on_failure":""}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "lambda", Value: "fetch"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().SetChain(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqChain) (*dto.Chain, error) {
+			assert.Equal(t, "fetch", r.Lambda)
+			assert.Equal(t, "compute", *r.OnSuccess)
+			assert.Equal(t, "", *r.OnFailure)
+			return &dto.Chain{OnSuccess: "compute"}, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.SetChain(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceTriggersSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("GET", "/lambda/triggers?lambda=file1", nil)
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().Triggers(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqTriggers) ([]*dto.RespTrigger, error) {
+			assert.Equal(t, "file1", r.Lambda)
+			return []*dto.RespTrigger{{ID: 1, Lambda: "file1"}}, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Triggers(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceRemoveTriggerSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("DELETE", "/lambda/triggers/7", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "7"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().RemoveTrigger(ctx, &dto.ReqURITrigger{ID: 7}).Return(nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.RemoveTrigger(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceAddTriggerSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("POST", "/lambda/testFunc/triggers",
+		bytes.NewBufferString(`{"type":"contract_event","address":"CCONTRACT","topics":[["transfer","*"]]}`))
+	ctx.Request.Header.Set("Content-Type", "application/json")
+	ctx.Params = gin.Params{{Key: "lambda", Value: "testFunc"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().AddTrigger(ctx, &dto.ReqAddTrigger{
+		Lambda:  "testFunc",
+		Type:    constant.TriggerContractEvent,
+		Address: "CCONTRACT",
+		Topics:  [][]string{{"transfer", "*"}},
+	}).Return(&dto.RespTrigger{ID: 1}, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.AddTrigger(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceRemoveTriggerBindError(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("DELETE", "/lambda/triggers/abc", nil)
+	ctx.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+	cd := &resource{}
+
+	cd.RemoveTrigger(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
+
+func TestParseReqTriggers(t *testing.T) {
+	req := httptest.NewRequest("POST", "/lambda", nil)
+	req.Form = map[string][]string{
+		"on_payment": {"GADDRESS1", " "},
+		"on_effect":  {" GADDRESS2 "},
+	}
+
+	triggers := parseReqTriggers(req)
+
+	assert.Equal(t, []*dto.ReqTrigger{
+		{Type: constant.TriggerPayment, Address: "GADDRESS1"},
+		{Type: constant.TriggerEffect, Address: "GADDRESS2"},
+	}, triggers)
+}
+
+func TestResourceReceiveHookSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("POST", "/hooks/hook1", bytes.NewBufferString(`{"price":"0.12"}`))
+	ctx.Request.Header.Set("Authorization", "Bearer whsec_test")
+	ctx.Params = gin.Params{{Key: "hookID", Value: "hook1"}}
+
+	mockService := testdata.NewMockLambdaService(ctrl)
+	mockService.EXPECT().ReceiveHook(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, req *dto.ReqHook) (*dto.RespHook, error) {
+			assert.Equal(t, "hook1", req.HookID)
+			assert.Equal(t, []byte(`{"price":"0.12"}`), req.Body)
+			assert.Equal(t, "Bearer whsec_test", req.Header.Get("Authorization"))
+			return &dto.RespHook{RequestID: "request_id"}, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.ReceiveHook(ctx)
+
+	assert.Equal(t, http.StatusAccepted, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+}
+
+func TestResourceReceiveHookBodyTooLarge(t *testing.T) {
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = httptest.NewRequest("POST", "/hooks/hook1", bytes.NewReader(make([]byte, constant.WebhookBodyMax+1)))
+	ctx.Params = gin.Params{{Key: "hookID", Value: "hook1"}}
+
+	cd := &resource{}
+
+	cd.ReceiveHook(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
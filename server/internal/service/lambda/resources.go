@@ -0,0 +1,73 @@
+package lambda
+
+import (
+	"fmt"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// validateResources checks the resources specified against the minimums of AWS,
+// and the ceilings of the organization. The ones not specified are skipped.
+func validateResources(org string, r dto.Resources) error {
+	ceiling := config.GlobalConfig.Lambda.Ceiling(org)
+
+	checks := []struct {
+		name  string
+		value int32
+		min   int32
+		max   int32
+		unit  string
+	}{
+		{"timeout", r.Timeout, constant.LambdaMinTimeout, ceiling.MaxTimeout, "seconds"},
+		{"memory", r.MemorySize, constant.LambdaMinMemory, ceiling.MaxMemory, "MB"},
+		{"ephemeral storage", r.EphemeralStorage, constant.LambdaMinEphemeralStorage, ceiling.MaxEphemeralStorage, "MB"},
+	}
+
+	for _, check := range checks {
+		if check.value == 0 {
+			continue
+		}
+
+		if check.value < check.min || check.value > check.max {
+			return errorx.BadRequest(fmt.Sprintf("the %s of lambda should be in %d-%d %s",
+				check.name, check.min, check.max, check.unit))
+		}
+	}
+
+	return nil
+}
+
+// defaultResources fills the resources not specified with the defaults.
+func defaultResources(r dto.Resources) dto.Resources {
+	if r.Timeout == 0 {
+		r.Timeout = constant.LambdaDefaultTimeout
+	}
+	if r.MemorySize == 0 {
+		r.MemorySize = constant.LambdaDefaultMemory
+	}
+	if r.EphemeralStorage == 0 {
+		r.EphemeralStorage = constant.LambdaDefaultEphemeralStorage
+	}
+
+	return r
+}
+
+// applyResources sets the resources specified to the configuration input, the others stay unchanged.
+func applyResources(input *lambda.UpdateFunctionConfigurationInput, r dto.Resources) {
+	if r.Timeout != 0 {
+		input.Timeout = aws.Int32(r.Timeout)
+	}
+	if r.MemorySize != 0 {
+		input.MemorySize = aws.Int32(r.MemorySize)
+	}
+	if r.EphemeralStorage != 0 {
+		input.EphemeralStorage = &lambTypes.EphemeralStorage{Size: aws.Int32(r.EphemeralStorage)}
+	}
+}
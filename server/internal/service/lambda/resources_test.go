@@ -0,0 +1,67 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateResourcesSuccess(t *testing.T) {
+	err := validateResources("org_name", dto.Resources{
+		Timeout:          120,
+		MemorySize:       1024,
+		EphemeralStorage: 1024,
+	})
+	assert.NoError(t, err)
+}
+
+func TestValidateResourcesBelowMinimum(t *testing.T) {
+	err := validateResources("org_name", dto.Resources{MemorySize: 64})
+	assert.Equal(t, errorx.BadRequest("the memory of lambda should be in 128-2048 MB"), err)
+}
+
+func TestValidateResourcesOrgOverride(t *testing.T) {
+	origin := config.GlobalConfig.Lambda.Orgs
+	defer func() {
+		config.GlobalConfig.Lambda.Orgs = origin
+	}()
+
+	config.GlobalConfig.Lambda.Orgs = map[string]config.LambdaCeiling{
+		"big_org": {MaxTimeout: 900},
+	}
+
+	assert.NoError(t, validateResources("big_org", dto.Resources{Timeout: 900}))
+	assert.Equal(t,
+		errorx.BadRequest("the ephemeral storage of lambda should be in 512-2048 MB"),
+		validateResources("big_org", dto.Resources{EphemeralStorage: 4096}),
+	)
+	assert.Equal(t,
+		errorx.BadRequest("the timeout of lambda should be in 1-300 seconds"),
+		validateResources("org_name", dto.Resources{Timeout: 900}),
+	)
+}
+
+func TestDefaultResources(t *testing.T) {
+	assert.Equal(t, dto.Resources{
+		Timeout:          120,
+		MemorySize:       constant.LambdaDefaultMemory,
+		EphemeralStorage: constant.LambdaDefaultEphemeralStorage,
+	}, defaultResources(dto.Resources{Timeout: 120}))
+}
+
+func TestApplyResources(t *testing.T) {
+	input := new(lambda.UpdateFunctionConfigurationInput)
+
+	applyResources(input, dto.Resources{MemorySize: 256})
+
+	assert.Nil(t, input.Timeout)
+	assert.Equal(t, aws.Int32(256), input.MemorySize)
+	assert.Nil(t, input.EphemeralStorage)
+}
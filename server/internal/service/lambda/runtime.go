@@ -0,0 +1,33 @@
+package lambda
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+)
+
+// validateRuntime checks the runtime against the allowlist, the empty one means not specified.
+func validateRuntime(runtime string) error {
+	if runtime == "" || slices.Contains(constant.LambdaRuntimes, runtime) {
+		return nil
+	}
+
+	return errorx.BadRequest(fmt.Sprintf("unsupported runtime: %s, should be one of: %s",
+		runtime, strings.Join(constant.LambdaRuntimes, ", ")))
+}
+
+// validateBundles checks all the bundles by the validator of the runtime,
+// before any of them is shipped to Lambda.
+func (svc *service) validateBundles(runtime string, files ...*dto.ReqFile) error {
+	for _, file := range files {
+		if err := svc.bundle.Validate(runtime, file.Name, file.Bytes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
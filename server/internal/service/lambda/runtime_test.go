@@ -0,0 +1,155 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRuntime(t *testing.T) {
+	assert.NoError(t, validateRuntime(""))
+	for _, runtime := range constant.LambdaRuntimes {
+		assert.NoError(t, validateRuntime(runtime), runtime)
+	}
+
+	assert.Equal(t,
+		errorx.BadRequest("unsupported runtime: java21, should be one of: nodejs20.x, nodejs22.x, python3.12"),
+		validateRuntime("java21"))
+}
+
+func TestRegisterUnsupportedRuntime(t *testing.T) {
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+
+	cd := &service{}
+
+	register, err := cd.Register(ctx, &dto.ReqRegister{
+		Runtime: "ruby3.3",
+		Files:   []*dto.ReqFile{{Name: "file1", Bytes: []byte("file1")}},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported runtime: ruby3.3")
+	assert.Nil(t, register)
+}
+
+func TestRegisterInvalidBundle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().FindByAccount(ctx, accountID).Times(1).
+		Return(make([]*dto.RespInfo, 0), nil)
+
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimePython312, "file1", []byte("file1")).Times(1).
+		Return(errorx.BadRequest("entry file: file1.py not found at the top level of: file1.zip"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		bundle:     mockBundle,
+	}
+
+	register, err := cd.Register(ctx, &dto.ReqRegister{
+		Runtime: constant.LambdaRuntimePython312,
+		Files:   []*dto.ReqFile{{Name: "file1", Bytes: []byte("file1")}},
+	})
+	assert.Equal(t, errorx.BadRequest("entry file: file1.py not found at the top level of: file1.zip"), err)
+	assert.Nil(t, register)
+}
+
+func TestUpdateSwitchRuntime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			FunctionArn:  testFunctionARN,
+			Runtime:      constant.LambdaRuntimeNodejs20,
+			Version:      "1",
+		}, nil)
+
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimePython312, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
+	mockAmazon.EXPECT().UpdateLambdaCode(ctx, gomock.Any()).Times(1).
+		Return(&lambda.UpdateFunctionCodeOutput{}, nil)
+
+	mockAmazon.EXPECT().WaitLambdaUpdated(ctx, gomock.Any()).Times(2).
+		Return(nil)
+
+	mockAmazon.EXPECT().UpdateLambdaConfig(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.UpdateFunctionConfigurationInput) (*lambda.UpdateFunctionConfigurationOutput, error) {
+			assert.Equal(t, lambTypes.RuntimePython312, input.Runtime)
+			assert.Equal(t, "file1.handler", *input.Handler)
+			return &lambda.UpdateFunctionConfigurationOutput{}, nil
+		})
+
+	mockAmazon.EXPECT().PublishLambdaVersion(ctx, gomock.Any()).Times(1).
+		Return(&lambda.PublishVersionOutput{
+			FunctionName: aws.String(testFunctionName),
+			FunctionArn:  aws.String(testFunctionARN),
+			Runtime:      lambTypes.RuntimePython312,
+			Handler:      aws.String("file1.handler"),
+			Version:      aws.String("2"),
+			CodeSha256:   aws.String("code_sha256"),
+			RevisionId:   aws.String("revision_id"),
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateLambdaAlias(ctx, gomock.Any()).Times(1).
+		Return(&lambda.UpdateAliasOutput{AliasArn: aws.String(testFunctionARN + ":live")}, nil)
+
+	mockLambRepo.EXPECT().UpdateLambdaTX(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+		amazon:     mockAmazon,
+		bundle:     mockBundle,
+	}
+
+	resp, err := cd.Update(ctx, &dto.ReqUpdate{
+		Lambda:  "file1",
+		Runtime: constant.LambdaRuntimePython312,
+		File:    &dto.ReqFile{Name: "file1", Bytes: []byte("file1")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, constant.LambdaRuntimePython312, resp.Lambda.Runtime)
+}
@@ -0,0 +1,392 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/pkg/schedulex"
+	"github.com/57blocks/auto-action/server/internal/pkg/util"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+	scheTypes "github.com/aws/aws-sdk-go-v2/service/scheduler/types"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	previewTimezone     = "UTC"
+	previewDefaultCount = 5
+	previewMaxCount     = 50
+)
+
+// SetSchedule bounds a scheduler with the Lambda when there is none,
+// otherwise changes the expression, the payload and/or the retry policy of the bound one.
+func (svc *service) SetSchedule(c context.Context, r *dto.ReqSchedule) (*dto.RespSchBrief, error) {
+	expression := strings.TrimSpace(r.Expression)
+	payload := strings.TrimSpace(r.Payload)
+
+	if expression != "" {
+		if _, err := schedulex.Parse(expression); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateRetryPolicy(r.RetryPolicy); err != nil {
+		return nil, err
+	}
+
+	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	target := targetARN(lamb)
+
+	if lamb.Scheduler.ScheduleArn == "" {
+		if expression == "" {
+			return nil, errorx.BadRequest("expression is required to bind a scheduler")
+		}
+
+		roleARN, err := svc.getRoleARN(c, util.GetRoleName(c, jwtOrg.(string), jwtAccount.(string)))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := svc.scheduledDestination(c, lamb); err != nil {
+			return nil, err
+		}
+
+		retry := mergeRetryPolicy(r.RetryPolicy, nil)
+		newSchResp, err := svc.boundScheduler(c, lamb.FunctionName, target, expression, payload, roleARN, retry)
+		if err != nil {
+			return nil, err
+		}
+
+		return svc.saveScheduler(c, lamb, model.BuildScheduler(
+			model.WithSchLambdaID(lamb.ID),
+			model.WithSchName(lamb.FunctionName),
+			model.WithSchArn(*newSchResp.ScheduleArn),
+			model.WithExpression(expression),
+			model.WithSchState(string(scheTypes.ScheduleStateEnabled)),
+			model.WithSchPayload(payload),
+			model.WithSchRetry(*retry.MaximumRetryAttempts, *retry.MaximumEventAgeInSeconds),
+		))
+	}
+
+	if expression == "" && payload == "" && !retrySpecified(r.RetryPolicy) {
+		return nil, errorx.BadRequest("either expression, payload or retry policy is required to change the scheduler")
+	}
+
+	input, err := svc.currentSchedule(c, lamb.Scheduler.ScheduleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if expression != "" {
+		input.ScheduleExpression = aws.String(expression)
+	}
+	if payload != "" {
+		eventJSON, err := genEventJSON(c, payload)
+		if err != nil {
+			return nil, err
+		}
+		input.Target.Input = aws.String(eventJSON)
+		lamb.Scheduler.Payload = payload
+	}
+	input.Target.RetryPolicy = mergeRetryPolicy(r.RetryPolicy, input.Target.RetryPolicy)
+	// schedulers bound before versioning are moved onto the managed alias along the way,
+	// and the ones bound before the dead-letter queue are pointed to it.
+	input.Target.Arn = aws.String(target)
+	deadLetter, err := svc.deadLetter(c, aws.ToString(input.Target.RoleArn))
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter != nil {
+		input.Target.DeadLetterConfig = deadLetter
+	}
+	if err := svc.scheduledDestination(c, lamb); err != nil {
+		return nil, err
+	}
+
+	return svc.updateScheduler(c, lamb, input)
+}
+
+func (svc *service) PauseSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error) {
+	return svc.switchSchedule(c, r, scheTypes.ScheduleStateDisabled)
+}
+
+func (svc *service) ResumeSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error) {
+	return svc.switchSchedule(c, r, scheTypes.ScheduleStateEnabled)
+}
+
+func (svc *service) switchSchedule(c context.Context, r *dto.ReqURILambda, state scheTypes.ScheduleState) (*dto.RespSchBrief, error) {
+	lamb, err := svc.scheduledLambda(c, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := svc.currentSchedule(c, lamb.Scheduler.ScheduleName)
+	if err != nil {
+		return nil, err
+	}
+	input.State = state
+
+	return svc.updateScheduler(c, lamb, input)
+}
+
+// RemoveSchedule detaches the scheduler from the Lambda, the Lambda itself stays.
+func (svc *service) RemoveSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error) {
+	lamb, err := svc.scheduledLambda(c, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	rmvSch, err := svc.amazon.RemoveScheduler(c, &scheduler.DeleteScheduleInput{
+		Name: aws.String(lamb.Scheduler.ScheduleName),
+	})
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to remove scheduler: %s, err: %s",
+			lamb.Scheduler.ScheduleName, err.Error()))
+	}
+	logx.Logger.INFO(fmt.Sprintf(
+		"scheduler <%s/%s> removed metadata %v",
+		lamb.Scheduler.ScheduleName, lamb.Scheduler.ScheduleArn,
+		rmvSch.ResultMetadata,
+	))
+
+	if err := svc.lambdaRepo.DeleteScheduler(c, lamb.ID); err != nil {
+		return nil, err
+	}
+
+	return &dto.RespSchBrief{
+		Arn:  lamb.Scheduler.ScheduleArn,
+		Name: lamb.Scheduler.ScheduleName,
+	}, nil
+}
+
+// scheduledLambda finds the Lambda of current account, which must be bound with a scheduler.
+func (svc *service) scheduledLambda(c context.Context, distinguish string) (*dto.RespInfo, error) {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, distinguish)
+	if err != nil {
+		return nil, err
+	}
+
+	if lamb.Scheduler.ScheduleArn == "" {
+		return nil, errorx.NotFound(fmt.Sprintf("none scheduler bound with lambda: %s", distinguish))
+	}
+
+	return lamb, nil
+}
+
+// currentSchedule fetches the definition of the scheduler as the base to update,
+// since UpdateSchedule replaces the whole definition instead of patching it.
+func (svc *service) currentSchedule(c context.Context, name string) (*scheduler.UpdateScheduleInput, error) {
+	current, err := svc.amazon.GetScheduler(c, &scheduler.GetScheduleInput{
+		Name: aws.String(name),
+	})
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to get scheduler: %s, err: %s", name, err.Error()))
+	}
+
+	return &scheduler.UpdateScheduleInput{
+		FlexibleTimeWindow:         current.FlexibleTimeWindow,
+		Name:                       current.Name,
+		ScheduleExpression:         current.ScheduleExpression,
+		Target:                     current.Target,
+		ActionAfterCompletion:      current.ActionAfterCompletion,
+		Description:                current.Description,
+		EndDate:                    current.EndDate,
+		GroupName:                  current.GroupName,
+		KmsKeyArn:                  current.KmsKeyArn,
+		ScheduleExpressionTimezone: current.ScheduleExpressionTimezone,
+		StartDate:                  current.StartDate,
+		State:                      current.State,
+	}, nil
+}
+
+// retargetScheduler points the target of the scheduler to the ARN given, the rest stays untouched.
+func (svc *service) retargetScheduler(c context.Context, name, arn string) error {
+	input, err := svc.currentSchedule(c, name)
+	if err != nil {
+		return err
+	}
+	if input.Target == nil {
+		return errorx.Internal(fmt.Sprintf("none target of scheduler: %s", name))
+	}
+	input.Target.Arn = aws.String(arn)
+
+	if _, err := svc.amazon.UpdateScheduler(c, input); err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to update scheduler: %s, err: %s", name, err.Error()))
+	}
+
+	return nil
+}
+
+func (svc *service) updateScheduler(
+	c context.Context,
+	lamb *dto.RespInfo,
+	input *scheduler.UpdateScheduleInput,
+) (*dto.RespSchBrief, error) {
+	updated, err := svc.amazon.UpdateScheduler(c, input)
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to update scheduler: %s, err: %s", *input.Name, err.Error()))
+	}
+
+	var current *scheTypes.RetryPolicy
+	if input.Target != nil {
+		current = input.Target.RetryPolicy
+	}
+	retry := mergeRetryPolicy(dto.RetryPolicy{}, current)
+
+	return svc.saveScheduler(c, lamb, model.BuildScheduler(
+		model.WithSchLambdaID(lamb.ID),
+		model.WithSchName(*input.Name),
+		model.WithSchArn(*updated.ScheduleArn),
+		model.WithExpression(*input.ScheduleExpression),
+		model.WithSchState(string(input.State)),
+		// the payload is kept unless changed
+		model.WithSchPayload(lamb.Scheduler.Payload),
+		model.WithSchRetry(*retry.MaximumRetryAttempts, *retry.MaximumEventAgeInSeconds),
+	))
+}
+
+func (svc *service) saveScheduler(c context.Context, lamb *dto.RespInfo, sch *model.LambdaScheduler) (*dto.RespSchBrief, error) {
+	if err := svc.lambdaRepo.SaveScheduler(c, sch); err != nil {
+		return nil, err
+	}
+
+	return &dto.RespSchBrief{
+		Arn:              sch.ScheduleArn,
+		Name:             sch.ScheduleName,
+		BoundLambdaArn:   targetARN(lamb),
+		Expression:       sch.Expression,
+		State:            sch.State,
+		MaxRetryAttempts: aws.Int32(sch.MaxRetryAttempts),
+		MaxEventAge:      sch.MaxEventAge,
+	}, nil
+}
+
+// validateRetryPolicy checks the retry policy is in the limits of EventBridge Scheduler.
+func validateRetryPolicy(r dto.RetryPolicy) error {
+	if r.MaxRetryAttempts != nil &&
+		(*r.MaxRetryAttempts < 0 || *r.MaxRetryAttempts > constant.SchedulerMaxRetryAttempts) {
+		return errorx.BadRequest(fmt.Sprintf("invalid max retry attempts: %d, should be between 0 and %d",
+			*r.MaxRetryAttempts, constant.SchedulerMaxRetryAttempts))
+	}
+
+	if r.MaxEventAge != nil &&
+		(*r.MaxEventAge < constant.SchedulerMinEventAge || *r.MaxEventAge > constant.SchedulerMaxEventAge) {
+		return errorx.BadRequest(fmt.Sprintf("invalid max event age: %d, should be between %d and %d seconds",
+			*r.MaxEventAge, constant.SchedulerMinEventAge, constant.SchedulerMaxEventAge))
+	}
+
+	return nil
+}
+
+func retrySpecified(r dto.RetryPolicy) bool {
+	return r.MaxRetryAttempts != nil || r.MaxEventAge != nil
+}
+
+// mergeRetryPolicy applies the retry policy given onto the current one of the scheduler,
+// the ones specified by neither of them fall back to the defaults of EventBridge Scheduler.
+func mergeRetryPolicy(r dto.RetryPolicy, current *scheTypes.RetryPolicy) *scheTypes.RetryPolicy {
+	merged := &scheTypes.RetryPolicy{
+		MaximumRetryAttempts:     aws.Int32(constant.SchedulerMaxRetryAttempts),
+		MaximumEventAgeInSeconds: aws.Int32(constant.SchedulerMaxEventAge),
+	}
+
+	if current != nil {
+		if current.MaximumRetryAttempts != nil {
+			merged.MaximumRetryAttempts = aws.Int32(*current.MaximumRetryAttempts)
+		}
+		if current.MaximumEventAgeInSeconds != nil {
+			merged.MaximumEventAgeInSeconds = aws.Int32(*current.MaximumEventAgeInSeconds)
+		}
+	}
+
+	if r.MaxRetryAttempts != nil {
+		merged.MaximumRetryAttempts = aws.Int32(*r.MaxRetryAttempts)
+	}
+	if r.MaxEventAge != nil {
+		merged.MaximumEventAgeInSeconds = aws.Int32(*r.MaxEventAge)
+	}
+
+	return merged
+}
+
+// targetARN the ARN which the scheduler of Lambda should invoke,
+// it is the managed alias once the Lambda gets published.
+func targetARN(lamb *dto.RespInfo) string {
+	if isPublished(lamb.Version) {
+		return fmt.Sprintf("%s:%s", lamb.FunctionArn, constant.LambdaAlias)
+	}
+
+	return lamb.FunctionArn
+}
+
+// Preview calculates the next fire times of the expression, displayed in the timezone, which is UTC by default.
+// The fire times are evaluated in UTC, the same as the timezone of the bound schedulers.
+func (svc *service) Preview(_ context.Context, r *dto.ReqPreview) (*dto.RespPreview, error) {
+	expression := strings.TrimSpace(r.Expression)
+	if expression == "" {
+		return nil, errorx.BadRequest("expression is required to preview")
+	}
+
+	schedule, err := schedulex.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	timezone := strings.TrimSpace(r.Timezone)
+	if timezone == "" {
+		timezone = previewTimezone
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid timezone: %s", timezone))
+	}
+
+	count := r.Count
+	switch {
+	case count < 0 || count > previewMaxCount:
+		return nil, errorx.BadRequest(fmt.Sprintf("the count of fire times should be in 1-%d", previewMaxCount))
+	case count == 0:
+		count = previewDefaultCount
+	}
+
+	fireTimes := schedulex.NextN(schedule, time.Now().UTC(), count)
+	for i, fire := range fireTimes {
+		fireTimes[i] = fire.In(loc)
+	}
+
+	return &dto.RespPreview{
+		Expression: expression,
+		Timezone:   timezone,
+		FireTimes:  fireTimes,
+	}, nil
+}
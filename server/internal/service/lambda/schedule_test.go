@@ -0,0 +1,779 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+	scheTypes "github.com/aws/aws-sdk-go-v2/service/scheduler/types"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testFunctionName = "org_name-account_name-file1"
+	testFunctionARN  = "arn:aws:lambda:us-east-2:123456789012:function:file1"
+	testScheduleARN  = "arn:aws:scheduler:us-east-2:123456789012:schedule/default/file1"
+)
+
+func TestSetScheduleCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqSchedule{
+		Lambda:     "file1",
+		Expression: "rate(5 minutes)",
+		Payload:    `{"foo":"bar"}`,
+	}
+	accountID := uint64(123)
+	roleARN := "arn:aws:iam::123456789012:role/AA-org_name-account_name-Role"
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			FunctionArn:  testFunctionARN,
+			Version:      "2",
+		}, nil)
+
+	mockAmazon.EXPECT().GetRole(ctx, &iam.GetRoleInput{
+		RoleName: aws.String("AA-org_name-account_name-Role"),
+	}).Times(1).
+		Return(&iam.GetRoleOutput{
+			Role: &iamTypes.Role{Arn: aws.String(roleARN)},
+		}, nil)
+
+	mockAmazon.EXPECT().BoundScheduler(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *scheduler.CreateScheduleInput, _ ...func(*scheduler.Options)) (*scheduler.CreateScheduleOutput, error) {
+			assert.Equal(t, testFunctionName, *input.Name)
+			assert.Equal(t, "rate(5 minutes)", *input.ScheduleExpression)
+			assert.Equal(t, testFunctionARN+":live", *input.Target.Arn)
+			assert.Equal(t, roleARN, *input.Target.RoleArn)
+			assert.Contains(t, *input.Target.Input, `"foo":"bar"`)
+			assert.Equal(t, int32(185), *input.Target.RetryPolicy.MaximumRetryAttempts)
+			assert.Equal(t, int32(86400), *input.Target.RetryPolicy.MaximumEventAgeInSeconds)
+			// none dead-letter queue configured
+			assert.Nil(t, input.Target.DeadLetterConfig)
+			return &scheduler.CreateScheduleOutput{ScheduleArn: aws.String(testScheduleARN)}, nil
+		})
+
+	mockLambRepo.EXPECT().SaveScheduler(ctx, &model.LambdaScheduler{
+		LambdaID:     1,
+		ScheduleName: testFunctionName,
+		ScheduleArn:  testScheduleARN,
+		Expression:   "rate(5 minutes)",
+		State:        "ENABLED",
+		Payload:      `{"foo":"bar"}`,
+		// the defaults of EventBridge Scheduler
+		MaxRetryAttempts: 185,
+		MaxEventAge:      86400,
+	}).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetSchedule(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespSchBrief{
+		Arn:              testScheduleARN,
+		Name:             testFunctionName,
+		BoundLambdaArn:   testFunctionARN + ":live",
+		Expression:       "rate(5 minutes)",
+		State:            "ENABLED",
+		MaxRetryAttempts: aws.Int32(185),
+		MaxEventAge:      86400,
+	}, resp)
+}
+
+func TestSetScheduleCreateWithoutExpression(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqSchedule{
+		Lambda:  "file1",
+		Payload: `{"foo":"bar"}`,
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetSchedule(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.BadRequest("expression is required to bind a scheduler"), err)
+	assert.Nil(t, resp)
+}
+
+func TestSetScheduleChangeExpression(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqSchedule{
+		Lambda:     "file1",
+		Expression: "rate(1 hour)",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			FunctionArn:  testFunctionARN,
+			Version:      "2",
+			Scheduler: dto.Scheduler{
+				ScheduleName: testFunctionName,
+				ScheduleArn:  testScheduleARN,
+				Expression:   "rate(5 minutes)",
+				State:        "DISABLED",
+			},
+		}, nil)
+
+	mockAmazon.EXPECT().GetScheduler(ctx, gomock.Any()).Times(1).
+		Return(&scheduler.GetScheduleOutput{
+			Name:               aws.String(testFunctionName),
+			ScheduleExpression: aws.String("rate(5 minutes)"),
+			Target: &scheTypes.Target{
+				Arn:   aws.String(testFunctionARN),
+				Input: aws.String(`{"foo":"bar"}`),
+			},
+			State: scheTypes.ScheduleStateDisabled,
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateScheduler(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *scheduler.UpdateScheduleInput) (*scheduler.UpdateScheduleOutput, error) {
+			assert.Equal(t, "rate(1 hour)", *input.ScheduleExpression)
+			assert.Equal(t, testFunctionARN+":live", *input.Target.Arn)
+			assert.Equal(t, `{"foo":"bar"}`, *input.Target.Input)
+			assert.Equal(t, scheTypes.ScheduleStateDisabled, input.State)
+			return &scheduler.UpdateScheduleOutput{ScheduleArn: aws.String(testScheduleARN)}, nil
+		})
+
+	mockLambRepo.EXPECT().SaveScheduler(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetSchedule(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "rate(1 hour)", resp.Expression)
+	assert.Equal(t, "DISABLED", resp.State)
+}
+
+func TestSetScheduleChangePayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqSchedule{
+		Lambda:  "file1",
+		Payload: `{"foo":"baz"}`,
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			FunctionArn:  testFunctionARN,
+			Scheduler: dto.Scheduler{
+				ScheduleName: testFunctionName,
+				ScheduleArn:  testScheduleARN,
+				Expression:   "rate(5 minutes)",
+				State:        "ENABLED",
+				Payload:      `{"foo":"bar"}`,
+			},
+		}, nil)
+
+	mockAmazon.EXPECT().GetScheduler(ctx, gomock.Any()).Times(1).
+		Return(&scheduler.GetScheduleOutput{
+			Name:               aws.String(testFunctionName),
+			ScheduleExpression: aws.String("rate(5 minutes)"),
+			Target: &scheTypes.Target{
+				Arn:   aws.String(testFunctionARN),
+				Input: aws.String(`{"foo":"bar"}`),
+			},
+			State: scheTypes.ScheduleStateEnabled,
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateScheduler(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *scheduler.UpdateScheduleInput) (*scheduler.UpdateScheduleOutput, error) {
+			assert.Equal(t, "rate(5 minutes)", *input.ScheduleExpression)
+			assert.Contains(t, *input.Target.Input, `"foo":"baz"`)
+			return &scheduler.UpdateScheduleOutput{ScheduleArn: aws.String(testScheduleARN)}, nil
+		})
+
+	mockLambRepo.EXPECT().SaveScheduler(ctx, &model.LambdaScheduler{
+		LambdaID:         1,
+		ScheduleName:     testFunctionName,
+		ScheduleArn:      testScheduleARN,
+		Expression:       "rate(5 minutes)",
+		State:            "ENABLED",
+		Payload:          `{"foo":"baz"}`,
+		MaxRetryAttempts: 185,
+		MaxEventAge:      86400,
+	}).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	_, err := cd.SetSchedule(ctx, request)
+	assert.NoError(t, err)
+}
+
+func TestSetScheduleNothingToChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqSchedule{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID: 1,
+			Scheduler: dto.Scheduler{
+				ScheduleArn: testScheduleARN,
+			},
+		}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetSchedule(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.BadRequest("either expression, payload or retry policy is required to change the scheduler"), err)
+	assert.Nil(t, resp)
+}
+
+func TestPauseScheduleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqURILambda{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			FunctionArn:  testFunctionARN,
+			Scheduler: dto.Scheduler{
+				ScheduleName: testFunctionName,
+				ScheduleArn:  testScheduleARN,
+				Payload:      `{"foo":"bar"}`,
+			},
+		}, nil)
+
+	mockAmazon.EXPECT().GetScheduler(ctx, gomock.Any()).Times(1).
+		Return(&scheduler.GetScheduleOutput{
+			Name:               aws.String(testFunctionName),
+			ScheduleExpression: aws.String("rate(5 minutes)"),
+			Target: &scheTypes.Target{
+				Arn: aws.String(testFunctionARN),
+				RetryPolicy: &scheTypes.RetryPolicy{
+					MaximumRetryAttempts:     aws.Int32(3),
+					MaximumEventAgeInSeconds: aws.Int32(3600),
+				},
+			},
+			State: scheTypes.ScheduleStateEnabled,
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateScheduler(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *scheduler.UpdateScheduleInput) (*scheduler.UpdateScheduleOutput, error) {
+			assert.Equal(t, scheTypes.ScheduleStateDisabled, input.State)
+			assert.Equal(t, "rate(5 minutes)", *input.ScheduleExpression)
+			return &scheduler.UpdateScheduleOutput{ScheduleArn: aws.String(testScheduleARN)}, nil
+		})
+
+	mockLambRepo.EXPECT().SaveScheduler(ctx, &model.LambdaScheduler{
+		LambdaID:     1,
+		ScheduleName: testFunctionName,
+		ScheduleArn:  testScheduleARN,
+		Expression:   "rate(5 minutes)",
+		State:        "DISABLED",
+		// the payload and the retry policy are kept along with pausing
+		Payload:          `{"foo":"bar"}`,
+		MaxRetryAttempts: 3,
+		MaxEventAge:      3600,
+	}).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.PauseSchedule(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "DISABLED", resp.State)
+	assert.Equal(t, testFunctionARN, resp.BoundLambdaArn)
+}
+
+func TestResumeScheduleNotBound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqURILambda{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, FunctionName: testFunctionName}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.ResumeSchedule(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.NotFound("none scheduler bound with lambda: file1"), err)
+	assert.Nil(t, resp)
+}
+
+func TestResumeScheduleUpdateError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqURILambda{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID: 1,
+			Scheduler: dto.Scheduler{
+				ScheduleName: testFunctionName,
+				ScheduleArn:  testScheduleARN,
+			},
+		}, nil)
+
+	mockAmazon.EXPECT().GetScheduler(ctx, gomock.Any()).Times(1).
+		Return(&scheduler.GetScheduleOutput{
+			Name:   aws.String(testFunctionName),
+			Target: &scheTypes.Target{Arn: aws.String(testFunctionARN)},
+			State:  scheTypes.ScheduleStateDisabled,
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateScheduler(ctx, gomock.Any()).Times(1).
+		Return(nil, errorx.Internal("update error"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.ResumeSchedule(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.Internal("failed to update scheduler: org_name-account_name-file1, err: update error"), err)
+	assert.Nil(t, resp)
+}
+
+func TestRemoveScheduleSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqURILambda{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID: 1,
+			Scheduler: dto.Scheduler{
+				ScheduleName: testFunctionName,
+				ScheduleArn:  testScheduleARN,
+			},
+		}, nil)
+
+	mockAmazon.EXPECT().RemoveScheduler(ctx, &scheduler.DeleteScheduleInput{
+		Name: aws.String(testFunctionName),
+	}).Times(1).
+		Return(&scheduler.DeleteScheduleOutput{}, nil)
+
+	mockLambRepo.EXPECT().DeleteScheduler(ctx, uint64(1)).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.RemoveSchedule(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespSchBrief{
+		Arn:  testScheduleARN,
+		Name: testFunctionName,
+	}, resp)
+}
+
+func TestRemoveScheduleDeleteError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqURILambda{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID: 1,
+			Scheduler: dto.Scheduler{
+				ScheduleName: testFunctionName,
+				ScheduleArn:  testScheduleARN,
+			},
+		}, nil)
+
+	mockAmazon.EXPECT().RemoveScheduler(ctx, gomock.Any()).Times(1).
+		Return(&scheduler.DeleteScheduleOutput{}, nil)
+
+	mockLambRepo.EXPECT().DeleteScheduler(ctx, uint64(1)).Times(1).
+		Return(errorx.Internal("failed to delete lambda scheduler"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.RemoveSchedule(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.Internal("failed to delete lambda scheduler"), err)
+	assert.Nil(t, resp)
+}
+
+func TestSetScheduleInvalidExpression(t *testing.T) {
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqSchedule{
+		Lambda:     "file1",
+		Expression: "rate(5 weeks)",
+	}
+
+	cd := &service{}
+
+	resp, err := cd.SetSchedule(ctx, request)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid schedule expression")
+	assert.Nil(t, resp)
+}
+
+func TestPreviewSuccess(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.Preview(new(gin.Context), &dto.ReqPreview{
+		Expression: "cron(0 12 * * ? *)",
+		Count:      3,
+		Timezone:   "Asia/Shanghai",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Shanghai", resp.Timezone)
+	assert.Len(t, resp.FireTimes, 3)
+
+	for _, fire := range resp.FireTimes {
+		assert.Equal(t, 20, fire.Hour())
+		assert.Equal(t, "Asia/Shanghai", fire.Location().String())
+	}
+}
+
+func TestPreviewDaylightSaving(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.Preview(new(gin.Context), &dto.ReqPreview{
+		Expression: "cron(0 12 * * ? *)",
+		Count:      3,
+		Timezone:   "America/New_York",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.FireTimes, 3)
+
+	// the schedulers fire at 12:00 UTC, which is 08:00 in New York during daylight saving time, and 07:00 otherwise.
+	for _, fire := range resp.FireTimes {
+		_, offset := fire.Zone()
+		assert.Equal(t, 12, fire.UTC().Hour())
+		assert.Equal(t, 12+offset/3600, fire.Hour())
+		assert.Equal(t, "America/New_York", fire.Location().String())
+	}
+}
+
+func TestPreviewDefault(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.Preview(new(gin.Context), &dto.ReqPreview{
+		Expression: "rate(1 hour)",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "UTC", resp.Timezone)
+	assert.Len(t, resp.FireTimes, 5)
+}
+
+func TestPreviewInvalidTimezone(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.Preview(new(gin.Context), &dto.ReqPreview{
+		Expression: "rate(1 hour)",
+		Timezone:   "Mars/Olympus",
+	})
+	assert.Equal(t, errorx.BadRequest("invalid timezone: Mars/Olympus"), err)
+	assert.Nil(t, resp)
+}
+
+func TestPreviewInvalidCount(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.Preview(new(gin.Context), &dto.ReqPreview{
+		Expression: "rate(1 hour)",
+		Count:      51,
+	})
+	assert.Equal(t, errorx.BadRequest("the count of fire times should be in 1-50"), err)
+	assert.Nil(t, resp)
+}
+
+func TestSetScheduleChangeRetry(t *testing.T) {
+	setDeadLetter(t, testDeadLetterARN, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqSchedule{
+		Lambda:      "file1",
+		RetryPolicy: dto.RetryPolicy{MaxRetryAttempts: aws.Int32(0)},
+	}
+	accountID := uint64(123)
+	roleARN := "arn:aws:iam::123456789012:role/AA-org_name-account_name-Role"
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: testFunctionName,
+			FunctionArn:  testFunctionARN,
+			Scheduler: dto.Scheduler{
+				ScheduleName: testFunctionName,
+				ScheduleArn:  testScheduleARN,
+				Payload:      `{"foo":"bar"}`,
+			},
+		}, nil)
+
+	mockAmazon.EXPECT().GetScheduler(ctx, gomock.Any()).Times(1).
+		Return(&scheduler.GetScheduleOutput{
+			Name:               aws.String(testFunctionName),
+			ScheduleExpression: aws.String("rate(5 minutes)"),
+			Target: &scheTypes.Target{
+				Arn:     aws.String(testFunctionARN),
+				RoleArn: aws.String(roleARN),
+				RetryPolicy: &scheTypes.RetryPolicy{
+					MaximumRetryAttempts:     aws.Int32(185),
+					MaximumEventAgeInSeconds: aws.Int32(3600),
+				},
+			},
+			State: scheTypes.ScheduleStateEnabled,
+		}, nil)
+
+	mockAmazon.EXPECT().PutRolePolicy(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *iam.PutRolePolicyInput) (*iam.PutRolePolicyOutput, error) {
+			assert.Equal(t, "AA-org_name-account_name-Role", *input.RoleName)
+			assert.Equal(t, "AA-org_name-account_name-Role-dead-letter-policy", *input.PolicyName)
+			assert.Contains(t, *input.PolicyDocument, testDeadLetterARN)
+			return &iam.PutRolePolicyOutput{}, nil
+		})
+
+	mockAmazon.EXPECT().UpdateScheduler(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *scheduler.UpdateScheduleInput) (*scheduler.UpdateScheduleOutput, error) {
+			// the event age not given is kept
+			assert.Equal(t, int32(0), *input.Target.RetryPolicy.MaximumRetryAttempts)
+			assert.Equal(t, int32(3600), *input.Target.RetryPolicy.MaximumEventAgeInSeconds)
+			assert.Equal(t, testDeadLetterARN, *input.Target.DeadLetterConfig.Arn)
+			return &scheduler.UpdateScheduleOutput{ScheduleArn: aws.String(testScheduleARN)}, nil
+		})
+
+	mockLambRepo.EXPECT().SaveScheduler(ctx, &model.LambdaScheduler{
+		LambdaID:         1,
+		ScheduleName:     testFunctionName,
+		ScheduleArn:      testScheduleARN,
+		Expression:       "rate(5 minutes)",
+		State:            "ENABLED",
+		Payload:          `{"foo":"bar"}`,
+		MaxRetryAttempts: 0,
+		MaxEventAge:      3600,
+	}).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.SetSchedule(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), *resp.MaxRetryAttempts)
+	assert.Equal(t, int32(3600), resp.MaxEventAge)
+}
+
+func TestSetScheduleInvalidRetry(t *testing.T) {
+	cd := &service{}
+
+	resp, err := cd.SetSchedule(new(gin.Context), &dto.ReqSchedule{
+		Lambda:      "file1",
+		RetryPolicy: dto.RetryPolicy{MaxRetryAttempts: aws.Int32(186)},
+	})
+	assert.Equal(t, errorx.BadRequest("invalid max retry attempts: 186, should be between 0 and 185"), err)
+	assert.Nil(t, resp)
+
+	resp, err = cd.SetSchedule(new(gin.Context), &dto.ReqSchedule{
+		Lambda:      "file1",
+		RetryPolicy: dto.RetryPolicy{MaxEventAge: aws.Int32(59)},
+	})
+	assert.Equal(t, errorx.BadRequest("invalid max event age: 59, should be between 60 and 86400 seconds"), err)
+	assert.Nil(t, resp)
+}
+
+func TestMergeRetryPolicy(t *testing.T) {
+	merged := mergeRetryPolicy(dto.RetryPolicy{}, nil)
+	assert.Equal(t, int32(185), *merged.MaximumRetryAttempts)
+	assert.Equal(t, int32(86400), *merged.MaximumEventAgeInSeconds)
+
+	merged = mergeRetryPolicy(dto.RetryPolicy{MaxEventAge: aws.Int32(120)}, merged)
+	assert.Equal(t, int32(185), *merged.MaximumRetryAttempts)
+	assert.Equal(t, int32(120), *merged.MaximumEventAgeInSeconds)
+}
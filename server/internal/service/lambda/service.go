@@ -13,14 +13,16 @@ import (
 	"github.com/57blocks/auto-action/server/internal/dto"
 	"github.com/57blocks/auto-action/server/internal/model"
 	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/pkg/schedulex"
 	"github.com/57blocks/auto-action/server/internal/pkg/util"
 	"github.com/57blocks/auto-action/server/internal/repo"
 	"github.com/57blocks/auto-action/server/internal/third-party/amazonx"
+	"github.com/57blocks/auto-action/server/internal/third-party/bundle"
 	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+	"github.com/57blocks/auto-action/server/internal/third-party/sorobanx"
+	"github.com/57blocks/auto-action/server/internal/third-party/stellarx"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
@@ -29,22 +31,53 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockgen -destination ../../testdata/lambda_service_mock.go -package testdata -source service.go Service
 type (
 	LambdaService interface {
 		Register(c context.Context, r *dto.ReqRegister) ([]*dto.RespRegister, error)
+		Update(c context.Context, r *dto.ReqUpdate) (*dto.RespUpdate, error)
 		Invoke(c context.Context, r *dto.ReqInvoke) (*dto.RespInvoke, error)
 		List(c context.Context, isFull bool) (interface{}, error)
 		Info(c context.Context, r *dto.ReqURILambda) (*dto.RespInfo, error)
 		Logs(c context.Context, r *dto.ReqURILambda, upgrader *websocket.Upgrader) error
+		LogsQuery(c context.Context, r *dto.ReqLogsQuery) (*dto.RespLogsQuery, error)
 		Remove(c context.Context, r *dto.ReqURILambda) (*dto.RespRemove, error)
+		Versions(c context.Context, r *dto.ReqURILambda) ([]*dto.RespVersion, error)
+		Rollback(c context.Context, r *dto.ReqRollback) (*dto.RespRollback, error)
+		SetSchedule(c context.Context, r *dto.ReqSchedule) (*dto.RespSchBrief, error)
+		PauseSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error)
+		ResumeSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error)
+		RemoveSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error)
+		Preview(c context.Context, r *dto.ReqPreview) (*dto.RespPreview, error)
+		Env(c context.Context, r *dto.ReqURILambda) (*dto.RespEnv, error)
+		SetEnv(c context.Context, r *dto.ReqSetEnv) (*dto.RespEnv, error)
+		UnsetEnv(c context.Context, r *dto.ReqUnsetEnv) (*dto.RespEnv, error)
+		Execution(c context.Context, r *dto.ReqExecution) (*dto.RespExecution, error)
+		Executions(c context.Context, r *dto.ReqExecutions) (*dto.RespExecutions, error)
+		Callback(c context.Context, r *dto.ReqCallback) error
+		Failures(c context.Context, r *dto.ReqFailures) (*dto.RespFailures, error)
+		Redrive(c context.Context, r *dto.ReqRedrive) (*dto.RespRedrive, error)
+		SetChain(c context.Context, r *dto.ReqChain) (*dto.Chain, error)
+		Triggers(c context.Context, r *dto.ReqTriggers) ([]*dto.RespTrigger, error)
+		AddTrigger(c context.Context, r *dto.ReqAddTrigger) (*dto.RespTrigger, error)
+		RemoveTrigger(c context.Context, r *dto.ReqURITrigger) error
+		WatchTriggers(c context.Context)
+		CreateWebhook(c context.Context, r *dto.ReqWebhook) (*dto.RespWebhook, error)
+		Webhooks(c context.Context, r *dto.ReqWebhooks) ([]*dto.RespWebhook, error)
+		RotateWebhook(c context.Context, r *dto.ReqWebhook) (*dto.RespWebhook, error)
+		RemoveWebhook(c context.Context, r *dto.ReqURILambda) error
+		ReceiveHook(c context.Context, r *dto.ReqHook) (*dto.RespHook, error)
 	}
 	service struct {
 		lambdaRepo repo.Lambda
 		amazon     amazonx.Amazon
 		oauthRepo  repo.OAuth
+		bundle     bundle.Bundle
+		stellar    stellarx.Stellar
+		soroban    sorobanx.Soroban
 	}
 )
 
@@ -58,6 +91,9 @@ func NewLambdaService() {
 			lambdaRepo: repo.LambdaRepo,
 			amazon:     amazonx.Conductor,
 			oauthRepo:  repo.OAuthRepo,
+			bundle:     bundle.Inspector,
+			stellar:    stellarx.Conductor,
+			soroban:    sorobanx.Conductor,
 		}
 	}
 }
@@ -65,15 +101,47 @@ func NewLambdaService() {
 type toBePersistPair struct {
 	Lambda    *model.Lambda
 	Scheduler *model.LambdaScheduler
+	Version   *model.LambdaVersion
+	Triggers  []*model.LambdaTrigger
 }
 
 func (svc *service) Register(c context.Context, r *dto.ReqRegister) ([]*dto.RespRegister, error) {
 	expression := r.Expression
 	files := r.Files
 
+	if err := validateRuntime(r.Runtime); err != nil {
+		return nil, err
+	}
+	runtime := r.Runtime
+	if runtime == "" {
+		runtime = constant.LambdaDefaultRuntime
+	}
+
+	if expression != "" {
+		if _, err := schedulex.Parse(expression); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateEnv(r.Env); err != nil {
+		return nil, err
+	}
+	if err := validateRetryPolicy(r.Retry); err != nil {
+		return nil, err
+	}
+	retry := mergeRetryPolicy(r.Retry, nil)
+	triggers, err := svc.validateTriggers(r.Triggers)
+	if err != nil {
+		return nil, err
+	}
+
 	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
 	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
 
+	if err := validateResources(jwtOrg.(string), r.Resources); err != nil {
+		return nil, err
+	}
+	resources := defaultResources(r.Resources)
+
 	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
 		OrgName: jwtOrg.(string),
 		AcnName: jwtAccount.(string),
@@ -91,9 +159,15 @@ func (svc *service) Register(c context.Context, r *dto.ReqRegister) ([]*dto.Resp
 		return nil, errorx.BadRequest(fmt.Sprintf("the number of lambdas is limited to %d", maxLimit))
 	}
 
+	if err := svc.validateBundles(runtime, files...); err != nil {
+		return nil, err
+	}
+
 	toBePersist := make([]toBePersistPair, 0, len(files))
 	resp := make([]*dto.RespRegister, 0, len(files))
 
+	variables := mergeEnv(r.Env, nil)
+
 	roleName := util.GetRoleName(c, jwtOrg.(string), jwtAccount.(string))
 	roleARN, err := svc.getRoleARN(c, roleName)
 	if err != nil {
@@ -101,7 +175,12 @@ func (svc *service) Register(c context.Context, r *dto.ReqRegister) ([]*dto.Resp
 	}
 
 	for _, file := range files {
-		newLamResp, err := svc.registerLambda(c, file, roleARN)
+		newLamResp, nonce, err := svc.registerLambda(c, file, roleARN, runtime, variables, resources)
+		if err != nil {
+			return nil, err
+		}
+
+		aliasARN, err := svc.pointAlias(c, *newLamResp.FunctionName, *newLamResp.Version, false)
 		if err != nil {
 			return nil, err
 		}
@@ -114,23 +193,40 @@ func (svc *service) Register(c context.Context, r *dto.ReqRegister) ([]*dto.Resp
 			Version: *newLamResp.Version,
 		}}
 
+		lamb := model.BuildLambda(
+			model.WithLambdaResp(newLamResp),
+			model.WithAccountID(user.ID),
+			model.WithEnvironment(variables),
+			model.WithRuntimeNonce(nonce),
+		)
 		tpp := toBePersistPair{
-			Lambda: model.BuildLambda(
-				model.WithLambdaResp(newLamResp),
-				model.WithAccountID(user.ID),
+			Lambda: lamb,
+			Version: model.BuildLambdaVersion(
+				model.WithVersion(*newLamResp.Version, *newLamResp.CodeSha256, *newLamResp.RevisionId),
+				model.WithVersionConfig(lamb),
+				model.WithUploader(jwtAccount.(string)),
 			),
 		}
 
 		if strings.TrimSpace(expression) != "" {
-			newSchResp, err := svc.boundScheduler(c, newLamResp, expression, r.Payload, roleARN)
+			newSchResp, err := svc.boundScheduler(c, *newLamResp.FunctionName, aliasARN, expression, r.Payload, roleARN, retry)
 			if err != nil {
 				return nil, err
 			}
+			if err := svc.scheduledDestination(c, &dto.RespInfo{
+				FunctionName: *newLamResp.FunctionName,
+				Version:      *newLamResp.Version,
+				Role:         roleARN,
+			}); err != nil {
+				return nil, err
+			}
 
 			respItem.Scheduler = &dto.RespSchBrief{
-				Arn:            *newSchResp.ScheduleArn,
-				Name:           *newLamResp.FunctionName,
-				BoundLambdaArn: *newLamResp.FunctionArn,
+				Arn:              *newSchResp.ScheduleArn,
+				Name:             *newLamResp.FunctionName,
+				BoundLambdaArn:   aliasARN,
+				MaxRetryAttempts: retry.MaximumRetryAttempts,
+				MaxEventAge:      *retry.MaximumEventAgeInSeconds,
 			}
 
 			tpp.Scheduler = model.BuildScheduler(
@@ -138,11 +234,22 @@ func (svc *service) Register(c context.Context, r *dto.ReqRegister) ([]*dto.Resp
 				model.WithSchArn(*newSchResp.ScheduleArn),
 				// in binding the scheduler with Lambda, the scheduler name is from the name of Lambda.
 				model.WithSchName(*newLamResp.FunctionName),
+				model.WithSchState(string(scheTypes.ScheduleStateEnabled)),
+				model.WithSchPayload(strings.TrimSpace(r.Payload)),
+				model.WithSchRetry(*retry.MaximumRetryAttempts, *retry.MaximumEventAgeInSeconds),
 			)
-		} else {
+		} else if len(triggers) == 0 {
 			logx.Logger.INFO(fmt.Sprintf("%s: will be triggered manually", file.Name))
 		}
 
+		for _, trigger := range triggers {
+			tpp.Triggers = append(tpp.Triggers, model.BuildLambdaTrigger(
+				model.WithTrigger(trigger.Type, trigger.Address),
+				model.WithTriggerTopics(trigger.Topics),
+				model.WithTriggerCursor(constant.TriggerCursorNow),
+			))
+		}
+
 		toBePersist = append(toBePersist, tpp)
 		resp = append(resp, respItem)
 	}
@@ -151,12 +258,39 @@ func (svc *service) Register(c context.Context, r *dto.ReqRegister) ([]*dto.Resp
 		return nil, err
 	}
 
+	for idx, pair := range toBePersist {
+		for _, trigger := range pair.Triggers {
+			resp[idx].Triggers = append(resp[idx].Triggers, &dto.RespTrigger{
+				ID:       trigger.ID,
+				LambdaID: pair.Lambda.ID,
+				Lambda:   pair.Lambda.FunctionName,
+				Type:     trigger.Type,
+				Address:  trigger.Address,
+				Topics:   trigger.Topics,
+				Cursor:   trigger.Cursor,
+			})
+		}
+	}
+
 	return resp, nil
 }
 
-func (svc *service) registerLambda(c context.Context, file *dto.ReqFile, roleARN string) (*lambda.CreateFunctionOutput, error) {
+func (svc *service) registerLambda(
+	c context.Context,
+	file *dto.ReqFile,
+	roleARN string,
+	runtime string,
+	variables dto.Env,
+	resources dto.Resources,
+) (*lambda.CreateFunctionOutput, string, error) {
 	splits := strings.Split(file.Name, ".")
 	fileName := splits[0]
+	functionName := util.GenLambdaFuncName(c, fileName)
+
+	environment, nonce, err := lambdaEnv(c, functionName, variables)
+	if err != nil {
+		return nil, "", err
+	}
 
 	// register lambda
 	lambdaFun, err := svc.amazon.RegisterLambda(
@@ -165,54 +299,65 @@ func (svc *service) registerLambda(c context.Context, file *dto.ReqFile, roleARN
 			Code: &lambTypes.FunctionCode{
 				ZipFile: file.Bytes,
 			},
-			FunctionName: aws.String(util.GenLambdaFuncName(c, fileName)),
-			// TODO: put into env when the infrastructure is ready, the same as `VpcConfig` below.
-			Environment: &lambTypes.Environment{Variables: map[string]string{"ENV_AWS_REGION": "us-east-2"}},
+			FunctionName: aws.String(functionName),
+			Environment:  environment,
 			// This execution role has full access of CloudWatch and Lambda execution access.
-			Role:        aws.String(roleARN),
-			Runtime:     lambTypes.RuntimeNodejs20x,
-			Timeout:     aws.Int32(30),
+			Role:       aws.String(roleARN),
+			Runtime:    lambTypes.Runtime(runtime),
+			Timeout:    aws.Int32(resources.Timeout),
+			MemorySize: aws.Int32(resources.MemorySize),
+			EphemeralStorage: &lambTypes.EphemeralStorage{
+				Size: aws.Int32(resources.EphemeralStorage),
+			},
 			Description: nil,
 			Handler:     aws.String(fmt.Sprintf("%s.handler", fileName)),
 			PackageType: lambTypes.PackageTypeZip,
-			Publish:     false,
+			Publish:     true,
 		},
 		func(opt *lambda.Options) {},
 	)
 	if err != nil {
-		return nil, errorx.Internal(fmt.Sprintf("failed to register lambda: %s, err: %s", fileName, err.Error()))
+		return nil, "", errorx.Internal(fmt.Sprintf("failed to register lambda: %s, err: %s", fileName, err.Error()))
 	}
 
-	return lambdaFun, nil
+	return lambdaFun, nonce, nil
 }
 
+// boundScheduler creates the scheduler named after the Lambda, which invokes the target,
+// the target is the ARN of the managed alias for the published Lambda.
+// The events failed to deliver after the retries go to the dead-letter queue, when it's configured.
 func (svc *service) boundScheduler(
 	c context.Context,
-	lambdaFun *lambda.CreateFunctionOutput,
+	functionName string,
+	targetARN string,
 	expression string,
 	inputPayload string,
 	roleARN string,
+	retry *scheTypes.RetryPolicy,
 ) (*scheduler.CreateScheduleOutput, error) {
-	event, err := util.GenEventPayload(c, inputPayload)
+	eventJSON, err := genEventJSON(c, inputPayload)
 	if err != nil {
 		return nil, err
 	}
-	eventJSON, err := json.Marshal(event)
+
+	deadLetter, err := svc.deadLetter(c, roleARN)
 	if err != nil {
-		return nil, errorx.Internal(fmt.Sprintf("failed to marshal event payload to json: %s", err.Error()))
+		return nil, err
 	}
 
 	newSchResp, err := svc.amazon.BoundScheduler(c, &scheduler.CreateScheduleInput{
 		FlexibleTimeWindow: &scheTypes.FlexibleTimeWindow{
 			Mode: scheTypes.FlexibleTimeWindowModeOff,
 		},
-		Name:               aws.String(*lambdaFun.FunctionName),
-		ScheduleExpression: aws.String(expression), // rate(1 minutes)/cron(...)
+		Name:               aws.String(functionName),
+		ScheduleExpression: aws.String(expression), // rate(1 minute)/cron(...)
 		Target: &scheTypes.Target{
-			Arn: lambdaFun.FunctionArn,
+			Arn: aws.String(targetARN),
 			// This role has the Lambda invoke access to all Lambda functions in current AWS account.
-			RoleArn: aws.String(roleARN),
-			Input:   aws.String(string(eventJSON)),
+			RoleArn:          aws.String(roleARN),
+			Input:            aws.String(eventJSON),
+			RetryPolicy:      retry,
+			DeadLetterConfig: deadLetter,
 		},
 		ActionAfterCompletion:      scheTypes.ActionAfterCompletionNone,
 		Description:                nil,
@@ -220,7 +365,7 @@ func (svc *service) boundScheduler(
 		State:                      scheTypes.ScheduleStateEnabled,
 	})
 	if err != nil {
-		return nil, errorx.Internal(fmt.Sprintf("failed to bound scheduler: %s, err: %s", *lambdaFun.FunctionName, err.Error()))
+		return nil, errorx.Internal(fmt.Sprintf("failed to bound scheduler: %s, err: %s", functionName, err.Error()))
 	}
 
 	logx.Logger.DEBUG(fmt.Sprintf("scheduler created: %s", *newSchResp.ScheduleArn))
@@ -228,6 +373,20 @@ func (svc *service) boundScheduler(
 	return newSchResp, nil
 }
 
+// genEventJSON generates the JSON of event payload which is delivered by the scheduler.
+func genEventJSON(c context.Context, inputPayload string) (string, error) {
+	event, err := util.GenEventPayload(c, inputPayload)
+	if err != nil {
+		return "", err
+	}
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return "", errorx.Internal(fmt.Sprintf("failed to marshal event payload to json: %s", err.Error()))
+	}
+
+	return string(eventJSON), nil
+}
+
 func (svc *service) persistRegisterResults(c context.Context, pairs []toBePersistPair) error {
 	if err := svc.lambdaRepo.PersistRegResult(c, func(tx *gorm.DB) error {
 		for _, pair := range pairs {
@@ -236,6 +395,24 @@ func (svc *service) persistRegisterResults(c context.Context, pairs []toBePersis
 				return errorx.Internal(fmt.Sprintf("failed to create lambda: %s", pair.Lambda.FunctionArn))
 			}
 
+			if pair.Version != nil {
+				pair.Version.LambdaID = pair.Lambda.ID
+
+				if err := tx.Table("lambda_version").Create(pair.Version).Error; err != nil {
+					return errorx.Internal(fmt.Sprintf("failed to create lambda version: %s:%s",
+						pair.Lambda.FunctionArn, pair.Version.Version))
+				}
+			}
+
+			for _, trigger := range pair.Triggers {
+				trigger.LambdaID = pair.Lambda.ID
+
+				if err := tx.Table("lambda_trigger").Create(trigger).Error; err != nil {
+					return errorx.Internal(fmt.Sprintf("failed to create lambda trigger: %s/%s",
+						trigger.Type, trigger.Address))
+				}
+			}
+
 			if pair.Scheduler == nil {
 				continue
 			}
@@ -257,6 +434,248 @@ func (svc *service) persistRegisterResults(c context.Context, pairs []toBePersis
 	return nil
 }
 
+func (svc *service) Update(c context.Context, r *dto.ReqUpdate) (*dto.RespUpdate, error) {
+	if err := validateRuntime(r.Runtime); err != nil {
+		return nil, err
+	}
+	if err := validateEnv(r.Env); err != nil {
+		return nil, err
+	}
+
+	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	if err := validateResources(jwtOrg.(string), r.Resources); err != nil {
+		return nil, err
+	}
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	// the bundle is checked by the runtime switched to, or the current one when not specified.
+	runtime := lamb.Runtime
+	if r.Runtime != "" {
+		runtime = r.Runtime
+	}
+	if err := svc.validateBundles(runtime, r.File); err != nil {
+		return nil, err
+	}
+
+	// the variables given are merged into the current ones, and the platform variables are refreshed along the way.
+	variables := mergeEnv(lamb.Environment, r.Env)
+	environment, nonce, err := lambdaEnv(c, lamb.FunctionName, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	switched := ""
+	if runtime != lamb.Runtime {
+		switched = runtime
+	}
+
+	published, err := svc.updateLambda(c, lamb.FunctionName, r.File, switched, environment, r.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasARN, err := svc.pointAlias(c, lamb.FunctionName, *published.Version, isPublished(lamb.Version))
+	if err != nil {
+		return nil, err
+	}
+
+	// the scheduler of Lambda registered before versioning is bound with the function itself,
+	// it's retargeted to the alias created along with the first published version.
+	if !isPublished(lamb.Version) && lamb.Scheduler.ScheduleArn != "" {
+		if err := svc.retargetScheduler(c, lamb.Scheduler.ScheduleName, aliasARN); err != nil {
+			return nil, err
+		}
+	}
+	// the destinations are set on the alias, which is missed by the ones set before the first published version.
+	if lamb.Scheduler.ScheduleArn != "" {
+		aliased := *lamb
+		aliased.Version = *published.Version
+		if err := svc.scheduledDestination(c, &aliased); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := svc.savePublished(c, lamb, published, jwtAccount.(string),
+		model.WithEnvironment(variables), model.WithRuntimeNonce(nonce)); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RespUpdate{
+		Lambda: &dto.RespLamBrief{
+			Name:       *published.FunctionName,
+			Arn:        *published.FunctionArn,
+			Runtime:    string(published.Runtime),
+			Handler:    *published.Handler,
+			Version:    *published.Version,
+			CodeSHA256: *published.CodeSha256,
+			RevisionID: *published.RevisionId,
+		},
+	}
+
+	if lamb.Scheduler.ScheduleArn != "" {
+		resp.Scheduler = &dto.RespSchBrief{
+			Arn:            lamb.Scheduler.ScheduleArn,
+			Name:           lamb.Scheduler.ScheduleName,
+			BoundLambdaArn: aliasARN,
+		}
+	}
+
+	return resp, nil
+}
+
+// savePublished refreshes the Lambda by the published version, and records the version.
+// Only the code and configuration related fields are refreshed, the bound scheduler stays untouched.
+func (svc *service) savePublished(
+	c context.Context,
+	lamb *dto.RespInfo,
+	published *lambda.PublishVersionOutput,
+	uploader string,
+	opts ...model.LambdaOpt,
+) error {
+	refreshed := model.BuildLambda(append([]model.LambdaOpt{model.WithLambdaPublished(published)}, opts...)...)
+
+	return svc.lambdaRepo.UpdateLambdaTX(c, func(tx *gorm.DB) error {
+		if err := tx.Table(model.TabNameLambda()).
+			Where("id = ?", lamb.ID).
+			Updates(refreshed).
+			Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to update lambda: %s", lamb.FunctionArn))
+		}
+
+		// Lambda returns the last version instead of publishing a new one when nothing changed.
+		if err := tx.Table(model.TabNameLambdaVersion()).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(model.BuildLambdaVersion(
+				model.WithLambdaID(lamb.ID),
+				model.WithVersion(*published.Version, *published.CodeSha256, *published.RevisionId),
+				model.WithVersionConfig(refreshed),
+				model.WithUploader(uploader),
+			)).
+			Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to create lambda version: %s:%s", lamb.FunctionArn, *published.Version))
+		}
+
+		return nil
+	})
+}
+
+// updateLambda replaces the code of the function, points the handler to the new entry file
+// along with the runtime switched to, the environment and the resources specified, then publishes a new version.
+// Each step could only start after the previous update is finished.
+func (svc *service) updateLambda(
+	c context.Context,
+	functionName string,
+	file *dto.ReqFile,
+	runtime string,
+	environment *lambTypes.Environment,
+	resources dto.Resources,
+) (*lambda.PublishVersionOutput, error) {
+	splits := strings.Split(file.Name, ".")
+	fileName := splits[0]
+
+	if _, err := svc.amazon.UpdateLambdaCode(c, &lambda.UpdateFunctionCodeInput{
+		FunctionName: aws.String(functionName),
+		ZipFile:      file.Bytes,
+		Publish:      false,
+	}); err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to update lambda code: %s, err: %s", functionName, err.Error()))
+	}
+
+	if err := svc.waitLambdaUpdated(c, functionName); err != nil {
+		return nil, err
+	}
+
+	input := &lambda.UpdateFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+		Handler:      aws.String(fmt.Sprintf("%s.handler", fileName)),
+		Environment:  environment,
+	}
+	if runtime != "" {
+		input.Runtime = lambTypes.Runtime(runtime)
+	}
+	applyResources(input, resources)
+
+	if _, err := svc.amazon.UpdateLambdaConfig(c, input); err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to update lambda config: %s, err: %s", functionName, err.Error()))
+	}
+
+	return svc.publishLambda(c, functionName)
+}
+
+// publishLambda publishes a new version of the function after the last update is finished.
+func (svc *service) publishLambda(c context.Context, functionName string) (*lambda.PublishVersionOutput, error) {
+	if err := svc.waitLambdaUpdated(c, functionName); err != nil {
+		return nil, err
+	}
+
+	published, err := svc.amazon.PublishLambdaVersion(c, &lambda.PublishVersionInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to publish lambda: %s, err: %s", functionName, err.Error()))
+	}
+
+	return published, nil
+}
+
+func (svc *service) waitLambdaUpdated(c context.Context, functionName string) error {
+	if err := svc.amazon.WaitLambdaUpdated(c, &lambda.GetFunctionInput{
+		FunctionName: aws.String(functionName),
+	}); err != nil {
+		return errorx.Internal(fmt.Sprintf("failed to wait lambda updated: %s, err: %s", functionName, err.Error()))
+	}
+
+	return nil
+}
+
+// pointAlias points the managed alias of the function to the published version, and returns the alias ARN.
+// The alias is created along with the first published version.
+func (svc *service) pointAlias(c context.Context, functionName, version string, exists bool) (string, error) {
+	if !exists {
+		created, err := svc.amazon.CreateLambdaAlias(c, &lambda.CreateAliasInput{
+			FunctionName:    aws.String(functionName),
+			FunctionVersion: aws.String(version),
+			Name:            aws.String(constant.LambdaAlias),
+		})
+		if err != nil {
+			return "", errorx.Internal(fmt.Sprintf("failed to create alias of lambda: %s, err: %s", functionName, err.Error()))
+		}
+
+		return *created.AliasArn, nil
+	}
+
+	updated, err := svc.amazon.UpdateLambdaAlias(c, &lambda.UpdateAliasInput{
+		FunctionName:    aws.String(functionName),
+		FunctionVersion: aws.String(version),
+		Name:            aws.String(constant.LambdaAlias),
+	})
+	if err != nil {
+		return "", errorx.Internal(fmt.Sprintf("failed to update alias of lambda: %s, err: %s", functionName, err.Error()))
+	}
+
+	return *updated.AliasArn, nil
+}
+
+// isPublished reports whether the Lambda has a published version, which means the managed alias exists.
+// Lambdas registered before versioning are left unpublished.
+func isPublished(version string) bool {
+	return version != "" && version != constant.LambdaUnpublished
+}
+
 func (svc *service) Invoke(c context.Context, r *dto.ReqInvoke) (*dto.RespInvoke, error) {
 	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
 
@@ -280,12 +699,22 @@ func (svc *service) Invoke(c context.Context, r *dto.ReqInvoke) (*dto.RespInvoke
 		return nil, errorx.Internal(fmt.Sprintf("failed to marshal payload: %s", err.Error()))
 	}
 
+	if r.Async {
+		return svc.invokeAsync(c, jwtAccount.(string), lamb, "", payloadBytes)
+	}
+
 	// invoke
-	invokeOutput, err := svc.amazon.InvokeLambda(c, &lambda.InvokeInput{
+	input := &lambda.InvokeInput{
 		FunctionName: aws.String(lamb.FunctionName),
 		LogType:      lambTypes.LogTypeTail,
 		Payload:      payloadBytes,
-	})
+	}
+	if isPublished(lamb.Version) {
+		input.Qualifier = aws.String(constant.LambdaAlias)
+	}
+
+	invokedAt := time.Now().UTC()
+	invokeOutput, err := svc.amazon.InvokeLambda(c, input)
 	if err != nil {
 		return nil, errorx.Internal(fmt.Sprintf("failed to invoke lambda: %s, error: %s", lamb.FunctionName, err.Error()))
 	}
@@ -304,7 +733,13 @@ func (svc *service) Invoke(c context.Context, r *dto.ReqInvoke) (*dto.RespInvoke
 	}
 	invokeOutput.Payload = []byte(decodedPayload)
 
-	return dto.BuildRespInvoke(dto.WithInvokeResp(invokeOutput)), nil
+	resp := dto.BuildRespInvoke(dto.WithInvokeResp(invokeOutput))
+	resp.ExecutionID = svc.recordInvocation(c, jwtAccount.(string), lamb, payloadBytes, invokedAt, invokeOutput)
+
+	svc.chainNext(c, lamb, resp.ExecutionID, jwtAccount.(string), invokeOutput.FunctionError == nil,
+		payloadBytes, invokeOutput.Payload)
+
+	return resp, nil
 }
 
 func (svc *service) List(c context.Context, isFull bool) (interface{}, error) {
@@ -329,6 +764,7 @@ func (svc *service) List(c context.Context, isFull bool) (interface{}, error) {
 		respBrief = append(respBrief, &dto.RespInList{
 			FunctionName: lamb.FunctionName,
 			FunctionArn:  lamb.FunctionArn,
+			Runtime:      lamb.Runtime,
 			Description:  lamb.Description,
 			CreatedAt:    lamb.CreatedAt,
 		})
@@ -350,70 +786,15 @@ func (svc *service) Info(c context.Context, r *dto.ReqURILambda) (*dto.RespInfo,
 		return nil, err
 	}
 
-	return info, nil
-}
-
-func (svc *service) Logs(c context.Context, req *dto.ReqURILambda, upgrader *websocket.Upgrader) error {
-	// websocket
-	ctx, ok := c.(*gin.Context)
-	if !ok {
-		return errorx.GinContextConv()
-	}
-
-	wsConn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
-	if err != nil {
-		return errorx.Internal(fmt.Sprintf("failed to upgrade websocket: %s", err.Error()))
-	}
-	defer wsConn.Close()
-
-	logGroupName := "/aws/lambda/" + util.GenLambdaFuncName(c, req.Lambda)
-
-	describeInput := &cloudwatchlogs.DescribeLogStreamsInput{
-		LogGroupName: &logGroupName,
-		OrderBy:      types.OrderByLastEventTime,
-		Descending:   aws.Bool(true),
-	}
-
-	describeOutput, err := svc.amazon.DescribeLogStreams(c, describeInput)
+	lambs, err := svc.lambdaRepo.FindByAccount(c, user.ID)
 	if err != nil {
-		return errorx.Internal(fmt.Sprintf("failed to describe log streams: %s", err.Error()))
-	}
-
-	if len(describeOutput.LogStreams) == 0 {
-		return errorx.NotFound("no log streams found")
+		return nil, err
 	}
-
-	logStreamName := describeOutput.LogStreams[0].LogStreamName
-
-	input := &cloudwatchlogs.GetLogEventsInput{
-		LogGroupName:  &logGroupName,
-		LogStreamName: logStreamName,
+	if chain := chainGraph(lambs, info.ID); len(chain.Links) > 0 {
+		info.Chain = chain
 	}
 
-	var nextToken *string
-
-	for {
-		if nextToken != nil {
-			input.NextToken = nextToken
-		}
-
-		output, err := svc.amazon.GetLogEvents(c, input)
-		if err != nil {
-			return errorx.Internal(fmt.Sprintf("failed to get log events: %s", err.Error()))
-		}
-
-		for _, event := range output.Events {
-			if err := wsConn.WriteMessage(websocket.TextMessage, []byte(*event.Message)); err != nil {
-				return errorx.Internal(fmt.Sprintf("failed to write message: %s", err.Error()))
-			}
-		}
-
-		nextToken = output.NextForwardToken
-
-		if nextToken == nil {
-			time.Sleep(30 * time.Second)
-		}
-	}
+	return info, nil
 }
 
 func (svc *service) Remove(c context.Context, r *dto.ReqURILambda) (*dto.RespRemove, error) {
@@ -477,6 +858,70 @@ func (svc *service) Remove(c context.Context, r *dto.ReqURILambda) (*dto.RespRem
 				return errorx.Internal(fmt.Sprintf("failed to delete scheduler: %s", lamb.Scheduler.ScheduleArn))
 			}
 
+			if err := tx.
+				Where(map[string]interface{}{
+					"lambda_id": lamb.ID,
+				}).
+				Delete(&model.LambdaVersion{}).Error; err != nil {
+				return errorx.Internal(fmt.Sprintf("failed to delete versions of lambda: %s", lamb.FunctionArn))
+			}
+
+			if err := tx.
+				Where(map[string]interface{}{
+					"lambda_id": lamb.ID,
+				}).
+				Delete(&model.LambdaTrigger{}).Error; err != nil {
+				return errorx.Internal(fmt.Sprintf("failed to delete triggers of lambda: %s", lamb.FunctionArn))
+			}
+
+			if err := tx.
+				Where(map[string]interface{}{
+					"lambda_id": lamb.ID,
+				}).
+				Delete(&model.LambdaWebhook{}).Error; err != nil {
+				return errorx.Internal(fmt.Sprintf("failed to delete webhook of lambda: %s", lamb.FunctionArn))
+			}
+
+			if err := tx.
+				Where(map[string]interface{}{
+					"lambda_id": lamb.ID,
+				}).
+				Delete(&model.KVEntry{}).Error; err != nil {
+				return errorx.Internal(fmt.Sprintf("failed to delete kv entries of lambda: %s", lamb.FunctionArn))
+			}
+
+			if err := tx.
+				Where(map[string]interface{}{
+					"lambda_id": lamb.ID,
+				}).
+				Delete(&model.LambdaSecret{}).Error; err != nil {
+				return errorx.Internal(fmt.Sprintf("failed to revoke secrets of lambda: %s", lamb.FunctionArn))
+			}
+
+			if err := tx.
+				Where(map[string]interface{}{
+					"lambda_id": lamb.ID,
+				}).
+				Delete(&model.LambdaExecution{}).Error; err != nil {
+				return errorx.Internal(fmt.Sprintf("failed to delete executions of lambda: %s", lamb.FunctionArn))
+			}
+
+			if err := tx.
+				Where(map[string]interface{}{
+					"lambda_id": lamb.ID,
+				}).
+				Delete(&model.LambdaFailure{}).Error; err != nil {
+				return errorx.Internal(fmt.Sprintf("failed to delete failures of lambda: %s", lamb.FunctionArn))
+			}
+
+			for _, column := range []string{"on_success", "on_failure"} {
+				if err := tx.Model(&model.Lambda{}).
+					Where(column+" = ?", lamb.ID).
+					Update(column, nil).Error; err != nil {
+					return errorx.Internal(fmt.Sprintf("failed to unchain lambda: %s", lamb.FunctionArn))
+				}
+			}
+
 			return nil
 		},
 		&sql.TxOptions{
@@ -497,6 +942,116 @@ func (svc *service) Remove(c context.Context, r *dto.ReqURILambda) (*dto.RespRem
 	}, nil
 }
 
+func (svc *service) Versions(c context.Context, r *dto.ReqURILambda) ([]*dto.RespVersion, error) {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := svc.lambdaRepo.FindVersions(c, lamb.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		version.Live = version.Version == lamb.Version
+	}
+
+	return versions, nil
+}
+
+func (svc *service) Rollback(c context.Context, r *dto.ReqRollback) (*dto.RespRollback, error) {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, r.Lambda)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isPublished(lamb.Version) {
+		return nil, errorx.BadRequest(fmt.Sprintf("none published version of lambda: %s", r.Lambda))
+	}
+	if lamb.Version == r.Version {
+		return nil, errorx.BadRequest(fmt.Sprintf("version %s is already live", r.Version))
+	}
+
+	target, err := svc.lambdaRepo.FindVersion(c, lamb.ID, r.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// the code is not re-uploaded, only the alias is repointed to the target version.
+	if _, err := svc.pointAlias(c, lamb.FunctionName, target.Version, true); err != nil {
+		return nil, err
+	}
+
+	rolledBack, err := rollbackColumns(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.lambdaRepo.UpdateLambdaTX(c, func(tx *gorm.DB) error {
+		if err := tx.Table(model.TabNameLambda()).
+			Where("id = ?", lamb.ID).
+			Updates(rolledBack).
+			Error; err != nil {
+			return errorx.Internal(fmt.Sprintf("failed to roll back lambda: %s", lamb.FunctionArn))
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return &dto.RespRollback{
+		Name:            lamb.FunctionName,
+		Arn:             lamb.FunctionArn,
+		PreviousVersion: lamb.Version,
+		Version:         target.Version,
+		CodeSHA256:      target.CodeSHA256,
+	}, nil
+}
+
+// rollbackColumns returns the columns of Lambda switched to the target version, along with the runtime nonce of it,
+// the configuration is restored unless the version is recorded without it.
+func rollbackColumns(target *dto.RespVersion) (map[string]interface{}, error) {
+	columns := map[string]interface{}{
+		"version":       target.Version,
+		"code_sha256":   target.CodeSHA256,
+		"revision_id":   target.RevisionID,
+		"runtime_nonce": target.RuntimeNonce,
+	}
+	if target.Runtime == "" {
+		return columns, nil
+	}
+
+	environment, err := json.Marshal(mergeEnv(target.Environment, nil))
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to marshal environment of version: %s", target.Version))
+	}
+
+	columns["runtime"] = target.Runtime
+	columns["handler"] = target.Handler
+	columns["timeout"] = target.Timeout
+	columns["memory_size"] = target.MemorySize
+	columns["ephemeral_storage"] = target.EphemeralStorage
+	columns["environment"] = string(environment)
+
+	return columns, nil
+}
+
 func (svc *service) getRoleARN(c context.Context, roleName string) (string, error) {
 	input := &iam.GetRoleInput{
 		RoleName: aws.String(roleName),
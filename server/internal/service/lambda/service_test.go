@@ -6,20 +6,18 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/57blocks/auto-action/server/internal/config"
 	"github.com/57blocks/auto-action/server/internal/constant"
 	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
 	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
 	"github.com/57blocks/auto-action/server/internal/testdata"
 	"github.com/57blocks/auto-action/server/internal/third-party/logx"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
-	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
@@ -81,7 +79,7 @@ func TestInfoSuccess(t *testing.T) {
 				Runtime:      "nodejs20.x",
 				Version:      "$LATEST",
 				Scheduler: dto.Scheduler{
-					Expression:  "rate(1 minutes)",
+					Expression:  "rate(1 minute)",
 					ScheduleArn: schARN,
 				},
 				CreatedAt: &now,
@@ -89,6 +87,9 @@ func TestInfoSuccess(t *testing.T) {
 			}, nil,
 		)
 
+	mockLambRepo.EXPECT().FindByAccount(ctx, accountID).Times(1).
+		Return([]*dto.RespInfo{}, nil)
+
 	cd := &service{
 		lambdaRepo: mockLambRepo,
 		oauthRepo:  mockOAuthRepo,
@@ -97,6 +98,7 @@ func TestInfoSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, sha256, info.CodeSHA256)
 	assert.Equal(t, schARN, info.Scheduler.ScheduleArn)
+	assert.Nil(t, info.Chain)
 }
 
 func TestInfoUserNotFound(t *testing.T) {
@@ -192,6 +194,17 @@ func TestInvokeSuccess(t *testing.T) {
 			Payload:   []byte(encodedPayload),
 		}, nil)
 
+	mockLambRepo.EXPECT().SaveExecution(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, exe *model.LambdaExecution) error {
+			assert.Equal(t, constant.ExecutionSync, exe.Mode)
+			assert.Equal(t, constant.ExecutionSucceeded, exe.Status)
+			assert.Equal(t, "account_name", exe.Invoker)
+			assert.Equal(t, "bar", exe.Result)
+			assert.Equal(t, "foo", exe.LogTail)
+			assert.NotEmpty(t, exe.ExecutionID)
+			return nil
+		})
+
 	cd := &service{
 		lambdaRepo: mockLambRepo,
 		amazon:     mockAmazon,
@@ -577,12 +590,15 @@ func TestRegisterSuccess(t *testing.T) {
 	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
 
 	ctx := new(gin.Context)
 	ctx.Set(constant.ClaimSub.Str(), "account_name")
 	ctx.Set(constant.ClaimIss.Str(), "org_name")
 	request := &dto.ReqRegister{
-		Expression: "rate(minutes)",
+		Expression: "rate(1 minute)",
+		Env:        dto.Env{"FOO": "bar"},
+		Resources:  dto.Resources{Timeout: 120, MemorySize: 512},
 		Files: []*dto.ReqFile{
 			{
 				Name:  "file1",
@@ -594,6 +610,7 @@ func TestRegisterSuccess(t *testing.T) {
 	roleARN := "arn:aws:iam::123456789012:role/AA-org_name-account_name-Role"
 	functionARN := "arn:aws:lambda:us-east-2:123456789012:function:file1"
 	scheduleARN := "arn:aws:scheduler:us-east-2:123456789012:schedule/default/file1"
+	aliasARN := functionARN + ":live"
 
 	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, &dto.ReqOrgAcn{
 		OrgName: "org_name",
@@ -618,20 +635,25 @@ func TestRegisterSuccess(t *testing.T) {
 		DoAndReturn(func(ctx *gin.Context, input *lambda.CreateFunctionInput, _ ...func(*lambda.Options)) (*lambda.CreateFunctionOutput, error) {
 			assert.Equal(t, []byte("file1"), input.Code.ZipFile)
 			assert.Equal(t, aws.String("org_name-account_name-file1"), input.FunctionName)
-			assert.Equal(t, map[string]string{"ENV_AWS_REGION": "us-east-2"}, input.Environment.Variables)
+			assert.Equal(t, "bar", input.Environment.Variables["FOO"])
+			assert.Equal(t, "org_name", input.Environment.Variables[constant.LambdaEnvOrg])
+			assert.Equal(t, "account_name", input.Environment.Variables[constant.LambdaEnvAccount])
+			assert.Equal(t, config.GlobalConfig.Amazon.Region, input.Environment.Variables[constant.LambdaEnvLegacyRegion])
 			assert.Equal(t, aws.String(roleARN), input.Role)
 			assert.Equal(t, lambTypes.RuntimeNodejs20x, input.Runtime)
-			assert.Equal(t, aws.Int32(30), input.Timeout)
+			assert.Equal(t, aws.Int32(120), input.Timeout)
+			assert.Equal(t, aws.Int32(512), input.MemorySize)
+			assert.Equal(t, aws.Int32(constant.LambdaDefaultEphemeralStorage), input.EphemeralStorage.Size)
 			assert.Equal(t, aws.String("file1.handler"), input.Handler)
 			assert.Equal(t, lambTypes.PackageTypeZip, input.PackageType)
-			assert.Equal(t, false, input.Publish)
+			assert.Equal(t, true, input.Publish)
 
 			return &lambda.CreateFunctionOutput{
 				FunctionName: aws.String("org_name-account_name-file1"),
 				FunctionArn:  aws.String(functionARN),
 				Runtime:      lambTypes.RuntimeNodejs20x,
 				Handler:      aws.String("file1.handler"),
-				Version:      aws.String("$LATEST"),
+				Version:      aws.String("1"),
 				Timeout:      aws.Int32(30),
 				Role:         aws.String(roleARN),
 				Description:  aws.String(""),
@@ -640,12 +662,17 @@ func TestRegisterSuccess(t *testing.T) {
 			}, nil
 		})
 
+	mockAmazon.EXPECT().CreateLambdaAlias(ctx, gomock.Any()).Times(1).
+		Return(&lambda.CreateAliasOutput{
+			AliasArn: aws.String(aliasARN),
+		}, nil)
+
 	mockAmazon.EXPECT().BoundScheduler(ctx, gomock.Any()).Times(1).
 		DoAndReturn(func(_ *gin.Context, input *scheduler.CreateScheduleInput, _ ...func(*scheduler.Options)) (*scheduler.CreateScheduleOutput, error) {
 			assert.Equal(t, scheTypes.FlexibleTimeWindowModeOff, input.FlexibleTimeWindow.Mode)
 			assert.Equal(t, aws.String("org_name-account_name-file1"), input.Name)
-			assert.Equal(t, aws.String("rate(minutes)"), input.ScheduleExpression)
-			assert.Equal(t, aws.String(functionARN), input.Target.Arn)
+			assert.Equal(t, aws.String("rate(1 minute)"), input.ScheduleExpression)
+			assert.Equal(t, aws.String(aliasARN), input.Target.Arn)
 
 			return &scheduler.CreateScheduleOutput{
 				ScheduleArn: aws.String(scheduleARN),
@@ -655,10 +682,14 @@ func TestRegisterSuccess(t *testing.T) {
 	mockLambRepo.EXPECT().PersistRegResult(ctx, gomock.Any()).Times(1).
 		Return(nil)
 
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
 	cd := &service{
 		lambdaRepo: mockLambRepo,
 		oauthRepo:  mockOAuthRepo,
 		amazon:     mockAmazon,
+		bundle:     mockBundle,
 	}
 
 	expectedResp := []*dto.RespRegister{
@@ -668,12 +699,14 @@ func TestRegisterSuccess(t *testing.T) {
 				Arn:     functionARN,
 				Runtime: "nodejs20.x",
 				Handler: "file1.handler",
-				Version: "$LATEST",
+				Version: "1",
 			},
 			Scheduler: &dto.RespSchBrief{
-				Arn:            scheduleARN,
-				BoundLambdaArn: functionARN,
-				Name:           "org_name-account_name-file1",
+				Arn:              scheduleARN,
+				BoundLambdaArn:   aliasARN,
+				Name:             "org_name-account_name-file1",
+				MaxRetryAttempts: aws.Int32(185),
+				MaxEventAge:      86400,
 			},
 		},
 	}
@@ -682,6 +715,49 @@ func TestRegisterSuccess(t *testing.T) {
 	assert.Equal(t, expectedResp, register)
 }
 
+func TestRegisterInvalidExpression(t *testing.T) {
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	request := &dto.ReqRegister{
+		Expression: "cron(0 12 * * * *)",
+		Files: []*dto.ReqFile{
+			{
+				Name:  "file1",
+				Bytes: []byte("file1"),
+			},
+		},
+	}
+
+	cd := &service{}
+
+	register, err := cd.Register(ctx, request)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "one of day-of-month and day-of-week must be ?")
+	assert.Nil(t, register)
+}
+
+func TestRegisterTimeoutExceedCeiling(t *testing.T) {
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	request := &dto.ReqRegister{
+		Resources: dto.Resources{Timeout: 900},
+		Files: []*dto.ReqFile{
+			{
+				Name:  "file1",
+				Bytes: []byte("file1"),
+			},
+		},
+	}
+
+	cd := &service{}
+
+	register, err := cd.Register(ctx, request)
+	assert.Equal(t, errorx.BadRequest("the timeout of lambda should be in 1-300 seconds"), err)
+	assert.Nil(t, register)
+}
+
 func TestRegisterUserNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -692,7 +768,7 @@ func TestRegisterUserNotFound(t *testing.T) {
 	ctx.Set(constant.ClaimSub.Str(), "account_name")
 	ctx.Set(constant.ClaimIss.Str(), "org_name")
 	request := &dto.ReqRegister{
-		Expression: "rate(minutes)",
+		Expression: "rate(1 minute)",
 		Files: []*dto.ReqFile{
 			{
 				Name:  "file1",
@@ -728,7 +804,7 @@ func TestRegisterMaxLimit(t *testing.T) {
 	ctx.Set(constant.ClaimIss.Str(), "org_name")
 	accountID := uint64(123)
 	request := &dto.ReqRegister{
-		Expression: "rate(minutes)",
+		Expression: "rate(1 minute)",
 		Files: []*dto.ReqFile{
 			{
 				Name:  "file1",
@@ -773,13 +849,14 @@ func TestRegisterGetRoleError(t *testing.T) {
 	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
 	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
 
 	ctx := new(gin.Context)
 	ctx.Set(constant.ClaimSub.Str(), "account_name")
 	ctx.Set(constant.ClaimIss.Str(), "org_name")
 	accountID := uint64(123)
 	request := &dto.ReqRegister{
-		Expression: "rate(minutes)",
+		Expression: "rate(1 minute)",
 		Files: []*dto.ReqFile{
 			{
 				Name:  "file1",
@@ -803,10 +880,14 @@ func TestRegisterGetRoleError(t *testing.T) {
 	}).Times(1).
 		Return(nil, errorx.Internal("failed to get role"))
 
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
 	cd := &service{
 		lambdaRepo: mockLambRepo,
 		oauthRepo:  mockOAuthRepo,
 		amazon:     mockAmazon,
+		bundle:     mockBundle,
 	}
 
 	register, err := cd.Register(ctx, request)
@@ -822,12 +903,13 @@ func TestRegisterRegisterLambdaError(t *testing.T) {
 	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
 	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
 
 	ctx := new(gin.Context)
 	ctx.Set(constant.ClaimSub.Str(), "account_name")
 	ctx.Set(constant.ClaimIss.Str(), "org_name")
 	request := &dto.ReqRegister{
-		Expression: "rate(minutes)",
+		Expression: "rate(1 minute)",
 		Files: []*dto.ReqFile{
 			{
 				Name:  "file1",
@@ -860,10 +942,14 @@ func TestRegisterRegisterLambdaError(t *testing.T) {
 	mockAmazon.EXPECT().RegisterLambda(ctx, gomock.Any(), gomock.Any()).Times(1).
 		Return(nil, errorx.Internal("failed to register lambda"))
 
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
 	cd := &service{
 		lambdaRepo: mockLambRepo,
 		oauthRepo:  mockOAuthRepo,
 		amazon:     mockAmazon,
+		bundle:     mockBundle,
 	}
 
 	register, err := cd.Register(ctx, request)
@@ -879,12 +965,13 @@ func TestRegisterRegisterSchedulerError(t *testing.T) {
 	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
 	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
 
 	ctx := new(gin.Context)
 	ctx.Set(constant.ClaimSub.Str(), "account_name")
 	ctx.Set(constant.ClaimIss.Str(), "org_name")
 	request := &dto.ReqRegister{
-		Expression: "rate(minutes)",
+		Expression: "rate(1 minute)",
 		Files: []*dto.ReqFile{
 			{
 				Name:  "file1",
@@ -895,6 +982,7 @@ func TestRegisterRegisterSchedulerError(t *testing.T) {
 	accountID := uint64(123)
 	roleARN := "arn:aws:iam::123456789012:role/AA-org_name-account_name-Role"
 	functionARN := "arn:aws:lambda:us-east-2:123456789012:function:file1"
+	aliasARN := functionARN + ":live"
 
 	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, &dto.ReqOrgAcn{
 		OrgName: "org_name",
@@ -921,7 +1009,7 @@ func TestRegisterRegisterSchedulerError(t *testing.T) {
 			FunctionArn:  aws.String(functionARN),
 			Runtime:      lambTypes.RuntimeNodejs20x,
 			Handler:      aws.String("file1.handler"),
-			Version:      aws.String("$LATEST"),
+			Version:      aws.String("1"),
 			Timeout:      aws.Int32(30),
 			Role:         aws.String(roleARN),
 			Description:  aws.String(""),
@@ -929,13 +1017,22 @@ func TestRegisterRegisterSchedulerError(t *testing.T) {
 			RevisionId:   aws.String("3c9a3513-5e43-419e-ae5b-aeeb459e44e3"),
 		}, nil)
 
+	mockAmazon.EXPECT().CreateLambdaAlias(ctx, gomock.Any()).Times(1).
+		Return(&lambda.CreateAliasOutput{
+			AliasArn: aws.String(aliasARN),
+		}, nil)
+
 	mockAmazon.EXPECT().BoundScheduler(ctx, gomock.Any()).Times(1).
 		Return(nil, errorx.Internal("failed to bound scheduler"))
 
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
 	cd := &service{
 		lambdaRepo: mockLambRepo,
 		oauthRepo:  mockOAuthRepo,
 		amazon:     mockAmazon,
+		bundle:     mockBundle,
 	}
 
 	register, err := cd.Register(ctx, request)
@@ -951,12 +1048,13 @@ func TestRegisterPersistRegisterResultsError(t *testing.T) {
 	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
 	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
 
 	ctx := new(gin.Context)
 	ctx.Set(constant.ClaimSub.Str(), "account_name")
 	ctx.Set(constant.ClaimIss.Str(), "org_name")
 	request := &dto.ReqRegister{
-		Expression: "rate(minutes)",
+		Expression: "rate(1 minute)",
 		Files: []*dto.ReqFile{
 			{
 				Name:  "file1",
@@ -968,6 +1066,7 @@ func TestRegisterPersistRegisterResultsError(t *testing.T) {
 	roleARN := "arn:aws:iam::123456789012:role/AA-org_name-account_name-Role"
 	functionARN := "arn:aws:lambda:us-east-2:123456789012:function:file1"
 	scheduleARN := "arn:aws:scheduler:us-east-2:123456789012:schedule/default/file1"
+	aliasARN := functionARN + ":live"
 
 	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, &dto.ReqOrgAcn{
 		OrgName: "org_name",
@@ -994,7 +1093,7 @@ func TestRegisterPersistRegisterResultsError(t *testing.T) {
 			FunctionArn:  aws.String(functionARN),
 			Runtime:      lambTypes.RuntimeNodejs20x,
 			Handler:      aws.String("file1.handler"),
-			Version:      aws.String("$LATEST"),
+			Version:      aws.String("1"),
 			Timeout:      aws.Int32(30),
 			Role:         aws.String(roleARN),
 			Description:  aws.String(""),
@@ -1002,6 +1101,11 @@ func TestRegisterPersistRegisterResultsError(t *testing.T) {
 			RevisionId:   aws.String("3c9a3513-5e43-419e-ae5b-aeeb459e44e3"),
 		}, nil)
 
+	mockAmazon.EXPECT().CreateLambdaAlias(ctx, gomock.Any()).Times(1).
+		Return(&lambda.CreateAliasOutput{
+			AliasArn: aws.String(aliasARN),
+		}, nil)
+
 	mockAmazon.EXPECT().BoundScheduler(ctx, gomock.Any()).Times(1).
 		Return(&scheduler.CreateScheduleOutput{
 			ScheduleArn: aws.String(scheduleARN),
@@ -1010,10 +1114,14 @@ func TestRegisterPersistRegisterResultsError(t *testing.T) {
 	mockLambRepo.EXPECT().PersistRegResult(ctx, gomock.Any()).Times(1).
 		Return(errorx.Internal("failed to persist register results"))
 
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
 	cd := &service{
 		lambdaRepo: mockLambRepo,
 		oauthRepo:  mockOAuthRepo,
 		amazon:     mockAmazon,
+		bundle:     mockBundle,
 	}
 
 	register, err := cd.Register(ctx, request)
@@ -1295,204 +1403,710 @@ func TestRemoveDeleteLambdaTXError(t *testing.T) {
 	assert.Nil(t, remove)
 }
 
-func TestLogSuccess(t *testing.T) {
+func TestLogsWSUpgradeError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	expectedMessage := `{"timestamp":1714857600000,"message":"log-message"}`
+	req, err := http.NewRequest("GET", "/logs", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	upgrader := &websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+	}
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{FunctionName: "org_name-account_name-file1"}, nil)
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		lambdaRepo: mockLambRepo,
+	}
+
+	err = svc.Logs(ctx, &dto.ReqURILambda{Lambda: "file1"}, upgrader)
+	assert.Error(t, err)
+	assert.Equal(t, `failed to upgrade websocket: websocket: the client is not using the websocket protocol: 'upgrade' token not found in 'Connection' header`, err.Error())
+}
+
+func TestUpdateSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
 
-	mockAmazon.EXPECT().DescribeLogStreams(gomock.Any(), gomock.Any()).AnyTimes().Return(&cloudwatchlogs.DescribeLogStreamsOutput{
-		LogStreams: []types.LogStream{
-			{
-				LogStreamName: aws.String("log-stream-name"),
-			},
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqUpdate{
+		Lambda:    "file1",
+		Env:       dto.Env{"BAZ": "qux"},
+		Resources: dto.Resources{Timeout: 120},
+		File: &dto.ReqFile{
+			Name:  "file2",
+			Bytes: []byte("file2 content"),
 		},
-	}, nil)
+	}
+	functionName := "org_name-account_name-file1"
+	functionARN := "arn:aws:lambda:us-east-2:123456789012:function:file1"
+	aliasARN := functionARN + ":live"
+	scheduleARN := "arn:aws:scheduler:us-east-2:123456789012:schedule/default/file1"
+	accountID := uint64(123)
 
-	mockAmazon.EXPECT().GetLogEvents(gomock.Any(), gomock.Any()).AnyTimes().Return(&cloudwatchlogs.GetLogEventsOutput{
-		Events: []types.OutputLogEvent{
-			{
-				Timestamp: aws.Int64(1714857600000),
-				Message:   aws.String(expectedMessage),
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: functionName,
+			FunctionArn:  functionARN,
+			Runtime:      constant.LambdaRuntimeNodejs20,
+			Version:      "1",
+			Environment:  dto.Env{"FOO": "bar"},
+			Scheduler: dto.Scheduler{
+				ScheduleArn:  scheduleARN,
+				ScheduleName: functionName,
 			},
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateLambdaCode(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.UpdateFunctionCodeInput) (*lambda.UpdateFunctionCodeOutput, error) {
+			assert.Equal(t, functionName, *input.FunctionName)
+			assert.Equal(t, []byte("file2 content"), input.ZipFile)
+			return &lambda.UpdateFunctionCodeOutput{}, nil
+		})
+
+	mockAmazon.EXPECT().WaitLambdaUpdated(ctx, gomock.Any()).Times(2).
+		Return(nil)
+
+	mockAmazon.EXPECT().UpdateLambdaConfig(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.UpdateFunctionConfigurationInput) (*lambda.UpdateFunctionConfigurationOutput, error) {
+			assert.Equal(t, functionName, *input.FunctionName)
+			assert.Equal(t, "file2.handler", *input.Handler)
+			assert.Empty(t, input.Runtime)
+			assert.Equal(t, "bar", input.Environment.Variables["FOO"])
+			assert.Equal(t, "qux", input.Environment.Variables["BAZ"])
+			assert.Equal(t, "org_name", input.Environment.Variables[constant.LambdaEnvOrg])
+			assert.Equal(t, aws.Int32(120), input.Timeout)
+			assert.Nil(t, input.MemorySize)
+			assert.Nil(t, input.EphemeralStorage)
+			return &lambda.UpdateFunctionConfigurationOutput{}, nil
+		})
+
+	mockAmazon.EXPECT().PublishLambdaVersion(ctx, gomock.Any()).Times(1).
+		Return(&lambda.PublishVersionOutput{
+			FunctionName: aws.String(functionName),
+			FunctionArn:  aws.String(functionARN),
+			Runtime:      lambTypes.RuntimeNodejs20x,
+			Handler:      aws.String("file2.handler"),
+			Version:      aws.String("2"),
+			CodeSha256:   aws.String("code_sha256"),
+			RevisionId:   aws.String("revision_id"),
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateLambdaAlias(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.UpdateAliasInput) (*lambda.UpdateAliasOutput, error) {
+			assert.Equal(t, functionName, *input.FunctionName)
+			assert.Equal(t, "2", *input.FunctionVersion)
+			assert.Equal(t, constant.LambdaAlias, *input.Name)
+			return &lambda.UpdateAliasOutput{AliasArn: aws.String(aliasARN)}, nil
+		})
+
+	mockLambRepo.EXPECT().UpdateLambdaTX(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file2", gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+		bundle:     mockBundle,
+	}
+
+	resp, err := cd.Update(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespUpdate{
+		Lambda: &dto.RespLamBrief{
+			Name:       functionName,
+			Arn:        functionARN,
+			Runtime:    string(lambTypes.RuntimeNodejs20x),
+			Handler:    "file2.handler",
+			Version:    "2",
+			CodeSHA256: "code_sha256",
+			RevisionID: "revision_id",
+		},
+		Scheduler: &dto.RespSchBrief{
+			Arn:            scheduleARN,
+			Name:           functionName,
+			BoundLambdaArn: aliasARN,
 		},
-	}, nil)
+	}, resp)
+}
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// create a new gin.Context
-		ctx, _ := gin.CreateTestContext(w)
-		ctx.Request = r
+func TestUpdateLambdaNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-		// create WebSocket upgrader
-		upgrader := &websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
-		}
-		request := &dto.ReqURILambda{
-			Lambda: "file1",
-		}
-
-		// call Logs method
-		svc := &service{
-			amazon: mockAmazon,
-		}
-		err := svc.Logs(ctx, request, upgrader)
-		if err != nil {
-			t.Fatalf("Logs method failed: %v", err)
-		}
-	}))
-	defer server.Close()
-
-	// convert HTTP URL to WebSocket URL
-	wsURL := "ws" + strings.TrimPrefix(server.URL, "http")
-
-	// create WebSocket client connection
-	dialer := websocket.Dialer{}
-	conn, _, err := dialer.Dial(wsURL, nil)
-	if err != nil {
-		t.Fatalf("Failed to connect to WebSocket server: %v", err)
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqUpdate{
+		Lambda: "file1",
+		File:   &dto.ReqFile{Name: "file1"},
 	}
-	defer conn.Close()
+	accountID := uint64(123)
 
-	// read the message sent from the server
-	_, message, err := conn.ReadMessage()
-	if err != nil {
-		t.Fatalf("Failed to read message: %v", err)
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(nil, errorx.NotFound("lambda not found"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
 	}
 
-	assert.Equal(t, expectedMessage, string(message))
+	resp, err := cd.Update(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.NotFound("lambda not found"), err)
+	assert.Nil(t, resp)
 }
 
-func TestLogsWSUpgradeError(t *testing.T) {
+func TestUpdateLambdaCodeError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	req, err := http.NewRequest("GET", "/logs", nil)
-	if err != nil {
-		t.Fatal(err)
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqUpdate{
+		Lambda: "file1",
+		File:   &dto.ReqFile{Name: "file1"},
 	}
+	functionName := "org_name-account_name-file1"
+	accountID := uint64(123)
 
-	w := httptest.NewRecorder()
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
 
-	ctx, _ := gin.CreateTestContext(w)
-	ctx.Request = req
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{FunctionName: functionName, Runtime: constant.LambdaRuntimeNodejs20}, nil)
 
-	upgrader := &websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool {
-			return true
-		},
+	mockAmazon.EXPECT().UpdateLambdaCode(ctx, gomock.Any()).Times(1).
+		Return(nil, errorx.Internal("update code error"))
+
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+		bundle:     mockBundle,
+	}
+
+	resp, err := cd.Update(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.Internal(
+		"failed to update lambda code: org_name-account_name-file1, err: update code error"), err)
+	assert.Nil(t, resp)
+}
+
+func TestUpdateLambdaTXError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqUpdate{
+		Lambda: "file1",
+		File:   &dto.ReqFile{Name: "file1"},
 	}
+	functionName := "org_name-account_name-file1"
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	// registered before versioning, the alias is created along with the first published version.
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			FunctionName: functionName,
+			Runtime:      constant.LambdaRuntimeNodejs20,
+			Version:      constant.LambdaUnpublished,
+		}, nil)
+
+	mockAmazon.EXPECT().UpdateLambdaCode(ctx, gomock.Any()).Times(1).
+		Return(&lambda.UpdateFunctionCodeOutput{}, nil)
+	mockAmazon.EXPECT().WaitLambdaUpdated(ctx, gomock.Any()).Times(2).
+		Return(nil)
+	mockAmazon.EXPECT().UpdateLambdaConfig(ctx, gomock.Any()).Times(1).
+		Return(&lambda.UpdateFunctionConfigurationOutput{}, nil)
+	mockAmazon.EXPECT().PublishLambdaVersion(ctx, gomock.Any()).Times(1).
+		Return(&lambda.PublishVersionOutput{
+			FunctionName: aws.String(functionName),
+			Handler:      aws.String("file1.handler"),
+			Version:      aws.String("1"),
+			CodeSha256:   aws.String("code_sha256"),
+			RevisionId:   aws.String("revision_id"),
+		}, nil)
+	mockAmazon.EXPECT().CreateLambdaAlias(ctx, gomock.Any()).Times(1).
+		Return(&lambda.CreateAliasOutput{AliasArn: aws.String("alias_arn")}, nil)
 
-	svc := &service{}
+	mockLambRepo.EXPECT().UpdateLambdaTX(ctx, gomock.Any()).Times(1).
+		Return(errorx.Internal("failed to update lambda"))
+
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+		bundle:     mockBundle,
+	}
 
-	err = svc.Logs(ctx, nil, upgrader)
+	resp, err := cd.Update(ctx, request)
 	assert.Error(t, err)
-	assert.Equal(t, `failed to upgrade websocket: websocket: the client is not using the websocket protocol: 'upgrade' token not found in 'Connection' header`, err.Error())
+	assert.Equal(t, errorx.Internal("failed to update lambda"), err)
+	assert.Nil(t, resp)
 }
 
-func TestLogsDescribeLogStreamsError(t *testing.T) {
+func TestUpdateRetargetLegacyScheduler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockBundle := testdata.NewMockBundle(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqUpdate{
+		Lambda: "file1",
+		File:   &dto.ReqFile{Name: "file1"},
+	}
+	functionName := "org_name-account_name-file1"
+	functionARN := "arn:aws:lambda:us-east-2:123456789012:function:file1"
+	aliasARN := functionARN + ":live"
+	scheduleARN := "arn:aws:scheduler:us-east-2:123456789012:schedule/default/file1"
+	accountID := uint64(123)
 
-	mockAmazon.EXPECT().DescribeLogStreams(gomock.Any(), gomock.Any()).AnyTimes().
-		Return(nil, errorx.Internal("failed to describe log streams"))
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx, _ := gin.CreateTestContext(w)
-		ctx.Request = r
+	// registered before versioning, the scheduler is bound with the function itself.
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: functionName,
+			FunctionArn:  functionARN,
+			Runtime:      constant.LambdaRuntimeNodejs20,
+			Version:      constant.LambdaUnpublished,
+			Scheduler: dto.Scheduler{
+				ScheduleArn:  scheduleARN,
+				ScheduleName: functionName,
+			},
+		}, nil)
 
-		upgrader := &websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true
+	mockAmazon.EXPECT().UpdateLambdaCode(ctx, gomock.Any()).Times(1).
+		Return(&lambda.UpdateFunctionCodeOutput{}, nil)
+	mockAmazon.EXPECT().WaitLambdaUpdated(ctx, gomock.Any()).Times(2).
+		Return(nil)
+	mockAmazon.EXPECT().UpdateLambdaConfig(ctx, gomock.Any()).Times(1).
+		Return(&lambda.UpdateFunctionConfigurationOutput{}, nil)
+	mockAmazon.EXPECT().PublishLambdaVersion(ctx, gomock.Any()).Times(1).
+		Return(&lambda.PublishVersionOutput{
+			FunctionName: aws.String(functionName),
+			FunctionArn:  aws.String(functionARN),
+			Handler:      aws.String("file1.handler"),
+			Version:      aws.String("1"),
+			CodeSha256:   aws.String("code_sha256"),
+			RevisionId:   aws.String("revision_id"),
+		}, nil)
+	mockAmazon.EXPECT().CreateLambdaAlias(ctx, gomock.Any()).Times(1).
+		Return(&lambda.CreateAliasOutput{AliasArn: aws.String(aliasARN)}, nil)
+
+	mockAmazon.EXPECT().GetScheduler(ctx, gomock.Any()).Times(1).
+		Return(&scheduler.GetScheduleOutput{
+			Name:               aws.String(functionName),
+			ScheduleExpression: aws.String("rate(5 minutes)"),
+			Target: &scheTypes.Target{
+				Arn:   aws.String(functionARN),
+				Input: aws.String(`{"foo":"bar"}`),
 			},
-		}
-		request := &dto.ReqURILambda{
-			Lambda: "file1",
-		}
-
-		svc := &service{
-			amazon: mockAmazon,
-		}
-		err := svc.Logs(ctx, request, upgrader)
-		assert.Error(t, err)
-		assert.Equal(t, errorx.Internal("failed to describe log streams"), err)
-	}))
-	defer server.Close()
+			State: scheTypes.ScheduleStateEnabled,
+		}, nil)
+	mockAmazon.EXPECT().UpdateScheduler(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *scheduler.UpdateScheduleInput) (*scheduler.UpdateScheduleOutput, error) {
+			assert.Equal(t, aliasARN, *input.Target.Arn)
+			assert.Equal(t, `{"foo":"bar"}`, *input.Target.Input)
+			assert.Equal(t, "rate(5 minutes)", *input.ScheduleExpression)
+			return &scheduler.UpdateScheduleOutput{ScheduleArn: aws.String(scheduleARN)}, nil
+		})
+
+	mockLambRepo.EXPECT().UpdateLambdaTX(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	mockBundle.EXPECT().Validate(constant.LambdaRuntimeNodejs20, "file1", gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+		bundle:     mockBundle,
+	}
+
+	resp, err := cd.Update(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, aliasARN, resp.Scheduler.BoundLambdaArn)
 }
 
-func TestLogsGetLogEventsError(t *testing.T) {
+func TestVersionsSuccess(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqURILambda{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, Version: "1"}, nil)
+
+	mockLambRepo.EXPECT().FindVersions(ctx, uint64(1)).Times(1).
+		Return([]*dto.RespVersion{
+			{Version: "2", CodeSHA256: "sha256_2"},
+			{Version: "1", CodeSHA256: "sha256_1"},
+		}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	versions, err := cd.Versions(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(versions))
+	assert.False(t, versions[0].Live)
+	assert.True(t, versions[1].Live)
+}
+
+func TestVersionsLambdaNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqURILambda{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(nil, errorx.NotFound("lambda not found"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	versions, err := cd.Versions(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.NotFound("lambda not found"), err)
+	assert.Nil(t, versions)
+}
+
+func TestRollbackSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
 
-	mockAmazon.EXPECT().DescribeLogStreams(gomock.Any(), gomock.Any()).AnyTimes().Return(&cloudwatchlogs.DescribeLogStreamsOutput{
-		LogStreams: []types.LogStream{
-			{
-				LogStreamName: aws.String("log-stream-name"),
-			},
-		},
-	}, nil)
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqRollback{
+		Lambda:  "file1",
+		Version: "1",
+	}
+	functionName := "org_name-account_name-file1"
+	functionARN := "arn:aws:lambda:us-east-2:123456789012:function:file1"
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			ID:           1,
+			FunctionName: functionName,
+			FunctionArn:  functionARN,
+			Version:      "2",
+		}, nil)
 
-	mockAmazon.EXPECT().GetLogEvents(gomock.Any(), gomock.Any()).AnyTimes().
-		Return(nil, errorx.Internal("failed to get log events"))
+	mockLambRepo.EXPECT().FindVersion(ctx, uint64(1), "1").Times(1).
+		Return(&dto.RespVersion{Version: "1", CodeSHA256: "sha256_1"}, nil)
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx, _ := gin.CreateTestContext(w)
-		ctx.Request = r
+	mockAmazon.EXPECT().UpdateLambdaAlias(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.UpdateAliasInput) (*lambda.UpdateAliasOutput, error) {
+			assert.Equal(t, functionName, *input.FunctionName)
+			assert.Equal(t, "1", *input.FunctionVersion)
+			return &lambda.UpdateAliasOutput{AliasArn: aws.String(functionARN + ":live")}, nil
+		})
 
-		upgrader := &websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
-		}
-		request := &dto.ReqURILambda{
-			Lambda: "file1",
-		}
-
-		svc := &service{
-			amazon: mockAmazon,
-		}
-		err := svc.Logs(ctx, request, upgrader)
-		assert.Error(t, err)
-		assert.Equal(t, errorx.Internal("failed to get log events"), err)
-	}))
-	defer server.Close()
+	mockLambRepo.EXPECT().UpdateLambdaTX(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Rollback(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespRollback{
+		Name:            functionName,
+		Arn:             functionARN,
+		PreviousVersion: "2",
+		Version:         "1",
+		CodeSHA256:      "sha256_1",
+	}, resp)
 }
 
-func TestLogsLogStreamNotFound(t *testing.T) {
+func TestRollbackColumns(t *testing.T) {
+	columns, err := rollbackColumns(&dto.RespVersion{
+		Version:          "1",
+		CodeSHA256:       "sha256_1",
+		RevisionID:       "revision_1",
+		Runtime:          constant.LambdaRuntimeNodejs20,
+		Handler:          "file1.handler",
+		Timeout:          60,
+		MemorySize:       256,
+		EphemeralStorage: 512,
+		Environment:      dto.Env{"FOO": "bar"},
+		RuntimeNonce:     "nonce_1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"version":           "1",
+		"code_sha256":       "sha256_1",
+		"revision_id":       "revision_1",
+		"runtime_nonce":     "nonce_1",
+		"runtime":           constant.LambdaRuntimeNodejs20,
+		"handler":           "file1.handler",
+		"timeout":           int32(60),
+		"memory_size":       int32(256),
+		"ephemeral_storage": int32(512),
+		"environment":       `{"FOO":"bar"}`,
+	}, columns)
+
+	// the versions recorded without the configuration only switch the code, none runtime token is valid for them.
+	columns, err = rollbackColumns(&dto.RespVersion{Version: "1", CodeSHA256: "sha256_1", RevisionID: "revision_1"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"version":       "1",
+		"code_sha256":   "sha256_1",
+		"revision_id":   "revision_1",
+		"runtime_nonce": "",
+	}, columns)
+}
+
+func TestRollbackUnpublished(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqRollback{
+		Lambda:  "file1",
+		Version: "1",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, Version: constant.LambdaUnpublished}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Rollback(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.BadRequest("none published version of lambda: file1"), err)
+	assert.Nil(t, resp)
+}
+
+func TestRollbackAlreadyLive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqRollback{
+		Lambda:  "file1",
+		Version: "2",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, Version: "2"}, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Rollback(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.BadRequest("version 2 is already live"), err)
+	assert.Nil(t, resp)
+}
+
+func TestRollbackVersionNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	request := &dto.ReqRollback{
+		Lambda:  "file1",
+		Version: "5",
+	}
+	accountID := uint64(123)
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
+
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{ID: 1, Version: "2"}, nil)
+
+	mockLambRepo.EXPECT().FindVersion(ctx, uint64(1), "5").Times(1).
+		Return(nil, errorx.NotFound("none version found by: 5"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Rollback(ctx, request)
+	assert.Error(t, err)
+	assert.Equal(t, errorx.NotFound("none version found by: 5"), err)
+	assert.Nil(t, resp)
+}
+
+func TestInvokePublishedThroughAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
 	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
 
-	mockAmazon.EXPECT().DescribeLogStreams(gomock.Any(), gomock.Any()).AnyTimes().Return(&cloudwatchlogs.DescribeLogStreamsOutput{
-		LogStreams: []types.LogStream{},
-	}, nil)
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	request := &dto.ReqInvoke{
+		Lambda: "file1",
+	}
+	accountID := uint64(123)
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx, _ := gin.CreateTestContext(w)
-		ctx.Request = r
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: accountID}, nil)
 
-		upgrader := &websocket.Upgrader{
-			CheckOrigin: func(r *http.Request) bool {
-				return true
-			},
-		}
-		request := &dto.ReqURILambda{
-			Lambda: "file1",
-		}
-
-		svc := &service{
-			amazon: mockAmazon,
-		}
-		err := svc.Logs(ctx, request, upgrader)
-		assert.Error(t, err)
-		assert.Equal(t, errorx.NotFound("no log streams found"), err)
-	}))
-	defer server.Close()
+	mockLambRepo.EXPECT().LambdaInfo(ctx, accountID, "file1").Times(1).
+		Return(&dto.RespInfo{
+			FunctionName: "org_name-account_name-file1",
+			Version:      "3",
+		}, nil)
+
+	mockAmazon.EXPECT().InvokeLambda(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ *gin.Context, input *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			assert.Equal(t, aws.String(constant.LambdaAlias), input.Qualifier)
+			return &lambda.InvokeOutput{
+				LogResult: aws.String(""),
+				Payload:   []byte(""),
+			}, nil
+		})
+
+	mockLambRepo.EXPECT().SaveExecution(ctx, gomock.Any()).Times(1).
+		Return(nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	_, err := cd.Invoke(ctx, request)
+	assert.NoError(t, err)
 }
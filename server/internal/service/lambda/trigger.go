@@ -0,0 +1,413 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+	"github.com/57blocks/auto-action/server/internal/third-party/sorobanx"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon/effects"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/strkey"
+)
+
+// The defaults of the streaming of the triggers, when they're not configured.
+const (
+	triggerReconcileInterval = 30 * time.Second
+	triggerBackoff           = time.Second
+	triggerMaxBackoff        = time.Minute
+)
+
+// errCursorMoved the cursor of the trigger has been moved by others, such as another instance of the server,
+// the stream is restarted from the one moved to.
+var errCursorMoved = errors.New("cursor of the trigger moved")
+
+// validateTriggers checks the types and the addresses of the triggers, the duplicated ones are dropped.
+// The topics of the contract event triggers are encoded into base64 XDR, which are filtered by.
+func (svc *service) validateTriggers(triggers []*dto.ReqTrigger) ([]*dto.ReqTrigger, error) {
+	validated := make([]*dto.ReqTrigger, 0, len(triggers))
+	seen := make(map[string]bool, len(triggers))
+
+	for _, trigger := range triggers {
+		address := strings.TrimSpace(trigger.Address)
+
+		var topics [][]string
+		switch trigger.Type {
+		case constant.TriggerPayment, constant.TriggerOperation, constant.TriggerEffect:
+			if !strkey.IsValidEd25519PublicKey(address) {
+				return nil, errorx.BadRequest(fmt.Sprintf("invalid address of the %s trigger: %s", trigger.Type, address))
+			}
+		case constant.TriggerContractEvent:
+			if !svc.soroban.Enabled() {
+				return nil, errorx.BadRequest("contract event triggers are not enabled on the server")
+			}
+			if !sorobanx.IsContractAddress(address) {
+				return nil, errorx.BadRequest(fmt.Sprintf("invalid contract of the %s trigger: %s", trigger.Type, address))
+			}
+
+			encoded, err := encodeTopics(trigger.Topics)
+			if err != nil {
+				return nil, err
+			}
+			topics = encoded
+		default:
+			return nil, errorx.BadRequest(fmt.Sprintf("invalid trigger type: %s, should be one of: %s",
+				trigger.Type, strings.Join([]string{
+					constant.TriggerPayment,
+					constant.TriggerOperation,
+					constant.TriggerEffect,
+					constant.TriggerContractEvent,
+				}, ", ")))
+		}
+
+		key := fmt.Sprintf("%s/%s/%v", trigger.Type, address, topics)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		validated = append(validated, &dto.ReqTrigger{Type: trigger.Type, Address: address, Topics: topics})
+	}
+
+	return validated, nil
+}
+
+// encodeTopics encodes the segments of the topic filters, none filters matches all the events of the contract.
+func encodeTopics(topics [][]string) ([][]string, error) {
+	if len(topics) > constant.TriggerTopicsMax {
+		return nil, errorx.BadRequest(fmt.Sprintf("too many topics: %d, should be at most %d",
+			len(topics), constant.TriggerTopicsMax))
+	}
+
+	encoded := make([][]string, 0, len(topics))
+	for _, topic := range topics {
+		if len(topic) == 0 || len(topic) > constant.TriggerSegmentsMax {
+			return nil, errorx.BadRequest(fmt.Sprintf("invalid topic: %s, should have 1 to %d segments",
+				strings.Join(topic, ","), constant.TriggerSegmentsMax))
+		}
+
+		segments := make([]string, 0, len(topic))
+		for _, segment := range topic {
+			seg, err := sorobanx.EncodeTopic(segment)
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+		}
+		encoded = append(encoded, segments)
+	}
+
+	return encoded, nil
+}
+
+// Triggers lists the triggers of the Lambda, or all the ones of the account when none Lambda given.
+func (svc *service) Triggers(c context.Context, r *dto.ReqTriggers) ([]*dto.RespTrigger, error) {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	var lambdaID uint64
+	if strings.TrimSpace(r.Lambda) != "" {
+		lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, strings.TrimSpace(r.Lambda))
+		if err != nil {
+			return nil, err
+		}
+
+		lambdaID = lamb.ID
+	}
+
+	return svc.lambdaRepo.ListTriggers(c, user.ID, lambdaID)
+}
+
+// AddTrigger adds the trigger to the Lambda of the account, which is streamed by the next reconciling.
+func (svc *service) AddTrigger(c context.Context, r *dto.ReqAddTrigger) (*dto.RespTrigger, error) {
+	triggers, err := svc.validateTriggers([]*dto.ReqTrigger{{Type: r.Type, Address: r.Address, Topics: r.Topics}})
+	if err != nil {
+		return nil, err
+	}
+	trigger := triggers[0]
+
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, strings.TrimSpace(r.Lambda))
+	if err != nil {
+		return nil, err
+	}
+
+	lt := model.BuildLambdaTrigger(
+		model.WithTrigger(trigger.Type, trigger.Address),
+		model.WithTriggerTopics(trigger.Topics),
+		model.WithTriggerCursor(constant.TriggerCursorNow),
+	)
+	lt.LambdaID = lamb.ID
+	if err := svc.lambdaRepo.SaveTrigger(c, lt); err != nil {
+		return nil, err
+	}
+
+	return &dto.RespTrigger{
+		ID:        lt.ID,
+		LambdaID:  lamb.ID,
+		Lambda:    lamb.FunctionName,
+		Type:      lt.Type,
+		Address:   lt.Address,
+		Topics:    lt.Topics,
+		Cursor:    lt.Cursor,
+		CreatedAt: lt.CreatedAt,
+	}, nil
+}
+
+// RemoveTrigger removes the trigger by its ID, the stream of it is stopped by the next reconciling.
+func (svc *service) RemoveTrigger(c context.Context, r *dto.ReqURITrigger) error {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return err
+	}
+
+	return svc.lambdaRepo.DeleteTrigger(c, user.ID, r.ID)
+}
+
+// WatchTriggers streams the activities of the accounts watched by the triggers until the context is done,
+// one stream for each trigger. The triggers added or removed are picked up by reconciling them by the interval.
+func (svc *service) WatchTriggers(c context.Context) {
+	interval := config.GlobalConfig.Lambda.Trigger.ReconcileInterval
+	if interval <= 0 {
+		interval = triggerReconcileInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	streams := make(map[uint64]context.CancelFunc)
+	for {
+		svc.reconcileTriggers(c, streams)
+
+		select {
+		case <-c.Done():
+			for _, cancel := range streams {
+				cancel()
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcileTriggers starts the streams of the triggers not streaming yet, and stops the ones of the removed.
+func (svc *service) reconcileTriggers(c context.Context, streams map[uint64]context.CancelFunc) {
+	triggers, err := svc.lambdaRepo.WatchedTriggers(c)
+	if err != nil {
+		logx.Logger.WARN(fmt.Sprintf("failed to reconcile triggers, err: %s", err.Error()))
+		return
+	}
+
+	watched := make(map[uint64]bool, len(triggers))
+	for _, trigger := range triggers {
+		watched[trigger.ID] = true
+		if _, ok := streams[trigger.ID]; ok {
+			continue
+		}
+
+		ctx, cancel := context.WithCancel(c)
+		streams[trigger.ID] = cancel
+
+		go svc.watchTrigger(ctx, trigger)
+	}
+
+	for id, cancel := range streams {
+		if !watched[id] {
+			cancel()
+			delete(streams, id)
+		}
+	}
+}
+
+// watchTrigger keeps the trigger streaming from its cursor in the database until the context is done
+// or the trigger is removed. The stream broken is restarted backing off, which is reset once any record handled.
+func (svc *service) watchTrigger(c context.Context, trigger *dto.RespTrigger) {
+	maxBackoff := config.GlobalConfig.Lambda.Trigger.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = triggerMaxBackoff
+	}
+	backoff := triggerBackoff
+
+	for {
+		err := svc.streamTrigger(c, trigger)
+		if c.Err() != nil {
+			return
+		}
+		if err != nil && !errors.Is(err, errCursorMoved) {
+			logx.Logger.WARN(fmt.Sprintf("stream of trigger: %d broken, err: %s", trigger.ID, err.Error()))
+		}
+
+		current, err := svc.lambdaRepo.FindTrigger(c, trigger.ID)
+		if err != nil {
+			e := new(errorx.Errorx)
+			if errors.As(err, &e) && e.Status() == http.StatusNotFound {
+				return
+			}
+
+			logx.Logger.WARN(fmt.Sprintf("failed to reload trigger: %d, err: %s", trigger.ID, err.Error()))
+		} else {
+			if current.Cursor != trigger.Cursor {
+				backoff = triggerBackoff
+			}
+			trigger.Cursor = current.Cursor
+			trigger.Ledger = current.Ledger
+		}
+
+		select {
+		case <-c.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// streamTrigger streams the records of the trigger from its cursor, and invokes the Lambda with each of them.
+// It stops once any record failed to hand, which is streamed again by the next start.
+func (svc *service) streamTrigger(c context.Context, trigger *dto.RespTrigger) error {
+	if trigger.Type == constant.TriggerContractEvent {
+		return svc.pollContractEvents(c, trigger)
+	}
+
+	ctx, cancel := context.WithCancel(c)
+	defer cancel()
+
+	cursor := trigger.Cursor
+	var failure error
+	handle := func(token string, field string, record interface{}) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := svc.fireTrigger(ctx, trigger, cursor, token, field, record); err != nil {
+			failure = err
+			cancel()
+			return
+		}
+
+		cursor = token
+	}
+
+	var err error
+	switch trigger.Type {
+	case constant.TriggerPayment:
+		err = svc.stellar.StreamPayments(ctx,
+			horizonclient.OperationRequest{ForAccount: trigger.Address, Cursor: cursor},
+			func(op operations.Operation) {
+				handle(op.PagingToken(), "operation", op)
+			})
+	case constant.TriggerOperation:
+		err = svc.stellar.StreamOperations(ctx,
+			horizonclient.OperationRequest{ForAccount: trigger.Address, Cursor: cursor},
+			func(op operations.Operation) {
+				handle(op.PagingToken(), "operation", op)
+			})
+	case constant.TriggerEffect:
+		err = svc.stellar.StreamEffects(ctx,
+			horizonclient.EffectRequest{ForAccount: trigger.Address, Cursor: cursor},
+			func(effect effects.Effect) {
+				handle(effect.PagingToken(), "effect", effect)
+			})
+	default:
+		err = errorx.Internal(fmt.Sprintf("unsupported trigger type: %s", trigger.Type))
+	}
+
+	if failure != nil {
+		return failure
+	}
+
+	return err
+}
+
+// fireTrigger claims the record by moving the cursor of the trigger to it, and invokes the Lambda with it.
+// The record claimed by others is skipped, and the cursor is moved back when the invocation failed,
+// so that each record is handed once across the restarts and the instances of the server.
+func (svc *service) fireTrigger(
+	c context.Context,
+	trigger *dto.RespTrigger,
+	from, to string,
+	field string,
+	record interface{},
+) error {
+	// the record claimed is handed to the end, even the stream is stopping
+	c = context.WithoutCancel(c)
+
+	claimed, err := svc.lambdaRepo.AdvanceCursor(c, trigger.ID, from, to)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return errCursorMoved
+	}
+
+	invokeErr := func() error {
+		lamb, err := svc.lambdaRepo.FindLambda(c, trigger.LambdaID)
+		if err != nil {
+			return err
+		}
+
+		payload, err := triggerPayload(trigger, field, record)
+		if err != nil {
+			return err
+		}
+
+		_, err = svc.invokeEvent(c, constant.TriggerInvoker, lamb, payload)
+		return err
+	}()
+	if invokeErr == nil {
+		return nil
+	}
+
+	if _, err := svc.lambdaRepo.AdvanceCursor(c, trigger.ID, to, from); err != nil {
+		logx.Logger.ERROR(fmt.Sprintf("failed to release the record: %s of trigger: %d, err: %s",
+			to, trigger.ID, err.Error()))
+	}
+
+	return invokeErr
+}
+
+// triggerPayload builds the event of the Lambda by the record streamed, which is set to the field
+// of its kind, along with the trigger and the organization and the account owning the Lambda.
+func triggerPayload(trigger *dto.RespTrigger, field string, record interface{}) ([]byte, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"organization": trigger.Organization,
+		"account":      trigger.Account,
+		"trigger": map[string]interface{}{
+			"id":      trigger.ID,
+			"type":    trigger.Type,
+			"address": trigger.Address,
+		},
+		field: record,
+	})
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to marshal payload: %s", err.Error()))
+	}
+
+	return payload, nil
+}
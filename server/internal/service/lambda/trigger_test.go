@@ -0,0 +1,307 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testTriggerAddress  = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+	testTriggerContract = "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+	// testTopicTransfer the symbol "transfer" in base64 XDR
+	testTopicTransfer = "AAAADwAAAAh0cmFuc2Zlcg=="
+)
+
+func testTrigger() *dto.RespTrigger {
+	return &dto.RespTrigger{
+		ID:           1,
+		LambdaID:     2,
+		Type:         constant.TriggerPayment,
+		Address:      testTriggerAddress,
+		Cursor:       constant.TriggerCursorNow,
+		Organization: "org_name",
+		Account:      "account_name",
+	}
+}
+
+func testPayment(token string) operations.Payment {
+	return operations.Payment{
+		Base:   operations.Base{ID: token, PT: token, Type: "payment"},
+		From:   "GFROM",
+		To:     testTriggerAddress,
+		Amount: "10.0000000",
+	}
+}
+
+func TestValidateTriggers(t *testing.T) {
+	cd := &service{}
+
+	triggers, err := cd.validateTriggers([]*dto.ReqTrigger{
+		{Type: constant.TriggerPayment, Address: testTriggerAddress},
+		{Type: constant.TriggerPayment, Address: " " + testTriggerAddress},
+		{Type: constant.TriggerEffect, Address: testTriggerAddress},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*dto.ReqTrigger{
+		{Type: constant.TriggerPayment, Address: testTriggerAddress},
+		{Type: constant.TriggerEffect, Address: testTriggerAddress},
+	}, triggers)
+
+	_, err = cd.validateTriggers([]*dto.ReqTrigger{{Type: "trade", Address: testTriggerAddress}})
+	assert.Equal(t, errorx.BadRequest("invalid trigger type: trade, should be one of: payment, operation, effect, contract_event"), err)
+
+	_, err = cd.validateTriggers([]*dto.ReqTrigger{{Type: constant.TriggerPayment, Address: "GINVALID"}})
+	assert.Equal(t, errorx.BadRequest("invalid address of the payment trigger: GINVALID"), err)
+}
+
+func TestValidateContractEventTriggers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSoroban := testdata.NewMockSoroban(ctrl)
+	mockSoroban.EXPECT().Enabled().AnyTimes().Return(true)
+
+	cd := &service{
+		soroban: mockSoroban,
+	}
+
+	triggers, err := cd.validateTriggers([]*dto.ReqTrigger{
+		{Type: constant.TriggerContractEvent, Address: testTriggerContract, Topics: [][]string{{"transfer", "*"}}},
+		{Type: constant.TriggerContractEvent, Address: testTriggerContract},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*dto.ReqTrigger{
+		{Type: constant.TriggerContractEvent, Address: testTriggerContract, Topics: [][]string{{testTopicTransfer, "*"}}},
+		{Type: constant.TriggerContractEvent, Address: testTriggerContract, Topics: [][]string{}},
+	}, triggers)
+
+	_, err = cd.validateTriggers([]*dto.ReqTrigger{{Type: constant.TriggerContractEvent, Address: testTriggerAddress}})
+	assert.Equal(t, errorx.BadRequest("invalid contract of the contract_event trigger: "+testTriggerAddress), err)
+
+	_, err = cd.validateTriggers([]*dto.ReqTrigger{{
+		Type:    constant.TriggerContractEvent,
+		Address: testTriggerContract,
+		Topics:  [][]string{{"a", "b", "c", "d", "e"}},
+	}})
+	assert.Equal(t, errorx.BadRequest("invalid topic: a,b,c,d,e, should have 1 to 4 segments"), err)
+}
+
+func TestValidateContractEventTriggersDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSoroban := testdata.NewMockSoroban(ctrl)
+	mockSoroban.EXPECT().Enabled().Times(1).Return(false)
+
+	cd := &service{
+		soroban: mockSoroban,
+	}
+
+	_, err := cd.validateTriggers([]*dto.ReqTrigger{{Type: constant.TriggerContractEvent, Address: testTriggerContract}})
+	assert.Equal(t, errorx.BadRequest("contract event triggers are not enabled on the server"), err)
+}
+
+func TestTriggersByLambda(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	triggers := []*dto.RespTrigger{testTrigger()}
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+	mockLambRepo.EXPECT().ListTriggers(ctx, uint64(123), uint64(2)).Times(1).
+		Return(triggers, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.Triggers(ctx, &dto.ReqTriggers{Lambda: "file1"})
+	assert.NoError(t, err)
+	assert.Equal(t, triggers, resp)
+}
+
+func TestRemoveTriggerNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().DeleteTrigger(ctx, uint64(123), uint64(7)).Times(1).
+		Return(errorx.NotFound("none trigger found by: 7"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	err := cd.RemoveTrigger(ctx, &dto.ReqURITrigger{ID: 7})
+	assert.Equal(t, errorx.NotFound("none trigger found by: 7"), err)
+}
+
+func TestStreamTriggerPayments(t *testing.T) {
+	setCallbackARN(t, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockStellar.EXPECT().StreamPayments(gomock.Any(), horizonclient.OperationRequest{
+		ForAccount: testTriggerAddress,
+		Cursor:     constant.TriggerCursorNow,
+	}, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _ horizonclient.OperationRequest, handler horizonclient.OperationHandler) error {
+			handler(testPayment("101"))
+			handler(testPayment("102"))
+			return nil
+		})
+
+	gomock.InOrder(
+		mockLambRepo.EXPECT().AdvanceCursor(gomock.Any(), uint64(1), constant.TriggerCursorNow, "101").Times(1).
+			Return(true, nil),
+		mockLambRepo.EXPECT().AdvanceCursor(gomock.Any(), uint64(1), "101", "102").Times(1).
+			Return(true, nil),
+	)
+	mockLambRepo.EXPECT().FindLambda(gomock.Any(), uint64(2)).Times(2).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+
+	payloads := make([]map[string]interface{}, 0, 2)
+	mockAmazon.EXPECT().InvokeLambda(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, input *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			payload := make(map[string]interface{})
+			assert.NoError(t, json.Unmarshal(input.Payload, &payload))
+			payloads = append(payloads, payload)
+			return &lambda.InvokeOutput{StatusCode: 202}, nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+		stellar:    mockStellar,
+	}
+
+	err := cd.streamTrigger(context.Background(), testTrigger())
+	assert.NoError(t, err)
+	assert.Len(t, payloads, 2)
+	assert.Equal(t, "org_name", payloads[0]["organization"])
+	assert.Equal(t, "account_name", payloads[0]["account"])
+	assert.Equal(t, map[string]interface{}{
+		"id":      float64(1),
+		"type":    constant.TriggerPayment,
+		"address": testTriggerAddress,
+	}, payloads[0]["trigger"])
+	assert.Equal(t, "101", payloads[0]["operation"].(map[string]interface{})["paging_token"])
+	assert.Equal(t, "10.0000000", payloads[1]["operation"].(map[string]interface{})["amount"])
+}
+
+func TestStreamTriggerCursorMoved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockStellar.EXPECT().StreamPayments(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _ horizonclient.OperationRequest, handler horizonclient.OperationHandler) error {
+			handler(testPayment("101"))
+			// the stream is stopping, the record is streamed again by the next start
+			handler(testPayment("102"))
+			return nil
+		})
+
+	mockLambRepo.EXPECT().AdvanceCursor(gomock.Any(), uint64(1), constant.TriggerCursorNow, "101").Times(1).
+		Return(false, nil)
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		stellar:    mockStellar,
+	}
+
+	err := cd.streamTrigger(context.Background(), testTrigger())
+	assert.True(t, errors.Is(err, errCursorMoved))
+}
+
+func TestFireTriggerReleasedOnFailure(t *testing.T) {
+	setCallbackARN(t, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	gomock.InOrder(
+		mockLambRepo.EXPECT().AdvanceCursor(gomock.Any(), uint64(1), "101", "102").Times(1).
+			Return(true, nil),
+		mockLambRepo.EXPECT().AdvanceCursor(gomock.Any(), uint64(1), "102", "101").Times(1).
+			Return(true, nil),
+	)
+	mockLambRepo.EXPECT().FindLambda(gomock.Any(), uint64(2)).Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+	mockAmazon.EXPECT().InvokeLambda(gomock.Any(), gomock.Any()).Times(1).
+		Return(nil, errors.New("throttled"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+	}
+
+	err := cd.fireTrigger(context.Background(), testTrigger(), "101", "102", "operation", testPayment("102"))
+	assert.Equal(t, errorx.Internal("failed to invoke lambda: "+testFunctionName+", error: throttled"), err)
+}
+
+func TestWatchTriggerRemoved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockStellar.EXPECT().StreamPayments(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		Return(errors.New("got bad HTTP status code 404"))
+	mockLambRepo.EXPECT().FindTrigger(gomock.Any(), uint64(1)).Times(1).
+		Return(nil, errorx.NotFound("none trigger found by: 1"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		stellar:    mockStellar,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cd.watchTrigger(ctx, testTrigger())
+	assert.NoError(t, ctx.Err())
+}
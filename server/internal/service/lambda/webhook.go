@@ -0,0 +1,354 @@
+package lambda
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// webhookTolerance the default tolerance of the timestamp of the signed requests, when it's not configured.
+const webhookTolerance = 5 * time.Minute
+
+var headerPattern = regexp.MustCompile(`^[A-Za-z0-9-]{1,64}$`)
+
+// validateWebhookHeaders checks the names of the headers picked into the event, the ones carrying
+// the secret or the signature are not allowed, the duplicated ones are dropped.
+func validateWebhookHeaders(headers []string) ([]string, error) {
+	if headers == nil {
+		return nil, nil
+	}
+	if len(headers) > constant.WebhookHeadersMax {
+		return nil, errorx.BadRequest(fmt.Sprintf("too many headers: %d, should be at most %d",
+			len(headers), constant.WebhookHeadersMax))
+	}
+
+	validated := make([]string, 0, len(headers))
+	seen := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		header = strings.TrimSpace(header)
+		if !headerPattern.MatchString(header) {
+			return nil, errorx.BadRequest(fmt.Sprintf("invalid header: %s", header))
+		}
+
+		header = http.CanonicalHeaderKey(header)
+		switch header {
+		case "Authorization", "Cookie", constant.WebhookSignatureHeader:
+			return nil, errorx.BadRequest(fmt.Sprintf("header: %s is not allowed to pick", header))
+		}
+
+		if seen[header] {
+			continue
+		}
+		seen[header] = true
+
+		validated = append(validated, header)
+	}
+
+	return validated, nil
+}
+
+// randomHex generates the random bytes of the length in hex.
+func randomHex(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", errorx.Internal(fmt.Sprintf("failed to generate random bytes, err: %s", err.Error()))
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+
+	return constant.WebhookSecretPrefix + secret, nil
+}
+
+func webhookPath(hookID string) string {
+	return "/hooks/" + hookID
+}
+
+// CreateWebhook creates the webhook of the Lambda, the secret of it is only returned this time.
+func (svc *service) CreateWebhook(c context.Context, r *dto.ReqWebhook) (*dto.RespWebhook, error) {
+	headers, err := validateWebhookHeaders(r.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, strings.TrimSpace(r.Lambda))
+	if err != nil {
+		return nil, err
+	}
+
+	hookID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := model.BuildLambdaWebhook(
+		model.WithWebhook(lamb.ID, hookID, secret),
+		model.WithWebhookHeaders(headers),
+	)
+	if err := svc.lambdaRepo.SaveWebhook(c, webhook); err != nil {
+		return nil, err
+	}
+
+	return &dto.RespWebhook{
+		ID:        webhook.ID,
+		LambdaID:  lamb.ID,
+		Lambda:    lamb.FunctionName,
+		HookID:    webhook.HookID,
+		Path:      webhookPath(webhook.HookID),
+		Secret:    webhook.Secret,
+		Headers:   webhook.Headers,
+		CreatedAt: webhook.CreatedAt,
+	}, nil
+}
+
+// Webhooks lists the webhooks of the Lambda, or all the ones of the account when none Lambda given.
+func (svc *service) Webhooks(c context.Context, r *dto.ReqWebhooks) ([]*dto.RespWebhook, error) {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	var lambdaID uint64
+	if strings.TrimSpace(r.Lambda) != "" {
+		lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, strings.TrimSpace(r.Lambda))
+		if err != nil {
+			return nil, err
+		}
+
+		lambdaID = lamb.ID
+	}
+
+	webhooks, err := svc.lambdaRepo.ListWebhooks(c, user.ID, lambdaID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, webhook := range webhooks {
+		webhook.Path = webhookPath(webhook.HookID)
+	}
+
+	return webhooks, nil
+}
+
+// RotateWebhook replaces the secret of the webhook of the Lambda, the old one is invalid at once.
+// The headers picked are replaced as well when given.
+func (svc *service) RotateWebhook(c context.Context, r *dto.ReqWebhook) (*dto.RespWebhook, error) {
+	headers, err := validateWebhookHeaders(r.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, strings.TrimSpace(r.Lambda))
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := svc.lambdaRepo.RotateWebhook(c, lamb.ID, secret, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RespWebhook{
+		ID:        webhook.ID,
+		LambdaID:  lamb.ID,
+		Lambda:    lamb.FunctionName,
+		HookID:    webhook.HookID,
+		Path:      webhookPath(webhook.HookID),
+		Secret:    secret,
+		Headers:   webhook.Headers,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}, nil
+}
+
+// RemoveWebhook removes the webhook of the Lambda, the requests to it are rejected since.
+func (svc *service) RemoveWebhook(c context.Context, r *dto.ReqURILambda) error {
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByAcn(c, jwtAccount.(string))
+	if err != nil {
+		return err
+	}
+
+	lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, strings.TrimSpace(r.Lambda))
+	if err != nil {
+		return err
+	}
+
+	return svc.lambdaRepo.DeleteWebhook(c, lamb.ID)
+}
+
+// ReceiveHook verifies the request of the inbound webhook by the secret of it, and invokes the Lambda
+// with the body and the headers picked asynchronously.
+func (svc *service) ReceiveHook(c context.Context, r *dto.ReqHook) (*dto.RespHook, error) {
+	webhook, err := svc.lambdaRepo.FindWebhook(c, r.HookID)
+	if err != nil {
+		e := new(errorx.Errorx)
+		if errors.As(err, &e) && e.Status() == http.StatusNotFound {
+			// the same as the one with the wrong secret, not to tell the webhooks existed
+			return nil, errorx.UnauthorizedWithMsg("invalid webhook signature")
+		}
+
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := verifyHook(webhook.Secret, r.Header, r.Body, now); err != nil {
+		return nil, err
+	}
+
+	lamb, err := svc.lambdaRepo.FindLambda(c, webhook.LambdaID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := hookPayload(webhook, r, now)
+	if err != nil {
+		return nil, err
+	}
+
+	requestID, err := svc.invokeEvent(c, constant.WebhookInvoker, lamb, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RespHook{RequestID: requestID}, nil
+}
+
+// verifyHook verifies the request either by the signature of the timestamp and the body, or by the secret
+// as the bearer token. The timestamp signed is required to be within the tolerance, which limits the replays
+// of the signed requests captured to the tolerance. The bearer ones carry nothing signed to bind,
+// so they are not protected against replays.
+func verifyHook(secret string, header http.Header, body []byte, now time.Time) error {
+	signature := header.Get(constant.WebhookSignatureHeader)
+	timestamp := header.Get(constant.WebhookTimestampHeader)
+
+	if signature == "" {
+		bearer, found := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(bearer)), []byte(secret)) != 1 {
+			return errorx.UnauthorizedWithMsg("invalid webhook signature")
+		}
+
+		return nil
+	}
+
+	if err := verifyHookTimestamp(timestamp, now); err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(signHook(secret, timestamp, body))) {
+		return errorx.UnauthorizedWithMsg("invalid webhook signature")
+	}
+
+	return nil
+}
+
+func verifyHookTimestamp(timestamp string, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errorx.UnauthorizedWithMsg(fmt.Sprintf("invalid webhook timestamp: %s, should be in unix seconds", timestamp))
+	}
+
+	tolerance := config.GlobalConfig.Lambda.Webhook.Tolerance
+	if tolerance <= 0 {
+		tolerance = webhookTolerance
+	}
+
+	if diff := now.Sub(time.Unix(seconds, 0)); diff > tolerance || diff < -tolerance {
+		return errorx.UnauthorizedWithMsg(fmt.Sprintf("webhook timestamp: %s out of the tolerance: %s", timestamp, tolerance))
+	}
+
+	return nil
+}
+
+// signHook signs the timestamp and the body of the request by the secret of the webhook.
+func signHook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return constant.WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// hookPayload builds the event of the Lambda by the request, the body in JSON is kept as is,
+// otherwise as the string, along with the headers picked and the organization and the account owning the Lambda.
+func hookPayload(webhook *dto.RespWebhook, r *dto.ReqHook, receivedAt time.Time) ([]byte, error) {
+	headers := make(map[string]string, len(webhook.Headers))
+	for _, name := range webhook.Headers {
+		if value := r.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	var body interface{} = string(r.Body)
+	if json.Valid(r.Body) {
+		body = json.RawMessage(r.Body)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"organization": webhook.Organization,
+		"account":      webhook.Account,
+		"webhook": map[string]interface{}{
+			"hook_id":     webhook.HookID,
+			"received_at": receivedAt.Format(time.RFC3339),
+		},
+		"headers": headers,
+		"body":    body,
+	})
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to marshal payload: %s", err.Error()))
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,225 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWebhookSecret = "whsec_test"
+
+func signedHeader(secret string, at time.Time, body []byte) http.Header {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+
+	header := http.Header{}
+	header.Set(constant.WebhookTimestampHeader, timestamp)
+	header.Set(constant.WebhookSignatureHeader, signHook(secret, timestamp, body))
+
+	return header
+}
+
+func TestValidateWebhookHeaders(t *testing.T) {
+	headers, err := validateWebhookHeaders([]string{"x-github-event", " X-GitHub-Event", "X-Request-Id"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"X-Github-Event", "X-Request-Id"}, headers)
+
+	headers, err = validateWebhookHeaders(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, headers)
+
+	_, err = validateWebhookHeaders([]string{"authorization"})
+	assert.Equal(t, errorx.BadRequest("header: Authorization is not allowed to pick"), err)
+
+	_, err = validateWebhookHeaders([]string{"X Event"})
+	assert.Equal(t, errorx.BadRequest("invalid header: X Event"), err)
+}
+
+func TestVerifyHookSigned(t *testing.T) {
+	now := time.Now().UTC()
+	body := []byte(`{"price":"0.12"}`)
+
+	assert.NoError(t, verifyHook(testWebhookSecret, signedHeader(testWebhookSecret, now, body), body, now))
+
+	// tampered body
+	err := verifyHook(testWebhookSecret, signedHeader(testWebhookSecret, now, body), []byte(`{"price":"9"}`), now)
+	assert.Equal(t, errorx.UnauthorizedWithMsg("invalid webhook signature"), err)
+
+	// signed by another secret
+	err = verifyHook(testWebhookSecret, signedHeader("whsec_other", now, body), body, now)
+	assert.Equal(t, errorx.UnauthorizedWithMsg("invalid webhook signature"), err)
+
+	// replayed out of the tolerance
+	past := now.Add(-10 * time.Minute)
+	err = verifyHook(testWebhookSecret, signedHeader(testWebhookSecret, past, body), body, now)
+	assert.Equal(t, errorx.UnauthorizedWithMsg("webhook timestamp: "+strconv.FormatInt(past.Unix(), 10)+
+		" out of the tolerance: 5m0s"), err)
+
+	header := signedHeader(testWebhookSecret, now, body)
+	header.Del(constant.WebhookTimestampHeader)
+	err = verifyHook(testWebhookSecret, header, body, now)
+	assert.Equal(t, errorx.UnauthorizedWithMsg("invalid webhook timestamp: , should be in unix seconds"), err)
+}
+
+func TestVerifyHookBearer(t *testing.T) {
+	now := time.Now().UTC()
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+testWebhookSecret)
+	assert.NoError(t, verifyHook(testWebhookSecret, header, nil, now))
+
+	// the timestamp is not signed by the bearer ones, which is ignored
+	header.Set(constant.WebhookTimestampHeader, strconv.FormatInt(now.Add(time.Hour).Unix(), 10))
+	assert.NoError(t, verifyHook(testWebhookSecret, header, nil, now))
+
+	header = http.Header{}
+	header.Set("Authorization", "Bearer whsec_wrong")
+	assert.Equal(t, errorx.UnauthorizedWithMsg("invalid webhook signature"), verifyHook(testWebhookSecret, header, nil, now))
+
+	assert.Equal(t, errorx.UnauthorizedWithMsg("invalid webhook signature"), verifyHook(testWebhookSecret, http.Header{}, nil, now))
+}
+
+func TestReceiveHookSuccess(t *testing.T) {
+	setCallbackARN(t, "")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockAmazon := testdata.NewMockAmazon(ctrl)
+
+	ctx := new(gin.Context)
+
+	mockLambRepo.EXPECT().FindWebhook(ctx, "hook1").Times(1).
+		Return(&dto.RespWebhook{
+			ID:           1,
+			LambdaID:     2,
+			HookID:       "hook1",
+			Secret:       testWebhookSecret,
+			Headers:      []string{"X-Github-Event"},
+			Organization: "org_name",
+			Account:      "account_name",
+		}, nil)
+	mockLambRepo.EXPECT().FindLambda(ctx, uint64(2)).Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+
+	var payload map[string]interface{}
+	mockAmazon.EXPECT().InvokeLambda(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, input *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
+			assert.NoError(t, json.Unmarshal(input.Payload, &payload))
+			return &lambda.InvokeOutput{StatusCode: 202}, nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		amazon:     mockAmazon,
+	}
+
+	body := []byte(`{"action":"opened"}`)
+	header := signedHeader(testWebhookSecret, time.Now(), body)
+	header.Set("X-GitHub-Event", "pull_request")
+	header.Set("X-Not-Picked", "value")
+
+	_, err := cd.ReceiveHook(ctx, &dto.ReqHook{HookID: "hook1", Header: header, Body: body})
+	assert.NoError(t, err)
+	assert.Equal(t, "org_name", payload["organization"])
+	assert.Equal(t, map[string]interface{}{"action": "opened"}, payload["body"])
+	assert.Equal(t, map[string]interface{}{"X-Github-Event": "pull_request"}, payload["headers"])
+	assert.Equal(t, "hook1", payload["webhook"].(map[string]interface{})["hook_id"])
+}
+
+func TestReceiveHookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+
+	ctx := new(gin.Context)
+
+	mockLambRepo.EXPECT().FindWebhook(ctx, "unknown").Times(1).
+		Return(nil, errorx.NotFound("none webhook found by: unknown"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+	}
+
+	resp, err := cd.ReceiveHook(ctx, &dto.ReqHook{HookID: "unknown", Header: http.Header{}})
+	assert.Nil(t, resp)
+	assert.Equal(t, errorx.UnauthorizedWithMsg("invalid webhook signature"), err)
+}
+
+func TestCreateWebhookSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+	mockLambRepo.EXPECT().SaveWebhook(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, webhook *model.LambdaWebhook) error {
+			assert.Equal(t, uint64(2), webhook.LambdaID)
+			assert.Equal(t, []string{}, webhook.Headers)
+			webhook.ID = 1
+			return nil
+		})
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.CreateWebhook(ctx, &dto.ReqWebhook{Lambda: "file1"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.HookID, 32)
+	assert.Equal(t, "/hooks/"+resp.HookID, resp.Path)
+	assert.True(t, strings.HasPrefix(resp.Secret, constant.WebhookSecretPrefix))
+	assert.Equal(t, testFunctionName, resp.Lambda)
+}
+
+func TestRotateWebhookNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	mockOAuthRepo.EXPECT().FindUserByAcn(ctx, "account_name").Times(1).
+		Return(&dto.RespUser{ID: 123}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(123), "file1").Times(1).
+		Return(&dto.RespInfo{ID: 2, FunctionName: testFunctionName}, nil)
+	mockLambRepo.EXPECT().RotateWebhook(ctx, uint64(2), gomock.Any(), gomock.Nil()).Times(1).
+		Return(nil, errorx.NotFound("none webhook found of lambda: 2"))
+
+	cd := &service{
+		lambdaRepo: mockLambRepo,
+		oauthRepo:  mockOAuthRepo,
+	}
+
+	resp, err := cd.RotateWebhook(ctx, &dto.ReqWebhook{Lambda: "file1"})
+	assert.Nil(t, resp)
+	assert.Equal(t, errorx.NotFound("none webhook found of lambda: 2"), err)
+}
@@ -0,0 +1,97 @@
+package secret
+
+import (
+	"net/http"
+
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	Resource interface {
+		Set(c *gin.Context)
+		List(c *gin.Context)
+		Remove(c *gin.Context)
+		Runtime(c *gin.Context)
+	}
+	resource struct {
+		service SecretService
+	}
+)
+
+var ResourceImpl Resource
+
+func NewSecretResource() {
+	if ResourceImpl == nil {
+		ResourceImpl = &resource{
+			service: SecretServiceImpl,
+		}
+	}
+}
+
+func (re *resource) Set(c *gin.Context) {
+	req := new(dto.ReqSetSecret)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Set(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) List(c *gin.Context) {
+	resp, err := re.service.List(c)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Remove(c *gin.Context) {
+	req := new(dto.ReqURISecret)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := re.service.Remove(c, req); err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, nil)
+}
+
+func (re *resource) Runtime(c *gin.Context) {
+	resp, err := re.service.Runtime(c)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
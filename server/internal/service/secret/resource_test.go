@@ -0,0 +1,103 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceSetSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("PUT", "/secret/API_KEY",
+		bytes.NewBufferString(`{"value":"chunk1.chunk2","actions":["action1"]}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "name", Value: "API_KEY"}}
+
+	mockService := testdata.NewMockSecretService(ctrl)
+
+	mockResp := &dto.RespSecret{Name: "API_KEY", Actions: []string{"org-acn-action1"}}
+	mockService.EXPECT().Set(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqSetSecret) (*dto.RespSecret, error) {
+			assert.Equal(t, "API_KEY", r.Name)
+			assert.Equal(t, "chunk1.chunk2", r.Value)
+			assert.Equal(t, []string{"action1"}, *r.Actions)
+			return mockResp, nil
+		})
+
+	re := &resource{
+		service: mockService,
+	}
+
+	re.Set(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+
+	resp := &dto.RespSecret{}
+	err := json.Unmarshal(w.Body.Bytes(), resp)
+	assert.Nil(t, err)
+	assert.Equal(t, mockResp, resp)
+}
+
+func TestResourceListServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/secret", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	mockService := testdata.NewMockSecretService(ctrl)
+	mockService.EXPECT().List(ctx).Return(nil, errors.New("error"))
+
+	re := &resource{
+		service: mockService,
+	}
+
+	re.List(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "error", ctx.Errors.Last().Error())
+}
+
+func TestResourceRuntimeSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/runtime/secrets", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	mockService := testdata.NewMockSecretService(ctrl)
+	mockService.EXPECT().Runtime(ctx).
+		Return(&dto.RespRuntimeSecrets{Secrets: map[string]string{"API_KEY": "secret"}}, nil)
+
+	re := &resource{
+		service: mockService,
+	}
+
+	re.Runtime(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.JSONEq(t, `{"secrets":{"API_KEY":"secret"}}`, w.Body.String())
+}
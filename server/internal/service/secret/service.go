@@ -0,0 +1,233 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/repo"
+	"github.com/57blocks/auto-action/server/internal/third-party/decrypt"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:generate mockgen -destination ../../testdata/secret_service_mock.go -package testdata -source service.go SecretService
+type (
+	SecretService interface {
+		Set(c context.Context, r *dto.ReqSetSecret) (*dto.RespSecret, error)
+		List(c context.Context) ([]*dto.RespSecret, error)
+		Remove(c context.Context, r *dto.ReqURISecret) error
+		Runtime(c context.Context) (*dto.RespRuntimeSecrets, error)
+	}
+	service struct {
+		oauthRepo  repo.OAuth
+		lambdaRepo repo.Lambda
+		secretRepo repo.Secret
+		decrypter  decrypt.Decrypter
+	}
+)
+
+var SecretServiceImpl SecretService
+
+func NewSecretService() {
+	if SecretServiceImpl == nil {
+		repo.NewOAuth()
+		repo.NewLambda()
+		repo.NewSecret()
+
+		SecretServiceImpl = &service{
+			oauthRepo:  repo.OAuthRepo,
+			lambdaRepo: repo.LambdaRepo,
+			secretRepo: repo.SecretRepo,
+			decrypter:  decrypt.RSADecrypter,
+		}
+	}
+}
+
+var secretNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,127}$`)
+
+// Set sets the secret of the account, the value is kept encrypted as given, and checked by decrypting it.
+// The actions granted with the secret are replaced when given.
+func (svc *service) Set(c context.Context, r *dto.ReqSetSecret) (*dto.RespSecret, error) {
+	if !secretNameRegex.MatchString(r.Name) {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid secret name: %s, should start with a letter or underscore, "+
+			"and contain only letters, numbers and underscores, 128 characters at most", r.Name))
+	}
+	if _, err := svc.decryptValue(r.Value); err != nil {
+		return nil, err
+	}
+
+	user, err := svc.user(c)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := svc.secretRepo.ListSecrets(c, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) >= constant.SecretMax && !existing(secrets, r.Name) {
+		return nil, errorx.BadRequest(fmt.Sprintf("the number of secrets is limited to %d", constant.SecretMax))
+	}
+
+	var lambdaIDs []uint64
+	if r.Actions != nil {
+		lambdaIDs = make([]uint64, 0, len(*r.Actions))
+		for _, action := range *r.Actions {
+			lamb, err := svc.lambdaRepo.LambdaInfo(c, user.ID, action)
+			if err != nil {
+				return nil, err
+			}
+
+			lambdaIDs = append(lambdaIDs, lamb.ID)
+		}
+	}
+
+	secret := &model.Secret{
+		AccountID: user.ID,
+		Name:      r.Name,
+		Value:     r.Value,
+	}
+	if err := svc.secretRepo.SaveSecret(c, secret); err != nil {
+		return nil, err
+	}
+
+	if r.Actions != nil {
+		if err := svc.secretRepo.GrantSecret(c, secret.ID, lambdaIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	grants, err := svc.secretRepo.ListGrants(c, []uint64{secret.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.RespSecret{
+		ID:      secret.ID,
+		Name:    secret.Name,
+		Actions: make([]string, 0, len(grants)),
+	}
+	for _, grant := range grants {
+		resp.Actions = append(resp.Actions, grant.Lambda)
+	}
+
+	return resp, nil
+}
+
+// List lists the secrets of the account by the names, along with the actions granted, never the values.
+func (svc *service) List(c context.Context) ([]*dto.RespSecret, error) {
+	user, err := svc.user(c)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := svc.secretRepo.ListSecrets(c, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint64, 0, len(secrets))
+	byID := make(map[uint64]*dto.RespSecret, len(secrets))
+	for _, secret := range secrets {
+		secret.Actions = make([]string, 0)
+		ids = append(ids, secret.ID)
+		byID[secret.ID] = secret
+	}
+
+	grants, err := svc.secretRepo.ListGrants(c, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, grant := range grants {
+		if secret, ok := byID[grant.SecretID]; ok {
+			secret.Actions = append(secret.Actions, grant.Lambda)
+		}
+	}
+
+	return secrets, nil
+}
+
+// Remove removes the secret of the account, the actions granted lose it along the way.
+func (svc *service) Remove(c context.Context, r *dto.ReqURISecret) error {
+	user, err := svc.user(c)
+	if err != nil {
+		return err
+	}
+
+	return svc.secretRepo.DeleteSecret(c, user.ID, r.Name)
+}
+
+// Runtime returns the values of the secrets granted to the function calling the runtime API.
+func (svc *service) Runtime(c context.Context) (*dto.RespRuntimeSecrets, error) {
+	function, _ := c.(*gin.Context).Get(constant.RuntimeFunction.Str())
+
+	user, err := svc.user(c)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, err := svc.secretRepo.LambdaSecrets(c, user.ID, function.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(secrets))
+	for _, secret := range secrets {
+		value, err := svc.decryptValue(secret.Value)
+		if err != nil {
+			return nil, errorx.Internal(fmt.Sprintf("failed to decrypt secret: %s", secret.Name))
+		}
+
+		values[secret.Name] = value
+	}
+
+	return &dto.RespRuntimeSecrets{Secrets: values}, nil
+}
+
+func (svc *service) user(c context.Context) (*dto.RespUser, error) {
+	jwtOrg, _ := c.(*gin.Context).Get(constant.ClaimIss.Str())
+	jwtAccount, _ := c.(*gin.Context).Get(constant.ClaimSub.Str())
+
+	return svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+}
+
+// decryptValue decrypts the value chunk by chunk, and joins them back.
+func (svc *service) decryptValue(value string) (string, error) {
+	if value == "" {
+		return "", errorx.BadRequest("the value of the secret is required")
+	}
+
+	var decrypted strings.Builder
+	for _, chunk := range strings.Split(value, constant.SecretChunkSeparator) {
+		plain, err := svc.decrypter.Decrypt([]byte(chunk))
+		if err != nil {
+			return "", errorx.BadRequest("invalid value of the secret, should be encrypted by the public key of the server")
+		}
+
+		decrypted.Write(plain)
+	}
+	if decrypted.Len() > constant.SecretValueMax {
+		return "", errorx.BadRequest(fmt.Sprintf("the size of the secret is limited to %d bytes", constant.SecretValueMax))
+	}
+
+	return decrypted.String(), nil
+}
+
+func existing(secrets []*dto.RespSecret, name string) bool {
+	for _, secret := range secrets {
+		if secret.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,187 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/testdata"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Before test, setup log
+func TestMain(m *testing.M) {
+	logx.Setup(&config.Configuration{
+		Log: config.Log{
+			Level:    "debug",
+			Encoding: "json",
+		},
+	})
+
+	os.Exit(m.Run())
+}
+
+func testContext() *gin.Context {
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "org_name")
+	ctx.Set(constant.ClaimSub.Str(), "account_name")
+
+	return ctx
+}
+
+func TestSetSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockLambRepo := testdata.NewMockLambda(ctrl)
+	mockSecretRepo := testdata.NewMockSecret(ctrl)
+	mockDecrypter := testdata.NewMockDecrypter(ctrl)
+
+	ctx := testContext()
+	actions := []string{"action1"}
+
+	mockDecrypter.EXPECT().Decrypt([]byte("chunk1")).Times(1).Return([]byte("sec"), nil)
+	mockDecrypter.EXPECT().Decrypt([]byte("chunk2")).Times(1).Return([]byte("ret"), nil)
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, req *dto.ReqOrgAcn) (*dto.RespUser, error) {
+			assert.Equal(t, "org_name", req.OrgName)
+			assert.Equal(t, "account_name", req.AcnName)
+			return &dto.RespUser{ID: 1}, nil
+		})
+	mockSecretRepo.EXPECT().ListSecrets(ctx, uint64(1)).Times(1).
+		Return([]*dto.RespSecret{}, nil)
+	mockLambRepo.EXPECT().LambdaInfo(ctx, uint64(1), "action1").Times(1).
+		Return(&dto.RespInfo{ID: 4}, nil)
+	mockSecretRepo.EXPECT().SaveSecret(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, secret *model.Secret) error {
+			assert.Equal(t, "API_KEY", secret.Name)
+			assert.Equal(t, "chunk1.chunk2", secret.Value)
+			secret.ID = 3
+			return nil
+		})
+	mockSecretRepo.EXPECT().GrantSecret(ctx, uint64(3), []uint64{4}).Times(1).
+		Return(nil)
+	mockSecretRepo.EXPECT().ListGrants(ctx, []uint64{3}).Times(1).
+		Return([]*dto.RespSecretGrant{{SecretID: 3, Lambda: "org_name-account_name-action1"}}, nil)
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		lambdaRepo: mockLambRepo,
+		secretRepo: mockSecretRepo,
+		decrypter:  mockDecrypter,
+	}
+
+	resp, err := svc.Set(ctx, &dto.ReqSetSecret{Name: "API_KEY", Value: "chunk1.chunk2", Actions: &actions})
+	assert.NoError(t, err)
+	assert.Equal(t, "API_KEY", resp.Name)
+	assert.Equal(t, []string{"org_name-account_name-action1"}, resp.Actions)
+}
+
+func TestSetInvalidName(t *testing.T) {
+	svc := &service{}
+
+	_, err := svc.Set(testContext(), &dto.ReqSetSecret{Name: "1-key", Value: "chunk"})
+	assert.ErrorContains(t, err, "invalid secret name: 1-key")
+}
+
+func TestSetInvalidValue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDecrypter := testdata.NewMockDecrypter(ctrl)
+	mockDecrypter.EXPECT().Decrypt([]byte("plain")).Times(1).Return(nil, errors.New("decryption error"))
+
+	svc := &service{
+		decrypter: mockDecrypter,
+	}
+
+	_, err := svc.Set(testContext(), &dto.ReqSetSecret{Name: "API_KEY", Value: "plain"})
+	assert.Equal(t, errorx.BadRequest("invalid value of the secret, should be encrypted by the public key of the server"), err)
+}
+
+func TestSetValueTooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDecrypter := testdata.NewMockDecrypter(ctrl)
+	mockDecrypter.EXPECT().Decrypt(gomock.Any()).Times(1).
+		Return([]byte(strings.Repeat("a", constant.SecretValueMax+1)), nil)
+
+	svc := &service{
+		decrypter: mockDecrypter,
+	}
+
+	_, err := svc.Set(testContext(), &dto.ReqSetSecret{Name: "API_KEY", Value: "chunk"})
+	assert.Equal(t, errorx.BadRequest("the size of the secret is limited to 4096 bytes"), err)
+}
+
+func TestListSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockSecretRepo := testdata.NewMockSecret(ctrl)
+
+	ctx := testContext()
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockSecretRepo.EXPECT().ListSecrets(ctx, uint64(1)).Times(1).
+		Return([]*dto.RespSecret{{ID: 3, Name: "API_KEY"}, {ID: 5, Name: "TOKEN"}}, nil)
+	mockSecretRepo.EXPECT().ListGrants(ctx, []uint64{3, 5}).Times(1).
+		Return([]*dto.RespSecretGrant{
+			{SecretID: 3, Lambda: "org-acn-func1"},
+			{SecretID: 3, Lambda: "org-acn-func2"},
+		}, nil)
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		secretRepo: mockSecretRepo,
+	}
+
+	resp, err := svc.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, resp, 2)
+	assert.Equal(t, []string{"org-acn-func1", "org-acn-func2"}, resp[0].Actions)
+	assert.Equal(t, []string{}, resp[1].Actions)
+}
+
+func TestRuntimeSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockSecretRepo := testdata.NewMockSecret(ctrl)
+	mockDecrypter := testdata.NewMockDecrypter(ctrl)
+
+	ctx := testContext()
+	ctx.Set(constant.RuntimeFunction.Str(), "org_name-account_name-action1")
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockSecretRepo.EXPECT().LambdaSecrets(ctx, uint64(1), "org_name-account_name-action1").Times(1).
+		Return([]*model.Secret{{Name: "API_KEY", Value: "chunk1"}}, nil)
+	mockDecrypter.EXPECT().Decrypt([]byte("chunk1")).Times(1).Return([]byte("secret"), nil)
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		secretRepo: mockSecretRepo,
+		decrypter:  mockDecrypter,
+	}
+
+	resp, err := svc.Runtime(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_KEY": "secret"}, resp.Secrets)
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"github.com/57blocks/auto-action/server/internal/service/cs"
+	"github.com/57blocks/auto-action/server/internal/service/kv"
 	"github.com/57blocks/auto-action/server/internal/service/lambda"
 	"github.com/57blocks/auto-action/server/internal/service/oauth"
+	"github.com/57blocks/auto-action/server/internal/service/secret"
 	"github.com/57blocks/auto-action/server/internal/service/wallet"
 )
 
@@ -16,6 +18,10 @@ func Setup() error {
 	oauth.NewOAuthResource()
 	wallet.NewWalletService()
 	wallet.NewWalletResource()
+	kv.NewKVService()
+	kv.NewKVResource()
+	secret.NewSecretService()
+	secret.NewSecretResource()
 
 	return nil
 }
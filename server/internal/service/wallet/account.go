@@ -0,0 +1,125 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/protocols/horizon"
+)
+
+// accountInfo queries the state of the address on the network, through the cache of the accounts.
+func (svc *service) accountInfo(c context.Context, address string) (*dto.RespWalletInfo, error) {
+	account, err := svc.stellar.CachedAccountDetail(c, address)
+	if err != nil {
+		if horizonclient.IsNotFoundError(err) {
+			return &dto.RespWalletInfo{
+				Address:  address,
+				Balances: make([]*dto.RespWalletBalance, 0),
+				Signers:  make([]*dto.RespWalletSigner, 0),
+			}, nil
+		}
+
+		return nil, errorx.Internal(fmt.Sprintf("failed to query wallet: %s, err: %s", address, err.Error()))
+	}
+
+	return walletInfo(account), nil
+}
+
+// awaitAccount polls Horizon by the interval until the account of the address is found, or the timeout reached.
+func (svc *service) awaitAccount(
+	c context.Context,
+	address string,
+	interval, timeout time.Duration,
+) (horizon.Account, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		account, err := svc.stellar.AccountDetail(c, horizonclient.AccountRequest{AccountID: address})
+		if err == nil {
+			return account, nil
+		}
+		if !horizonclient.IsNotFoundError(err) {
+			return horizon.Account{}, errorx.Internal(fmt.Sprintf("failed to query wallet: %s, err: %s", address, err.Error()))
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return horizon.Account{}, errorx.Internal(fmt.Sprintf("the account of the wallet: %s is not found in %s", address, timeout))
+		}
+
+		select {
+		case <-c.Done():
+			return horizon.Account{}, errorx.Internal(fmt.Sprintf("waiting for the wallet: %s is cancelled", address))
+		case <-time.After(interval):
+		}
+	}
+}
+
+// nativeBalance returns the XLM balance of the account.
+func nativeBalance(account horizon.Account) string {
+	for _, balance := range account.Balances {
+		if balance.Type == "native" {
+			return balance.Balance
+		}
+	}
+
+	return "0"
+}
+
+func walletInfo(account horizon.Account) *dto.RespWalletInfo {
+	entries := 2 + int64(account.SubentryCount) + int64(account.NumSponsoring) - int64(account.NumSponsored)
+
+	info := &dto.RespWalletInfo{
+		Address:        account.AccountID,
+		Activated:      true,
+		Sequence:       strconv.FormatInt(account.Sequence, 10),
+		SubentryCount:  account.SubentryCount,
+		NumSponsoring:  account.NumSponsoring,
+		NumSponsored:   account.NumSponsored,
+		MinimumReserve: amount.StringFromInt64(entries * constant.StellarBaseReserve),
+		Balances:       make([]*dto.RespWalletBalance, 0, len(account.Balances)),
+		Signers:        make([]*dto.RespWalletSigner, 0, len(account.Signers)),
+		Thresholds: dto.RespWalletThresholds{
+			Low:    account.Thresholds.LowThreshold,
+			Medium: account.Thresholds.MedThreshold,
+			High:   account.Thresholds.HighThreshold,
+		},
+		Flags: dto.RespWalletFlags{
+			AuthRequired:        account.Flags.AuthRequired,
+			AuthRevocable:       account.Flags.AuthRevocable,
+			AuthImmutable:       account.Flags.AuthImmutable,
+			AuthClawbackEnabled: account.Flags.AuthClawbackEnabled,
+		},
+	}
+
+	for _, balance := range account.Balances {
+		info.Balances = append(info.Balances, &dto.RespWalletBalance{
+			AssetType:                         balance.Type,
+			AssetCode:                         balance.Code,
+			AssetIssuer:                       balance.Issuer,
+			LiquidityPoolID:                   balance.LiquidityPoolId,
+			Balance:                           balance.Balance,
+			Limit:                             balance.Limit,
+			BuyingLiabilities:                 balance.BuyingLiabilities,
+			SellingLiabilities:                balance.SellingLiabilities,
+			IsAuthorized:                      balance.IsAuthorized,
+			IsAuthorizedToMaintainLiabilities: balance.IsAuthorizedToMaintainLiabilities,
+			IsClawbackEnabled:                 balance.IsClawbackEnabled,
+		})
+	}
+	for _, signer := range account.Signers {
+		info.Signers = append(info.Signers, &dto.RespWalletSigner{
+			Key:    signer.Key,
+			Type:   signer.Type,
+			Weight: signer.Weight,
+		})
+	}
+
+	return info
+}
@@ -0,0 +1,120 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/txnbuild"
+)
+
+// activationTxTimeout the seconds the activation transaction is valid for after built.
+const activationTxTimeout = 300
+
+// activationDay the start of today in UTC, since when the activations are counted against the daily cap.
+func activationDay() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+// funding returns the funding wallet of the organization, along with the activations since the start of today in UTC.
+func (svc *service) funding(c context.Context, orgID uint64) (*dto.RespFundingWallet, error) {
+	designated, err := svc.walletRepo.FundingWallet(c, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	activated, err := svc.walletRepo.CountActivations(c, orgID, activationDay())
+	if err != nil {
+		return nil, err
+	}
+
+	activation := config.GlobalConfig.Wallet.Activation
+
+	return &dto.RespFundingWallet{
+		Address:         designated.Address,
+		AccountID:       designated.AccountID,
+		StartingBalance: activation.StartingBalance,
+		Sponsored:       activation.Sponsored,
+		DailyCap:        activation.DailyCap,
+		ActivatedToday:  activated,
+	}, nil
+}
+
+// activationTx builds the transaction from the funding wallet creating the account of the address,
+// which is wrapped by the sponsoring of the future reserves when sponsored.
+func (svc *service) activationTx(
+	c context.Context,
+	funding *dto.RespFundingWallet,
+	address string,
+) (*txnbuild.GenericTransaction, error) {
+	_, err := svc.stellar.AccountDetail(c, horizonclient.AccountRequest{AccountID: address})
+	if err == nil {
+		return nil, errorx.BadRequest(fmt.Sprintf("the wallet: %s is activated already", address))
+	}
+	if !horizonclient.IsNotFoundError(err) {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query wallet: %s, err: %s", address, err.Error()))
+	}
+
+	funder, err := svc.stellar.AccountDetail(c, horizonclient.AccountRequest{AccountID: funding.Address})
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to query funding wallet: %s, err: %s", funding.Address, err.Error()))
+	}
+
+	operations := []txnbuild.Operation{
+		&txnbuild.CreateAccount{Destination: address, Amount: funding.StartingBalance},
+	}
+	if funding.Sponsored {
+		operations = []txnbuild.Operation{
+			&txnbuild.BeginSponsoringFutureReserves{SponsoredID: address},
+			operations[0],
+			&txnbuild.EndSponsoringFutureReserves{SourceAccount: address},
+		}
+	}
+
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &funder,
+		IncrementSequenceNum: true,
+		Operations:           operations,
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewTimeout(activationTxTimeout)},
+	})
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to build activation transaction: %s", err.Error()))
+	}
+
+	return txnbuild.NewGenericTransactionWithTransaction(tx), nil
+}
+
+// submitActivation builds the activation transaction of the address, signs it by the funding wallet,
+// along with the address when sponsored, and submits it to Horizon.
+func (svc *service) submitActivation(
+	c context.Context,
+	funding *dto.RespFundingWallet,
+	address string,
+) (*dto.RespSubmitTransaction, error) {
+	tx, err := svc.activationTx(c, funding, address)
+	if err != nil {
+		return nil, err
+	}
+
+	csToken, err := svc.csService.CubeSignerToken(c)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := []string{funding.Address}
+	if funding.Sponsored {
+		signers = append(signers, address)
+	}
+	envelope, err := svc.signTx(c, csToken, tx, signers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.submitTx(c, envelope)
+}
@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/57blocks/auto-action/server/internal/dto"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/pkg/util"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/txnbuild"
+	"github.com/stellar/go/xdr"
+)
+
+// txSource returns the account paying for the transaction, the fee account of the fee bump one.
+func txSource(tx *txnbuild.GenericTransaction) string {
+	if inner, ok := tx.Transaction(); ok {
+		return inner.SourceAccount().AccountID
+	}
+	if feeBump, ok := tx.FeeBump(); ok {
+		return feeBump.FeeAccount()
+	}
+
+	return ""
+}
+
+// signTx signs the hash of the transaction by the CubeSigner keys of the addresses,
+// and returns the envelope along with the signatures.
+func (svc *service) signTx(c context.Context, csToken string, tx *txnbuild.GenericTransaction, addresses ...string) (string, error) {
+	hash, err := tx.Hash(svc.stellar.NetworkPassphrase())
+	if err != nil {
+		return "", errorx.BadRequest(fmt.Sprintf("failed to hash transaction: %s", err.Error()))
+	}
+
+	decorated := make([]xdr.DecoratedSignature, 0, len(addresses))
+	for _, address := range addresses {
+		kp, err := keypair.ParseAddress(address)
+		if err != nil {
+			return "", errorx.BadRequest(fmt.Sprintf("invalid wallet address: %s", address))
+		}
+
+		signature, err := svc.resty.SignCSBlob(c, csToken, util.GetCSKeyFromAddress(address), hash[:])
+		if err != nil {
+			return "", err
+		}
+		if err := kp.Verify(hash[:], signature); err != nil {
+			return "", errorx.Internal(fmt.Sprintf("invalid signature of the wallet: %s", address))
+		}
+
+		decorated = append(decorated, xdr.DecoratedSignature{
+			Hint:      xdr.SignatureHint(kp.Hint()),
+			Signature: xdr.Signature(signature),
+		})
+	}
+
+	var envelope string
+	if inner, ok := tx.Transaction(); ok {
+		if inner, err = inner.AddSignatureDecorated(decorated...); err == nil {
+			envelope, err = inner.Base64()
+		}
+	} else if feeBump, ok := tx.FeeBump(); ok {
+		if feeBump, err = feeBump.AddSignatureDecorated(decorated...); err == nil {
+			envelope, err = feeBump.Base64()
+		}
+	}
+	if err != nil {
+		return "", errorx.Internal(fmt.Sprintf("failed to sign transaction: %s", err.Error()))
+	}
+
+	return envelope, nil
+}
+
+// submitTx submits the envelope to Horizon, the result codes are returned when it's rejected.
+func (svc *service) submitTx(c context.Context, envelope string) (*dto.RespSubmitTransaction, error) {
+	tx, err := svc.stellar.SubmitTransactionXDR(c, envelope)
+	if err == nil {
+		return &dto.RespSubmitTransaction{
+			Hash:       tx.Hash,
+			Successful: tx.Successful,
+			Ledger:     tx.Ledger,
+		}, nil
+	}
+
+	hErr := horizonclient.GetError(err)
+	if hErr == nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to submit transaction: %s", err.Error()))
+	}
+
+	codes, cErr := hErr.ResultCodes()
+	if cErr != nil {
+		return nil, errorx.Internal(fmt.Sprintf("failed to submit transaction: %s, %s", hErr.Problem.Title, hErr.Problem.Detail))
+	}
+
+	hash, _ := hErr.Problem.Extras["hash"].(string)
+	logx.Logger.ERROR(fmt.Sprintf("transaction %s rejected: %s", hash, codes.TransactionCode))
+
+	return &dto.RespSubmitTransaction{
+		Hash:        hash,
+		Successful:  false,
+		Transaction: codes.TransactionCode,
+		Operations:  codes.OperationCodes,
+	}, nil
+}
@@ -15,6 +15,12 @@ type (
 		Remove(c *gin.Context)
 		List(c *gin.Context)
 		Verify(c *gin.Context)
+		Info(c *gin.Context)
+		Fund(c *gin.Context)
+		Funding(c *gin.Context)
+		SetFunding(c *gin.Context)
+		Activate(c *gin.Context)
+		Submit(c *gin.Context)
 	}
 	resource struct {
 		service WalletService
@@ -62,7 +68,15 @@ func (re *resource) Remove(c *gin.Context) {
 }
 
 func (re *resource) List(c *gin.Context) {
-	resp, err := re.service.List(c)
+	req := new(dto.ReqListWallets)
+
+	if err := c.ShouldBindQuery(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.List(c, req)
 	if err != nil {
 		c.Error(err)
 		c.Abort()
@@ -87,3 +101,115 @@ func (re *resource) Verify(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, resp)
 }
+
+func (re *resource) Info(c *gin.Context) {
+	req := new(dto.ReqWalletInfo)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Info(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Fund(c *gin.Context) {
+	req := new(dto.ReqFundWallet)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Fund(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Funding(c *gin.Context) {
+	resp, err := re.service.Funding(c)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) SetFunding(c *gin.Context) {
+	req := new(dto.ReqSetFundingWallet)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.SetFunding(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Activate(c *gin.Context) {
+	req := new(dto.ReqActivateWallet)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Activate(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (re *resource) Submit(c *gin.Context) {
+	req := new(dto.ReqSubmitTransaction)
+
+	if err := c.BindUri(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.Error(errorx.BadRequest(err.Error()))
+		c.Abort()
+		return
+	}
+
+	resp, err := re.service.Submit(c, req)
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
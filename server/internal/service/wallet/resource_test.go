@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -143,7 +144,7 @@ func TestResourceListSuccess(t *testing.T) {
 			},
 		},
 	}
-	mockService.EXPECT().List(ctx).Return(mockResp, nil)
+	mockService.EXPECT().List(ctx, gomock.Any()).Return(mockResp, nil)
 
 	cd := &resource{
 		service: mockService,
@@ -173,7 +174,7 @@ func TestResourceListServiceError(t *testing.T) {
 
 	mockService := testdata.NewMockWalletService(ctrl)
 
-	mockService.EXPECT().List(ctx).Return(nil, errors.New("error"))
+	mockService.EXPECT().List(ctx, gomock.Any()).Return(nil, errors.New("error"))
 
 	cd := &resource{
 		service: mockService,
@@ -234,3 +235,167 @@ func TestResourceVerifyServiceError(t *testing.T) {
 	assert.NotNil(t, ctx.Errors)
 	assert.Equal(t, "error", ctx.Errors.Last().Error())
 }
+
+func TestResourceSubmitSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("POST", "/wallet/test/transactions", bytes.NewBufferString(`{"xdr":"test_envelope"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "address", Value: "test"}}
+
+	mockService := testdata.NewMockWalletService(ctrl)
+
+	mockResp := &dto.RespSubmitTransaction{Hash: "test_hash", Successful: true, Ledger: 1}
+	mockService.EXPECT().Submit(ctx, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, r *dto.ReqSubmitTransaction) (*dto.RespSubmitTransaction, error) {
+			assert.Equal(t, "test", r.Address)
+			assert.Equal(t, "test_envelope", r.XDR)
+			return mockResp, nil
+		})
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Submit(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+
+	resp := &dto.RespSubmitTransaction{}
+	err := json.Unmarshal(w.Body.Bytes(), resp)
+	assert.Nil(t, err)
+	assert.Equal(t, mockResp, resp)
+}
+
+func TestResourceSubmitInvalidBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("POST", "/wallet/test/transactions", bytes.NewBufferString(`{"xdr":`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "address", Value: "test"}}
+
+	cd := &resource{
+		service: testdata.NewMockWalletService(ctrl),
+	}
+
+	cd.Submit(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
+
+func TestResourceInfoSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("GET", "/wallet/test", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "address", Value: "test"}}
+
+	mockService := testdata.NewMockWalletService(ctrl)
+
+	mockResp := &dto.RespWalletInfo{
+		Address:   "test",
+		Activated: true,
+		Balances:  []*dto.RespWalletBalance{{AssetType: "native", Balance: "1.0000000"}},
+		Signers:   []*dto.RespWalletSigner{},
+	}
+	mockService.EXPECT().Info(ctx, &dto.ReqWalletInfo{Address: "test"}).Return(mockResp, nil)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Info(ctx)
+
+	assert.Equal(t, http.StatusOK, ctx.Writer.Status())
+	assert.Nil(t, ctx.Errors)
+
+	resp := &dto.RespWalletInfo{}
+	err := json.Unmarshal(w.Body.Bytes(), resp)
+	assert.Nil(t, err)
+	assert.Equal(t, mockResp, resp)
+}
+
+func TestResourceFundServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("POST", "/wallet/test/fund", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "address", Value: "test"}}
+
+	mockService := testdata.NewMockWalletService(ctrl)
+	mockService.EXPECT().Fund(ctx, &dto.ReqFundWallet{Address: "test"}).Return(nil, errors.New("error"))
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Fund(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "error", ctx.Errors.Last().Error())
+}
+
+func TestResourceActivateServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("POST", "/wallet/test/activate", nil)
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+	ctx.Params = gin.Params{{Key: "address", Value: "test"}}
+
+	mockService := testdata.NewMockWalletService(ctrl)
+	mockService.EXPECT().Activate(ctx, &dto.ReqActivateWallet{Address: "test"}).Return(nil, errors.New("error"))
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.Activate(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+	assert.Equal(t, "error", ctx.Errors.Last().Error())
+}
+
+func TestResourceSetFundingInvalidBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := httptest.NewRequest("PUT", "/wallet/funding", bytes.NewBufferString("{"))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(w)
+	ctx.Request = req
+
+	mockService := testdata.NewMockWalletService(ctrl)
+
+	cd := &resource{
+		service: mockService,
+	}
+
+	cd.SetFunding(ctx)
+
+	assert.NotNil(t, ctx.Errors)
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/txnbuild"
 )
 
 //go:generate mockgen -destination ../../testdata/wallet_service_mock.go -package testdata -source service.go Service
@@ -26,15 +27,22 @@ type (
 	WalletService interface {
 		Create(c context.Context) (*dto.RespCreateWallet, error)
 		Remove(c context.Context, r *dto.ReqRemoveWallet) error
-		List(c context.Context) (*dto.RespListWallets, error)
+		List(c context.Context, r *dto.ReqListWallets) (*dto.RespListWallets, error)
 		Verify(c context.Context, r *dto.ReqVerifyWallet) (*dto.RespVerifyWallet, error)
+		Info(c context.Context, r *dto.ReqWalletInfo) (*dto.RespWalletInfo, error)
+		Fund(c context.Context, r *dto.ReqFundWallet) (*dto.RespFundWallet, error)
+		Funding(c context.Context) (*dto.RespFundingWallet, error)
+		SetFunding(c context.Context, r *dto.ReqSetFundingWallet) (*dto.RespFundingWallet, error)
+		Activate(c context.Context, r *dto.ReqActivateWallet) (*dto.RespActivateWallet, error)
+		Submit(c context.Context, r *dto.ReqSubmitTransaction) (*dto.RespSubmitTransaction, error)
 	}
 	service struct {
-		oauthRepo repo.OAuth
-		csRepo    repo.CubeSigner
-		resty     restyx.Resty
-		csService svcCS.CSservice
-		stellar   stellarx.Stellar
+		oauthRepo  repo.OAuth
+		csRepo     repo.CubeSigner
+		walletRepo repo.Wallet
+		resty      restyx.Resty
+		csService  svcCS.CSservice
+		stellar    stellarx.Stellar
 	}
 )
 
@@ -44,13 +52,15 @@ func NewWalletService() {
 	if WalletServiceImpl == nil {
 		repo.NewOAuth()
 		repo.NewCubeSigner()
+		repo.NewWallet()
 
 		WalletServiceImpl = &service{
-			oauthRepo: repo.OAuthRepo,
-			csRepo:    repo.CubeSignerRepo,
-			resty:     restyx.Conductor,
-			csService: svcCS.CSserviceImpl,
-			stellar:   stellarx.Conductor,
+			oauthRepo:  repo.OAuthRepo,
+			csRepo:     repo.CubeSignerRepo,
+			walletRepo: repo.WalletRepo,
+			resty:      restyx.Conductor,
+			csService:  svcCS.CSserviceImpl,
+			stellar:    stellarx.Conductor,
 		}
 	}
 }
@@ -171,7 +181,7 @@ func (svc *service) Remove(c context.Context, r *dto.ReqRemoveWallet) error {
 	return nil
 }
 
-func (svc *service) List(c context.Context) (*dto.RespListWallets, error) {
+func (svc *service) List(c context.Context, r *dto.ReqListWallets) (*dto.RespListWallets, error) {
 	ctx, ok := c.(*gin.Context)
 	if !ok {
 		return nil, errorx.GinContextConv()
@@ -205,6 +215,17 @@ func (svc *service) List(c context.Context) (*dto.RespListWallets, error) {
 		response.Data[i] = dto.RespListWallet{
 			Address: address,
 		}
+
+		if r.Balances {
+			info, err := svc.accountInfo(c, address)
+			if err != nil {
+				logx.Logger.ERROR(fmt.Sprintf("query wallet address %s occurred error: %s", address, err.Error()))
+				continue
+			}
+
+			response.Data[i].Activated = &info.Activated
+			response.Data[i].Balances = info.Balances
+		}
 	}
 
 	return response, nil
@@ -250,3 +271,278 @@ func (svc *service) Verify(c context.Context, r *dto.ReqVerifyWallet) (*dto.Resp
 		IsValid: true,
 	}, nil
 }
+
+// Info returns the state of the wallet on the network, an address not activated yet is reported without the state.
+func (svc *service) Info(c context.Context, r *dto.ReqWalletInfo) (*dto.RespWalletInfo, error) {
+	ctx, ok := c.(*gin.Context)
+	if !ok {
+		return nil, errorx.GinContextConv()
+	}
+
+	jwtOrg, _ := ctx.Get(constant.ClaimIss.Str())
+	jwtAccount, _ := ctx.Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keyId := util.GetCSKeyFromAddress(r.Address)
+	_, err = svc.csRepo.FindCSKey(c, keyId, user.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "cube signer key not found") {
+			return nil, errorx.Internal(fmt.Sprintf("no existed wallet address found: %s", r.Address))
+		}
+		return nil, err
+	}
+
+	return svc.accountInfo(c, r.Address)
+}
+
+// Fund funds the wallet by the Friendbot, which is refused on the mainnet, and waits for the account on Horizon.
+func (svc *service) Fund(c context.Context, r *dto.ReqFundWallet) (*dto.RespFundWallet, error) {
+	if config.GlobalConfig.Bound.Name == string(constant.StellarNetworkTypeMainNet) {
+		return nil, errorx.BadRequest("the friendbot is not available on the mainnet")
+	}
+	if config.GlobalConfig.Wallet.Friendbot.URL == "" {
+		return nil, errorx.BadRequest("the friendbot is not configured")
+	}
+
+	ctx, ok := c.(*gin.Context)
+	if !ok {
+		return nil, errorx.GinContextConv()
+	}
+
+	jwtOrg, _ := ctx.Get(constant.ClaimIss.Str())
+	jwtAccount, _ := ctx.Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keyId := util.GetCSKeyFromAddress(r.Address)
+	_, err = svc.csRepo.FindCSKey(c, keyId, user.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "cube signer key not found") {
+			return nil, errorx.Internal(fmt.Sprintf("no existed wallet address found: %s", r.Address))
+		}
+		return nil, err
+	}
+
+	if err := svc.resty.FundByFriendbot(c, r.Address); err != nil {
+		return nil, err
+	}
+
+	account, err := svc.awaitAccount(c, r.Address, config.GlobalConfig.Wallet.Friendbot.PollInterval,
+		config.GlobalConfig.Wallet.Friendbot.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RespFundWallet{
+		Address: r.Address,
+		Balance: nativeBalance(account),
+	}, nil
+}
+
+// Funding returns the funding wallet designated by the organization, along with the activations of today.
+func (svc *service) Funding(c context.Context) (*dto.RespFundingWallet, error) {
+	ctx, ok := c.(*gin.Context)
+	if !ok {
+		return nil, errorx.GinContextConv()
+	}
+
+	jwtOrg, _ := ctx.Get(constant.ClaimIss.Str())
+	jwtAccount, _ := ctx.Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.funding(c, uint64(user.OrganizationId))
+}
+
+// SetFunding designates the wallet of the account as the funding wallet of the organization, the empty address clears it.
+// The funding wallet designated by another account can't be replaced or cleared.
+func (svc *service) SetFunding(c context.Context, r *dto.ReqSetFundingWallet) (*dto.RespFundingWallet, error) {
+	ctx, ok := c.(*gin.Context)
+	if !ok {
+		return nil, errorx.GinContextConv()
+	}
+
+	jwtOrg, _ := ctx.Get(constant.ClaimIss.Str())
+	jwtAccount, _ := ctx.Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Address != "" {
+		keyId := util.GetCSKeyFromAddress(r.Address)
+		_, err = svc.csRepo.FindCSKey(c, keyId, user.ID)
+		if err != nil {
+			if strings.Contains(err.Error(), "cube signer key not found") {
+				return nil, errorx.Internal(fmt.Sprintf("no existed wallet address found: %s", r.Address))
+			}
+			return nil, err
+		}
+	}
+
+	if err := svc.walletRepo.SetFundingWallet(c, uint64(user.OrganizationId), user.ID, r.Address); err != nil {
+		return nil, err
+	}
+
+	return svc.funding(c, uint64(user.OrganizationId))
+}
+
+// Activate activates the wallet by the funding wallet of the organization, which sends the starting balance to it,
+// and sponsors the base reserves of it when configured.
+func (svc *service) Activate(c context.Context, r *dto.ReqActivateWallet) (*dto.RespActivateWallet, error) {
+	ctx, ok := c.(*gin.Context)
+	if !ok {
+		return nil, errorx.GinContextConv()
+	}
+
+	jwtOrg, _ := ctx.Get(constant.ClaimIss.Str())
+	jwtAccount, _ := ctx.Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keyId := util.GetCSKeyFromAddress(r.Address)
+	_, err = svc.csRepo.FindCSKey(c, keyId, user.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "cube signer key not found") {
+			return nil, errorx.Internal(fmt.Sprintf("no existed wallet address found: %s", r.Address))
+		}
+		return nil, err
+	}
+
+	orgID := uint64(user.OrganizationId)
+	funding, err := svc.funding(c, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if funding.Address == "" {
+		return nil, errorx.BadRequest(fmt.Sprintf("none funding wallet designated by the organization: %s", jwtOrg.(string)))
+	}
+	if funding.Address == r.Address {
+		return nil, errorx.BadRequest("the funding wallet can't activate itself")
+	}
+	// the funding wallet is only spent while it's owned by the account designated it
+	if _, err := svc.csRepo.FindCSKey(c, util.GetCSKeyFromAddress(funding.Address), funding.AccountID); err != nil {
+		if strings.Contains(err.Error(), "cube signer key not found") {
+			return nil, errorx.BadRequest(fmt.Sprintf("the funding wallet: %s is not owned by the organization", funding.Address))
+		}
+		return nil, err
+	}
+
+	// the slot under the daily cap is reserved before signing, and released once the activation failed
+	activation := &model.WalletActivation{
+		OrganizationID:  orgID,
+		AccountID:       user.ID,
+		Address:         r.Address,
+		Funder:          funding.Address,
+		StartingBalance: funding.StartingBalance,
+		Sponsored:       funding.Sponsored,
+	}
+	if err := svc.walletRepo.ReserveActivation(c, activation, activationDay(), funding.DailyCap); err != nil {
+		return nil, err
+	}
+
+	submitted, err := svc.submitActivation(c, funding, r.Address)
+	if err != nil || !submitted.Successful {
+		if err := svc.walletRepo.ReleaseActivation(c, activation.ID); err != nil {
+			logx.Logger.ERROR(fmt.Sprintf("release activation of wallet %s occurred error: %s", r.Address, err.Error()))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if submitted.Successful {
+		if err := svc.walletRepo.CompleteActivation(c, activation.ID, submitted.Hash); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.RespActivateWallet{
+		RespSubmitTransaction: *submitted,
+		Address:               r.Address,
+		Funder:                funding.Address,
+		StartingBalance:       funding.StartingBalance,
+		Sponsored:             funding.Sponsored,
+	}, nil
+}
+
+// Submit signs the transaction by the wallet, which must be the source of it, and submits it to Horizon.
+func (svc *service) Submit(c context.Context, r *dto.ReqSubmitTransaction) (*dto.RespSubmitTransaction, error) {
+	if r.XDR == "" {
+		return nil, errorx.BadRequest("the transaction envelope is required")
+	}
+
+	ctx, ok := c.(*gin.Context)
+	if !ok {
+		return nil, errorx.GinContextConv()
+	}
+
+	jwtOrg, _ := ctx.Get(constant.ClaimIss.Str())
+	jwtAccount, _ := ctx.Get(constant.ClaimSub.Str())
+
+	user, err := svc.oauthRepo.FindUserByOrgAcn(c, &dto.ReqOrgAcn{
+		OrgName: jwtOrg.(string),
+		AcnName: jwtAccount.(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keyId := util.GetCSKeyFromAddress(r.Address)
+	_, err = svc.csRepo.FindCSKey(c, keyId, user.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "cube signer key not found") {
+			return nil, errorx.Internal(fmt.Sprintf("no existed wallet address found: %s", r.Address))
+		}
+		return nil, err
+	}
+
+	tx, err := txnbuild.TransactionFromXDR(r.XDR)
+	if err != nil {
+		return nil, errorx.BadRequest(fmt.Sprintf("invalid transaction envelope: %s", err.Error()))
+	}
+	if source := txSource(tx); source != r.Address {
+		return nil, errorx.BadRequest(fmt.Sprintf("the source account of the transaction: %s is not the wallet: %s", source, r.Address))
+	}
+
+	csToken, err := svc.csService.CubeSignerToken(c)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := svc.signTx(c, csToken, tx, r.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.submitTx(c, envelope)
+}
@@ -3,17 +3,25 @@ package wallet
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/57blocks/auto-action/server/internal/config"
 	"github.com/57blocks/auto-action/server/internal/constant"
 	"github.com/57blocks/auto-action/server/internal/dto"
 	"github.com/57blocks/auto-action/server/internal/model"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
 	"github.com/57blocks/auto-action/server/internal/testdata"
 	"github.com/57blocks/auto-action/server/internal/third-party/logx"
 	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
 	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/txnbuild"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -784,7 +792,7 @@ func TestListSuccess(t *testing.T) {
 		csRepo:    mockCSRepo,
 	}
 
-	wallets, err := svc.List(ctx)
+	wallets, err := svc.List(ctx, &dto.ReqListWallets{})
 	assert.NoError(t, err)
 	assert.Equal(t, &dto.RespListWallets{
 		Data: []dto.RespListWallet{
@@ -814,7 +822,7 @@ func TestListFindUserError(t *testing.T) {
 		oauthRepo: mockOAuthRepo,
 	}
 
-	wallets, err := svc.List(ctx)
+	wallets, err := svc.List(ctx, &dto.ReqListWallets{})
 	assert.Error(t, err)
 	assert.Equal(t, "user not found", err.Error())
 	assert.Nil(t, wallets)
@@ -846,7 +854,7 @@ func TestListFindCSKeysError(t *testing.T) {
 		csRepo:    mockCSRepo,
 	}
 
-	wallets, err := svc.List(ctx)
+	wallets, err := svc.List(ctx, &dto.ReqListWallets{})
 	assert.Error(t, err)
 	assert.Equal(t, "find cs keys error", err.Error())
 	assert.Nil(t, wallets)
@@ -1009,3 +1017,680 @@ func TestVerifyAccountDetailError(t *testing.T) {
 		IsValid: false,
 	}, wallet)
 }
+
+func testTransaction(t *testing.T, source string) string {
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source, Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations: []txnbuild.Operation{&txnbuild.Payment{
+			Destination: keypair.MustRandom().Address(),
+			Amount:      "1",
+			Asset:       txnbuild.NativeAsset{},
+		}},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	assert.NoError(t, err)
+
+	envelope, err := tx.Base64()
+	assert.NoError(t, err)
+
+	return envelope
+}
+
+func TestSubmitSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	kp := keypair.MustRandom()
+	testKeyId := "Key#Stellar_" + kp.Address()
+	csToken := "cs-token"
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockResty := testdata.NewMockResty(ctrl)
+	mockCS := testdata.NewMockCSservice(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockCS.EXPECT().CubeSignerToken(ctx).Times(1).
+		Return(csToken, nil)
+	mockStellar.EXPECT().NetworkPassphrase().Times(1).
+		Return(network.TestNetworkPassphrase)
+	mockResty.EXPECT().SignCSBlob(ctx, csToken, testKeyId, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _, _ string, message []byte) ([]byte, error) {
+			return kp.Sign(message)
+		})
+	mockStellar.EXPECT().SubmitTransactionXDR(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, envelope string) (horizon.Transaction, error) {
+			tx, err := txnbuild.TransactionFromXDR(envelope)
+			assert.NoError(t, err)
+			inner, ok := tx.Transaction()
+			assert.True(t, ok)
+			assert.Len(t, inner.Signatures(), 1)
+			return horizon.Transaction{Hash: "test_hash", Successful: true, Ledger: 7}, nil
+		})
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		csRepo:    mockCSRepo,
+		resty:     mockResty,
+		csService: mockCS,
+		stellar:   mockStellar,
+	}
+
+	resp, err := svc.Submit(ctx, &dto.ReqSubmitTransaction{Address: kp.Address(), XDR: testTransaction(t, kp.Address())})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespSubmitTransaction{Hash: "test_hash", Successful: true, Ledger: 7}, resp)
+}
+
+func TestSubmitMissingXDR(t *testing.T) {
+	svc := &service{}
+
+	_, err := svc.Submit(new(gin.Context), &dto.ReqSubmitTransaction{Address: "test-key"})
+	assert.EqualError(t, err, "the transaction envelope is required")
+}
+
+func TestSubmitSourceMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	kp := keypair.MustRandom()
+	other := keypair.MustRandom().Address()
+	testKeyId := "Key#Stellar_" + kp.Address()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		csRepo:    mockCSRepo,
+	}
+
+	_, err := svc.Submit(ctx, &dto.ReqSubmitTransaction{Address: kp.Address(), XDR: testTransaction(t, other)})
+	assert.EqualError(t, err, fmt.Sprintf("the source account of the transaction: %s is not the wallet: %s", other, kp.Address()))
+}
+
+func TestSubmitRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	kp := keypair.MustRandom()
+	testKeyId := "Key#Stellar_" + kp.Address()
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockResty := testdata.NewMockResty(ctrl)
+	mockCS := testdata.NewMockCSservice(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockCS.EXPECT().CubeSignerToken(ctx).Times(1).
+		Return("cs-token", nil)
+	mockStellar.EXPECT().NetworkPassphrase().Times(1).
+		Return(network.TestNetworkPassphrase)
+	mockResty.EXPECT().SignCSBlob(ctx, "cs-token", testKeyId, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _, _ string, message []byte) ([]byte, error) {
+			return kp.Sign(message)
+		})
+	mockStellar.EXPECT().SubmitTransactionXDR(ctx, gomock.Any()).Times(1).
+		Return(horizon.Transaction{}, &horizonclient.Error{Problem: problem.P{
+			Title: "Transaction Failed",
+			Extras: map[string]interface{}{
+				"hash": "test_hash",
+				"result_codes": map[string]interface{}{
+					"transaction": "tx_failed",
+					"operations":  []interface{}{"op_underfunded"},
+				},
+			},
+		}})
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		csRepo:    mockCSRepo,
+		resty:     mockResty,
+		csService: mockCS,
+		stellar:   mockStellar,
+	}
+
+	resp, err := svc.Submit(ctx, &dto.ReqSubmitTransaction{Address: kp.Address(), XDR: testTransaction(t, kp.Address())})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespSubmitTransaction{
+		Hash:        "test_hash",
+		Transaction: "tx_failed",
+		Operations:  []string{"op_underfunded"},
+	}, resp)
+}
+
+func TestInfoSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	testKeyId := "Key#Stellar_test-key"
+	testAddress := "test-key"
+	authorized := true
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockStellar.EXPECT().CachedAccountDetail(ctx, testAddress).Times(1).
+		Return(horizon.Account{
+			AccountID:     testAddress,
+			Sequence:      42,
+			SubentryCount: 1,
+			Thresholds:    horizon.AccountThresholds{LowThreshold: 1, MedThreshold: 2, HighThreshold: 3},
+			Balances: []horizon.Balance{
+				{Balance: "9.5000000", Asset: base.Asset{Type: "native"}},
+				{
+					Balance:      "10.0000000",
+					Limit:        "100.0000000",
+					IsAuthorized: &authorized,
+					Asset:        base.Asset{Type: "credit_alphanum4", Code: "USDC", Issuer: "test-issuer"},
+				},
+			},
+			Signers: []horizon.Signer{{Key: testAddress, Type: "ed25519_public_key", Weight: 1}},
+		}, nil)
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		csRepo:    mockCSRepo,
+		stellar:   mockStellar,
+	}
+
+	info, err := svc.Info(ctx, &dto.ReqWalletInfo{Address: testAddress})
+	assert.NoError(t, err)
+	assert.True(t, info.Activated)
+	assert.Equal(t, "42", info.Sequence)
+	assert.Equal(t, "1.5000000", info.MinimumReserve)
+	assert.Equal(t, dto.RespWalletThresholds{Low: 1, Medium: 2, High: 3}, info.Thresholds)
+	assert.Len(t, info.Balances, 2)
+	assert.Equal(t, &dto.RespWalletBalance{
+		AssetType:    "credit_alphanum4",
+		AssetCode:    "USDC",
+		AssetIssuer:  "test-issuer",
+		Balance:      "10.0000000",
+		Limit:        "100.0000000",
+		IsAuthorized: &authorized,
+	}, info.Balances[1])
+	assert.Equal(t, []*dto.RespWalletSigner{{Key: testAddress, Type: "ed25519_public_key", Weight: 1}}, info.Signers)
+}
+
+func TestInfoNotActivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	testKeyId := "Key#Stellar_test-key"
+	testAddress := "test-key"
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockStellar.EXPECT().CachedAccountDetail(ctx, testAddress).Times(1).
+		Return(horizon.Account{}, &horizonclient.Error{Problem: problem.P{Type: "https://stellar.org/horizon-errors/not_found"}})
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		csRepo:    mockCSRepo,
+		stellar:   mockStellar,
+	}
+
+	info, err := svc.Info(ctx, &dto.ReqWalletInfo{Address: testAddress})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespWalletInfo{
+		Address:  testAddress,
+		Balances: []*dto.RespWalletBalance{},
+		Signers:  []*dto.RespWalletSigner{},
+	}, info)
+}
+
+func TestListWithBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockCSRepo.EXPECT().FindCSKeysByAccount(ctx, uint64(1)).Times(1).
+		Return([]*model.CubeSignerKey{{Key: "Key#Stellar_test-key1"}, {Key: "Key#Stellar_test-key2"}}, nil)
+	mockStellar.EXPECT().CachedAccountDetail(ctx, "test-key1").Times(1).
+		Return(horizon.Account{
+			AccountID: "test-key1",
+			Balances:  []horizon.Balance{{Balance: "9.5000000", Asset: base.Asset{Type: "native"}}},
+		}, nil)
+	mockStellar.EXPECT().CachedAccountDetail(ctx, "test-key2").Times(1).
+		Return(horizon.Account{}, &horizonclient.Error{Problem: problem.P{Type: "https://stellar.org/horizon-errors/not_found"}})
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		csRepo:    mockCSRepo,
+		stellar:   mockStellar,
+	}
+
+	activated, inactivated := true, false
+	wallets, err := svc.List(ctx, &dto.ReqListWallets{Balances: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespListWallets{
+		Data: []dto.RespListWallet{
+			{
+				Address:   "test-key1",
+				Activated: &activated,
+				Balances:  []*dto.RespWalletBalance{{AssetType: "native", Balance: "9.5000000"}},
+			},
+			{
+				Address:   "test-key2",
+				Activated: &inactivated,
+				Balances:  []*dto.RespWalletBalance{},
+			},
+		},
+	}, wallets)
+}
+
+func TestFundSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	interval := config.GlobalConfig.Wallet.Friendbot.PollInterval
+	config.GlobalConfig.Wallet.Friendbot.PollInterval = time.Millisecond
+	defer func() { config.GlobalConfig.Wallet.Friendbot.PollInterval = interval }()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	testKeyId := "Key#Stellar_test-key"
+	testAddress := "test-key"
+	req := horizonclient.AccountRequest{AccountID: testAddress}
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockResty := testdata.NewMockResty(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockResty.EXPECT().FundByFriendbot(ctx, testAddress).Times(1).
+		Return(nil)
+	gomock.InOrder(
+		mockStellar.EXPECT().AccountDetail(ctx, req).Times(1).
+			Return(horizon.Account{}, &horizonclient.Error{Problem: problem.P{Type: "https://stellar.org/horizon-errors/not_found"}}),
+		mockStellar.EXPECT().AccountDetail(ctx, req).Times(1).
+			Return(horizon.Account{
+				AccountID: testAddress,
+				Balances:  []horizon.Balance{{Balance: "10000.0000000", Asset: base.Asset{Type: "native"}}},
+			}, nil),
+	)
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		csRepo:    mockCSRepo,
+		resty:     mockResty,
+		stellar:   mockStellar,
+	}
+
+	resp, err := svc.Fund(ctx, &dto.ReqFundWallet{Address: testAddress})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespFundWallet{Address: testAddress, Balance: "10000.0000000"}, resp)
+}
+
+func TestFundMainnetRefused(t *testing.T) {
+	name := config.GlobalConfig.Bound.Name
+	config.GlobalConfig.Bound.Name = string(constant.StellarNetworkTypeMainNet)
+	defer func() { config.GlobalConfig.Bound.Name = name }()
+
+	svc := &service{}
+
+	_, err := svc.Fund(new(gin.Context), &dto.ReqFundWallet{Address: "test-key"})
+	assert.EqualError(t, err, "the friendbot is not available on the mainnet")
+}
+
+func TestFundAccountNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	friendbot := config.GlobalConfig.Wallet.Friendbot
+	config.GlobalConfig.Wallet.Friendbot.PollInterval = time.Millisecond
+	config.GlobalConfig.Wallet.Friendbot.Timeout = 5 * time.Millisecond
+	defer func() { config.GlobalConfig.Wallet.Friendbot = friendbot }()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	testKeyId := "Key#Stellar_test-key"
+	testAddress := "test-key"
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockResty := testdata.NewMockResty(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockResty.EXPECT().FundByFriendbot(ctx, testAddress).Times(1).
+		Return(nil)
+	mockStellar.EXPECT().AccountDetail(ctx, horizonclient.AccountRequest{AccountID: testAddress}).MinTimes(1).
+		Return(horizon.Account{}, &horizonclient.Error{Problem: problem.P{Type: "https://stellar.org/horizon-errors/not_found"}})
+
+	svc := &service{
+		oauthRepo: mockOAuthRepo,
+		csRepo:    mockCSRepo,
+		resty:     mockResty,
+		stellar:   mockStellar,
+	}
+
+	_, err := svc.Fund(ctx, &dto.ReqFundWallet{Address: testAddress})
+	assert.EqualError(t, err, "the account of the wallet: test-key is not found in 5ms")
+}
+
+func TestActivateSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	activation := config.GlobalConfig.Wallet.Activation
+	config.GlobalConfig.Wallet.Activation.Sponsored = true
+	defer func() { config.GlobalConfig.Wallet.Activation = activation }()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	kp := keypair.MustRandom()
+	funder := keypair.MustRandom()
+	testKeyId := "Key#Stellar_" + kp.Address()
+	funderKeyId := "Key#Stellar_" + funder.Address()
+	csToken := "cs-token"
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockWalletRepo := testdata.NewMockWallet(ctrl)
+	mockResty := testdata.NewMockResty(ctrl)
+	mockCS := testdata.NewMockCSservice(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1, OrganizationId: 2}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockWalletRepo.EXPECT().FundingWallet(ctx, uint64(2)).Times(1).
+		Return(&model.FundingWallet{Address: funder.Address(), AccountID: 3}, nil)
+	mockWalletRepo.EXPECT().CountActivations(ctx, uint64(2), gomock.Any()).Times(1).
+		Return(int64(0), nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, funderKeyId, uint64(3)).Times(1).
+		Return(&model.CubeSignerKey{Key: funderKeyId}, nil)
+	mockStellar.EXPECT().AccountDetail(ctx, horizonclient.AccountRequest{AccountID: kp.Address()}).Times(1).
+		Return(horizon.Account{}, &horizonclient.Error{Problem: problem.P{Type: "https://stellar.org/horizon-errors/not_found"}})
+	mockStellar.EXPECT().AccountDetail(ctx, horizonclient.AccountRequest{AccountID: funder.Address()}).Times(1).
+		Return(horizon.Account{AccountID: funder.Address(), Sequence: 1}, nil)
+	mockCS.EXPECT().CubeSignerToken(ctx).Times(1).
+		Return(csToken, nil)
+	mockStellar.EXPECT().NetworkPassphrase().Times(1).
+		Return(network.TestNetworkPassphrase)
+	mockResty.EXPECT().SignCSBlob(ctx, csToken, funderKeyId, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _, _ string, message []byte) ([]byte, error) {
+			return funder.Sign(message)
+		})
+	mockResty.EXPECT().SignCSBlob(ctx, csToken, testKeyId, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _, _ string, message []byte) ([]byte, error) {
+			return kp.Sign(message)
+		})
+	mockStellar.EXPECT().SubmitTransactionXDR(ctx, gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, envelope string) (horizon.Transaction, error) {
+			tx, err := txnbuild.TransactionFromXDR(envelope)
+			assert.NoError(t, err)
+			inner, ok := tx.Transaction()
+			assert.True(t, ok)
+			assert.Equal(t, funder.Address(), inner.SourceAccount().AccountID)
+			assert.Len(t, inner.Operations(), 3)
+			assert.Len(t, inner.Signatures(), 2)
+			return horizon.Transaction{Hash: "test_hash", Successful: true, Ledger: 7}, nil
+		})
+	mockWalletRepo.EXPECT().ReserveActivation(ctx, &model.WalletActivation{
+		OrganizationID:  2,
+		AccountID:       1,
+		Address:         kp.Address(),
+		Funder:          funder.Address(),
+		StartingBalance: activation.StartingBalance,
+		Sponsored:       true,
+	}, gomock.Any(), activation.DailyCap).Times(1).
+		DoAndReturn(func(_ context.Context, reserved *model.WalletActivation, _ time.Time, _ int) error {
+			reserved.ID = 5
+			return nil
+		})
+	mockWalletRepo.EXPECT().CompleteActivation(ctx, uint64(5), "test_hash").Times(1).Return(nil)
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		csRepo:     mockCSRepo,
+		walletRepo: mockWalletRepo,
+		resty:      mockResty,
+		csService:  mockCS,
+		stellar:    mockStellar,
+	}
+
+	resp, err := svc.Activate(ctx, &dto.ReqActivateWallet{Address: kp.Address()})
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RespActivateWallet{
+		RespSubmitTransaction: dto.RespSubmitTransaction{Hash: "test_hash", Successful: true, Ledger: 7},
+		Address:               kp.Address(),
+		Funder:                funder.Address(),
+		StartingBalance:       activation.StartingBalance,
+		Sponsored:             true,
+	}, resp)
+}
+
+func TestActivateNoFundingWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	testKeyId := "Key#Stellar_test-key"
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockWalletRepo := testdata.NewMockWallet(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1, OrganizationId: 2}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockWalletRepo.EXPECT().FundingWallet(ctx, uint64(2)).Times(1).
+		Return(&model.FundingWallet{}, nil)
+	mockWalletRepo.EXPECT().CountActivations(ctx, uint64(2), gomock.Any()).Times(1).
+		Return(int64(0), nil)
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		csRepo:     mockCSRepo,
+		walletRepo: mockWalletRepo,
+	}
+
+	_, err := svc.Activate(ctx, &dto.ReqActivateWallet{Address: "test-key"})
+	assert.EqualError(t, err, "none funding wallet designated by the organization: test-org")
+}
+
+func TestActivateDailyCapReached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	testKeyId := "Key#Stellar_test-key"
+	dailyCap := config.GlobalConfig.Wallet.Activation.DailyCap
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockWalletRepo := testdata.NewMockWallet(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1, OrganizationId: 2}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockWalletRepo.EXPECT().FundingWallet(ctx, uint64(2)).Times(1).
+		Return(&model.FundingWallet{Address: "test-funder", AccountID: 3}, nil)
+	mockWalletRepo.EXPECT().CountActivations(ctx, uint64(2), gomock.Any()).Times(1).
+		Return(int64(dailyCap), nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, "Key#Stellar_test-funder", uint64(3)).Times(1).
+		Return(&model.CubeSignerKey{Key: "Key#Stellar_test-funder"}, nil)
+	mockWalletRepo.EXPECT().ReserveActivation(ctx, gomock.Any(), gomock.Any(), dailyCap).Times(1).
+		Return(errorx.BadRequest(fmt.Sprintf("the number of wallet activations is limited to %d per day", dailyCap)))
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		csRepo:     mockCSRepo,
+		walletRepo: mockWalletRepo,
+	}
+
+	_, err := svc.Activate(ctx, &dto.ReqActivateWallet{Address: "test-key"})
+	assert.EqualError(t, err, fmt.Sprintf("the number of wallet activations is limited to %d per day", dailyCap))
+}
+
+func TestActivateFunderNotOwned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	testKeyId := "Key#Stellar_test-key"
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockWalletRepo := testdata.NewMockWallet(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1, OrganizationId: 2}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockWalletRepo.EXPECT().FundingWallet(ctx, uint64(2)).Times(1).
+		Return(&model.FundingWallet{Address: "test-funder", AccountID: 3}, nil)
+	mockWalletRepo.EXPECT().CountActivations(ctx, uint64(2), gomock.Any()).Times(1).
+		Return(int64(0), nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, "Key#Stellar_test-funder", uint64(3)).Times(1).
+		Return(nil, errorx.NotFound("cube signer key not found"))
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		csRepo:     mockCSRepo,
+		walletRepo: mockWalletRepo,
+	}
+
+	_, err := svc.Activate(ctx, &dto.ReqActivateWallet{Address: "test-key"})
+	assert.EqualError(t, err, "the funding wallet: test-funder is not owned by the organization")
+}
+
+func TestSetFundingDesignatedByOther(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockWalletRepo := testdata.NewMockWallet(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1, OrganizationId: 2}, nil)
+	mockWalletRepo.EXPECT().SetFundingWallet(ctx, uint64(2), uint64(1), "").Times(1).
+		Return(errorx.ForbiddenWithMsg("the funding wallet of the organization is designated by another account"))
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		walletRepo: mockWalletRepo,
+	}
+
+	_, err := svc.SetFunding(ctx, &dto.ReqSetFundingWallet{})
+	assert.EqualError(t, err, "the funding wallet of the organization is designated by another account")
+}
+
+func TestActivateReleasedOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := new(gin.Context)
+	ctx.Set(constant.ClaimIss.Str(), "test-org")
+	ctx.Set(constant.ClaimSub.Str(), "test-account")
+	testKeyId := "Key#Stellar_test-key"
+
+	mockOAuthRepo := testdata.NewMockOAuth(ctrl)
+	mockCSRepo := testdata.NewMockCubeSigner(ctrl)
+	mockWalletRepo := testdata.NewMockWallet(ctrl)
+	mockStellar := testdata.NewMockStellar(ctrl)
+
+	mockOAuthRepo.EXPECT().FindUserByOrgAcn(ctx, gomock.Any()).Times(1).
+		Return(&dto.RespUser{ID: 1, OrganizationId: 2}, nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, testKeyId, uint64(1)).Times(1).
+		Return(&model.CubeSignerKey{Key: testKeyId}, nil)
+	mockWalletRepo.EXPECT().FundingWallet(ctx, uint64(2)).Times(1).
+		Return(&model.FundingWallet{Address: "test-funder", AccountID: 3}, nil)
+	mockWalletRepo.EXPECT().CountActivations(ctx, uint64(2), gomock.Any()).Times(1).
+		Return(int64(0), nil)
+	mockCSRepo.EXPECT().FindCSKey(ctx, "Key#Stellar_test-funder", uint64(3)).Times(1).
+		Return(&model.CubeSignerKey{Key: "Key#Stellar_test-funder"}, nil)
+	mockWalletRepo.EXPECT().ReserveActivation(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, reserved *model.WalletActivation, _ time.Time, _ int) error {
+			reserved.ID = 5
+			return nil
+		})
+	// activated by others after the slot reserved
+	mockStellar.EXPECT().AccountDetail(ctx, horizonclient.AccountRequest{AccountID: "test-key"}).Times(1).
+		Return(horizon.Account{AccountID: "test-key"}, nil)
+	mockWalletRepo.EXPECT().ReleaseActivation(ctx, uint64(5)).Times(1).Return(nil)
+
+	svc := &service{
+		oauthRepo:  mockOAuthRepo,
+		csRepo:     mockCSRepo,
+		walletRepo: mockWalletRepo,
+		stellar:    mockStellar,
+	}
+
+	_, err := svc.Activate(ctx, &dto.ReqActivateWallet{Address: "test-key"})
+	assert.EqualError(t, err, "the wallet: test-key is activated already")
+}
@@ -13,6 +13,7 @@ import (
 	lambda "github.com/aws/aws-sdk-go-v2/service/lambda"
 	scheduler "github.com/aws/aws-sdk-go-v2/service/scheduler"
 	secretsmanager "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	sqs "github.com/aws/aws-sdk-go-v2/service/sqs"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -59,6 +60,21 @@ func (mr *MockAmazonMockRecorder) BoundScheduler(c, input interface{}, opts ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BoundScheduler", reflect.TypeOf((*MockAmazon)(nil).BoundScheduler), varargs...)
 }
 
+// CreateLambdaAlias mocks base method.
+func (m *MockAmazon) CreateLambdaAlias(c context.Context, input *lambda.CreateAliasInput) (*lambda.CreateAliasOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLambdaAlias", c, input)
+	ret0, _ := ret[0].(*lambda.CreateAliasOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLambdaAlias indicates an expected call of CreateLambdaAlias.
+func (mr *MockAmazonMockRecorder) CreateLambdaAlias(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLambdaAlias", reflect.TypeOf((*MockAmazon)(nil).CreateLambdaAlias), c, input)
+}
+
 // CreateRole mocks base method.
 func (m *MockAmazon) CreateRole(c context.Context, input *iam.CreateRoleInput) (*iam.CreateRoleOutput, error) {
 	m.ctrl.T.Helper()
@@ -89,6 +105,21 @@ func (mr *MockAmazonMockRecorder) CreateSecret(c, input interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecret", reflect.TypeOf((*MockAmazon)(nil).CreateSecret), c, input)
 }
 
+// DeleteMessageBatch mocks base method.
+func (m *MockAmazon) DeleteMessageBatch(c context.Context, input *sqs.DeleteMessageBatchInput) (*sqs.DeleteMessageBatchOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageBatch", c, input)
+	ret0, _ := ret[0].(*sqs.DeleteMessageBatchOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessageBatch indicates an expected call of DeleteMessageBatch.
+func (mr *MockAmazonMockRecorder) DeleteMessageBatch(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageBatch", reflect.TypeOf((*MockAmazon)(nil).DeleteMessageBatch), c, input)
+}
+
 // DescribeLogStreams mocks base method.
 func (m *MockAmazon) DescribeLogStreams(c context.Context, input *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
 	m.ctrl.T.Helper()
@@ -104,6 +135,36 @@ func (mr *MockAmazonMockRecorder) DescribeLogStreams(c, input interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLogStreams", reflect.TypeOf((*MockAmazon)(nil).DescribeLogStreams), c, input)
 }
 
+// FilterLogEvents mocks base method.
+func (m *MockAmazon) FilterLogEvents(c context.Context, input *cloudwatchlogs.FilterLogEventsInput) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FilterLogEvents", c, input)
+	ret0, _ := ret[0].(*cloudwatchlogs.FilterLogEventsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterLogEvents indicates an expected call of FilterLogEvents.
+func (mr *MockAmazonMockRecorder) FilterLogEvents(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterLogEvents", reflect.TypeOf((*MockAmazon)(nil).FilterLogEvents), c, input)
+}
+
+// GetLambdaEventInvokeConfig mocks base method.
+func (m *MockAmazon) GetLambdaEventInvokeConfig(c context.Context, input *lambda.GetFunctionEventInvokeConfigInput) (*lambda.GetFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLambdaEventInvokeConfig", c, input)
+	ret0, _ := ret[0].(*lambda.GetFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLambdaEventInvokeConfig indicates an expected call of GetLambdaEventInvokeConfig.
+func (mr *MockAmazonMockRecorder) GetLambdaEventInvokeConfig(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLambdaEventInvokeConfig", reflect.TypeOf((*MockAmazon)(nil).GetLambdaEventInvokeConfig), c, input)
+}
+
 // GetLogEvents mocks base method.
 func (m *MockAmazon) GetLogEvents(c context.Context, input *cloudwatchlogs.GetLogEventsInput) (*cloudwatchlogs.GetLogEventsOutput, error) {
 	m.ctrl.T.Helper()
@@ -179,6 +240,36 @@ func (mr *MockAmazonMockRecorder) InvokeLambda(c, input interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvokeLambda", reflect.TypeOf((*MockAmazon)(nil).InvokeLambda), c, input)
 }
 
+// PublishLambdaVersion mocks base method.
+func (m *MockAmazon) PublishLambdaVersion(c context.Context, input *lambda.PublishVersionInput) (*lambda.PublishVersionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishLambdaVersion", c, input)
+	ret0, _ := ret[0].(*lambda.PublishVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishLambdaVersion indicates an expected call of PublishLambdaVersion.
+func (mr *MockAmazonMockRecorder) PublishLambdaVersion(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishLambdaVersion", reflect.TypeOf((*MockAmazon)(nil).PublishLambdaVersion), c, input)
+}
+
+// PutLambdaEventInvokeConfig mocks base method.
+func (m *MockAmazon) PutLambdaEventInvokeConfig(c context.Context, input *lambda.PutFunctionEventInvokeConfigInput) (*lambda.PutFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutLambdaEventInvokeConfig", c, input)
+	ret0, _ := ret[0].(*lambda.PutFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutLambdaEventInvokeConfig indicates an expected call of PutLambdaEventInvokeConfig.
+func (mr *MockAmazonMockRecorder) PutLambdaEventInvokeConfig(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutLambdaEventInvokeConfig", reflect.TypeOf((*MockAmazon)(nil).PutLambdaEventInvokeConfig), c, input)
+}
+
 // PutResourcePolicy mocks base method.
 func (m *MockAmazon) PutResourcePolicy(c context.Context, input *secretsmanager.PutResourcePolicyInput) (*secretsmanager.PutResourcePolicyOutput, error) {
 	m.ctrl.T.Helper()
@@ -209,6 +300,21 @@ func (mr *MockAmazonMockRecorder) PutRolePolicy(c, input interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRolePolicy", reflect.TypeOf((*MockAmazon)(nil).PutRolePolicy), c, input)
 }
 
+// ReceiveMessage mocks base method.
+func (m *MockAmazon) ReceiveMessage(c context.Context, input *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveMessage", c, input)
+	ret0, _ := ret[0].(*sqs.ReceiveMessageOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveMessage indicates an expected call of ReceiveMessage.
+func (mr *MockAmazonMockRecorder) ReceiveMessage(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveMessage", reflect.TypeOf((*MockAmazon)(nil).ReceiveMessage), c, input)
+}
+
 // RegisterLambda mocks base method.
 func (m *MockAmazon) RegisterLambda(c context.Context, input *lambda.CreateFunctionInput, opts ...func(*lambda.Options)) (*lambda.CreateFunctionOutput, error) {
 	m.ctrl.T.Helper()
@@ -259,6 +365,80 @@ func (mr *MockAmazonMockRecorder) RemoveScheduler(c, input interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveScheduler", reflect.TypeOf((*MockAmazon)(nil).RemoveScheduler), c, input)
 }
 
+// UpdateLambdaAlias mocks base method.
+func (m *MockAmazon) UpdateLambdaAlias(c context.Context, input *lambda.UpdateAliasInput) (*lambda.UpdateAliasOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLambdaAlias", c, input)
+	ret0, _ := ret[0].(*lambda.UpdateAliasOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLambdaAlias indicates an expected call of UpdateLambdaAlias.
+func (mr *MockAmazonMockRecorder) UpdateLambdaAlias(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLambdaAlias", reflect.TypeOf((*MockAmazon)(nil).UpdateLambdaAlias), c, input)
+}
+
+// UpdateLambdaCode mocks base method.
+func (m *MockAmazon) UpdateLambdaCode(c context.Context, input *lambda.UpdateFunctionCodeInput) (*lambda.UpdateFunctionCodeOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLambdaCode", c, input)
+	ret0, _ := ret[0].(*lambda.UpdateFunctionCodeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLambdaCode indicates an expected call of UpdateLambdaCode.
+func (mr *MockAmazonMockRecorder) UpdateLambdaCode(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLambdaCode", reflect.TypeOf((*MockAmazon)(nil).UpdateLambdaCode), c, input)
+}
+
+// UpdateLambdaConfig mocks base method.
+func (m *MockAmazon) UpdateLambdaConfig(c context.Context, input *lambda.UpdateFunctionConfigurationInput) (*lambda.UpdateFunctionConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLambdaConfig", c, input)
+	ret0, _ := ret[0].(*lambda.UpdateFunctionConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLambdaConfig indicates an expected call of UpdateLambdaConfig.
+func (mr *MockAmazonMockRecorder) UpdateLambdaConfig(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLambdaConfig", reflect.TypeOf((*MockAmazon)(nil).UpdateLambdaConfig), c, input)
+}
+
+// UpdateScheduler mocks base method.
+func (m *MockAmazon) UpdateScheduler(c context.Context, input *scheduler.UpdateScheduleInput) (*scheduler.UpdateScheduleOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduler", c, input)
+	ret0, _ := ret[0].(*scheduler.UpdateScheduleOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduler indicates an expected call of UpdateScheduler.
+func (mr *MockAmazonMockRecorder) UpdateScheduler(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduler", reflect.TypeOf((*MockAmazon)(nil).UpdateScheduler), c, input)
+}
+
+// WaitLambdaUpdated mocks base method.
+func (m *MockAmazon) WaitLambdaUpdated(c context.Context, input *lambda.GetFunctionInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitLambdaUpdated", c, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitLambdaUpdated indicates an expected call of WaitLambdaUpdated.
+func (mr *MockAmazonMockRecorder) WaitLambdaUpdated(c, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitLambdaUpdated", reflect.TypeOf((*MockAmazon)(nil).WaitLambdaUpdated), c, input)
+}
+
 // MockSecretManagerClient is a mock of SecretManagerClient interface.
 type MockSecretManagerClient struct {
 	ctrl     *gomock.Controller
@@ -365,6 +545,26 @@ func (m *MockLambdaClient) EXPECT() *MockLambdaClientMockRecorder {
 	return m.recorder
 }
 
+// CreateAlias mocks base method.
+func (m *MockLambdaClient) CreateAlias(ctx context.Context, params *lambda.CreateAliasInput, optFns ...func(*lambda.Options)) (*lambda.CreateAliasOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAlias", varargs...)
+	ret0, _ := ret[0].(*lambda.CreateAliasOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlias indicates an expected call of CreateAlias.
+func (mr *MockLambdaClientMockRecorder) CreateAlias(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlias", reflect.TypeOf((*MockLambdaClient)(nil).CreateAlias), varargs...)
+}
+
 // CreateFunction mocks base method.
 func (m *MockLambdaClient) CreateFunction(ctx context.Context, params *lambda.CreateFunctionInput, optFns ...func(*lambda.Options)) (*lambda.CreateFunctionOutput, error) {
 	m.ctrl.T.Helper()
@@ -405,6 +605,46 @@ func (mr *MockLambdaClientMockRecorder) DeleteFunction(ctx, params interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFunction", reflect.TypeOf((*MockLambdaClient)(nil).DeleteFunction), varargs...)
 }
 
+// GetFunction mocks base method.
+func (m *MockLambdaClient) GetFunction(ctx context.Context, params *lambda.GetFunctionInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFunction", varargs...)
+	ret0, _ := ret[0].(*lambda.GetFunctionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunction indicates an expected call of GetFunction.
+func (mr *MockLambdaClientMockRecorder) GetFunction(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunction", reflect.TypeOf((*MockLambdaClient)(nil).GetFunction), varargs...)
+}
+
+// GetFunctionEventInvokeConfig mocks base method.
+func (m *MockLambdaClient) GetFunctionEventInvokeConfig(ctx context.Context, params *lambda.GetFunctionEventInvokeConfigInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFunctionEventInvokeConfig", varargs...)
+	ret0, _ := ret[0].(*lambda.GetFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFunctionEventInvokeConfig indicates an expected call of GetFunctionEventInvokeConfig.
+func (mr *MockLambdaClientMockRecorder) GetFunctionEventInvokeConfig(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFunctionEventInvokeConfig", reflect.TypeOf((*MockLambdaClient)(nil).GetFunctionEventInvokeConfig), varargs...)
+}
+
 // Invoke mocks base method.
 func (m *MockLambdaClient) Invoke(ctx context.Context, params *lambda.InvokeInput, optFns ...func(*lambda.Options)) (*lambda.InvokeOutput, error) {
 	m.ctrl.T.Helper()
@@ -425,6 +665,106 @@ func (mr *MockLambdaClientMockRecorder) Invoke(ctx, params interface{}, optFns .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invoke", reflect.TypeOf((*MockLambdaClient)(nil).Invoke), varargs...)
 }
 
+// PublishVersion mocks base method.
+func (m *MockLambdaClient) PublishVersion(ctx context.Context, params *lambda.PublishVersionInput, optFns ...func(*lambda.Options)) (*lambda.PublishVersionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PublishVersion", varargs...)
+	ret0, _ := ret[0].(*lambda.PublishVersionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishVersion indicates an expected call of PublishVersion.
+func (mr *MockLambdaClientMockRecorder) PublishVersion(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishVersion", reflect.TypeOf((*MockLambdaClient)(nil).PublishVersion), varargs...)
+}
+
+// PutFunctionEventInvokeConfig mocks base method.
+func (m *MockLambdaClient) PutFunctionEventInvokeConfig(ctx context.Context, params *lambda.PutFunctionEventInvokeConfigInput, optFns ...func(*lambda.Options)) (*lambda.PutFunctionEventInvokeConfigOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutFunctionEventInvokeConfig", varargs...)
+	ret0, _ := ret[0].(*lambda.PutFunctionEventInvokeConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFunctionEventInvokeConfig indicates an expected call of PutFunctionEventInvokeConfig.
+func (mr *MockLambdaClientMockRecorder) PutFunctionEventInvokeConfig(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFunctionEventInvokeConfig", reflect.TypeOf((*MockLambdaClient)(nil).PutFunctionEventInvokeConfig), varargs...)
+}
+
+// UpdateAlias mocks base method.
+func (m *MockLambdaClient) UpdateAlias(ctx context.Context, params *lambda.UpdateAliasInput, optFns ...func(*lambda.Options)) (*lambda.UpdateAliasOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateAlias", varargs...)
+	ret0, _ := ret[0].(*lambda.UpdateAliasOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAlias indicates an expected call of UpdateAlias.
+func (mr *MockLambdaClientMockRecorder) UpdateAlias(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlias", reflect.TypeOf((*MockLambdaClient)(nil).UpdateAlias), varargs...)
+}
+
+// UpdateFunctionCode mocks base method.
+func (m *MockLambdaClient) UpdateFunctionCode(ctx context.Context, params *lambda.UpdateFunctionCodeInput, optFns ...func(*lambda.Options)) (*lambda.UpdateFunctionCodeOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFunctionCode", varargs...)
+	ret0, _ := ret[0].(*lambda.UpdateFunctionCodeOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionCode indicates an expected call of UpdateFunctionCode.
+func (mr *MockLambdaClientMockRecorder) UpdateFunctionCode(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionCode", reflect.TypeOf((*MockLambdaClient)(nil).UpdateFunctionCode), varargs...)
+}
+
+// UpdateFunctionConfiguration mocks base method.
+func (m *MockLambdaClient) UpdateFunctionConfiguration(ctx context.Context, params *lambda.UpdateFunctionConfigurationInput, optFns ...func(*lambda.Options)) (*lambda.UpdateFunctionConfigurationOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateFunctionConfiguration", varargs...)
+	ret0, _ := ret[0].(*lambda.UpdateFunctionConfigurationOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFunctionConfiguration indicates an expected call of UpdateFunctionConfiguration.
+func (mr *MockLambdaClientMockRecorder) UpdateFunctionConfiguration(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFunctionConfiguration", reflect.TypeOf((*MockLambdaClient)(nil).UpdateFunctionConfiguration), varargs...)
+}
+
 // MockSchedulerClient is a mock of SchedulerClient interface.
 type MockSchedulerClient struct {
 	ctrl     *gomock.Controller
@@ -508,6 +848,26 @@ func (mr *MockSchedulerClientMockRecorder) GetSchedule(ctx, params interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockSchedulerClient)(nil).GetSchedule), varargs...)
 }
 
+// UpdateSchedule mocks base method.
+func (m *MockSchedulerClient) UpdateSchedule(ctx context.Context, params *scheduler.UpdateScheduleInput, optFns ...func(*scheduler.Options)) (*scheduler.UpdateScheduleOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateSchedule", varargs...)
+	ret0, _ := ret[0].(*scheduler.UpdateScheduleOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockSchedulerClientMockRecorder) UpdateSchedule(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockSchedulerClient)(nil).UpdateSchedule), varargs...)
+}
+
 // MockCloudWatchLogsClient is a mock of CloudWatchLogsClient interface.
 type MockCloudWatchLogsClient struct {
 	ctrl     *gomock.Controller
@@ -551,6 +911,26 @@ func (mr *MockCloudWatchLogsClientMockRecorder) DescribeLogStreams(ctx, params i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLogStreams", reflect.TypeOf((*MockCloudWatchLogsClient)(nil).DescribeLogStreams), varargs...)
 }
 
+// FilterLogEvents mocks base method.
+func (m *MockCloudWatchLogsClient) FilterLogEvents(ctx context.Context, params *cloudwatchlogs.FilterLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FilterLogEvents", varargs...)
+	ret0, _ := ret[0].(*cloudwatchlogs.FilterLogEventsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FilterLogEvents indicates an expected call of FilterLogEvents.
+func (mr *MockCloudWatchLogsClientMockRecorder) FilterLogEvents(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterLogEvents", reflect.TypeOf((*MockCloudWatchLogsClient)(nil).FilterLogEvents), varargs...)
+}
+
 // GetLogEvents mocks base method.
 func (m *MockCloudWatchLogsClient) GetLogEvents(ctx context.Context, params *cloudwatchlogs.GetLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.GetLogEventsOutput, error) {
 	m.ctrl.T.Helper()
@@ -653,3 +1033,66 @@ func (mr *MockIamClientMockRecorder) PutRolePolicy(ctx, params interface{}, optF
 	varargs := append([]interface{}{ctx, params}, optFns...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRolePolicy", reflect.TypeOf((*MockIamClient)(nil).PutRolePolicy), varargs...)
 }
+
+// MockSqsClient is a mock of SqsClient interface.
+type MockSqsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSqsClientMockRecorder
+}
+
+// MockSqsClientMockRecorder is the mock recorder for MockSqsClient.
+type MockSqsClientMockRecorder struct {
+	mock *MockSqsClient
+}
+
+// NewMockSqsClient creates a new mock instance.
+func NewMockSqsClient(ctrl *gomock.Controller) *MockSqsClient {
+	mock := &MockSqsClient{ctrl: ctrl}
+	mock.recorder = &MockSqsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSqsClient) EXPECT() *MockSqsClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteMessageBatch mocks base method.
+func (m *MockSqsClient) DeleteMessageBatch(ctx context.Context, params *sqs.DeleteMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteMessageBatch", varargs...)
+	ret0, _ := ret[0].(*sqs.DeleteMessageBatchOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessageBatch indicates an expected call of DeleteMessageBatch.
+func (mr *MockSqsClientMockRecorder) DeleteMessageBatch(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageBatch", reflect.TypeOf((*MockSqsClient)(nil).DeleteMessageBatch), varargs...)
+}
+
+// ReceiveMessage mocks base method.
+func (m *MockSqsClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReceiveMessage", varargs...)
+	ret0, _ := ret[0].(*sqs.ReceiveMessageOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveMessage indicates an expected call of ReceiveMessage.
+func (mr *MockSqsClientMockRecorder) ReceiveMessage(ctx, params interface{}, optFns ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveMessage", reflect.TypeOf((*MockSqsClient)(nil).ReceiveMessage), varargs...)
+}
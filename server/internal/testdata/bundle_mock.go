@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bundle.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBundle is a mock of Bundle interface.
+type MockBundle struct {
+	ctrl     *gomock.Controller
+	recorder *MockBundleMockRecorder
+}
+
+// MockBundleMockRecorder is the mock recorder for MockBundle.
+type MockBundleMockRecorder struct {
+	mock *MockBundle
+}
+
+// NewMockBundle creates a new mock instance.
+func NewMockBundle(ctrl *gomock.Controller) *MockBundle {
+	mock := &MockBundle{ctrl: ctrl}
+	mock.recorder = &MockBundleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBundle) EXPECT() *MockBundleMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockBundle) Validate(runtime, name string, zipBytes []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", runtime, name, zipBytes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockBundleMockRecorder) Validate(runtime, name, zipBytes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockBundle)(nil).Validate), runtime, name, zipBytes)
+}
+
+// MockValidator is a mock of Validator interface.
+type MockValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MockValidatorMockRecorder
+}
+
+// MockValidatorMockRecorder is the mock recorder for MockValidator.
+type MockValidatorMockRecorder struct {
+	mock *MockValidator
+}
+
+// NewMockValidator creates a new mock instance.
+func NewMockValidator(ctrl *gomock.Controller) *MockValidator {
+	mock := &MockValidator{ctrl: ctrl}
+	mock.recorder = &MockValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValidator) EXPECT() *MockValidatorMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockValidator) Validate(name string, zipBytes []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", name, zipBytes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockValidatorMockRecorder) Validate(name, zipBytes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockValidator)(nil).Validate), name, zipBytes)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: kv.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/57blocks/auto-action/server/internal/dto"
+	model "github.com/57blocks/auto-action/server/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKV is a mock of KV interface.
+type MockKV struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVMockRecorder
+}
+
+// MockKVMockRecorder is the mock recorder for MockKV.
+type MockKVMockRecorder struct {
+	mock *MockKV
+}
+
+// NewMockKV creates a new mock instance.
+func NewMockKV(ctrl *gomock.Controller) *MockKV {
+	mock := &MockKV{ctrl: ctrl}
+	mock.recorder = &MockKVMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKV) EXPECT() *MockKVMockRecorder {
+	return m.recorder
+}
+
+// DeleteEntry mocks base method.
+func (m *MockKV) DeleteEntry(c context.Context, acnID, lambdaID uint64, key string, version *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", c, acnID, lambdaID, key, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockKVMockRecorder) DeleteEntry(c, acnID, lambdaID, key, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockKV)(nil).DeleteEntry), c, acnID, lambdaID, key, version)
+}
+
+// FindEntry mocks base method.
+func (m *MockKV) FindEntry(c context.Context, acnID, lambdaID uint64, key string) (*dto.RespKVEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEntry", c, acnID, lambdaID, key)
+	ret0, _ := ret[0].(*dto.RespKVEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindEntry indicates an expected call of FindEntry.
+func (mr *MockKVMockRecorder) FindEntry(c, acnID, lambdaID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEntry", reflect.TypeOf((*MockKV)(nil).FindEntry), c, acnID, lambdaID, key)
+}
+
+// ListEntries mocks base method.
+func (m *MockKV) ListEntries(c context.Context, acnID, lambdaID uint64, prefix string, limit int) ([]*dto.RespKVEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", c, acnID, lambdaID, prefix, limit)
+	ret0, _ := ret[0].([]*dto.RespKVEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockKVMockRecorder) ListEntries(c, acnID, lambdaID, prefix, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockKV)(nil).ListEntries), c, acnID, lambdaID, prefix, limit)
+}
+
+// PutEntry mocks base method.
+func (m *MockKV) PutEntry(c context.Context, entry *model.KVEntry, version *int64, maxKeys int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutEntry", c, entry, version, maxKeys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutEntry indicates an expected call of PutEntry.
+func (mr *MockKVMockRecorder) PutEntry(c, entry, version, maxKeys interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutEntry", reflect.TypeOf((*MockKV)(nil).PutEntry), c, entry, version, maxKeys)
+}
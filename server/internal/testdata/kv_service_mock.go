@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/57blocks/auto-action/server/internal/dto"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockKVService is a mock of KVService interface.
+type MockKVService struct {
+	ctrl     *gomock.Controller
+	recorder *MockKVServiceMockRecorder
+}
+
+// MockKVServiceMockRecorder is the mock recorder for MockKVService.
+type MockKVServiceMockRecorder struct {
+	mock *MockKVService
+}
+
+// NewMockKVService creates a new mock instance.
+func NewMockKVService(ctrl *gomock.Controller) *MockKVService {
+	mock := &MockKVService{ctrl: ctrl}
+	mock.recorder = &MockKVServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKVService) EXPECT() *MockKVServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockKVService) Get(c context.Context, r *dto.ReqKVEntry) (*dto.RespKVEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", c, r)
+	ret0, _ := ret[0].(*dto.RespKVEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockKVServiceMockRecorder) Get(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKVService)(nil).Get), c, r)
+}
+
+// List mocks base method.
+func (m *MockKVService) List(c context.Context, r *dto.ReqKVList) (*dto.RespKVEntries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", c, r)
+	ret0, _ := ret[0].(*dto.RespKVEntries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockKVServiceMockRecorder) List(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKVService)(nil).List), c, r)
+}
+
+// Put mocks base method.
+func (m *MockKVService) Put(c context.Context, r *dto.ReqKVPut) (*dto.RespKVEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", c, r)
+	ret0, _ := ret[0].(*dto.RespKVEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockKVServiceMockRecorder) Put(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockKVService)(nil).Put), c, r)
+}
+
+// Remove mocks base method.
+func (m *MockKVService) Remove(c context.Context, r *dto.ReqKVEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", c, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockKVServiceMockRecorder) Remove(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockKVService)(nil).Remove), c, r)
+}
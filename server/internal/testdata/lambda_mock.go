@@ -10,6 +10,7 @@ import (
 	reflect "reflect"
 
 	dto "github.com/57blocks/auto-action/server/internal/dto"
+	model "github.com/57blocks/auto-action/server/internal/model"
 	gomock "github.com/golang/mock/gomock"
 	gorm "gorm.io/gorm"
 )
@@ -37,6 +38,36 @@ func (m *MockLambda) EXPECT() *MockLambdaMockRecorder {
 	return m.recorder
 }
 
+// AdvanceCursor mocks base method.
+func (m *MockLambda) AdvanceCursor(c context.Context, id uint64, from, to string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceCursor", c, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceCursor indicates an expected call of AdvanceCursor.
+func (mr *MockLambdaMockRecorder) AdvanceCursor(c, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceCursor", reflect.TypeOf((*MockLambda)(nil).AdvanceCursor), c, id, from, to)
+}
+
+// AdvanceLedger mocks base method.
+func (m *MockLambda) AdvanceLedger(c context.Context, id uint64, from string, ledger uint32, to string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdvanceLedger", c, id, from, ledger, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdvanceLedger indicates an expected call of AdvanceLedger.
+func (mr *MockLambdaMockRecorder) AdvanceLedger(c, id, from, ledger, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdvanceLedger", reflect.TypeOf((*MockLambda)(nil).AdvanceLedger), c, id, from, ledger, to)
+}
+
 // DeleteLambdaTX mocks base method.
 func (m *MockLambda) DeleteLambdaTX(c context.Context, f func(*gorm.DB) error, opts ...*sql.TxOptions) error {
 	m.ctrl.T.Helper()
@@ -56,6 +87,48 @@ func (mr *MockLambdaMockRecorder) DeleteLambdaTX(c, f interface{}, opts ...inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLambdaTX", reflect.TypeOf((*MockLambda)(nil).DeleteLambdaTX), varargs...)
 }
 
+// DeleteScheduler mocks base method.
+func (m *MockLambda) DeleteScheduler(c context.Context, lambdaID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduler", c, lambdaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduler indicates an expected call of DeleteScheduler.
+func (mr *MockLambdaMockRecorder) DeleteScheduler(c, lambdaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduler", reflect.TypeOf((*MockLambda)(nil).DeleteScheduler), c, lambdaID)
+}
+
+// DeleteTrigger mocks base method.
+func (m *MockLambda) DeleteTrigger(c context.Context, acnID, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTrigger", c, acnID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTrigger indicates an expected call of DeleteTrigger.
+func (mr *MockLambdaMockRecorder) DeleteTrigger(c, acnID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTrigger", reflect.TypeOf((*MockLambda)(nil).DeleteTrigger), c, acnID, id)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockLambda) DeleteWebhook(c context.Context, lambdaID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", c, lambdaID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockLambdaMockRecorder) DeleteWebhook(c, lambdaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockLambda)(nil).DeleteWebhook), c, lambdaID)
+}
+
 // FindByAccount mocks base method.
 func (m *MockLambda) FindByAccount(c context.Context, accountId uint64) ([]*dto.RespInfo, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +144,171 @@ func (mr *MockLambdaMockRecorder) FindByAccount(c, accountId interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccount", reflect.TypeOf((*MockLambda)(nil).FindByAccount), c, accountId)
 }
 
+// FindExecution mocks base method.
+func (m *MockLambda) FindExecution(c context.Context, acnID uint64, executionID string) (*dto.RespExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExecution", c, acnID, executionID)
+	ret0, _ := ret[0].(*dto.RespExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExecution indicates an expected call of FindExecution.
+func (mr *MockLambdaMockRecorder) FindExecution(c, acnID, executionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExecution", reflect.TypeOf((*MockLambda)(nil).FindExecution), c, acnID, executionID)
+}
+
+// FindFailures mocks base method.
+func (m *MockLambda) FindFailures(c context.Context, lambdaID uint64, ids []uint64) ([]*dto.RespFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindFailures", c, lambdaID, ids)
+	ret0, _ := ret[0].([]*dto.RespFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindFailures indicates an expected call of FindFailures.
+func (mr *MockLambdaMockRecorder) FindFailures(c, lambdaID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindFailures", reflect.TypeOf((*MockLambda)(nil).FindFailures), c, lambdaID, ids)
+}
+
+// FindLambda mocks base method.
+func (m *MockLambda) FindLambda(c context.Context, lambdaID uint64) (*dto.RespInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLambda", c, lambdaID)
+	ret0, _ := ret[0].(*dto.RespInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLambda indicates an expected call of FindLambda.
+func (mr *MockLambdaMockRecorder) FindLambda(c, lambdaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLambda", reflect.TypeOf((*MockLambda)(nil).FindLambda), c, lambdaID)
+}
+
+// FindLambdaByArn mocks base method.
+func (m *MockLambda) FindLambdaByArn(c context.Context, functionArn string) (*dto.RespInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLambdaByArn", c, functionArn)
+	ret0, _ := ret[0].(*dto.RespInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLambdaByArn indicates an expected call of FindLambdaByArn.
+func (mr *MockLambdaMockRecorder) FindLambdaByArn(c, functionArn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLambdaByArn", reflect.TypeOf((*MockLambda)(nil).FindLambdaByArn), c, functionArn)
+}
+
+// FindRuntimeNonce mocks base method.
+func (m *MockLambda) FindRuntimeNonce(c context.Context, functionName string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRuntimeNonce", c, functionName)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRuntimeNonce indicates an expected call of FindRuntimeNonce.
+func (mr *MockLambdaMockRecorder) FindRuntimeNonce(c, functionName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRuntimeNonce", reflect.TypeOf((*MockLambda)(nil).FindRuntimeNonce), c, functionName)
+}
+
+// FindScheduledLambda mocks base method.
+func (m *MockLambda) FindScheduledLambda(c context.Context, scheduleArn string) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindScheduledLambda", c, scheduleArn)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindScheduledLambda indicates an expected call of FindScheduledLambda.
+func (mr *MockLambdaMockRecorder) FindScheduledLambda(c, scheduleArn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindScheduledLambda", reflect.TypeOf((*MockLambda)(nil).FindScheduledLambda), c, scheduleArn)
+}
+
+// FindTrigger mocks base method.
+func (m *MockLambda) FindTrigger(c context.Context, id uint64) (*dto.RespTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTrigger", c, id)
+	ret0, _ := ret[0].(*dto.RespTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTrigger indicates an expected call of FindTrigger.
+func (mr *MockLambdaMockRecorder) FindTrigger(c, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTrigger", reflect.TypeOf((*MockLambda)(nil).FindTrigger), c, id)
+}
+
+// FindVersion mocks base method.
+func (m *MockLambda) FindVersion(c context.Context, lambdaID uint64, version string) (*dto.RespVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", c, lambdaID, version)
+	ret0, _ := ret[0].(*dto.RespVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockLambdaMockRecorder) FindVersion(c, lambdaID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockLambda)(nil).FindVersion), c, lambdaID, version)
+}
+
+// FindVersions mocks base method.
+func (m *MockLambda) FindVersions(c context.Context, lambdaID uint64) ([]*dto.RespVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersions", c, lambdaID)
+	ret0, _ := ret[0].([]*dto.RespVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockLambdaMockRecorder) FindVersions(c, lambdaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockLambda)(nil).FindVersions), c, lambdaID)
+}
+
+// FindWebhook mocks base method.
+func (m *MockLambda) FindWebhook(c context.Context, hookID string) (*dto.RespWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWebhook", c, hookID)
+	ret0, _ := ret[0].(*dto.RespWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWebhook indicates an expected call of FindWebhook.
+func (mr *MockLambdaMockRecorder) FindWebhook(c, hookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWebhook", reflect.TypeOf((*MockLambda)(nil).FindWebhook), c, hookID)
+}
+
+// FinishExecution mocks base method.
+func (m *MockLambda) FinishExecution(c context.Context, executionID string, exe *model.LambdaExecution) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishExecution", c, executionID, exe)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishExecution indicates an expected call of FinishExecution.
+func (mr *MockLambdaMockRecorder) FinishExecution(c, executionID, exe interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishExecution", reflect.TypeOf((*MockLambda)(nil).FinishExecution), c, executionID, exe)
+}
+
 // LambdaInfo mocks base method.
 func (m *MockLambda) LambdaInfo(c context.Context, acnID uint64, distinguish string) (*dto.RespInfo, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +324,83 @@ func (mr *MockLambdaMockRecorder) LambdaInfo(c, acnID, distinguish interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LambdaInfo", reflect.TypeOf((*MockLambda)(nil).LambdaInfo), c, acnID, distinguish)
 }
 
+// LatestVersion mocks base method.
+func (m *MockLambda) LatestVersion(c context.Context, lambdaID uint64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestVersion", c, lambdaID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestVersion indicates an expected call of LatestVersion.
+func (mr *MockLambdaMockRecorder) LatestVersion(c, lambdaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestVersion", reflect.TypeOf((*MockLambda)(nil).LatestVersion), c, lambdaID)
+}
+
+// ListExecutions mocks base method.
+func (m *MockLambda) ListExecutions(c context.Context, lambdaID uint64, q *dto.ReqExecutions) ([]*dto.RespExecution, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExecutions", c, lambdaID, q)
+	ret0, _ := ret[0].([]*dto.RespExecution)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListExecutions indicates an expected call of ListExecutions.
+func (mr *MockLambdaMockRecorder) ListExecutions(c, lambdaID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExecutions", reflect.TypeOf((*MockLambda)(nil).ListExecutions), c, lambdaID, q)
+}
+
+// ListFailures mocks base method.
+func (m *MockLambda) ListFailures(c context.Context, lambdaID uint64, q *dto.ReqFailures) ([]*dto.RespFailure, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFailures", c, lambdaID, q)
+	ret0, _ := ret[0].([]*dto.RespFailure)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFailures indicates an expected call of ListFailures.
+func (mr *MockLambdaMockRecorder) ListFailures(c, lambdaID, q interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFailures", reflect.TypeOf((*MockLambda)(nil).ListFailures), c, lambdaID, q)
+}
+
+// ListTriggers mocks base method.
+func (m *MockLambda) ListTriggers(c context.Context, acnID, lambdaID uint64) ([]*dto.RespTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTriggers", c, acnID, lambdaID)
+	ret0, _ := ret[0].([]*dto.RespTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTriggers indicates an expected call of ListTriggers.
+func (mr *MockLambdaMockRecorder) ListTriggers(c, acnID, lambdaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTriggers", reflect.TypeOf((*MockLambda)(nil).ListTriggers), c, acnID, lambdaID)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockLambda) ListWebhooks(c context.Context, acnID, lambdaID uint64) ([]*dto.RespWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", c, acnID, lambdaID)
+	ret0, _ := ret[0].([]*dto.RespWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockLambdaMockRecorder) ListWebhooks(c, acnID, lambdaID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockLambda)(nil).ListWebhooks), c, acnID, lambdaID)
+}
+
 // PersistRegResult mocks base method.
 func (m *MockLambda) PersistRegResult(c context.Context, fc func(*gorm.DB) error, opts ...*sql.TxOptions) error {
 	m.ctrl.T.Helper()
@@ -104,3 +419,179 @@ func (mr *MockLambdaMockRecorder) PersistRegResult(c, fc interface{}, opts ...in
 	varargs := append([]interface{}{c, fc}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistRegResult", reflect.TypeOf((*MockLambda)(nil).PersistRegResult), varargs...)
 }
+
+// RotateWebhook mocks base method.
+func (m *MockLambda) RotateWebhook(c context.Context, lambdaID uint64, secret string, headers []string) (*model.LambdaWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateWebhook", c, lambdaID, secret, headers)
+	ret0, _ := ret[0].(*model.LambdaWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateWebhook indicates an expected call of RotateWebhook.
+func (mr *MockLambdaMockRecorder) RotateWebhook(c, lambdaID, secret, headers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateWebhook", reflect.TypeOf((*MockLambda)(nil).RotateWebhook), c, lambdaID, secret, headers)
+}
+
+// SaveExecution mocks base method.
+func (m *MockLambda) SaveExecution(c context.Context, exe *model.LambdaExecution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveExecution", c, exe)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveExecution indicates an expected call of SaveExecution.
+func (mr *MockLambdaMockRecorder) SaveExecution(c, exe interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveExecution", reflect.TypeOf((*MockLambda)(nil).SaveExecution), c, exe)
+}
+
+// SaveFailure mocks base method.
+func (m *MockLambda) SaveFailure(c context.Context, failure *model.LambdaFailure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFailure", c, failure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFailure indicates an expected call of SaveFailure.
+func (mr *MockLambdaMockRecorder) SaveFailure(c, failure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFailure", reflect.TypeOf((*MockLambda)(nil).SaveFailure), c, failure)
+}
+
+// SaveScheduler mocks base method.
+func (m *MockLambda) SaveScheduler(c context.Context, sch *model.LambdaScheduler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveScheduler", c, sch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveScheduler indicates an expected call of SaveScheduler.
+func (mr *MockLambdaMockRecorder) SaveScheduler(c, sch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveScheduler", reflect.TypeOf((*MockLambda)(nil).SaveScheduler), c, sch)
+}
+
+// SaveTrigger mocks base method.
+func (m *MockLambda) SaveTrigger(c context.Context, trigger *model.LambdaTrigger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTrigger", c, trigger)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTrigger indicates an expected call of SaveTrigger.
+func (mr *MockLambdaMockRecorder) SaveTrigger(c, trigger interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTrigger", reflect.TypeOf((*MockLambda)(nil).SaveTrigger), c, trigger)
+}
+
+// SaveWebhook mocks base method.
+func (m *MockLambda) SaveWebhook(c context.Context, webhook *model.LambdaWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhook", c, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhook indicates an expected call of SaveWebhook.
+func (mr *MockLambdaMockRecorder) SaveWebhook(c, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhook", reflect.TypeOf((*MockLambda)(nil).SaveWebhook), c, webhook)
+}
+
+// SetChain mocks base method.
+func (m *MockLambda) SetChain(c context.Context, lambdaID uint64, onSuccess, onFailure *uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChain", c, lambdaID, onSuccess, onFailure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChain indicates an expected call of SetChain.
+func (mr *MockLambdaMockRecorder) SetChain(c, lambdaID, onSuccess, onFailure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChain", reflect.TypeOf((*MockLambda)(nil).SetChain), c, lambdaID, onSuccess, onFailure)
+}
+
+// TrackedExecution mocks base method.
+func (m *MockLambda) TrackedExecution(c context.Context, executionID string) (*dto.RespExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackedExecution", c, executionID)
+	ret0, _ := ret[0].(*dto.RespExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackedExecution indicates an expected call of TrackedExecution.
+func (mr *MockLambdaMockRecorder) TrackedExecution(c, executionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackedExecution", reflect.TypeOf((*MockLambda)(nil).TrackedExecution), c, executionID)
+}
+
+// UpdateExecution mocks base method.
+func (m *MockLambda) UpdateExecution(c context.Context, executionID string, exe *model.LambdaExecution) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExecution", c, executionID, exe)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateExecution indicates an expected call of UpdateExecution.
+func (mr *MockLambdaMockRecorder) UpdateExecution(c, executionID, exe interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExecution", reflect.TypeOf((*MockLambda)(nil).UpdateExecution), c, executionID, exe)
+}
+
+// UpdateFailure mocks base method.
+func (m *MockLambda) UpdateFailure(c context.Context, id uint64, failure *model.LambdaFailure) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFailure", c, id, failure)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFailure indicates an expected call of UpdateFailure.
+func (mr *MockLambdaMockRecorder) UpdateFailure(c, id, failure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFailure", reflect.TypeOf((*MockLambda)(nil).UpdateFailure), c, id, failure)
+}
+
+// UpdateLambdaTX mocks base method.
+func (m *MockLambda) UpdateLambdaTX(c context.Context, f func(*gorm.DB) error, opts ...*sql.TxOptions) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{c, f}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateLambdaTX", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLambdaTX indicates an expected call of UpdateLambdaTX.
+func (mr *MockLambdaMockRecorder) UpdateLambdaTX(c, f interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{c, f}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLambdaTX", reflect.TypeOf((*MockLambda)(nil).UpdateLambdaTX), varargs...)
+}
+
+// WatchedTriggers mocks base method.
+func (m *MockLambda) WatchedTriggers(c context.Context) ([]*dto.RespTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchedTriggers", c)
+	ret0, _ := ret[0].([]*dto.RespTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchedTriggers indicates an expected call of WatchedTriggers.
+func (mr *MockLambdaMockRecorder) WatchedTriggers(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchedTriggers", reflect.TypeOf((*MockLambda)(nil).WatchedTriggers), c)
+}
@@ -36,6 +36,110 @@ func (m *MockLambdaService) EXPECT() *MockLambdaServiceMockRecorder {
 	return m.recorder
 }
 
+// AddTrigger mocks base method.
+func (m *MockLambdaService) AddTrigger(c context.Context, r *dto.ReqAddTrigger) (*dto.RespTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrigger", c, r)
+	ret0, _ := ret[0].(*dto.RespTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTrigger indicates an expected call of AddTrigger.
+func (mr *MockLambdaServiceMockRecorder) AddTrigger(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrigger", reflect.TypeOf((*MockLambdaService)(nil).AddTrigger), c, r)
+}
+
+// Callback mocks base method.
+func (m *MockLambdaService) Callback(c context.Context, r *dto.ReqCallback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Callback", c, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Callback indicates an expected call of Callback.
+func (mr *MockLambdaServiceMockRecorder) Callback(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Callback", reflect.TypeOf((*MockLambdaService)(nil).Callback), c, r)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockLambdaService) CreateWebhook(c context.Context, r *dto.ReqWebhook) (*dto.RespWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", c, r)
+	ret0, _ := ret[0].(*dto.RespWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockLambdaServiceMockRecorder) CreateWebhook(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockLambdaService)(nil).CreateWebhook), c, r)
+}
+
+// Env mocks base method.
+func (m *MockLambdaService) Env(c context.Context, r *dto.ReqURILambda) (*dto.RespEnv, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Env", c, r)
+	ret0, _ := ret[0].(*dto.RespEnv)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Env indicates an expected call of Env.
+func (mr *MockLambdaServiceMockRecorder) Env(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Env", reflect.TypeOf((*MockLambdaService)(nil).Env), c, r)
+}
+
+// Execution mocks base method.
+func (m *MockLambdaService) Execution(c context.Context, r *dto.ReqExecution) (*dto.RespExecution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execution", c, r)
+	ret0, _ := ret[0].(*dto.RespExecution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execution indicates an expected call of Execution.
+func (mr *MockLambdaServiceMockRecorder) Execution(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execution", reflect.TypeOf((*MockLambdaService)(nil).Execution), c, r)
+}
+
+// Executions mocks base method.
+func (m *MockLambdaService) Executions(c context.Context, r *dto.ReqExecutions) (*dto.RespExecutions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Executions", c, r)
+	ret0, _ := ret[0].(*dto.RespExecutions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Executions indicates an expected call of Executions.
+func (mr *MockLambdaServiceMockRecorder) Executions(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Executions", reflect.TypeOf((*MockLambdaService)(nil).Executions), c, r)
+}
+
+// Failures mocks base method.
+func (m *MockLambdaService) Failures(c context.Context, r *dto.ReqFailures) (*dto.RespFailures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Failures", c, r)
+	ret0, _ := ret[0].(*dto.RespFailures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Failures indicates an expected call of Failures.
+func (mr *MockLambdaServiceMockRecorder) Failures(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Failures", reflect.TypeOf((*MockLambdaService)(nil).Failures), c, r)
+}
+
 // Info mocks base method.
 func (m *MockLambdaService) Info(c context.Context, r *dto.ReqURILambda) (*dto.RespInfo, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +199,81 @@ func (mr *MockLambdaServiceMockRecorder) Logs(c, r, upgrader interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logs", reflect.TypeOf((*MockLambdaService)(nil).Logs), c, r, upgrader)
 }
 
+// LogsQuery mocks base method.
+func (m *MockLambdaService) LogsQuery(c context.Context, r *dto.ReqLogsQuery) (*dto.RespLogsQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogsQuery", c, r)
+	ret0, _ := ret[0].(*dto.RespLogsQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogsQuery indicates an expected call of LogsQuery.
+func (mr *MockLambdaServiceMockRecorder) LogsQuery(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogsQuery", reflect.TypeOf((*MockLambdaService)(nil).LogsQuery), c, r)
+}
+
+// PauseSchedule mocks base method.
+func (m *MockLambdaService) PauseSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseSchedule", c, r)
+	ret0, _ := ret[0].(*dto.RespSchBrief)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PauseSchedule indicates an expected call of PauseSchedule.
+func (mr *MockLambdaServiceMockRecorder) PauseSchedule(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseSchedule", reflect.TypeOf((*MockLambdaService)(nil).PauseSchedule), c, r)
+}
+
+// Preview mocks base method.
+func (m *MockLambdaService) Preview(c context.Context, r *dto.ReqPreview) (*dto.RespPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preview", c, r)
+	ret0, _ := ret[0].(*dto.RespPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Preview indicates an expected call of Preview.
+func (mr *MockLambdaServiceMockRecorder) Preview(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preview", reflect.TypeOf((*MockLambdaService)(nil).Preview), c, r)
+}
+
+// ReceiveHook mocks base method.
+func (m *MockLambdaService) ReceiveHook(c context.Context, r *dto.ReqHook) (*dto.RespHook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveHook", c, r)
+	ret0, _ := ret[0].(*dto.RespHook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveHook indicates an expected call of ReceiveHook.
+func (mr *MockLambdaServiceMockRecorder) ReceiveHook(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveHook", reflect.TypeOf((*MockLambdaService)(nil).ReceiveHook), c, r)
+}
+
+// Redrive mocks base method.
+func (m *MockLambdaService) Redrive(c context.Context, r *dto.ReqRedrive) (*dto.RespRedrive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redrive", c, r)
+	ret0, _ := ret[0].(*dto.RespRedrive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redrive indicates an expected call of Redrive.
+func (mr *MockLambdaServiceMockRecorder) Redrive(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redrive", reflect.TypeOf((*MockLambdaService)(nil).Redrive), c, r)
+}
+
 // Register mocks base method.
 func (m *MockLambdaService) Register(c context.Context, r *dto.ReqRegister) ([]*dto.RespRegister, error) {
 	m.ctrl.T.Helper()
@@ -124,3 +303,223 @@ func (mr *MockLambdaServiceMockRecorder) Remove(c, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockLambdaService)(nil).Remove), c, r)
 }
+
+// RemoveSchedule mocks base method.
+func (m *MockLambdaService) RemoveSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSchedule", c, r)
+	ret0, _ := ret[0].(*dto.RespSchBrief)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveSchedule indicates an expected call of RemoveSchedule.
+func (mr *MockLambdaServiceMockRecorder) RemoveSchedule(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSchedule", reflect.TypeOf((*MockLambdaService)(nil).RemoveSchedule), c, r)
+}
+
+// RemoveTrigger mocks base method.
+func (m *MockLambdaService) RemoveTrigger(c context.Context, r *dto.ReqURITrigger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrigger", c, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTrigger indicates an expected call of RemoveTrigger.
+func (mr *MockLambdaServiceMockRecorder) RemoveTrigger(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrigger", reflect.TypeOf((*MockLambdaService)(nil).RemoveTrigger), c, r)
+}
+
+// RemoveWebhook mocks base method.
+func (m *MockLambdaService) RemoveWebhook(c context.Context, r *dto.ReqURILambda) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWebhook", c, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveWebhook indicates an expected call of RemoveWebhook.
+func (mr *MockLambdaServiceMockRecorder) RemoveWebhook(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWebhook", reflect.TypeOf((*MockLambdaService)(nil).RemoveWebhook), c, r)
+}
+
+// ResumeSchedule mocks base method.
+func (m *MockLambdaService) ResumeSchedule(c context.Context, r *dto.ReqURILambda) (*dto.RespSchBrief, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeSchedule", c, r)
+	ret0, _ := ret[0].(*dto.RespSchBrief)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeSchedule indicates an expected call of ResumeSchedule.
+func (mr *MockLambdaServiceMockRecorder) ResumeSchedule(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeSchedule", reflect.TypeOf((*MockLambdaService)(nil).ResumeSchedule), c, r)
+}
+
+// Rollback mocks base method.
+func (m *MockLambdaService) Rollback(c context.Context, r *dto.ReqRollback) (*dto.RespRollback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", c, r)
+	ret0, _ := ret[0].(*dto.RespRollback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockLambdaServiceMockRecorder) Rollback(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockLambdaService)(nil).Rollback), c, r)
+}
+
+// RotateWebhook mocks base method.
+func (m *MockLambdaService) RotateWebhook(c context.Context, r *dto.ReqWebhook) (*dto.RespWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateWebhook", c, r)
+	ret0, _ := ret[0].(*dto.RespWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateWebhook indicates an expected call of RotateWebhook.
+func (mr *MockLambdaServiceMockRecorder) RotateWebhook(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateWebhook", reflect.TypeOf((*MockLambdaService)(nil).RotateWebhook), c, r)
+}
+
+// SetChain mocks base method.
+func (m *MockLambdaService) SetChain(c context.Context, r *dto.ReqChain) (*dto.Chain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChain", c, r)
+	ret0, _ := ret[0].(*dto.Chain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetChain indicates an expected call of SetChain.
+func (mr *MockLambdaServiceMockRecorder) SetChain(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChain", reflect.TypeOf((*MockLambdaService)(nil).SetChain), c, r)
+}
+
+// SetEnv mocks base method.
+func (m *MockLambdaService) SetEnv(c context.Context, r *dto.ReqSetEnv) (*dto.RespEnv, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEnv", c, r)
+	ret0, _ := ret[0].(*dto.RespEnv)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEnv indicates an expected call of SetEnv.
+func (mr *MockLambdaServiceMockRecorder) SetEnv(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnv", reflect.TypeOf((*MockLambdaService)(nil).SetEnv), c, r)
+}
+
+// SetSchedule mocks base method.
+func (m *MockLambdaService) SetSchedule(c context.Context, r *dto.ReqSchedule) (*dto.RespSchBrief, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSchedule", c, r)
+	ret0, _ := ret[0].(*dto.RespSchBrief)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetSchedule indicates an expected call of SetSchedule.
+func (mr *MockLambdaServiceMockRecorder) SetSchedule(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSchedule", reflect.TypeOf((*MockLambdaService)(nil).SetSchedule), c, r)
+}
+
+// Triggers mocks base method.
+func (m *MockLambdaService) Triggers(c context.Context, r *dto.ReqTriggers) ([]*dto.RespTrigger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Triggers", c, r)
+	ret0, _ := ret[0].([]*dto.RespTrigger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Triggers indicates an expected call of Triggers.
+func (mr *MockLambdaServiceMockRecorder) Triggers(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Triggers", reflect.TypeOf((*MockLambdaService)(nil).Triggers), c, r)
+}
+
+// UnsetEnv mocks base method.
+func (m *MockLambdaService) UnsetEnv(c context.Context, r *dto.ReqUnsetEnv) (*dto.RespEnv, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsetEnv", c, r)
+	ret0, _ := ret[0].(*dto.RespEnv)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnsetEnv indicates an expected call of UnsetEnv.
+func (mr *MockLambdaServiceMockRecorder) UnsetEnv(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsetEnv", reflect.TypeOf((*MockLambdaService)(nil).UnsetEnv), c, r)
+}
+
+// Update mocks base method.
+func (m *MockLambdaService) Update(c context.Context, r *dto.ReqUpdate) (*dto.RespUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", c, r)
+	ret0, _ := ret[0].(*dto.RespUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLambdaServiceMockRecorder) Update(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLambdaService)(nil).Update), c, r)
+}
+
+// Versions mocks base method.
+func (m *MockLambdaService) Versions(c context.Context, r *dto.ReqURILambda) ([]*dto.RespVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Versions", c, r)
+	ret0, _ := ret[0].([]*dto.RespVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Versions indicates an expected call of Versions.
+func (mr *MockLambdaServiceMockRecorder) Versions(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Versions", reflect.TypeOf((*MockLambdaService)(nil).Versions), c, r)
+}
+
+// WatchTriggers mocks base method.
+func (m *MockLambdaService) WatchTriggers(c context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WatchTriggers", c)
+}
+
+// WatchTriggers indicates an expected call of WatchTriggers.
+func (mr *MockLambdaServiceMockRecorder) WatchTriggers(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTriggers", reflect.TypeOf((*MockLambdaService)(nil).WatchTriggers), c)
+}
+
+// Webhooks mocks base method.
+func (m *MockLambdaService) Webhooks(c context.Context, r *dto.ReqWebhooks) ([]*dto.RespWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhooks", c, r)
+	ret0, _ := ret[0].([]*dto.RespWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Webhooks indicates an expected call of Webhooks.
+func (mr *MockLambdaServiceMockRecorder) Webhooks(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhooks", reflect.TypeOf((*MockLambdaService)(nil).Webhooks), c, r)
+}
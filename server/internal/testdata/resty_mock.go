@@ -106,3 +106,32 @@ func (mr *MockRestyMockRecorder) DeleteCSKeyFromRole(c, csToken, keyId, role int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCSKeyFromRole", reflect.TypeOf((*MockResty)(nil).DeleteCSKeyFromRole), c, csToken, keyId, role)
 }
+
+// FundByFriendbot mocks base method.
+func (m *MockResty) FundByFriendbot(c context.Context, address string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FundByFriendbot", c, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FundByFriendbot indicates an expected call of FundByFriendbot.
+func (mr *MockRestyMockRecorder) FundByFriendbot(c, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FundByFriendbot", reflect.TypeOf((*MockResty)(nil).FundByFriendbot), c, address)
+}
+
+// SignCSBlob mocks base method.
+func (m *MockResty) SignCSBlob(c context.Context, csToken, keyId string, message []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignCSBlob", c, csToken, keyId, message)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignCSBlob indicates an expected call of SignCSBlob.
+func (mr *MockRestyMockRecorder) SignCSBlob(c, csToken, keyId, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignCSBlob", reflect.TypeOf((*MockResty)(nil).SignCSBlob), c, csToken, keyId, message)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: secret.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/57blocks/auto-action/server/internal/dto"
+	model "github.com/57blocks/auto-action/server/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSecret is a mock of Secret interface.
+type MockSecret struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretMockRecorder
+}
+
+// MockSecretMockRecorder is the mock recorder for MockSecret.
+type MockSecretMockRecorder struct {
+	mock *MockSecret
+}
+
+// NewMockSecret creates a new mock instance.
+func NewMockSecret(ctrl *gomock.Controller) *MockSecret {
+	mock := &MockSecret{ctrl: ctrl}
+	mock.recorder = &MockSecretMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecret) EXPECT() *MockSecretMockRecorder {
+	return m.recorder
+}
+
+// DeleteSecret mocks base method.
+func (m *MockSecret) DeleteSecret(c context.Context, acnID uint64, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecret", c, acnID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecret indicates an expected call of DeleteSecret.
+func (mr *MockSecretMockRecorder) DeleteSecret(c, acnID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecret", reflect.TypeOf((*MockSecret)(nil).DeleteSecret), c, acnID, name)
+}
+
+// GrantSecret mocks base method.
+func (m *MockSecret) GrantSecret(c context.Context, secretID uint64, lambdaIDs []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantSecret", c, secretID, lambdaIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantSecret indicates an expected call of GrantSecret.
+func (mr *MockSecretMockRecorder) GrantSecret(c, secretID, lambdaIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantSecret", reflect.TypeOf((*MockSecret)(nil).GrantSecret), c, secretID, lambdaIDs)
+}
+
+// LambdaSecrets mocks base method.
+func (m *MockSecret) LambdaSecrets(c context.Context, acnID uint64, functionName string) ([]*model.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LambdaSecrets", c, acnID, functionName)
+	ret0, _ := ret[0].([]*model.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LambdaSecrets indicates an expected call of LambdaSecrets.
+func (mr *MockSecretMockRecorder) LambdaSecrets(c, acnID, functionName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LambdaSecrets", reflect.TypeOf((*MockSecret)(nil).LambdaSecrets), c, acnID, functionName)
+}
+
+// ListGrants mocks base method.
+func (m *MockSecret) ListGrants(c context.Context, secretIDs []uint64) ([]*dto.RespSecretGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGrants", c, secretIDs)
+	ret0, _ := ret[0].([]*dto.RespSecretGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGrants indicates an expected call of ListGrants.
+func (mr *MockSecretMockRecorder) ListGrants(c, secretIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGrants", reflect.TypeOf((*MockSecret)(nil).ListGrants), c, secretIDs)
+}
+
+// ListSecrets mocks base method.
+func (m *MockSecret) ListSecrets(c context.Context, acnID uint64) ([]*dto.RespSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecrets", c, acnID)
+	ret0, _ := ret[0].([]*dto.RespSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecrets indicates an expected call of ListSecrets.
+func (mr *MockSecretMockRecorder) ListSecrets(c, acnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecrets", reflect.TypeOf((*MockSecret)(nil).ListSecrets), c, acnID)
+}
+
+// SaveSecret mocks base method.
+func (m *MockSecret) SaveSecret(c context.Context, secret *model.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSecret", c, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSecret indicates an expected call of SaveSecret.
+func (mr *MockSecretMockRecorder) SaveSecret(c, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSecret", reflect.TypeOf((*MockSecret)(nil).SaveSecret), c, secret)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/57blocks/auto-action/server/internal/dto"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSecretService is a mock of SecretService interface.
+type MockSecretService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSecretServiceMockRecorder
+}
+
+// MockSecretServiceMockRecorder is the mock recorder for MockSecretService.
+type MockSecretServiceMockRecorder struct {
+	mock *MockSecretService
+}
+
+// NewMockSecretService creates a new mock instance.
+func NewMockSecretService(ctrl *gomock.Controller) *MockSecretService {
+	mock := &MockSecretService{ctrl: ctrl}
+	mock.recorder = &MockSecretServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSecretService) EXPECT() *MockSecretServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockSecretService) List(c context.Context) ([]*dto.RespSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", c)
+	ret0, _ := ret[0].([]*dto.RespSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSecretServiceMockRecorder) List(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSecretService)(nil).List), c)
+}
+
+// Remove mocks base method.
+func (m *MockSecretService) Remove(c context.Context, r *dto.ReqURISecret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", c, r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockSecretServiceMockRecorder) Remove(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockSecretService)(nil).Remove), c, r)
+}
+
+// Runtime mocks base method.
+func (m *MockSecretService) Runtime(c context.Context) (*dto.RespRuntimeSecrets, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Runtime", c)
+	ret0, _ := ret[0].(*dto.RespRuntimeSecrets)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Runtime indicates an expected call of Runtime.
+func (mr *MockSecretServiceMockRecorder) Runtime(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Runtime", reflect.TypeOf((*MockSecretService)(nil).Runtime), c)
+}
+
+// Set mocks base method.
+func (m *MockSecretService) Set(c context.Context, r *dto.ReqSetSecret) (*dto.RespSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", c, r)
+	ret0, _ := ret[0].(*dto.RespSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockSecretServiceMockRecorder) Set(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockSecretService)(nil).Set), c, r)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: soroban.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	context "context"
+	reflect "reflect"
+
+	sorobanx "github.com/57blocks/auto-action/server/internal/third-party/sorobanx"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSoroban is a mock of Soroban interface.
+type MockSoroban struct {
+	ctrl     *gomock.Controller
+	recorder *MockSorobanMockRecorder
+}
+
+// MockSorobanMockRecorder is the mock recorder for MockSoroban.
+type MockSorobanMockRecorder struct {
+	mock *MockSoroban
+}
+
+// NewMockSoroban creates a new mock instance.
+func NewMockSoroban(ctrl *gomock.Controller) *MockSoroban {
+	mock := &MockSoroban{ctrl: ctrl}
+	mock.recorder = &MockSorobanMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSoroban) EXPECT() *MockSorobanMockRecorder {
+	return m.recorder
+}
+
+// Enabled mocks base method.
+func (m *MockSoroban) Enabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Enabled indicates an expected call of Enabled.
+func (mr *MockSorobanMockRecorder) Enabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enabled", reflect.TypeOf((*MockSoroban)(nil).Enabled))
+}
+
+// GetEvents mocks base method.
+func (m *MockSoroban) GetEvents(c context.Context, req *sorobanx.EventsRequest) (*sorobanx.EventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvents", c, req)
+	ret0, _ := ret[0].(*sorobanx.EventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents.
+func (mr *MockSorobanMockRecorder) GetEvents(c, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockSoroban)(nil).GetEvents), c, req)
+}
+
+// GetLatestLedger mocks base method.
+func (m *MockSoroban) GetLatestLedger(c context.Context) (*sorobanx.LatestLedger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestLedger", c)
+	ret0, _ := ret[0].(*sorobanx.LatestLedger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestLedger indicates an expected call of GetLatestLedger.
+func (mr *MockSorobanMockRecorder) GetLatestLedger(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestLedger", reflect.TypeOf((*MockSoroban)(nil).GetLatestLedger), c)
+}
@@ -51,6 +51,92 @@ func (mr *MockStellarMockRecorder) AccountDetail(c, req interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountDetail", reflect.TypeOf((*MockStellar)(nil).AccountDetail), c, req)
 }
 
+// CachedAccountDetail mocks base method.
+func (m *MockStellar) CachedAccountDetail(c context.Context, address string) (horizon.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachedAccountDetail", c, address)
+	ret0, _ := ret[0].(horizon.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CachedAccountDetail indicates an expected call of CachedAccountDetail.
+func (mr *MockStellarMockRecorder) CachedAccountDetail(c, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedAccountDetail", reflect.TypeOf((*MockStellar)(nil).CachedAccountDetail), c, address)
+}
+
+// NetworkPassphrase mocks base method.
+func (m *MockStellar) NetworkPassphrase() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkPassphrase")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NetworkPassphrase indicates an expected call of NetworkPassphrase.
+func (mr *MockStellarMockRecorder) NetworkPassphrase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkPassphrase", reflect.TypeOf((*MockStellar)(nil).NetworkPassphrase))
+}
+
+// StreamEffects mocks base method.
+func (m *MockStellar) StreamEffects(c context.Context, req horizonclient.EffectRequest, handler horizonclient.EffectHandler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamEffects", c, req, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamEffects indicates an expected call of StreamEffects.
+func (mr *MockStellarMockRecorder) StreamEffects(c, req, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamEffects", reflect.TypeOf((*MockStellar)(nil).StreamEffects), c, req, handler)
+}
+
+// StreamOperations mocks base method.
+func (m *MockStellar) StreamOperations(c context.Context, req horizonclient.OperationRequest, handler horizonclient.OperationHandler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamOperations", c, req, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamOperations indicates an expected call of StreamOperations.
+func (mr *MockStellarMockRecorder) StreamOperations(c, req, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamOperations", reflect.TypeOf((*MockStellar)(nil).StreamOperations), c, req, handler)
+}
+
+// StreamPayments mocks base method.
+func (m *MockStellar) StreamPayments(c context.Context, req horizonclient.OperationRequest, handler horizonclient.OperationHandler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPayments", c, req, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPayments indicates an expected call of StreamPayments.
+func (mr *MockStellarMockRecorder) StreamPayments(c, req, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPayments", reflect.TypeOf((*MockStellar)(nil).StreamPayments), c, req, handler)
+}
+
+// SubmitTransactionXDR mocks base method.
+func (m *MockStellar) SubmitTransactionXDR(c context.Context, envelope string) (horizon.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitTransactionXDR", c, envelope)
+	ret0, _ := ret[0].(horizon.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitTransactionXDR indicates an expected call of SubmitTransactionXDR.
+func (mr *MockStellarMockRecorder) SubmitTransactionXDR(c, envelope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTransactionXDR", reflect.TypeOf((*MockStellar)(nil).SubmitTransactionXDR), c, envelope)
+}
+
 // MockHorizonClient is a mock of HorizonClient interface.
 type MockHorizonClient struct {
 	ctrl     *gomock.Controller
@@ -88,3 +174,60 @@ func (mr *MockHorizonClientMockRecorder) AccountDetail(req interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountDetail", reflect.TypeOf((*MockHorizonClient)(nil).AccountDetail), req)
 }
+
+// StreamEffects mocks base method.
+func (m *MockHorizonClient) StreamEffects(ctx context.Context, req horizonclient.EffectRequest, handler horizonclient.EffectHandler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamEffects", ctx, req, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamEffects indicates an expected call of StreamEffects.
+func (mr *MockHorizonClientMockRecorder) StreamEffects(ctx, req, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamEffects", reflect.TypeOf((*MockHorizonClient)(nil).StreamEffects), ctx, req, handler)
+}
+
+// StreamOperations mocks base method.
+func (m *MockHorizonClient) StreamOperations(ctx context.Context, req horizonclient.OperationRequest, handler horizonclient.OperationHandler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamOperations", ctx, req, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamOperations indicates an expected call of StreamOperations.
+func (mr *MockHorizonClientMockRecorder) StreamOperations(ctx, req, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamOperations", reflect.TypeOf((*MockHorizonClient)(nil).StreamOperations), ctx, req, handler)
+}
+
+// StreamPayments mocks base method.
+func (m *MockHorizonClient) StreamPayments(ctx context.Context, req horizonclient.OperationRequest, handler horizonclient.OperationHandler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamPayments", ctx, req, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamPayments indicates an expected call of StreamPayments.
+func (mr *MockHorizonClientMockRecorder) StreamPayments(ctx, req, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamPayments", reflect.TypeOf((*MockHorizonClient)(nil).StreamPayments), ctx, req, handler)
+}
+
+// SubmitTransactionXDR mocks base method.
+func (m *MockHorizonClient) SubmitTransactionXDR(transactionXdr string) (horizon.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitTransactionXDR", transactionXdr)
+	ret0, _ := ret[0].(horizon.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitTransactionXDR indicates an expected call of SubmitTransactionXDR.
+func (mr *MockHorizonClientMockRecorder) SubmitTransactionXDR(transactionXdr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTransactionXDR", reflect.TypeOf((*MockHorizonClient)(nil).SubmitTransactionXDR), transactionXdr)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: wallet.go
+
+// Package testdata is a generated GoMock package.
+package testdata
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/57blocks/auto-action/server/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWallet is a mock of Wallet interface.
+type MockWallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletMockRecorder
+}
+
+// MockWalletMockRecorder is the mock recorder for MockWallet.
+type MockWalletMockRecorder struct {
+	mock *MockWallet
+}
+
+// NewMockWallet creates a new mock instance.
+func NewMockWallet(ctrl *gomock.Controller) *MockWallet {
+	mock := &MockWallet{ctrl: ctrl}
+	mock.recorder = &MockWalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWallet) EXPECT() *MockWalletMockRecorder {
+	return m.recorder
+}
+
+// CompleteActivation mocks base method.
+func (m *MockWallet) CompleteActivation(c context.Context, id uint64, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteActivation", c, id, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteActivation indicates an expected call of CompleteActivation.
+func (mr *MockWalletMockRecorder) CompleteActivation(c, id, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteActivation", reflect.TypeOf((*MockWallet)(nil).CompleteActivation), c, id, hash)
+}
+
+// CountActivations mocks base method.
+func (m *MockWallet) CountActivations(c context.Context, orgID uint64, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActivations", c, orgID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActivations indicates an expected call of CountActivations.
+func (mr *MockWalletMockRecorder) CountActivations(c, orgID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActivations", reflect.TypeOf((*MockWallet)(nil).CountActivations), c, orgID, since)
+}
+
+// FundingWallet mocks base method.
+func (m *MockWallet) FundingWallet(c context.Context, orgID uint64) (*model.FundingWallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FundingWallet", c, orgID)
+	ret0, _ := ret[0].(*model.FundingWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FundingWallet indicates an expected call of FundingWallet.
+func (mr *MockWalletMockRecorder) FundingWallet(c, orgID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FundingWallet", reflect.TypeOf((*MockWallet)(nil).FundingWallet), c, orgID)
+}
+
+// ReleaseActivation mocks base method.
+func (m *MockWallet) ReleaseActivation(c context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseActivation", c, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseActivation indicates an expected call of ReleaseActivation.
+func (mr *MockWalletMockRecorder) ReleaseActivation(c, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseActivation", reflect.TypeOf((*MockWallet)(nil).ReleaseActivation), c, id)
+}
+
+// ReserveActivation mocks base method.
+func (m *MockWallet) ReserveActivation(c context.Context, activation *model.WalletActivation, since time.Time, dailyCap int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveActivation", c, activation, since, dailyCap)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveActivation indicates an expected call of ReserveActivation.
+func (mr *MockWalletMockRecorder) ReserveActivation(c, activation, since, dailyCap interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveActivation", reflect.TypeOf((*MockWallet)(nil).ReserveActivation), c, activation, since, dailyCap)
+}
+
+// SetFundingWallet mocks base method.
+func (m *MockWallet) SetFundingWallet(c context.Context, orgID, accountID uint64, address string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFundingWallet", c, orgID, accountID, address)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFundingWallet indicates an expected call of SetFundingWallet.
+func (mr *MockWalletMockRecorder) SetFundingWallet(c, orgID, accountID, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFundingWallet", reflect.TypeOf((*MockWallet)(nil).SetFundingWallet), c, orgID, accountID, address)
+}
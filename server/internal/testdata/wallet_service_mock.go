@@ -35,6 +35,21 @@ func (m *MockWalletService) EXPECT() *MockWalletServiceMockRecorder {
 	return m.recorder
 }
 
+// Activate mocks base method.
+func (m *MockWalletService) Activate(c context.Context, r *dto.ReqActivateWallet) (*dto.RespActivateWallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activate", c, r)
+	ret0, _ := ret[0].(*dto.RespActivateWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockWalletServiceMockRecorder) Activate(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockWalletService)(nil).Activate), c, r)
+}
+
 // Create mocks base method.
 func (m *MockWalletService) Create(c context.Context) (*dto.RespCreateWallet, error) {
 	m.ctrl.T.Helper()
@@ -50,19 +65,64 @@ func (mr *MockWalletServiceMockRecorder) Create(c interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWalletService)(nil).Create), c)
 }
 
+// Fund mocks base method.
+func (m *MockWalletService) Fund(c context.Context, r *dto.ReqFundWallet) (*dto.RespFundWallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fund", c, r)
+	ret0, _ := ret[0].(*dto.RespFundWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fund indicates an expected call of Fund.
+func (mr *MockWalletServiceMockRecorder) Fund(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fund", reflect.TypeOf((*MockWalletService)(nil).Fund), c, r)
+}
+
+// Funding mocks base method.
+func (m *MockWalletService) Funding(c context.Context) (*dto.RespFundingWallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Funding", c)
+	ret0, _ := ret[0].(*dto.RespFundingWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Funding indicates an expected call of Funding.
+func (mr *MockWalletServiceMockRecorder) Funding(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Funding", reflect.TypeOf((*MockWalletService)(nil).Funding), c)
+}
+
+// Info mocks base method.
+func (m *MockWalletService) Info(c context.Context, r *dto.ReqWalletInfo) (*dto.RespWalletInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Info", c, r)
+	ret0, _ := ret[0].(*dto.RespWalletInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockWalletServiceMockRecorder) Info(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockWalletService)(nil).Info), c, r)
+}
+
 // List mocks base method.
-func (m *MockWalletService) List(c context.Context) (*dto.RespListWallets, error) {
+func (m *MockWalletService) List(c context.Context, r *dto.ReqListWallets) (*dto.RespListWallets, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", c)
+	ret := m.ctrl.Call(m, "List", c, r)
 	ret0, _ := ret[0].(*dto.RespListWallets)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockWalletServiceMockRecorder) List(c interface{}) *gomock.Call {
+func (mr *MockWalletServiceMockRecorder) List(c, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWalletService)(nil).List), c)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWalletService)(nil).List), c, r)
 }
 
 // Remove mocks base method.
@@ -79,6 +139,36 @@ func (mr *MockWalletServiceMockRecorder) Remove(c, r interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockWalletService)(nil).Remove), c, r)
 }
 
+// SetFunding mocks base method.
+func (m *MockWalletService) SetFunding(c context.Context, r *dto.ReqSetFundingWallet) (*dto.RespFundingWallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFunding", c, r)
+	ret0, _ := ret[0].(*dto.RespFundingWallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetFunding indicates an expected call of SetFunding.
+func (mr *MockWalletServiceMockRecorder) SetFunding(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFunding", reflect.TypeOf((*MockWalletService)(nil).SetFunding), c, r)
+}
+
+// Submit mocks base method.
+func (m *MockWalletService) Submit(c context.Context, r *dto.ReqSubmitTransaction) (*dto.RespSubmitTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", c, r)
+	ret0, _ := ret[0].(*dto.RespSubmitTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockWalletServiceMockRecorder) Submit(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockWalletService)(nil).Submit), c, r)
+}
+
 // Verify mocks base method.
 func (m *MockWalletService) Verify(c context.Context, r *dto.ReqVerifyWallet) (*dto.RespVerifyWallet, error) {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package amazonx
 
 import (
 	"context"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
@@ -9,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/scheduler"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 )
 
 //go:generate mockgen -destination ../../testdata/amazon_mock.go -package testdata -source amazon.go Amazon
@@ -19,6 +21,30 @@ type (
 			input *lambda.CreateFunctionInput,
 			opts ...func(*lambda.Options),
 		) (*lambda.CreateFunctionOutput, error)
+		UpdateLambdaCode(
+			c context.Context,
+			input *lambda.UpdateFunctionCodeInput,
+		) (*lambda.UpdateFunctionCodeOutput, error)
+		UpdateLambdaConfig(
+			c context.Context,
+			input *lambda.UpdateFunctionConfigurationInput,
+		) (*lambda.UpdateFunctionConfigurationOutput, error)
+		WaitLambdaUpdated(
+			c context.Context,
+			input *lambda.GetFunctionInput,
+		) error
+		PublishLambdaVersion(
+			c context.Context,
+			input *lambda.PublishVersionInput,
+		) (*lambda.PublishVersionOutput, error)
+		CreateLambdaAlias(
+			c context.Context,
+			input *lambda.CreateAliasInput,
+		) (*lambda.CreateAliasOutput, error)
+		UpdateLambdaAlias(
+			c context.Context,
+			input *lambda.UpdateAliasInput,
+		) (*lambda.UpdateAliasOutput, error)
 		BoundScheduler(
 			c context.Context,
 			input *scheduler.CreateScheduleInput,
@@ -28,10 +54,22 @@ type (
 			c context.Context,
 			input *scheduler.GetScheduleInput,
 		) (*scheduler.GetScheduleOutput, error)
+		UpdateScheduler(
+			c context.Context,
+			input *scheduler.UpdateScheduleInput,
+		) (*scheduler.UpdateScheduleOutput, error)
 		InvokeLambda(
 			c context.Context,
 			input *lambda.InvokeInput,
 		) (*lambda.InvokeOutput, error)
+		GetLambdaEventInvokeConfig(
+			c context.Context,
+			input *lambda.GetFunctionEventInvokeConfigInput,
+		) (*lambda.GetFunctionEventInvokeConfigOutput, error)
+		PutLambdaEventInvokeConfig(
+			c context.Context,
+			input *lambda.PutFunctionEventInvokeConfigInput,
+		) (*lambda.PutFunctionEventInvokeConfigOutput, error)
 		DescribeLogStreams(
 			c context.Context,
 			input *cloudwatchlogs.DescribeLogStreamsInput,
@@ -40,6 +78,10 @@ type (
 			c context.Context,
 			input *cloudwatchlogs.GetLogEventsInput,
 		) (*cloudwatchlogs.GetLogEventsOutput, error)
+		FilterLogEvents(
+			c context.Context,
+			input *cloudwatchlogs.FilterLogEventsInput,
+		) (*cloudwatchlogs.FilterLogEventsOutput, error)
 		GetSecretValue(
 			c context.Context,
 			input *secretsmanager.GetSecretValueInput,
@@ -72,6 +114,14 @@ type (
 			c context.Context,
 			input *secretsmanager.PutResourcePolicyInput,
 		) (*secretsmanager.PutResourcePolicyOutput, error)
+		ReceiveMessage(
+			c context.Context,
+			input *sqs.ReceiveMessageInput,
+		) (*sqs.ReceiveMessageOutput, error)
+		DeleteMessageBatch(
+			c context.Context,
+			input *sqs.DeleteMessageBatchInput,
+		) (*sqs.DeleteMessageBatchOutput, error)
 	}
 
 	SecretManagerClient interface {
@@ -84,17 +134,27 @@ type (
 		DeleteFunction(ctx context.Context, params *lambda.DeleteFunctionInput, optFns ...func(*lambda.Options)) (*lambda.DeleteFunctionOutput, error)
 		Invoke(ctx context.Context, params *lambda.InvokeInput, optFns ...func(*lambda.Options)) (*lambda.InvokeOutput, error)
 		CreateFunction(ctx context.Context, params *lambda.CreateFunctionInput, optFns ...func(*lambda.Options)) (*lambda.CreateFunctionOutput, error)
+		UpdateFunctionCode(ctx context.Context, params *lambda.UpdateFunctionCodeInput, optFns ...func(*lambda.Options)) (*lambda.UpdateFunctionCodeOutput, error)
+		UpdateFunctionConfiguration(ctx context.Context, params *lambda.UpdateFunctionConfigurationInput, optFns ...func(*lambda.Options)) (*lambda.UpdateFunctionConfigurationOutput, error)
+		GetFunction(ctx context.Context, params *lambda.GetFunctionInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionOutput, error)
+		PublishVersion(ctx context.Context, params *lambda.PublishVersionInput, optFns ...func(*lambda.Options)) (*lambda.PublishVersionOutput, error)
+		CreateAlias(ctx context.Context, params *lambda.CreateAliasInput, optFns ...func(*lambda.Options)) (*lambda.CreateAliasOutput, error)
+		UpdateAlias(ctx context.Context, params *lambda.UpdateAliasInput, optFns ...func(*lambda.Options)) (*lambda.UpdateAliasOutput, error)
+		GetFunctionEventInvokeConfig(ctx context.Context, params *lambda.GetFunctionEventInvokeConfigInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionEventInvokeConfigOutput, error)
+		PutFunctionEventInvokeConfig(ctx context.Context, params *lambda.PutFunctionEventInvokeConfigInput, optFns ...func(*lambda.Options)) (*lambda.PutFunctionEventInvokeConfigOutput, error)
 	}
 
 	SchedulerClient interface {
 		CreateSchedule(ctx context.Context, params *scheduler.CreateScheduleInput, optFns ...func(*scheduler.Options)) (*scheduler.CreateScheduleOutput, error)
 		DeleteSchedule(ctx context.Context, params *scheduler.DeleteScheduleInput, optFns ...func(*scheduler.Options)) (*scheduler.DeleteScheduleOutput, error)
 		GetSchedule(ctx context.Context, params *scheduler.GetScheduleInput, optFns ...func(*scheduler.Options)) (*scheduler.GetScheduleOutput, error)
+		UpdateSchedule(ctx context.Context, params *scheduler.UpdateScheduleInput, optFns ...func(*scheduler.Options)) (*scheduler.UpdateScheduleOutput, error)
 	}
 
 	CloudWatchLogsClient interface {
 		DescribeLogStreams(ctx context.Context, params *cloudwatchlogs.DescribeLogStreamsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error)
 		GetLogEvents(ctx context.Context, params *cloudwatchlogs.GetLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.GetLogEventsOutput, error)
+		FilterLogEvents(ctx context.Context, params *cloudwatchlogs.FilterLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error)
 	}
 
 	IamClient interface {
@@ -103,6 +163,11 @@ type (
 		PutRolePolicy(ctx context.Context, params *iam.PutRolePolicyInput, optFns ...func(*iam.Options)) (*iam.PutRolePolicyOutput, error)
 	}
 
+	SqsClient interface {
+		ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+		DeleteMessageBatch(ctx context.Context, params *sqs.DeleteMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
+	}
+
 	amazon struct {
 		amazonConfig         aws.Config
 		secretManagerClient  SecretManagerClient
@@ -110,6 +175,7 @@ type (
 		schedulerClient      SchedulerClient
 		cloudWatchLogsClient CloudWatchLogsClient
 		iamClient            IamClient
+		sqsClient            SqsClient
 	}
 	amazonOpt func(*amazon)
 )
@@ -159,14 +225,66 @@ func withIamClient(client IamClient) amazonOpt {
 	}
 }
 
+func withSqsClient(client SqsClient) amazonOpt {
+	return func(a *amazon) {
+		a.sqsClient = client
+	}
+}
+
 // Conductor implementation of Amazon
 var Conductor Amazon
 
+const lambdaUpdateMaxWait = 2 * time.Minute
+
 func (a *amazon) RegisterLambda(c context.Context, input *lambda.CreateFunctionInput, opts ...func(*lambda.Options)) (*lambda.CreateFunctionOutput,
 	error) {
 	return a.lambdaClient.CreateFunction(c, input, opts...)
 }
 
+func (a *amazon) UpdateLambdaCode(
+	c context.Context,
+	input *lambda.UpdateFunctionCodeInput,
+) (*lambda.UpdateFunctionCodeOutput, error) {
+	return a.lambdaClient.UpdateFunctionCode(c, input)
+}
+
+func (a *amazon) UpdateLambdaConfig(
+	c context.Context,
+	input *lambda.UpdateFunctionConfigurationInput,
+) (*lambda.UpdateFunctionConfigurationOutput, error) {
+	return a.lambdaClient.UpdateFunctionConfiguration(c, input)
+}
+
+// WaitLambdaUpdated blocks until the last update of the function is no longer in progress,
+// Lambda rejects any further update on the function before that.
+func (a *amazon) WaitLambdaUpdated(
+	c context.Context,
+	input *lambda.GetFunctionInput,
+) error {
+	return lambda.NewFunctionUpdatedV2Waiter(a.lambdaClient).Wait(c, input, lambdaUpdateMaxWait)
+}
+
+func (a *amazon) PublishLambdaVersion(
+	c context.Context,
+	input *lambda.PublishVersionInput,
+) (*lambda.PublishVersionOutput, error) {
+	return a.lambdaClient.PublishVersion(c, input)
+}
+
+func (a *amazon) CreateLambdaAlias(
+	c context.Context,
+	input *lambda.CreateAliasInput,
+) (*lambda.CreateAliasOutput, error) {
+	return a.lambdaClient.CreateAlias(c, input)
+}
+
+func (a *amazon) UpdateLambdaAlias(
+	c context.Context,
+	input *lambda.UpdateAliasInput,
+) (*lambda.UpdateAliasOutput, error) {
+	return a.lambdaClient.UpdateAlias(c, input)
+}
+
 func (a *amazon) BoundScheduler(c context.Context, input *scheduler.CreateScheduleInput, opts ...func(*scheduler.Options)) (*scheduler.CreateScheduleOutput, error) {
 	return a.schedulerClient.CreateSchedule(c, input, opts...)
 }
@@ -175,10 +293,31 @@ func (a *amazon) GetScheduler(c context.Context, input *scheduler.GetScheduleInp
 	return a.schedulerClient.GetSchedule(c, input)
 }
 
+func (a *amazon) UpdateScheduler(
+	c context.Context,
+	input *scheduler.UpdateScheduleInput,
+) (*scheduler.UpdateScheduleOutput, error) {
+	return a.schedulerClient.UpdateSchedule(c, input)
+}
+
 func (a *amazon) InvokeLambda(c context.Context, input *lambda.InvokeInput) (*lambda.InvokeOutput, error) {
 	return a.lambdaClient.Invoke(c, input)
 }
 
+func (a *amazon) GetLambdaEventInvokeConfig(
+	c context.Context,
+	input *lambda.GetFunctionEventInvokeConfigInput,
+) (*lambda.GetFunctionEventInvokeConfigOutput, error) {
+	return a.lambdaClient.GetFunctionEventInvokeConfig(c, input)
+}
+
+func (a *amazon) PutLambdaEventInvokeConfig(
+	c context.Context,
+	input *lambda.PutFunctionEventInvokeConfigInput,
+) (*lambda.PutFunctionEventInvokeConfigOutput, error) {
+	return a.lambdaClient.PutFunctionEventInvokeConfig(c, input)
+}
+
 func (a *amazon) DescribeLogStreams(
 	c context.Context,
 	input *cloudwatchlogs.DescribeLogStreamsInput,
@@ -193,6 +332,13 @@ func (a *amazon) GetLogEvents(
 	return a.cloudWatchLogsClient.GetLogEvents(c, input)
 }
 
+func (a *amazon) FilterLogEvents(
+	c context.Context,
+	input *cloudwatchlogs.FilterLogEventsInput,
+) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	return a.cloudWatchLogsClient.FilterLogEvents(c, input)
+}
+
 func (a *amazon) GetSecretValue(
 	c context.Context,
 	input *secretsmanager.GetSecretValueInput,
@@ -248,3 +394,17 @@ func (a *amazon) PutResourcePolicy(
 ) (*secretsmanager.PutResourcePolicyOutput, error) {
 	return a.secretManagerClient.PutResourcePolicy(c, input)
 }
+
+func (a *amazon) ReceiveMessage(
+	c context.Context,
+	input *sqs.ReceiveMessageInput,
+) (*sqs.ReceiveMessageOutput, error) {
+	return a.sqsClient.ReceiveMessage(c, input)
+}
+
+func (a *amazon) DeleteMessageBatch(
+	c context.Context,
+	input *sqs.DeleteMessageBatchInput,
+) (*sqs.DeleteMessageBatchOutput, error) {
+	return a.sqsClient.DeleteMessageBatch(c, input)
+}
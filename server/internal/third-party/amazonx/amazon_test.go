@@ -10,8 +10,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambTypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 	"github.com/aws/aws-sdk-go-v2/service/scheduler"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 )
@@ -113,6 +115,122 @@ func TestRegisterLambda(t *testing.T) {
 	assert.Equal(t, expectedOutput, output)
 }
 
+func TestUpdateLambdaCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambdaClient := testdata.NewMockLambdaClient(ctrl)
+
+	expectedOutput := &lambda.UpdateFunctionCodeOutput{}
+	mockLambdaClient.EXPECT().UpdateFunctionCode(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		lambdaClient: mockLambdaClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.UpdateLambdaCode(ctx, &lambda.UpdateFunctionCodeInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
+func TestUpdateLambdaConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambdaClient := testdata.NewMockLambdaClient(ctrl)
+
+	expectedOutput := &lambda.UpdateFunctionConfigurationOutput{}
+	mockLambdaClient.EXPECT().UpdateFunctionConfiguration(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		lambdaClient: mockLambdaClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.UpdateLambdaConfig(ctx, &lambda.UpdateFunctionConfigurationInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
+func TestWaitLambdaUpdated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambdaClient := testdata.NewMockLambdaClient(ctrl)
+
+	mockLambdaClient.EXPECT().GetFunction(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&lambda.GetFunctionOutput{
+			Configuration: &lambTypes.FunctionConfiguration{
+				LastUpdateStatus: lambTypes.LastUpdateStatusSuccessful,
+			},
+		}, nil)
+
+	amazon := &amazon{
+		lambdaClient: mockLambdaClient,
+	}
+	ctx := new(gin.Context)
+	err := amazon.WaitLambdaUpdated(ctx, &lambda.GetFunctionInput{FunctionName: aws.String("test")})
+	assert.NoError(t, err)
+}
+
+func TestPublishLambdaVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambdaClient := testdata.NewMockLambdaClient(ctrl)
+
+	expectedOutput := &lambda.PublishVersionOutput{}
+	mockLambdaClient.EXPECT().PublishVersion(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		lambdaClient: mockLambdaClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.PublishLambdaVersion(ctx, &lambda.PublishVersionInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
+func TestCreateLambdaAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambdaClient := testdata.NewMockLambdaClient(ctrl)
+
+	expectedOutput := &lambda.CreateAliasOutput{}
+	mockLambdaClient.EXPECT().CreateAlias(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		lambdaClient: mockLambdaClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.CreateLambdaAlias(ctx, &lambda.CreateAliasInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
+func TestUpdateLambdaAlias(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambdaClient := testdata.NewMockLambdaClient(ctrl)
+
+	expectedOutput := &lambda.UpdateAliasOutput{}
+	mockLambdaClient.EXPECT().UpdateAlias(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		lambdaClient: mockLambdaClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.UpdateLambdaAlias(ctx, &lambda.UpdateAliasInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
 func TestInvokeLambda(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -170,6 +288,25 @@ func TestGetScheduler(t *testing.T) {
 	assert.Equal(t, expectedOutput, output)
 }
 
+func TestUpdateScheduler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSchedulerClient := testdata.NewMockSchedulerClient(ctrl)
+
+	expectedOutput := &scheduler.UpdateScheduleOutput{}
+	mockSchedulerClient.EXPECT().UpdateSchedule(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		schedulerClient: mockSchedulerClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.UpdateScheduler(ctx, &scheduler.UpdateScheduleInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
 func TestRemoveScheduler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -227,6 +364,63 @@ func TestGetLogEvents(t *testing.T) {
 	assert.Equal(t, expectedOutput, output)
 }
 
+func TestFilterLogEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCloudWatchLogsClient := testdata.NewMockCloudWatchLogsClient(ctrl)
+
+	expectedOutput := &cloudwatchlogs.FilterLogEventsOutput{}
+	mockCloudWatchLogsClient.EXPECT().FilterLogEvents(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		cloudWatchLogsClient: mockCloudWatchLogsClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.FilterLogEvents(ctx, &cloudwatchlogs.FilterLogEventsInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
+func TestGetLambdaEventInvokeConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambdaClient := testdata.NewMockLambdaClient(ctrl)
+
+	expectedOutput := &lambda.GetFunctionEventInvokeConfigOutput{}
+	mockLambdaClient.EXPECT().GetFunctionEventInvokeConfig(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		lambdaClient: mockLambdaClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.GetLambdaEventInvokeConfig(ctx, &lambda.GetFunctionEventInvokeConfigInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
+func TestPutLambdaEventInvokeConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLambdaClient := testdata.NewMockLambdaClient(ctrl)
+
+	expectedOutput := &lambda.PutFunctionEventInvokeConfigOutput{}
+	mockLambdaClient.EXPECT().PutFunctionEventInvokeConfig(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		lambdaClient: mockLambdaClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.PutLambdaEventInvokeConfig(ctx, &lambda.PutFunctionEventInvokeConfigInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
 func TestGetRole(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -283,3 +477,41 @@ func TestPutRolePolicy(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedOutput, output)
 }
+
+func TestReceiveMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSqsClient := testdata.NewMockSqsClient(ctrl)
+
+	expectedOutput := &sqs.ReceiveMessageOutput{}
+	mockSqsClient.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		sqsClient: mockSqsClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
+
+func TestDeleteMessageBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSqsClient := testdata.NewMockSqsClient(ctrl)
+
+	expectedOutput := &sqs.DeleteMessageBatchOutput{}
+	mockSqsClient.EXPECT().DeleteMessageBatch(gomock.Any(), gomock.Any()).
+		Return(expectedOutput, nil)
+
+	amazon := &amazon{
+		sqsClient: mockSqsClient,
+	}
+	ctx := new(gin.Context)
+	output, err := amazon.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOutput, output)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/scheduler"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 )
 
 var (
@@ -25,6 +26,7 @@ var (
 	schedulerClient      *scheduler.Client
 	cloudWatchLogsClient *cloudwatchlogs.Client
 	iamClient            *iam.Client
+	sqsClient            *sqs.Client
 )
 
 func Setup() error {
@@ -45,6 +47,7 @@ func Setup() error {
 		cloudWatchLogsClient = cloudwatchlogs.NewFromConfig(amazonConfig)
 		secretManagerClient = secretsmanager.NewFromConfig(amazonConfig)
 		iamClient = iam.NewFromConfig(amazonConfig)
+		sqsClient = sqs.NewFromConfig(amazonConfig)
 
 		Conductor = buildAmazonConductor(
 			withConfig(amazonConfig),
@@ -53,6 +56,7 @@ func Setup() error {
 			withSchedulerClient(schedulerClient),
 			withCloudWatchLogsClient(cloudWatchLogsClient),
 			withIamClient(iamClient),
+			withSqsClient(sqsClient),
 		)
 	})
 
@@ -0,0 +1,76 @@
+package bundle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/57blocks/auto-action/server/internal/constant"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/eslint"
+)
+
+//go:generate mockgen -destination ../../testdata/bundle_mock.go -package testdata -source bundle.go Bundle
+type (
+	// Bundle validates the zipped bundles of Lambdas, by the validator registered for their runtime.
+	Bundle interface {
+		Validate(runtime, name string, zipBytes []byte) error
+	}
+
+	// Validator checks the bundle against the conventions of one runtime,
+	// the name is the one of the bundle, which is also the one of the entry file.
+	Validator interface {
+		Validate(name string, zipBytes []byte) error
+	}
+
+	// ValidatorFunc adapts an ordinary function to the Validator.
+	ValidatorFunc func(name string, zipBytes []byte) error
+
+	inspector struct {
+		validators map[string]Validator
+	}
+)
+
+var Inspector Bundle
+
+func Setup() error {
+	if err := eslint.Setup(); err != nil {
+		return err
+	}
+
+	js := ValidatorFunc(eslint.Check)
+
+	Inspector = NewInspector(map[string]Validator{
+		constant.LambdaRuntimeNodejs20:  js,
+		constant.LambdaRuntimeNodejs22:  js,
+		constant.LambdaRuntimePython312: ValidatorFunc(CheckPython),
+	})
+
+	return nil
+}
+
+// NewInspector builds the Bundle with the validators keyed by runtime.
+func NewInspector(validators map[string]Validator) Bundle {
+	return &inspector{validators: validators}
+}
+
+func (f ValidatorFunc) Validate(name string, zipBytes []byte) error {
+	return f(name, zipBytes)
+}
+
+func (i *inspector) Validate(runtime, name string, zipBytes []byte) error {
+	validator, ok := i.validators[runtime]
+	if !ok {
+		return errorx.BadRequest(fmt.Sprintf("none validator registered for runtime: %s", runtime))
+	}
+
+	if err := validator.Validate(name, zipBytes); err != nil {
+		e := new(errorx.Errorx)
+		if errors.As(err, &e) {
+			return err
+		}
+
+		return errorx.Internal(fmt.Sprintf("failed to validate bundle: %s.zip, err: %s", name, err.Error()))
+	}
+
+	return nil
+}
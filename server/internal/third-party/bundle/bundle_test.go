@@ -0,0 +1,135 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	testConfig := config.Configuration{
+		Log: config.Log{
+			Level:    "debug",
+			Encoding: "json",
+		},
+	}
+	logx.Setup(&testConfig)
+
+	os.Exit(m.Run())
+}
+
+func zipOf(t *testing.T, files map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+
+	for name, content := range files {
+		w, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestInspectorValidate(t *testing.T) {
+	called := ""
+	inspector := NewInspector(map[string]Validator{
+		"nodejs20.x": ValidatorFunc(func(name string, _ []byte) error {
+			called = name
+			return nil
+		}),
+	})
+
+	assert.NoError(t, inspector.Validate("nodejs20.x", "file1", nil))
+	assert.Equal(t, "file1", called)
+}
+
+func TestInspectorValidateUnregistered(t *testing.T) {
+	inspector := NewInspector(map[string]Validator{})
+
+	err := inspector.Validate("python3.12", "file1", nil)
+	assert.Equal(t, errorx.BadRequest("none validator registered for runtime: python3.12"), err)
+}
+
+func TestInspectorValidateUnrecognizedError(t *testing.T) {
+	inspector := NewInspector(map[string]Validator{
+		"nodejs20.x": ValidatorFunc(func(string, []byte) error {
+			return errors.New("disk full")
+		}),
+	})
+
+	err := inspector.Validate("nodejs20.x", "file1", nil)
+	e := new(errorx.Errorx)
+	assert.ErrorAs(t, err, &e)
+	assert.Equal(t, "failed to validate bundle: file1.zip, err: disk full", e.Message)
+}
+
+func TestCheckPythonSuccess(t *testing.T) {
+	err := CheckPython("file1", zipOf(t, map[string]string{
+		"file1.py":        "from lib.util import greet\n\n\ndef handler(event, context):\n    return greet(event)\n",
+		"lib/__init__.py": "",
+		"lib/util.py":     "def greet(event):\n    return {'hello': event}\n",
+	}))
+	assert.NoError(t, err)
+}
+
+func TestCheckPythonInvalidZip(t *testing.T) {
+	err := CheckPython("file1", []byte("not a zip"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid zip file: file1.zip")
+}
+
+func TestCheckPythonEntryNotFound(t *testing.T) {
+	err := CheckPython("file1", zipOf(t, map[string]string{
+		"src/file1.py": "def handler(event, context):\n    pass\n",
+	}))
+	assert.Equal(t, errorx.BadRequest("entry file: file1.py not found at the top level of: file1.zip"), err)
+}
+
+func TestCheckPythonHandlerNotDefined(t *testing.T) {
+	err := CheckPython("file1", zipOf(t, map[string]string{
+		"file1.py": "def main(event, context):\n    pass\n",
+	}))
+	assert.Equal(t, errorx.BadRequest("file1.zip/file1.py: handler function is not defined"), err)
+}
+
+func TestCheckPythonInvalidPath(t *testing.T) {
+	err := CheckPython("file1", zipOf(t, map[string]string{
+		"file1.py":   "def handler(event, context):\n    pass\n",
+		"../evil.py": "",
+	}))
+	assert.Equal(t, errorx.BadRequest("invalid file path: file1.zip/../evil.py"), err)
+}
+
+func TestCheckPythonSyntaxError(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not found")
+	}
+
+	err := CheckPython("file1", zipOf(t, map[string]string{
+		"file1.py": "def handler(event, context):\n    return {\n",
+	}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "file1.zip/file1.py: line 2")
+	assert.Contains(t, err.Error(), "SyntaxError")
+}
+
+func TestPySyntaxError(t *testing.T) {
+	output := `  File "file1.py", line 2
+    return {
+           ^
+SyntaxError: '{' was never closed
+`
+	assert.Equal(t, "line 2: SyntaxError: '{' was never closed", pySyntaxError(output))
+}
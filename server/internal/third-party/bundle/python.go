@@ -0,0 +1,140 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+)
+
+const PyExt = ".py"
+
+// the handler invoked by Lambda is the function named handler in the entry file.
+var pyHandlerRegex = regexp.MustCompile(`(?m)^def\s+handler\s*\(`)
+
+// CheckPython checks the entry file, which is named after the bundle at the top level,
+// and defines the handler function, then compiles the python files to catch the syntax errors.
+// The compiling is skipped when the interpreter is not found.
+func CheckPython(name string, zipBytes []byte) error {
+	zipName := name + ".zip"
+
+	archive, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	if err != nil {
+		return errorx.BadRequest(fmt.Sprintf("invalid zip file: %s, err: %s", zipName, err.Error()))
+	}
+
+	sources := make(map[string][]byte)
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !strings.HasSuffix(file.Name, PyExt) {
+			continue
+		}
+		if !filepath.IsLocal(file.Name) {
+			return errorx.BadRequest(fmt.Sprintf("invalid file path: %s/%s", zipName, file.Name))
+		}
+
+		content, err := readZipFile(file)
+		if err != nil {
+			return err
+		}
+
+		sources[file.Name] = content
+	}
+
+	entry := name + PyExt
+	content, ok := sources[entry]
+	if !ok {
+		return errorx.BadRequest(fmt.Sprintf("entry file: %s not found at the top level of: %s", entry, zipName))
+	}
+	if !pyHandlerRegex.Match(content) {
+		return errorx.BadRequest(fmt.Sprintf("%s/%s: handler function is not defined", zipName, entry))
+	}
+
+	return compilePython(zipName, sources)
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	open, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer open.Close()
+
+	return io.ReadAll(open)
+}
+
+// compilePython writes the sources into a temporary directory, and compiles them by py_compile,
+// the first syntax error is reported with the file and line of it.
+func compilePython(zipName string, sources map[string][]byte) error {
+	interpreter, err := exec.LookPath("python3")
+	if err != nil {
+		logx.Logger.WARN(fmt.Sprintf("python3 not found, skip compiling: %s", zipName))
+		return nil
+	}
+
+	tmpDir, err := os.MkdirTemp("", "bundle-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	paths := make([]string, 0, len(sources))
+	for name, content := range sources {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return err
+		}
+
+		paths = append(paths, name)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		cmd := exec.Command(interpreter, "-m", "py_compile", path)
+		cmd.Dir = tmpDir
+
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			continue
+		}
+
+		return errorx.BadRequest(fmt.Sprintf("%s/%s: %s", zipName, path, pySyntaxError(string(output))))
+	}
+
+	return nil
+}
+
+// pySyntaxError picks the line number and the error from the output of py_compile.
+func pySyntaxError(output string) string {
+	var location, message string
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(line, "File "):
+			if idx := strings.Index(line, "line "); idx >= 0 {
+				location = line[idx:]
+			}
+		case line != "":
+			message = line
+		}
+	}
+
+	if location == "" {
+		return message
+	}
+
+	return fmt.Sprintf("%s: %s", location, message)
+}
@@ -2,8 +2,6 @@ package eslint
 
 import (
 	"fmt"
-	"io"
-	"mime/multipart"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -24,17 +22,22 @@ const (
 
 var fileSystem util.FileSystem = &util.RealFileSystem{}
 
-func init() {
+// Setup warms up the ESLint inside docker, so that the first check is not slowed down by npx.
+func Setup() error {
 	if util.IsRunningInsideDocker(fileSystem) {
 		output, err := exec.Command("npx", "eslint", "-c", LintConfig, ".").CombinedOutput()
 		if err != nil {
-			panic(fmt.Sprintf("failed to init eslint: %s%s%s", string(output), PathSeparator, err.Error()))
+			return fmt.Errorf("failed to init eslint: %s%s%s", string(output), PathSeparator, err.Error())
 		}
 	}
+
+	return nil
 }
 
-func Check(zipFile *multipart.FileHeader) error {
-	tmpFilePath := filepath.Join(".", LintDir, uuid.New().String(), zipFile.Filename)
+// Check unzips the bundle named by the name, and lints the js files at the top level of it.
+func Check(name string, zipBytes []byte) error {
+	zipName := name + ".zip"
+	tmpFilePath := filepath.Join(".", LintDir, uuid.New().String(), zipName)
 	tmpDir := filepath.Dir(tmpFilePath)
 	defer os.RemoveAll(tmpDir)
 
@@ -42,19 +45,7 @@ func Check(zipFile *multipart.FileHeader) error {
 		return err
 	}
 
-	out, err := os.Create(tmpFilePath)
-	if err != nil {
-		return err
-	}
-	defer out.Close()
-
-	open, err := zipFile.Open()
-	if err != nil {
-		return err
-	}
-	defer open.Close()
-
-	if _, err := io.Copy(out, open); err != nil {
+	if err := os.WriteFile(tmpFilePath, zipBytes, 0o644); err != nil {
 		return err
 	}
 
@@ -85,7 +76,7 @@ func Check(zipFile *multipart.FileHeader) error {
 					break
 				}
 			}
-			return errorx.BadRequest(fmt.Sprintf("%s/%s: %s", zipFile.Filename, file.Name(), errMessage))
+			return errorx.BadRequest(fmt.Sprintf("%s/%s: %s", zipName, file.Name(), errMessage))
 		}
 	}
 
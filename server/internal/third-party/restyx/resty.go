@@ -2,8 +2,12 @@ package restyx
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/57blocks/auto-action/server/internal/config"
 	"github.com/57blocks/auto-action/server/internal/dto"
@@ -21,6 +25,8 @@ type (
 		AddCSKeyToRole(c context.Context, csToken string, keyId string, role string) error
 		DeleteCSKey(c context.Context, csToken string, keyId string) error
 		DeleteCSKeyFromRole(c context.Context, csToken string, keyId string, role string) error
+		SignCSBlob(c context.Context, csToken string, keyId string, message []byte) ([]byte, error)
+		FundByFriendbot(c context.Context, address string) error
 	}
 
 	restyx struct {
@@ -163,3 +169,56 @@ func (r *restyx) DeleteCSKeyFromRole(c context.Context, csToken string, keyId st
 
 	return nil
 }
+
+// SignCSBlob signs the raw message by the key, the key must be allowed with raw blob signing.
+func (r *restyx) SignCSBlob(c context.Context, csToken string, keyId string, message []byte) ([]byte, error) {
+	URL := fmt.Sprintf(
+		"%s/v1/org/%s/blob/sign/%s",
+		config.GlobalConfig.CS.Endpoint,
+		url.PathEscape(config.GlobalConfig.CS.Organization),
+		url.PathEscape(keyId),
+	)
+
+	var signResp dto.RespSignCsBlob
+	resp, err := r.client.R().
+		SetHeader("Authorization", csToken).
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]interface{}{
+			"message_base64": base64.StdEncoding.EncodeToString(message),
+		}).
+		SetResult(&signResp).
+		Post(URL)
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("sign blob by cube signer key occurred error: %s", err.Error()))
+	}
+	if resp.IsError() {
+		return nil, errorx.Internal(fmt.Sprintf("sign blob by cube signer key occurred error: %d, %s", resp.StatusCode(), resp.String()))
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(signResp.Signature, "0x"))
+	if err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("invalid signature by cube signer key: %s", signResp.Signature))
+	}
+	logx.Logger.DEBUG(fmt.Sprintf("sign blob by cube signer key success: %s", keyId))
+
+	return signature, nil
+}
+
+// FundByFriendbot asks the Friendbot to create and fund the account of the address, on the networks other than the mainnet.
+func (r *restyx) FundByFriendbot(c context.Context, address string) error {
+	resp, err := r.client.R().
+		SetQueryParam("addr", address).
+		Get(config.GlobalConfig.Wallet.Friendbot.URL)
+	if err != nil {
+		return errorx.Internal(fmt.Sprintf("fund by friendbot occurred error: %s", err.Error()))
+	}
+	if resp.IsError() {
+		if resp.StatusCode() == http.StatusBadRequest && strings.Contains(resp.String(), "createAccountAlreadyExist") {
+			return errorx.BadRequest(fmt.Sprintf("the account of the address: %s is funded already", address))
+		}
+		return errorx.Internal(fmt.Sprintf("fund by friendbot occurred error: %d, %s", resp.StatusCode(), resp.String()))
+	}
+	logx.Logger.DEBUG(fmt.Sprintf("fund by friendbot success: %s", address))
+
+	return nil
+}
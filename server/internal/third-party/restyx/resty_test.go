@@ -1,7 +1,9 @@
 package restyx
 
 import (
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -203,3 +205,84 @@ func TestDeleteCSKeyFromRoleFailed(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, `delete cube signer key from role occurred error: 400, {"status":{"message": "error", "code": 400}}`, err.Error())
 }
+
+func TestSignCSBlobSuccess(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "https://api.fake.com/v1/org/ORG1/blob/sign/Key1",
+		func(req *http.Request) (*http.Response, error) {
+			body := make(map[string]string)
+			assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			assert.Equal(t, "aGFzaA==", body["message_base64"])
+
+			resp := httpmock.NewStringResponse(200, `{"signature": "0x0a0b"}`)
+			resp.Header.Set("Content-Type", "application/json")
+			return resp, nil
+		})
+	ctx := new(gin.Context)
+
+	cd := &restyx{client: restyClient}
+	signature, err := cd.SignCSBlob(ctx, "test_cs_token", "Key1", []byte("hash"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x0b}, signature)
+}
+
+func TestSignCSBlobFailed(t *testing.T) {
+	restyClient := resty.New()
+	httpmock.ActivateNonDefault(restyClient.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "https://api.fake.com/v1/org/ORG1/blob/sign/Key1",
+		httpmock.NewStringResponder(403, `{"status":{"message": "error", "code": 403}}`))
+	ctx := new(gin.Context)
+
+	cd := &restyx{client: restyClient}
+	signature, err := cd.SignCSBlob(ctx, "test_cs_token", "Key1", []byte("hash"))
+
+	assert.Error(t, err)
+	assert.Equal(t, `sign blob by cube signer key occurred error: 403, {"status":{"message": "error", "code": 403}}`, err.Error())
+	assert.Nil(t, signature)
+}
+
+func TestFundByFriendbotSuccess(t *testing.T) {
+	friendbot := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "test_address", req.URL.Query().Get("addr"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"successful": true}`))
+	}))
+	defer friendbot.Close()
+
+	url := config.GlobalConfig.Wallet.Friendbot.URL
+	config.GlobalConfig.Wallet.Friendbot.URL = friendbot.URL
+	defer func() { config.GlobalConfig.Wallet.Friendbot.URL = url }()
+
+	ctx := new(gin.Context)
+
+	cd := &restyx{client: resty.New()}
+	err := cd.FundByFriendbot(ctx, "test_address")
+
+	assert.NoError(t, err)
+}
+
+func TestFundByFriendbotFundedAlready(t *testing.T) {
+	friendbot := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status": 400, "extras": {"result_codes": {"operations": ["op_already_exists"]}}, ` +
+			`"detail": "createAccountAlreadyExist (AAAAAAAAAGT/////AAAAAQAAAAAAAAAA/////AAAAAA=)"}`))
+	}))
+	defer friendbot.Close()
+
+	url := config.GlobalConfig.Wallet.Friendbot.URL
+	config.GlobalConfig.Wallet.Friendbot.URL = friendbot.URL
+	defer func() { config.GlobalConfig.Wallet.Friendbot.URL = url }()
+
+	ctx := new(gin.Context)
+
+	cd := &restyx{client: resty.New()}
+	err := cd.FundByFriendbot(ctx, "test_address")
+
+	assert.EqualError(t, err, "the account of the address: test_address is funded already")
+}
@@ -2,9 +2,11 @@ package third_party
 
 import (
 	"github.com/57blocks/auto-action/server/internal/third-party/amazonx"
+	"github.com/57blocks/auto-action/server/internal/third-party/bundle"
 	"github.com/57blocks/auto-action/server/internal/third-party/decrypt"
 	"github.com/57blocks/auto-action/server/internal/third-party/jwtx"
 	"github.com/57blocks/auto-action/server/internal/third-party/restyx"
+	"github.com/57blocks/auto-action/server/internal/third-party/sorobanx"
 	"github.com/57blocks/auto-action/server/internal/third-party/stellarx"
 )
 
@@ -24,6 +26,12 @@ func Setup() error {
 	if err := stellarx.Setup(); err != nil {
 		return err
 	}
+	if err := bundle.Setup(); err != nil {
+		return err
+	}
+	if err := sorobanx.Setup(); err != nil {
+		return err
+	}
 
 	return nil
 }
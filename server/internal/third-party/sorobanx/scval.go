@@ -0,0 +1,194 @@
+package sorobanx
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+// The wildcards of the topic filters, matching any one segment, and any segments after.
+const (
+	TopicWildcard     = "*"
+	TopicWildcardRest = "**"
+)
+
+// topicXDRPrefix the prefix of the topic segment given in base64 XDR ScVal as is.
+const topicXDRPrefix = "xdr:"
+
+var symbolPattern = regexp.MustCompile(`^[a-zA-Z0-9_]{1,32}$`)
+
+// EncodeTopic encodes the segment of the topic filter into base64 XDR ScVal, the wildcards are kept.
+// The segment is an account or contract address, a symbol, or the base64 XDR ScVal prefixed by "xdr:".
+func EncodeTopic(segment string) (string, error) {
+	segment = strings.TrimSpace(segment)
+
+	var val xdr.ScVal
+	switch {
+	case segment == TopicWildcard || segment == TopicWildcardRest:
+		return segment, nil
+	case strings.HasPrefix(segment, topicXDRPrefix):
+		encoded := strings.TrimPrefix(segment, topicXDRPrefix)
+		if err := xdr.SafeUnmarshalBase64(encoded, &val); err != nil {
+			return "", errorx.BadRequest(fmt.Sprintf("invalid xdr of the topic: %s", encoded))
+		}
+		return encoded, nil
+	case strkey.IsValidEd25519PublicKey(segment):
+		accountID, err := xdr.AddressToAccountId(segment)
+		if err != nil {
+			return "", errorx.BadRequest(fmt.Sprintf("invalid address of the topic: %s", segment))
+		}
+		val = xdr.ScVal{
+			Type:    xdr.ScValTypeScvAddress,
+			Address: &xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &accountID},
+		}
+	case IsContractAddress(segment):
+		raw := strkey.MustDecode(strkey.VersionByteContract, segment)
+		var contractID xdr.Hash
+		copy(contractID[:], raw)
+		val = xdr.ScVal{
+			Type:    xdr.ScValTypeScvAddress,
+			Address: &xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contractID},
+		}
+	case symbolPattern.MatchString(segment):
+		sym := xdr.ScSymbol(segment)
+		val = xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}
+	default:
+		return "", errorx.BadRequest(fmt.Sprintf("invalid topic: %s, should be an address, a symbol, "+
+			"or the base64 xdr prefixed by %s", segment, topicXDRPrefix))
+	}
+
+	encoded, err := xdr.MarshalBase64(val)
+	if err != nil {
+		return "", errorx.Internal(fmt.Sprintf("failed to encode topic: %s, err: %s", segment, err.Error()))
+	}
+
+	return encoded, nil
+}
+
+// IsContractAddress reports whether the address is the strkey of a contract, C...
+func IsContractAddress(address string) bool {
+	_, err := strkey.Decode(strkey.VersionByteContract, address)
+	return err == nil
+}
+
+// DecodeScVal decodes the base64 XDR ScVal into the value marshalled into JSON.
+func DecodeScVal(encoded string) (interface{}, error) {
+	var val xdr.ScVal
+	if err := xdr.SafeUnmarshalBase64(encoded, &val); err != nil {
+		return nil, errorx.Internal(fmt.Sprintf("invalid xdr of the scval: %s, err: %s", encoded, err.Error()))
+	}
+
+	return scValJSON(val), nil
+}
+
+// scValJSON converts the ScVal into the JSON value, the integers beyond 32 bits are in decimal strings
+// to keep the precision, the bytes in hex, and the ones not convertible are kept in base64 XDR.
+func scValJSON(val xdr.ScVal) interface{} {
+	switch val.Type {
+	case xdr.ScValTypeScvBool:
+		return *val.B
+	case xdr.ScValTypeScvVoid:
+		return nil
+	case xdr.ScValTypeScvU32:
+		return uint32(*val.U32)
+	case xdr.ScValTypeScvI32:
+		return int32(*val.I32)
+	case xdr.ScValTypeScvU64:
+		return fmt.Sprintf("%d", uint64(*val.U64))
+	case xdr.ScValTypeScvI64:
+		return fmt.Sprintf("%d", int64(*val.I64))
+	case xdr.ScValTypeScvTimepoint:
+		return fmt.Sprintf("%d", uint64(*val.Timepoint))
+	case xdr.ScValTypeScvDuration:
+		return fmt.Sprintf("%d", uint64(*val.Duration))
+	case xdr.ScValTypeScvU128:
+		return joinParts(false, uint64(val.U128.Hi), uint64(val.U128.Lo)).String()
+	case xdr.ScValTypeScvI128:
+		return joinParts(true, uint64(val.I128.Hi), uint64(val.I128.Lo)).String()
+	case xdr.ScValTypeScvU256:
+		return joinParts(false, uint64(val.U256.HiHi), uint64(val.U256.HiLo),
+			uint64(val.U256.LoHi), uint64(val.U256.LoLo)).String()
+	case xdr.ScValTypeScvI256:
+		return joinParts(true, uint64(val.I256.HiHi), uint64(val.I256.HiLo),
+			uint64(val.I256.LoHi), uint64(val.I256.LoLo)).String()
+	case xdr.ScValTypeScvBytes:
+		return hex.EncodeToString(*val.Bytes)
+	case xdr.ScValTypeScvString:
+		return string(*val.Str)
+	case xdr.ScValTypeScvSymbol:
+		return string(*val.Sym)
+	case xdr.ScValTypeScvAddress:
+		if address, err := val.Address.String(); err == nil {
+			return address
+		}
+	case xdr.ScValTypeScvVec:
+		if val.Vec == nil || *val.Vec == nil {
+			return []interface{}{}
+		}
+
+		items := make([]interface{}, 0, len(**val.Vec))
+		for _, item := range **val.Vec {
+			items = append(items, scValJSON(item))
+		}
+		return items
+	case xdr.ScValTypeScvMap:
+		if val.Map == nil || *val.Map == nil {
+			return map[string]interface{}{}
+		}
+
+		return scMapJSON(**val.Map)
+	}
+
+	encoded, _ := xdr.MarshalBase64(val)
+	return map[string]interface{}{"xdr": encoded}
+}
+
+// scMapJSON converts the map into the JSON object when all the keys are symbols or strings,
+// otherwise into the list of the key and value pairs.
+func scMapJSON(entries xdr.ScMap) interface{} {
+	object := make(map[string]interface{}, len(entries))
+	for _, entry := range entries {
+		var key string
+		switch entry.Key.Type {
+		case xdr.ScValTypeScvSymbol:
+			key = string(*entry.Key.Sym)
+		case xdr.ScValTypeScvString:
+			key = string(*entry.Key.Str)
+		default:
+			pairs := make([]interface{}, 0, len(entries))
+			for _, pair := range entries {
+				pairs = append(pairs, map[string]interface{}{
+					"key":   scValJSON(pair.Key),
+					"value": scValJSON(pair.Val),
+				})
+			}
+			return pairs
+		}
+
+		object[key] = scValJSON(entry.Val)
+	}
+
+	return object
+}
+
+// joinParts joins the 64 bits parts of the integer from the highest, the highest one is signed when it's signed.
+func joinParts(signed bool, parts ...uint64) *big.Int {
+	value := new(big.Int)
+	for _, part := range parts {
+		value.Lsh(value, 64)
+		value.Or(value, new(big.Int).SetUint64(part))
+	}
+
+	if signed && parts[0]>>63 == 1 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(64*len(parts))))
+	}
+
+	return value
+}
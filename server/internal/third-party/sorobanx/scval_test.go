@@ -0,0 +1,74 @@
+package sorobanx
+
+import (
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAccount  = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+	testContract = "CA3D5KRYM6CB7OWQ6TWYRR3Z4T7GNZLKERYNZGGA5SOAOPIFY6YQGAXE"
+)
+
+func TestEncodeTopic(t *testing.T) {
+	encoded, err := EncodeTopic("transfer")
+	assert.NoError(t, err)
+	assert.Equal(t, "AAAADwAAAAh0cmFuc2Zlcg==", encoded)
+
+	for _, wildcard := range []string{TopicWildcard, TopicWildcardRest} {
+		encoded, err = EncodeTopic(wildcard)
+		assert.NoError(t, err)
+		assert.Equal(t, wildcard, encoded)
+	}
+
+	for _, address := range []string{testAccount, testContract} {
+		encoded, err = EncodeTopic(address)
+		assert.NoError(t, err)
+
+		decoded, err := DecodeScVal(encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, address, decoded)
+	}
+
+	encoded, err = EncodeTopic("xdr:AAAAAwAAAAo=")
+	assert.NoError(t, err)
+	assert.Equal(t, "AAAAAwAAAAo=", encoded)
+
+	_, err = EncodeTopic("not a symbol")
+	assert.Equal(t, errorx.BadRequest("invalid topic: not a symbol, should be an address, a symbol, "+
+		"or the base64 xdr prefixed by xdr:"), err)
+}
+
+func TestDecodeScVal(t *testing.T) {
+	sym := xdr.ScSymbol("amount")
+	str := xdr.ScString("memo")
+	i128 := xdr.Int128Parts{Hi: -1, Lo: xdr.Uint64(^uint64(0) - 9)}
+	u64 := xdr.Uint64(1 << 40)
+	vec := &xdr.ScVec{
+		{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+		{Type: xdr.ScValTypeScvU64, U64: &u64},
+	}
+	scMap := &xdr.ScMap{
+		{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, Val: xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &i128}},
+		{Key: xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str}, Val: xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &vec}},
+	}
+
+	encoded, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &scMap})
+	assert.NoError(t, err)
+
+	decoded, err := DecodeScVal(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"amount": "-10",
+		"memo":   []interface{}{"amount", "1099511627776"},
+	}, decoded)
+}
+
+func TestDecodeScValInvalid(t *testing.T) {
+	_, err := DecodeScVal("invalid")
+	assert.Error(t, err)
+}
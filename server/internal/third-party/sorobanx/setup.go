@@ -0,0 +1,15 @@
+package sorobanx
+
+import (
+	"github.com/57blocks/auto-action/server/internal/config"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func Setup() error {
+	Conductor = &soroban{
+		client:   resty.New(),
+		endpoint: config.GlobalConfig.Soroban.RPC,
+	}
+	return nil
+}
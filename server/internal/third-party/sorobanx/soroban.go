@@ -0,0 +1,156 @@
+package sorobanx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+
+	"github.com/go-resty/resty/v2"
+)
+
+//go:generate mockgen -destination ../../testdata/soroban_mock.go -package testdata -source soroban.go Soroban
+type (
+	Soroban interface {
+		Enabled() bool
+		GetLatestLedger(c context.Context) (*LatestLedger, error)
+		GetEvents(c context.Context, req *EventsRequest) (*EventsResponse, error)
+	}
+
+	soroban struct {
+		client   *resty.Client
+		endpoint string
+	}
+)
+
+var Conductor Soroban
+
+// The JSON-RPC envelopes of Soroban RPC.
+type (
+	rpcRequest struct {
+		JSONRPC string      `json:"jsonrpc"`
+		ID      int         `json:"id"`
+		Method  string      `json:"method"`
+		Params  interface{} `json:"params,omitempty"`
+	}
+
+	rpcResponse struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+
+	rpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+)
+
+type (
+	LatestLedger struct {
+		ID              string `json:"id"`
+		ProtocolVersion int    `json:"protocolVersion"`
+		Sequence        uint32 `json:"sequence"`
+	}
+
+	// EventsRequest queries the events from the start ledger, or after the cursor of the pagination,
+	// the start ledger is omitted along with the cursor.
+	EventsRequest struct {
+		StartLedger uint32        `json:"startLedger,omitempty"`
+		Filters     []EventFilter `json:"filters"`
+		Pagination  *Pagination   `json:"pagination,omitempty"`
+	}
+
+	// EventFilter the events emitted by any of the contracts, and matching any of the topic filters,
+	// each topic filter is the segments of base64 XDR ScVal, or the wildcard "*".
+	EventFilter struct {
+		EventType   string     `json:"type,omitempty"`
+		ContractIDs []string   `json:"contractIds,omitempty"`
+		Topics      [][]string `json:"topics,omitempty"`
+	}
+
+	Pagination struct {
+		Cursor string `json:"cursor,omitempty"`
+		Limit  uint   `json:"limit,omitempty"`
+	}
+
+	EventsResponse struct {
+		Events       []*Event `json:"events"`
+		LatestLedger uint32   `json:"latestLedger"`
+		Cursor       string   `json:"cursor"`
+	}
+
+	// Event the event emitted by the contract, the topics and the value are base64 XDR ScVal.
+	Event struct {
+		EventType                string   `json:"type"`
+		Ledger                   uint32   `json:"ledger"`
+		LedgerClosedAt           string   `json:"ledgerClosedAt"`
+		ContractID               string   `json:"contractId"`
+		ID                       string   `json:"id"`
+		InSuccessfulContractCall bool     `json:"inSuccessfulContractCall"`
+		TxHash                   string   `json:"txHash"`
+		Topic                    []string `json:"topic"`
+		Value                    string   `json:"value"`
+	}
+)
+
+// Enabled reports whether the Soroban RPC is configured on the server.
+func (s *soroban) Enabled() bool {
+	return s.endpoint != ""
+}
+
+func (s *soroban) GetLatestLedger(c context.Context) (*LatestLedger, error) {
+	ledger := new(LatestLedger)
+	if err := s.call(c, "getLatestLedger", nil, ledger); err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+func (s *soroban) GetEvents(c context.Context, req *EventsRequest) (*EventsResponse, error) {
+	events := new(EventsResponse)
+	if err := s.call(c, "getEvents", req, events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// call sends the JSON-RPC request of the method, and decodes the result of the response into the one given.
+func (s *soroban) call(c context.Context, method string, params interface{}, result interface{}) error {
+	if !s.Enabled() {
+		return errorx.Internal("soroban rpc is not configured on the server")
+	}
+
+	resp, err := s.client.R().
+		SetContext(c).
+		SetHeader("Content-Type", "application/json").
+		SetBody(&rpcRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  method,
+			Params:  params,
+		}).
+		Post(s.endpoint)
+	if err != nil {
+		return errorx.Internal(fmt.Sprintf("soroban rpc: %s occurred error: %s", method, err.Error()))
+	}
+	if resp.IsError() {
+		return errorx.Internal(fmt.Sprintf("soroban rpc: %s occurred error: %d, %s", method, resp.StatusCode(), resp.String()))
+	}
+
+	rpcResp := new(rpcResponse)
+	if err := json.Unmarshal(resp.Body(), rpcResp); err != nil {
+		return errorx.Internal(fmt.Sprintf("soroban rpc: %s responded invalid json: %s", method, err.Error()))
+	}
+	if rpcResp.Error != nil {
+		return errorx.Internal(fmt.Sprintf("soroban rpc: %s occurred error: %d, %s", method, rpcResp.Error.Code, rpcResp.Error.Message))
+	}
+
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return errorx.Internal(fmt.Sprintf("soroban rpc: %s responded invalid result: %s", method, err.Error()))
+	}
+
+	return nil
+}
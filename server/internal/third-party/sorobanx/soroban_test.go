@@ -0,0 +1,112 @@
+package sorobanx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/57blocks/auto-action/server/internal/config"
+	"github.com/57blocks/auto-action/server/internal/pkg/errorx"
+	"github.com/57blocks/auto-action/server/internal/third-party/logx"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	testConfig := config.Configuration{
+		Log: config.Log{
+			Level:    "debug",
+			Encoding: "json",
+		},
+	}
+	logx.Setup(&testConfig)
+
+	os.Exit(m.Run())
+}
+
+// fakeRPC serves the Soroban RPC by the results keyed by the method, and records the requests.
+func fakeRPC(t *testing.T, results map[string]string) (*httptest.Server, *[]map[string]interface{}) {
+	requests := make([]map[string]interface{}, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := make(map[string]interface{})
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+
+		w.Header().Set("Content-Type", "application/json")
+		result, ok := results[req["method"].(string)]
+		if !ok {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestGetLatestLedgerSuccess(t *testing.T) {
+	server, _ := fakeRPC(t, map[string]string{
+		"getLatestLedger": `{"id":"ledger_hash","protocolVersion":21,"sequence":1024}`,
+	})
+
+	s := &soroban{client: resty.New(), endpoint: server.URL}
+
+	ledger, err := s.GetLatestLedger(new(gin.Context))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1024), ledger.Sequence)
+}
+
+func TestGetEventsSuccess(t *testing.T) {
+	server, requests := fakeRPC(t, map[string]string{
+		"getEvents": `{"events":[{"type":"contract","ledger":1000,"ledgerClosedAt":"2026-01-01T00:00:00Z",` +
+			`"contractId":"CONTRACT","id":"0004294967296000-0000000001","inSuccessfulContractCall":true,` +
+			`"txHash":"tx_hash","topic":["AAAADwAAAAh0cmFuc2Zlcg=="],"value":"AAAAAwAAAAo="}],"latestLedger":1010}`,
+	})
+
+	s := &soroban{client: resty.New(), endpoint: server.URL}
+
+	resp, err := s.GetEvents(new(gin.Context), &EventsRequest{
+		StartLedger: 1000,
+		Filters: []EventFilter{{
+			EventType:   "contract",
+			ContractIDs: []string{"CONTRACT"},
+			Topics:      [][]string{{"AAAADwAAAAh0cmFuc2Zlcg==", TopicWildcard}},
+		}},
+		Pagination: &Pagination{Limit: 100},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1010), resp.LatestLedger)
+	assert.Len(t, resp.Events, 1)
+	assert.Equal(t, "0004294967296000-0000000001", resp.Events[0].ID)
+	assert.Equal(t, []string{"AAAADwAAAAh0cmFuc2Zlcg=="}, resp.Events[0].Topic)
+
+	assert.Len(t, *requests, 1)
+	params := (*requests)[0]["params"].(map[string]interface{})
+	assert.Equal(t, float64(1000), params["startLedger"])
+	assert.Equal(t, map[string]interface{}{"limit": float64(100)}, params["pagination"])
+}
+
+func TestGetEventsRPCError(t *testing.T) {
+	server, _ := fakeRPC(t, map[string]string{})
+
+	s := &soroban{client: resty.New(), endpoint: server.URL}
+
+	resp, err := s.GetEvents(new(gin.Context), &EventsRequest{StartLedger: 1})
+	assert.Nil(t, resp)
+	assert.Equal(t, errorx.Internal("soroban rpc: getEvents occurred error: -32601, method not found"), err)
+}
+
+func TestCallNotConfigured(t *testing.T) {
+	s := &soroban{client: resty.New()}
+
+	assert.False(t, s.Enabled())
+
+	_, err := s.GetLatestLedger(new(gin.Context))
+	assert.Equal(t, errorx.Internal("soroban rpc is not configured on the server"), err)
+}
@@ -0,0 +1,69 @@
+package stellarx
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stellar/go/protocols/horizon"
+)
+
+// accountCache keeps the accounts fetched from Horizon for a short while,
+// a nil cache or a zero TTL keeps nothing.
+type accountCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedAccount
+}
+
+type cachedAccount struct {
+	account   horizon.Account
+	expiresAt time.Time
+}
+
+func newAccountCache(ttl time.Duration) *accountCache {
+	return &accountCache{
+		ttl:     ttl,
+		entries: make(map[string]cachedAccount),
+	}
+}
+
+func (ac *accountCache) get(address string) (horizon.Account, bool) {
+	if ac == nil {
+		return horizon.Account{}, false
+	}
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	cached, ok := ac.entries[address]
+	if !ok {
+		return horizon.Account{}, false
+	}
+	if time.Now().After(cached.expiresAt) {
+		delete(ac.entries, address)
+		return horizon.Account{}, false
+	}
+
+	return cached.account, true
+}
+
+func (ac *accountCache) put(address string, account horizon.Account) {
+	if ac == nil || ac.ttl <= 0 {
+		return
+	}
+
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	now := time.Now()
+	for key, cached := range ac.entries {
+		if now.After(cached.expiresAt) {
+			delete(ac.entries, key)
+		}
+	}
+
+	ac.entries[address] = cachedAccount{
+		account:   account,
+		expiresAt: now.Add(ac.ttl),
+	}
+}
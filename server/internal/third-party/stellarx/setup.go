@@ -5,13 +5,22 @@ import (
 	"github.com/57blocks/auto-action/server/internal/constant"
 
 	"github.com/stellar/go/clients/horizonclient"
+	"github.com/stellar/go/network"
 )
 
 func Setup() error {
 	if config.GlobalConfig.Bound.Name == string(constant.StellarNetworkTypeMainNet) {
-		Conductor = &stellar{client: horizonclient.DefaultPublicNetClient}
+		Conductor = &stellar{
+			client:     horizonclient.DefaultPublicNetClient,
+			passphrase: network.PublicNetworkPassphrase,
+			accounts:   newAccountCache(config.GlobalConfig.Wallet.CacheTTL),
+		}
 	} else {
-		Conductor = &stellar{client: horizonclient.DefaultTestNetClient}
+		Conductor = &stellar{
+			client:     horizonclient.DefaultTestNetClient,
+			passphrase: network.TestNetworkPassphrase,
+			accounts:   newAccountCache(config.GlobalConfig.Wallet.CacheTTL),
+		}
 	}
 	return nil
 }
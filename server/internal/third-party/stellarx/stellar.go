@@ -11,14 +11,26 @@ import (
 type (
 	Stellar interface {
 		AccountDetail(c context.Context, req horizonclient.AccountRequest) (horizon.Account, error)
+		CachedAccountDetail(c context.Context, address string) (horizon.Account, error)
+		StreamPayments(c context.Context, req horizonclient.OperationRequest, handler horizonclient.OperationHandler) error
+		StreamOperations(c context.Context, req horizonclient.OperationRequest, handler horizonclient.OperationHandler) error
+		StreamEffects(c context.Context, req horizonclient.EffectRequest, handler horizonclient.EffectHandler) error
+		SubmitTransactionXDR(c context.Context, envelope string) (horizon.Transaction, error)
+		NetworkPassphrase() string
 	}
 
 	HorizonClient interface {
 		AccountDetail(req horizonclient.AccountRequest) (horizon.Account, error)
+		StreamPayments(ctx context.Context, req horizonclient.OperationRequest, handler horizonclient.OperationHandler) error
+		StreamOperations(ctx context.Context, req horizonclient.OperationRequest, handler horizonclient.OperationHandler) error
+		StreamEffects(ctx context.Context, req horizonclient.EffectRequest, handler horizonclient.EffectHandler) error
+		SubmitTransactionXDR(transactionXdr string) (horizon.Transaction, error)
 	}
 
 	stellar struct {
-		client HorizonClient
+		client     HorizonClient
+		passphrase string
+		accounts   *accountCache
 	}
 )
 
@@ -27,3 +39,56 @@ var Conductor Stellar
 func (s *stellar) AccountDetail(c context.Context, req horizonclient.AccountRequest) (horizon.Account, error) {
 	return s.client.AccountDetail(req)
 }
+
+// CachedAccountDetail returns the account reused within the TTL of the cache, the failures are never cached.
+func (s *stellar) CachedAccountDetail(c context.Context, address string) (horizon.Account, error) {
+	if account, ok := s.accounts.get(address); ok {
+		return account, nil
+	}
+
+	account, err := s.client.AccountDetail(horizonclient.AccountRequest{AccountID: address})
+	if err != nil {
+		return horizon.Account{}, err
+	}
+	s.accounts.put(address, account)
+
+	return account, nil
+}
+
+// StreamPayments streams the payments from the cursor of the request until the context is done,
+// the handler is called in order, one by one.
+func (s *stellar) StreamPayments(
+	c context.Context,
+	req horizonclient.OperationRequest,
+	handler horizonclient.OperationHandler,
+) error {
+	return s.client.StreamPayments(c, req, handler)
+}
+
+// StreamOperations streams the operations from the cursor of the request until the context is done.
+func (s *stellar) StreamOperations(
+	c context.Context,
+	req horizonclient.OperationRequest,
+	handler horizonclient.OperationHandler,
+) error {
+	return s.client.StreamOperations(c, req, handler)
+}
+
+// StreamEffects streams the effects from the cursor of the request until the context is done.
+func (s *stellar) StreamEffects(
+	c context.Context,
+	req horizonclient.EffectRequest,
+	handler horizonclient.EffectHandler,
+) error {
+	return s.client.StreamEffects(c, req, handler)
+}
+
+// SubmitTransactionXDR submits the signed transaction envelope, and waits for the result of it.
+func (s *stellar) SubmitTransactionXDR(c context.Context, envelope string) (horizon.Transaction, error) {
+	return s.client.SubmitTransactionXDR(envelope)
+}
+
+// NetworkPassphrase returns the passphrase of the network bound, by which the transactions are hashed.
+func (s *stellar) NetworkPassphrase() string {
+	return s.passphrase
+}
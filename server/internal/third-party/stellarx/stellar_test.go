@@ -3,6 +3,7 @@ package stellarx
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/57blocks/auto-action/server/internal/testdata"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stellar/go/clients/horizonclient"
 	"github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/effects"
+	"github.com/stellar/go/protocols/horizon/operations"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,3 +62,147 @@ func TestAccountDetailError(t *testing.T) {
 	assert.Equal(t, "error", err.Error())
 	assert.Equal(t, horizon.Account{}, account)
 }
+
+func TestStreamPaymentsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := testdata.NewMockHorizonClient(ctrl)
+
+	ctx := new(gin.Context)
+	req := horizonclient.OperationRequest{ForAccount: "test_account_id", Cursor: "now"}
+
+	mockClient.EXPECT().StreamPayments(ctx, req, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, _ horizonclient.OperationRequest, handler horizonclient.OperationHandler) error {
+			handler(operations.Payment{Base: operations.Base{PT: "123"}})
+			return nil
+		})
+
+	s := &stellar{
+		client: mockClient,
+	}
+
+	tokens := make([]string, 0)
+	err := s.StreamPayments(ctx, req, func(op operations.Operation) {
+		tokens = append(tokens, op.PagingToken())
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"123"}, tokens)
+}
+
+func TestStreamOperationsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := testdata.NewMockHorizonClient(ctrl)
+
+	ctx := new(gin.Context)
+	req := horizonclient.OperationRequest{ForAccount: "test_account_id"}
+
+	mockClient.EXPECT().StreamOperations(ctx, req, gomock.Any()).Return(errors.New("error"))
+
+	s := &stellar{
+		client: mockClient,
+	}
+
+	err := s.StreamOperations(ctx, req, func(operations.Operation) {})
+
+	assert.Error(t, err)
+	assert.Equal(t, "error", err.Error())
+}
+
+func TestStreamEffectsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := testdata.NewMockHorizonClient(ctrl)
+
+	ctx := new(gin.Context)
+	req := horizonclient.EffectRequest{ForAccount: "test_account_id", Cursor: "now"}
+
+	mockClient.EXPECT().StreamEffects(ctx, req, gomock.Any()).
+		DoAndReturn(func(_ *gin.Context, _ horizonclient.EffectRequest, handler horizonclient.EffectHandler) error {
+			handler(effects.AccountCredited{Base: effects.Base{PT: "456"}})
+			return nil
+		})
+
+	s := &stellar{
+		client: mockClient,
+	}
+
+	tokens := make([]string, 0)
+	err := s.StreamEffects(ctx, req, func(effect effects.Effect) {
+		tokens = append(tokens, effect.PagingToken())
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"456"}, tokens)
+}
+
+func TestSubmitTransactionXDRSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := testdata.NewMockHorizonClient(ctrl)
+
+	ctx := new(gin.Context)
+	expectedTx := horizon.Transaction{Hash: "test_hash", Successful: true}
+
+	mockClient.EXPECT().SubmitTransactionXDR("test_envelope").Return(expectedTx, nil)
+
+	s := &stellar{
+		client: mockClient,
+	}
+
+	tx, err := s.SubmitTransactionXDR(ctx, "test_envelope")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTx, tx)
+}
+
+func TestCachedAccountDetailReused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := testdata.NewMockHorizonClient(ctrl)
+
+	ctx := new(gin.Context)
+	expectedAccount := horizon.Account{AccountID: "test_account_id"}
+	req := horizonclient.AccountRequest{AccountID: expectedAccount.AccountID}
+
+	mockClient.EXPECT().AccountDetail(req).Times(1).Return(expectedAccount, nil)
+
+	s := &stellar{
+		client:   mockClient,
+		accounts: newAccountCache(time.Minute),
+	}
+
+	for i := 0; i < 2; i++ {
+		account, err := s.CachedAccountDetail(ctx, expectedAccount.AccountID)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedAccount, account)
+	}
+}
+
+func TestCachedAccountDetailErrorNotCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := testdata.NewMockHorizonClient(ctrl)
+
+	ctx := new(gin.Context)
+	req := horizonclient.AccountRequest{AccountID: "test_account_id"}
+
+	mockClient.EXPECT().AccountDetail(req).Times(2).Return(horizon.Account{}, errors.New("error"))
+
+	s := &stellar{
+		client:   mockClient,
+		accounts: newAccountCache(time.Minute),
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := s.CachedAccountDetail(ctx, "test_account_id")
+		assert.EqualError(t, err, "error")
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,6 +15,7 @@ import (
 	"github.com/57blocks/auto-action/server/internal/config"
 	"github.com/57blocks/auto-action/server/internal/db"
 	"github.com/57blocks/auto-action/server/internal/service"
+	"github.com/57blocks/auto-action/server/internal/service/lambda"
 	thirdParty "github.com/57blocks/auto-action/server/internal/third-party"
 	"github.com/57blocks/auto-action/server/internal/third-party/logx"
 )
@@ -38,10 +40,20 @@ func main() {
 
 	logx.Logger.INFO("boots: server")
 
+	// the requests are derived from the base context, which is cancelled by the shutdown,
+	// so the hijacked connections like the websocket of logs stop as well.
+	baseCtx, cancelBase := context.WithCancel(context.Background())
 	server = &http.Server{
 		Addr:    ":8080",
 		Handler: api.GinEngine,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
+	server.RegisterOnShutdown(cancelBase)
+
+	// the triggers stream the account activities on Stellar in the background, stopped along with the server
+	go lambda.LambdaServiceImpl.WatchTriggers(baseCtx)
 
 	go server.ListenAndServe()
 